@@ -7,6 +7,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"flag"
+	"io"
 	"net/http"
 	"os"
 	"os/signal"
@@ -16,6 +17,7 @@ import (
 	"sync"
 	"sync/atomic"
 	"syscall"
+	"time"
 
 	v1 "github.com/garethgeorge/backrest/gen/go/v1"
 	"github.com/garethgeorge/backrest/gen/go/v1/v1connect"
@@ -29,9 +31,13 @@ import (
 	"github.com/garethgeorge/backrest/internal/logstore"
 	"github.com/garethgeorge/backrest/internal/metric"
 	"github.com/garethgeorge/backrest/internal/oplog"
+	"github.com/garethgeorge/backrest/internal/oplog/pgstore"
 	"github.com/garethgeorge/backrest/internal/oplog/sqlitestore"
 	"github.com/garethgeorge/backrest/internal/orchestrator"
+	"github.com/garethgeorge/backrest/internal/orchestrator/tasks"
 	"github.com/garethgeorge/backrest/internal/resticinstaller"
+	"github.com/garethgeorge/backrest/internal/search"
+	"github.com/garethgeorge/backrest/internal/tracing"
 	"github.com/garethgeorge/backrest/webui"
 	"github.com/mattn/go-colorable"
 	"go.uber.org/zap"
@@ -62,12 +68,31 @@ func runApp() {
 		return
 	}
 
+	if *restoreSelfBackupRepo != "" {
+		if err := restoreSelfBackup(context.Background(), resticPath, *restoreSelfBackupRepo, *restoreSelfBackupInstance, *restoreSelfBackupSnapshot); err != nil {
+			zap.L().Fatal("error restoring self backup", zap.Error(err))
+		}
+		zap.L().Info("restored backrest state from self backup, starting backrest")
+	}
+
 	// Setup context and signal handling
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 	go onterm(os.Interrupt, cancel)
 	go onterm(os.Interrupt, newForceKillHandler())
 
+	if shutdownTracing, err := tracing.Setup(ctx, env.OTLPEndpoint(), version); err != nil {
+		zap.L().Error("error setting up tracing, traces will not be exported", zap.Error(err))
+	} else {
+		defer func() {
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := shutdownTracing(shutdownCtx); err != nil {
+				zap.L().Warn("error flushing traces", zap.Error(err))
+			}
+		}()
+	}
+
 	// Create dependency components
 	configMgr := &config.ConfigManager{Store: createConfigStore()}
 	cfg, err := configMgr.Get()
@@ -92,9 +117,9 @@ func runApp() {
 		}
 	}()
 
-	orch, err := orchestrator.NewOrchestrator(resticPath, configMgr, opLog, logStore)
-	if err != nil {
-		zap.L().Fatal("error creating orchestrator", zap.Error(err))
+	searchIndex := newSearchIndex(opLog, logStore)
+	if searchIndex != nil {
+		defer searchIndex.Close()
 	}
 
 	kvdbPath := path.Join(env.DataDir(), "kvdb.sqlite")
@@ -104,6 +129,30 @@ func runApp() {
 	}
 	defer sharedKvdb.Close()
 
+	digestStore, err := tasks.NewDigestStore(sharedKvdb)
+	if err != nil {
+		zap.L().Fatal("error creating hook digest store", zap.Error(err))
+	}
+
+	stalenessStore, err := tasks.NewPlanStalenessStore(sharedKvdb)
+	if err != nil {
+		zap.L().Fatal("error creating plan staleness store", zap.Error(err))
+	}
+
+	selfBackupState := newSelfBackupState(opLogStore, sharedKvdb)
+	orch, err := orchestrator.NewOrchestrator(resticPath, configMgr, opLog, logStore, digestStore, selfBackupState, stalenessStore)
+	if err != nil {
+		zap.L().Fatal("error creating orchestrator", zap.Error(err))
+	}
+
+	// metrics are restored from the oplog after the orchestrator has scrubbed incomplete operations.
+	metric.GetRegistry().SetTaskSource(orch.TaskInfo)
+	if stopMetrics, err := metric.GetRegistry().TrackOpLog(opLog); err != nil {
+		zap.L().Error("error restoring metrics from the oplog", zap.Error(err))
+	} else {
+		defer stopMetrics()
+	}
+
 	peerStateManager, err := syncapi.NewSqlitePeerStateManager(sharedKvdb)
 	if err != nil {
 		zap.L().Fatal("error creating peer state manager", zap.Error(err))
@@ -124,7 +173,7 @@ func runApp() {
 	}()
 
 	// Setup and start HTTP server
-	server := newServer(configMgr, peerStateManager, orch, opLog, logStore, syncMgr, authenticator)
+	server := newServer(configMgr, peerStateManager, orch, opLog, logStore, searchIndex, syncMgr, authenticator)
 	go func() {
 		<-ctx.Done()
 		server.Shutdown(context.Background())
@@ -143,13 +192,9 @@ func createConfigStore() config.ConfigStore {
 	return &config.JsonFileStore{Path: env.ConfigFilePath()}
 }
 
-func newOpLog(cfg *v1.Config) (*oplog.OpLog, *sqlitestore.SqliteStore, error) {
-	oplogFile := path.Join(env.DataDir(), "oplog.sqlite")
-	opstore, err := sqlitestore.NewSqliteStore(oplogFile)
-	if errors.Is(err, sqlitestore.ErrLocked) {
-		zap.L().Fatal("oplog is locked by another instance of backrest", zap.String("data_dir", env.DataDir()))
-	} else if err != nil {
-		zap.L().Warn("operation log may be corrupted, if errors recur delete the file and restart. Your backups stored in your repos are safe.", zap.String("oplog_file", oplogFile))
+func newOpLog(cfg *v1.Config) (*oplog.OpLog, io.Closer, error) {
+	opstore, err := newOpStore()
+	if err != nil {
 		return nil, nil, err
 	}
 	migratePopulateGuids(opstore, cfg)
@@ -164,8 +209,42 @@ func newOpLog(cfg *v1.Config) (*oplog.OpLog, *sqlitestore.SqliteStore, error) {
 	return log, opstore, nil
 }
 
+type closableOpStore interface {
+	oplog.OpStore
+	io.Closer
+}
+
+func newOpStore() (closableOpStore, error) {
+	if url := env.OplogPostgresURL(); url != "" {
+		opstore, err := pgstore.NewPostgresStore(url)
+		if errors.Is(err, pgstore.ErrLocked) {
+			zap.L().Fatal("oplog is locked by another instance of backrest using the same postgres database")
+		} else if err != nil {
+			return nil, err
+		}
+		return opstore, nil
+	}
+
+	oplogFile := path.Join(env.DataDir(), "oplog.sqlite")
+	opstore, err := sqlitestore.NewSqliteStore(oplogFile)
+	if errors.Is(err, sqlitestore.ErrLocked) {
+		zap.L().Fatal("oplog is locked by another instance of backrest", zap.String("data_dir", env.DataDir()))
+	} else if err != nil {
+		zap.L().Warn("operation log may be corrupted, if errors recur delete the file and restart. Your backups stored in your repos are safe.", zap.String("oplog_file", oplogFile))
+		return nil, err
+	}
+	return opstore, nil
+}
+
 func newLogStore(opLog *oplog.OpLog) (*logstore.LogStore, func(), error) {
-	logStore, err := logstore.NewLogStore(filepath.Join(env.DataDir(), "tasklogs"))
+	opts := []logstore.Option{
+		logstore.WithQuota(env.LogsMaxSizeBytes()),
+		logstore.WithMaxBytesPerOwner(env.LogsMaxSizePerOperationBytes()),
+	}
+	if level := env.LogsCompressionLevel(); level != 0 {
+		opts = append(opts, logstore.WithCompressionLevel(level))
+	}
+	logStore, err := logstore.NewLogStore(filepath.Join(env.DataDir(), "tasklogs"), opts...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -190,6 +269,20 @@ func newLogStore(opLog *oplog.OpLog) (*logstore.LogStore, func(), error) {
 	return logStore, unsubscribe, nil
 }
 
+// newSearchIndex returns the operation search index, or nil if it can't be opened. Search is then unavailable but backrest keeps running.
+func newSearchIndex(opLog *oplog.OpLog, logStore *logstore.LogStore) *search.Index {
+	var indexedLogs *logstore.LogStore
+	if env.SearchIndexLogs() {
+		indexedLogs = logStore
+	}
+	searchIndex, err := search.NewIndex(path.Join(env.DataDir(), "search.sqlite"), opLog, indexedLogs)
+	if err != nil {
+		zap.L().Error("error opening search index, search will be unavailable", zap.Error(err))
+		return nil
+	}
+	return searchIndex
+}
+
 func newAuthenticator(configMgr *config.ConfigManager) *auth.Authenticator {
 	secretFile := path.Join(env.DataDir(), "jwt-secret")
 	data, err := os.ReadFile(secretFile)
@@ -218,11 +311,12 @@ func newServer(
 	orch *orchestrator.Orchestrator,
 	opLog *oplog.OpLog,
 	logStore *logstore.LogStore,
+	searchIndex *search.Index,
 	syncMgr *syncapi.SyncManager,
 	authenticator *auth.Authenticator,
 ) *http.Server {
 	// API Handlers
-	apiBackrestHandler := api.NewBackrestHandler(configMgr, peerStateManager, orch, opLog, logStore)
+	apiBackrestHandler := api.NewBackrestHandler(configMgr, peerStateManager, orch, opLog, logStore, searchIndex)
 	apiAuthenticationHandler := api.NewAuthenticationHandler(authenticator)
 	syncHandler := syncapi.NewBackrestSyncHandler(syncMgr)
 	syncStateHandler := syncapi.NewBackrestSyncStateHandler(syncMgr)
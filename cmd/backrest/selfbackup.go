@@ -0,0 +1,178 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/garethgeorge/backrest/internal/env"
+	"github.com/garethgeorge/backrest/internal/kvstore"
+	"github.com/garethgeorge/backrest/internal/oplog/sqlitestore"
+	"github.com/garethgeorge/backrest/internal/orchestrator/repo"
+	"github.com/garethgeorge/backrest/internal/orchestrator/tasks"
+	"github.com/garethgeorge/backrest/pkg/restic"
+	"go.uber.org/zap"
+)
+
+var (
+	restoreSelfBackupRepo     = flag.String("restore-self-backup", "", "URI of a repo to restore backrest's config, operation log and key value store from before starting, only a new install can be restored into. The repo password is read from RESTIC_PASSWORD or RESTIC_PASSWORD_FILE.")
+	restoreSelfBackupSnapshot = flag.String("restore-self-backup-snapshot", "latest", "ID of the self backup snapshot restored by -restore-self-backup, defaults to the latest self backup in the repo.")
+	restoreSelfBackupInstance = flag.String("restore-self-backup-instance", "", "ID of the instance whose self backup is restored by -restore-self-backup, required if the repo holds self backups of several instances.")
+)
+
+const (
+	selfBackupConfigFile = "config.json"
+	selfBackupOplogFile  = "oplog.sqlite"
+	selfBackupKvdbFile   = "kvdb.sqlite"
+)
+
+// newSelfBackupState returns the state included in self backups. The operation log is only included if it's stored in
+// sqlite, a postgres database is expected to be backed up with postgres' own tools.
+func newSelfBackupState(opStore io.Closer, kvdb *sql.DB) *tasks.SelfBackupState {
+	state := &tasks.SelfBackupState{
+		StagingDir: filepath.Join(env.DataDir(), "self-backup"),
+		Sources: []tasks.SelfBackupSource{
+			tasks.SelfBackupFileSource(selfBackupConfigFile, env.ConfigFilePath()),
+			{
+				Name: selfBackupKvdbFile,
+				Copy: func(ctx context.Context, path string) error {
+					return kvstore.SnapshotSqliteDb(ctx, kvdb, path)
+				},
+			},
+		},
+	}
+	if store, ok := opStore.(*sqlitestore.SqliteStore); ok {
+		state.Sources = append(state.Sources, tasks.SelfBackupSource{Name: selfBackupOplogFile, Copy: store.Snapshot})
+	}
+	return state
+}
+
+// restoreSelfBackup bootstraps a new install from a self backup of the instance in the repo at uri.
+func restoreSelfBackup(ctx context.Context, resticPath, uri, instanceID, snapshotID string) error {
+	dests := map[string]string{
+		selfBackupConfigFile: env.ConfigFilePath(),
+		selfBackupOplogFile:  filepath.Join(env.DataDir(), selfBackupOplogFile),
+		selfBackupKvdbFile:   filepath.Join(env.DataDir(), selfBackupKvdbFile),
+	}
+	for _, dest := range dests {
+		if _, err := os.Stat(dest); err == nil {
+			return fmt.Errorf("%s already exists, a self backup is only restored into a new install", dest)
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("stat %s: %w", dest, err)
+		}
+	}
+
+	r := restic.NewRepo(resticPath, uri, restic.WithEnviron())
+	snapshots, err := r.Snapshots(ctx, restic.WithFlags("--tag", repo.TagSelfBackup))
+	if err != nil {
+		return fmt.Errorf("list self backups: %w", err)
+	}
+	snapshot, err := selectSelfBackup(snapshots, instanceID, snapshotID)
+	if err != nil {
+		return err
+	}
+	if len(snapshot.Paths) != 1 {
+		return fmt.Errorf("self backup %s has %d paths, expected 1", snapshot.Id, len(snapshot.Paths))
+	}
+	dir := snapshotDirPath(snapshot.Paths[0])
+
+	_, entries, err := r.ListDirectory(ctx, snapshot.Id, dir)
+	if err != nil {
+		return fmt.Errorf("list files in self backup %s: %w", snapshot.Id, err)
+	}
+	zap.L().Info("restoring self backup", zap.String("snapshot", snapshot.Id), zap.String("time", snapshot.Time), zap.Strings("tags", snapshot.Tags))
+
+	restored := 0
+	for _, entry := range entries {
+		dest, ok := dests[entry.Name]
+		if !ok || entry.Type != "file" || path.Dir(entry.Path) != dir {
+			continue
+		}
+		if err := dumpFile(ctx, r, snapshot.Id, entry.Path, dest); err != nil {
+			return fmt.Errorf("restore %s: %w", entry.Name, err)
+		}
+		zap.L().Info("restored file from self backup", zap.String("file", entry.Name), zap.String("dest", dest))
+		restored++
+	}
+	if restored == 0 {
+		return fmt.Errorf("self backup %s contains no backrest state", snapshot.Id)
+	}
+	return nil
+}
+
+// selectSelfBackup returns the instance's self backup with the given ID (or ID prefix), or its latest self backup if the
+// ID is "latest". The instance may only be omitted if the self backups are all of one instance.
+func selectSelfBackup(snapshots []*restic.Snapshot, instanceID, snapshotID string) (*restic.Snapshot, error) {
+	if instanceID != "" {
+		snapshots = slices.DeleteFunc(slices.Clone(snapshots), func(s *restic.Snapshot) bool {
+			return repo.InstanceIDFromTags(s.Tags) != instanceID
+		})
+		if len(snapshots) == 0 {
+			return nil, fmt.Errorf("no self backups of instance %q found in the repo", instanceID)
+		}
+	}
+	if len(snapshots) == 0 {
+		return nil, errors.New("no self backups found in the repo")
+	}
+	if instanceID == "" {
+		var instances []string
+		for _, snapshot := range snapshots {
+			if id := repo.InstanceIDFromTags(snapshot.Tags); !slices.Contains(instances, id) {
+				instances = append(instances, id)
+			}
+		}
+		if len(instances) > 1 {
+			slices.Sort(instances)
+			return nil, fmt.Errorf("the repo holds self backups of instances %q, select one with -restore-self-backup-instance", instances)
+		}
+	}
+	if snapshotID == "latest" {
+		return slices.MaxFunc(snapshots, func(a, b *restic.Snapshot) int {
+			return cmp.Compare(a.UnixTimeMs(), b.UnixTimeMs())
+		}), nil
+	}
+	for _, snapshot := range snapshots {
+		if strings.HasPrefix(snapshot.Id, snapshotID) {
+			return snapshot, nil
+		}
+	}
+	return nil, fmt.Errorf("self backup %q not found in the repo", snapshotID)
+}
+
+// snapshotDirPath returns the path of a backed up directory within a snapshot, restic stores windows paths as /C/dir.
+func snapshotDirPath(dir string) string {
+	if vol := filepath.VolumeName(dir); len(vol) == 2 && vol[1] == ':' {
+		return "/" + vol[:1] + filepath.ToSlash(dir[len(vol):])
+	}
+	return filepath.ToSlash(dir)
+}
+
+// dumpFile writes a file from the snapshot to dest, the file is only moved into place once it's complete.
+func dumpFile(ctx context.Context, r *restic.Repo, snapshotID, file, dest string) error {
+	if err := os.MkdirAll(filepath.Dir(dest), 0700); err != nil {
+		return fmt.Errorf("create directory: %w", err)
+	}
+	tmp := dest + ".restore-tmp"
+	out, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp)
+	if err := r.Dump(ctx, snapshotID, file, out); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, dest)
+}
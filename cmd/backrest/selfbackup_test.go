@@ -0,0 +1,115 @@
+package main
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/garethgeorge/backrest/internal/orchestrator/repo"
+	"github.com/garethgeorge/backrest/pkg/restic"
+)
+
+func TestSelectSelfBackup(t *testing.T) {
+	selfBackup := func(id, instance, time string) *restic.Snapshot {
+		return &restic.Snapshot{Id: id, Time: time, Tags: []string{repo.TagSelfBackup, repo.TagForInstance(instance)}}
+	}
+	snapshots := []*restic.Snapshot{
+		selfBackup("aaaa1111", "laptop", "2025-01-01T12:00:00Z"),
+		selfBackup("bbbb2222", "laptop", "2025-01-03T12:00:00Z"),
+		selfBackup("cccc3333", "server", "2025-01-04T12:00:00Z"),
+		selfBackup("dddd4444", "server", "2025-01-02T12:00:00Z"),
+	}
+
+	tests := []struct {
+		name       string
+		snapshots  []*restic.Snapshot
+		instanceID string
+		snapshotID string
+		want       string
+		wantErr    bool
+	}{
+		{
+			name:       "latest of instance",
+			snapshots:  snapshots,
+			instanceID: "laptop",
+			snapshotID: "latest",
+			want:       "bbbb2222",
+		},
+		{
+			name:       "latest of the only instance",
+			snapshots:  snapshots[2:],
+			snapshotID: "latest",
+			want:       "cccc3333",
+		},
+		{
+			name:       "latest of several instances requires an instance",
+			snapshots:  snapshots,
+			snapshotID: "latest",
+			wantErr:    true,
+		},
+		{
+			name:       "ID prefix",
+			snapshots:  snapshots,
+			instanceID: "server",
+			snapshotID: "dddd",
+			want:       "dddd4444",
+		},
+		{
+			name:       "ID of another instance",
+			snapshots:  snapshots,
+			instanceID: "laptop",
+			snapshotID: "cccc3333",
+			wantErr:    true,
+		},
+		{
+			name:       "unknown instance",
+			snapshots:  snapshots,
+			instanceID: "desktop",
+			snapshotID: "latest",
+			wantErr:    true,
+		},
+		{
+			name:       "no self backups",
+			snapshotID: "latest",
+			wantErr:    true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := selectSelfBackup(tc.snapshots, tc.instanceID, tc.snapshotID)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("selectSelfBackup() = %v, want an error", got.Id)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("selectSelfBackup() error = %v", err)
+			}
+			if got.Id != tc.want {
+				t.Errorf("selectSelfBackup() = %v, want %v", got.Id, tc.want)
+			}
+		})
+	}
+}
+
+func TestSnapshotDirPath(t *testing.T) {
+	tests := []struct {
+		dir         string
+		want        string
+		windowsOnly bool // volume names are only parsed on windows.
+	}{
+		{dir: "/var/lib/backrest/self-backup", want: "/var/lib/backrest/self-backup"},
+		{dir: `C:\Users\backrest\AppData\Roaming\backrest\self-backup`, want: "/C/Users/backrest/AppData/Roaming/backrest/self-backup", windowsOnly: true},
+		{dir: `D:\self-backup`, want: "/D/self-backup", windowsOnly: true},
+	}
+
+	for _, tc := range tests {
+		if tc.windowsOnly && runtime.GOOS != "windows" {
+			continue
+		}
+		if got := snapshotDirPath(tc.dir); got != tc.want {
+			t.Errorf("snapshotDirPath(%q) = %q, want %q", tc.dir, got, tc.want)
+		}
+	}
+}
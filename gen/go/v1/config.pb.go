@@ -76,7 +76,7 @@ func (x Multihost_Permission_Type) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Multihost_Permission_Type.Descriptor instead.
 func (Multihost_Permission_Type) EnumDescriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{1, 1, 0}
+	return file_v1_config_proto_rawDescGZIP(), []int{4, 1, 0}
 }
 
 type CommandPrefix_IONiceLevel int32
@@ -128,7 +128,7 @@ func (x CommandPrefix_IONiceLevel) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use CommandPrefix_IONiceLevel.Descriptor instead.
 func (CommandPrefix_IONiceLevel) EnumDescriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{4, 0}
+	return file_v1_config_proto_rawDescGZIP(), []int{7, 0}
 }
 
 type CommandPrefix_CPUNiceLevel int32
@@ -177,7 +177,7 @@ func (x CommandPrefix_CPUNiceLevel) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use CommandPrefix_CPUNiceLevel.Descriptor instead.
 func (CommandPrefix_CPUNiceLevel) EnumDescriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{4, 1}
+	return file_v1_config_proto_rawDescGZIP(), []int{7, 1}
 }
 
 type Schedule_Clock int32
@@ -229,7 +229,7 @@ func (x Schedule_Clock) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Schedule_Clock.Descriptor instead.
 func (Schedule_Clock) EnumDescriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{8, 0}
+	return file_v1_config_proto_rawDescGZIP(), []int{12, 0}
 }
 
 type Hook_Condition int32
@@ -255,6 +255,25 @@ const (
 	Hook_CONDITION_FORGET_START   Hook_Condition = 300 // forget started.
 	Hook_CONDITION_FORGET_ERROR   Hook_Condition = 301 // forget failed.
 	Hook_CONDITION_FORGET_SUCCESS Hook_Condition = 302 // forget succeeded.
+	// staleness conditions
+	Hook_CONDITION_PLAN_STALE     Hook_Condition = 400 // plan's last successful backup is older than its stale_after_hours.
+	Hook_CONDITION_PLAN_RECOVERED Hook_Condition = 401 // a backup succeeded for a plan that was stale.
+	// restore conditions
+	Hook_CONDITION_RESTORE_START   Hook_Condition = 500 // restore started.
+	Hook_CONDITION_RESTORE_ERROR   Hook_Condition = 501 // restore failed.
+	Hook_CONDITION_RESTORE_SUCCESS Hook_Condition = 502 // restore succeeded.
+	// stats conditions
+	Hook_CONDITION_STATS_START   Hook_Condition = 600 // stats started.
+	Hook_CONDITION_STATS_ERROR   Hook_Condition = 601 // stats failed.
+	Hook_CONDITION_STATS_SUCCESS Hook_Condition = 602 // stats succeeded.
+	// index snapshots conditions
+	Hook_CONDITION_INDEX_SNAPSHOTS_START   Hook_Condition = 700 // snapshot indexing started.
+	Hook_CONDITION_INDEX_SNAPSHOTS_ERROR   Hook_Condition = 701 // snapshot indexing failed.
+	Hook_CONDITION_INDEX_SNAPSHOTS_SUCCESS Hook_Condition = 702 // snapshot indexing succeeded.
+	// run command conditions
+	Hook_CONDITION_RUN_COMMAND_START   Hook_Condition = 800 // run command started.
+	Hook_CONDITION_RUN_COMMAND_ERROR   Hook_Condition = 801 // run command failed.
+	Hook_CONDITION_RUN_COMMAND_SUCCESS Hook_Condition = 802 // run command succeeded.
 )
 
 // Enum value maps for Hook_Condition.
@@ -277,25 +296,53 @@ var (
 		300: "CONDITION_FORGET_START",
 		301: "CONDITION_FORGET_ERROR",
 		302: "CONDITION_FORGET_SUCCESS",
+		400: "CONDITION_PLAN_STALE",
+		401: "CONDITION_PLAN_RECOVERED",
+		500: "CONDITION_RESTORE_START",
+		501: "CONDITION_RESTORE_ERROR",
+		502: "CONDITION_RESTORE_SUCCESS",
+		600: "CONDITION_STATS_START",
+		601: "CONDITION_STATS_ERROR",
+		602: "CONDITION_STATS_SUCCESS",
+		700: "CONDITION_INDEX_SNAPSHOTS_START",
+		701: "CONDITION_INDEX_SNAPSHOTS_ERROR",
+		702: "CONDITION_INDEX_SNAPSHOTS_SUCCESS",
+		800: "CONDITION_RUN_COMMAND_START",
+		801: "CONDITION_RUN_COMMAND_ERROR",
+		802: "CONDITION_RUN_COMMAND_SUCCESS",
 	}
 	Hook_Condition_value = map[string]int32{
-		"CONDITION_UNKNOWN":          0,
-		"CONDITION_ANY_ERROR":        1,
-		"CONDITION_SNAPSHOT_START":   2,
-		"CONDITION_SNAPSHOT_END":     3,
-		"CONDITION_SNAPSHOT_ERROR":   4,
-		"CONDITION_SNAPSHOT_WARNING": 5,
-		"CONDITION_SNAPSHOT_SUCCESS": 6,
-		"CONDITION_SNAPSHOT_SKIPPED": 7,
-		"CONDITION_PRUNE_START":      100,
-		"CONDITION_PRUNE_ERROR":      101,
-		"CONDITION_PRUNE_SUCCESS":    102,
-		"CONDITION_CHECK_START":      200,
-		"CONDITION_CHECK_ERROR":      201,
-		"CONDITION_CHECK_SUCCESS":    202,
-		"CONDITION_FORGET_START":     300,
-		"CONDITION_FORGET_ERROR":     301,
-		"CONDITION_FORGET_SUCCESS":   302,
+		"CONDITION_UNKNOWN":                 0,
+		"CONDITION_ANY_ERROR":               1,
+		"CONDITION_SNAPSHOT_START":          2,
+		"CONDITION_SNAPSHOT_END":            3,
+		"CONDITION_SNAPSHOT_ERROR":          4,
+		"CONDITION_SNAPSHOT_WARNING":        5,
+		"CONDITION_SNAPSHOT_SUCCESS":        6,
+		"CONDITION_SNAPSHOT_SKIPPED":        7,
+		"CONDITION_PRUNE_START":             100,
+		"CONDITION_PRUNE_ERROR":             101,
+		"CONDITION_PRUNE_SUCCESS":           102,
+		"CONDITION_CHECK_START":             200,
+		"CONDITION_CHECK_ERROR":             201,
+		"CONDITION_CHECK_SUCCESS":           202,
+		"CONDITION_FORGET_START":            300,
+		"CONDITION_FORGET_ERROR":            301,
+		"CONDITION_FORGET_SUCCESS":          302,
+		"CONDITION_PLAN_STALE":              400,
+		"CONDITION_PLAN_RECOVERED":          401,
+		"CONDITION_RESTORE_START":           500,
+		"CONDITION_RESTORE_ERROR":           501,
+		"CONDITION_RESTORE_SUCCESS":         502,
+		"CONDITION_STATS_START":             600,
+		"CONDITION_STATS_ERROR":             601,
+		"CONDITION_STATS_SUCCESS":           602,
+		"CONDITION_INDEX_SNAPSHOTS_START":   700,
+		"CONDITION_INDEX_SNAPSHOTS_ERROR":   701,
+		"CONDITION_INDEX_SNAPSHOTS_SUCCESS": 702,
+		"CONDITION_RUN_COMMAND_START":       800,
+		"CONDITION_RUN_COMMAND_ERROR":       801,
+		"CONDITION_RUN_COMMAND_SUCCESS":     802,
 	}
 )
 
@@ -323,7 +370,7 @@ func (x Hook_Condition) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Hook_Condition.Descriptor instead.
 func (Hook_Condition) EnumDescriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{9, 0}
+	return file_v1_config_proto_rawDescGZIP(), []int{13, 0}
 }
 
 type Hook_OnError int32
@@ -381,7 +428,7 @@ func (x Hook_OnError) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Hook_OnError.Descriptor instead.
 func (Hook_OnError) EnumDescriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{9, 1}
+	return file_v1_config_proto_rawDescGZIP(), []int{13, 1}
 }
 
 type Hook_Webhook_Method int32
@@ -390,6 +437,8 @@ const (
 	Hook_Webhook_UNKNOWN Hook_Webhook_Method = 0
 	Hook_Webhook_GET     Hook_Webhook_Method = 1
 	Hook_Webhook_POST    Hook_Webhook_Method = 2
+	Hook_Webhook_PUT     Hook_Webhook_Method = 3
+	Hook_Webhook_PATCH   Hook_Webhook_Method = 4
 )
 
 // Enum value maps for Hook_Webhook_Method.
@@ -398,11 +447,15 @@ var (
 		0: "UNKNOWN",
 		1: "GET",
 		2: "POST",
+		3: "PUT",
+		4: "PATCH",
 	}
 	Hook_Webhook_Method_value = map[string]int32{
 		"UNKNOWN": 0,
 		"GET":     1,
 		"POST":    2,
+		"PUT":     3,
+		"PATCH":   4,
 	}
 )
 
@@ -430,7 +483,56 @@ func (x Hook_Webhook_Method) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Hook_Webhook_Method.Descriptor instead.
 func (Hook_Webhook_Method) EnumDescriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{9, 1, 0}
+	return file_v1_config_proto_rawDescGZIP(), []int{13, 2, 0}
+}
+
+type Hook_Email_Security int32
+
+const (
+	Hook_Email_SECURITY_STARTTLS Hook_Email_Security = 0 // upgrade the connection with STARTTLS, fails if the server does not support it.
+	Hook_Email_SECURITY_TLS      Hook_Email_Security = 1 // implicit TLS e.g. port 465.
+	Hook_Email_SECURITY_NONE     Hook_Email_Security = 2 // plaintext, only recommended for local relays.
+)
+
+// Enum value maps for Hook_Email_Security.
+var (
+	Hook_Email_Security_name = map[int32]string{
+		0: "SECURITY_STARTTLS",
+		1: "SECURITY_TLS",
+		2: "SECURITY_NONE",
+	}
+	Hook_Email_Security_value = map[string]int32{
+		"SECURITY_STARTTLS": 0,
+		"SECURITY_TLS":      1,
+		"SECURITY_NONE":     2,
+	}
+)
+
+func (x Hook_Email_Security) Enum() *Hook_Email_Security {
+	p := new(Hook_Email_Security)
+	*p = x
+	return p
+}
+
+func (x Hook_Email_Security) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Hook_Email_Security) Descriptor() protoreflect.EnumDescriptor {
+	return file_v1_config_proto_enumTypes[7].Descriptor()
+}
+
+func (Hook_Email_Security) Type() protoreflect.EnumType {
+	return &file_v1_config_proto_enumTypes[7]
+}
+
+func (x Hook_Email_Security) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Hook_Email_Security.Descriptor instead.
+func (Hook_Email_Security) EnumDescriptor() ([]byte, []int) {
+	return file_v1_config_proto_rawDescGZIP(), []int{13, 9, 0}
 }
 
 // Config is the top level config object for restic UI.
@@ -441,13 +543,16 @@ type Config struct {
 	Version int32 `protobuf:"varint,6,opt,name=version,proto3" json:"version,omitempty"` // version of the config file format. Used to determine when to run migrations.
 	// The instance name for the Backrest installation.
 	// This identifies backups created by this instance and is displayed in the UI.
-	Instance      string     `protobuf:"bytes,2,opt,name=instance,proto3" json:"instance,omitempty"`
-	Repos         []*Repo    `protobuf:"bytes,3,rep,name=repos,proto3" json:"repos,omitempty"`
-	Plans         []*Plan    `protobuf:"bytes,4,rep,name=plans,proto3" json:"plans,omitempty"`
-	Auth          *Auth      `protobuf:"bytes,5,opt,name=auth,proto3" json:"auth,omitempty"`
-	Multihost     *Multihost `protobuf:"bytes,7,opt,name=multihost,json=sync,proto3" json:"multihost,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	Instance         string                  `protobuf:"bytes,2,opt,name=instance,proto3" json:"instance,omitempty"`
+	Repos            []*Repo                 `protobuf:"bytes,3,rep,name=repos,proto3" json:"repos,omitempty"`
+	Plans            []*Plan                 `protobuf:"bytes,4,rep,name=plans,proto3" json:"plans,omitempty"`
+	Auth             *Auth                   `protobuf:"bytes,5,opt,name=auth,proto3" json:"auth,omitempty"`
+	Multihost        *Multihost              `protobuf:"bytes,7,opt,name=multihost,json=sync,proto3" json:"multihost,omitempty"`
+	GlobalHooks      []*GlobalHook           `protobuf:"bytes,8,rep,name=global_hooks,json=globalHooks,proto3" json:"global_hooks,omitempty"`                // hooks run for events of every repo and plan selected by their scopes.
+	OperationHistory *OperationHistoryPolicy `protobuf:"bytes,9,opt,name=operation_history,json=operationHistory,proto3" json:"operation_history,omitempty"` // how long operations are kept in the operation history, built in defaults apply if unset.
+	SelfBackup       *SelfBackup             `protobuf:"bytes,10,opt,name=self_backup,json=selfBackup,proto3" json:"self_backup,omitempty"`                  // backs up backrest's own state to a repo, disabled if unset.
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *Config) Reset() {
@@ -529,6 +634,196 @@ func (x *Config) GetMultihost() *Multihost {
 	return nil
 }
 
+func (x *Config) GetGlobalHooks() []*GlobalHook {
+	if x != nil {
+		return x.GlobalHooks
+	}
+	return nil
+}
+
+func (x *Config) GetOperationHistory() *OperationHistoryPolicy {
+	if x != nil {
+		return x.OperationHistory
+	}
+	return nil
+}
+
+func (x *Config) GetSelfBackup() *SelfBackup {
+	if x != nil {
+		return x.SelfBackup
+	}
+	return nil
+}
+
+// SelfBackup periodically snapshots backrest's config, operation log and key value store into a repo under a reserved tag.
+// A new install is bootstrapped from the latest snapshot with the -restore-self-backup flag. The config includes repo passwords.
+type SelfBackup struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Repo          string                 `protobuf:"bytes,1,opt,name=repo,proto3" json:"repo,omitempty"`                          // ID of the repo the state is backed up to.
+	Schedule      *Schedule              `protobuf:"bytes,2,opt,name=schedule,proto3" json:"schedule,omitempty"`                  // schedule for the self backup, never runs if unset.
+	KeepLast      int32                  `protobuf:"varint,3,opt,name=keep_last,json=keepLast,proto3" json:"keep_last,omitempty"` // number of self backup snapshots of this instance kept in the repo, defaults to 30 if 0.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SelfBackup) Reset() {
+	*x = SelfBackup{}
+	mi := &file_v1_config_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SelfBackup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SelfBackup) ProtoMessage() {}
+
+func (x *SelfBackup) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_config_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SelfBackup.ProtoReflect.Descriptor instead.
+func (*SelfBackup) Descriptor() ([]byte, []int) {
+	return file_v1_config_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SelfBackup) GetRepo() string {
+	if x != nil {
+		return x.Repo
+	}
+	return ""
+}
+
+func (x *SelfBackup) GetSchedule() *Schedule {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
+func (x *SelfBackup) GetKeepLast() int32 {
+	if x != nil {
+		return x.KeepLast
+	}
+	return 0
+}
+
+// OperationHistoryPolicy controls the garbage collection of the operation history. Snapshots in repos are unaffected.
+type OperationHistoryPolicy struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// rules overriding the built in retention of operations, the most specific rule matching an operation applies (plan and type, then plan, then type).
+	Rules         []*OperationHistoryPolicy_Rule     `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	Compaction    *OperationHistoryPolicy_Compaction `protobuf:"bytes,2,opt,name=compaction,proto3" json:"compaction,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OperationHistoryPolicy) Reset() {
+	*x = OperationHistoryPolicy{}
+	mi := &file_v1_config_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OperationHistoryPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperationHistoryPolicy) ProtoMessage() {}
+
+func (x *OperationHistoryPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_config_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperationHistoryPolicy.ProtoReflect.Descriptor instead.
+func (*OperationHistoryPolicy) Descriptor() ([]byte, []int) {
+	return file_v1_config_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *OperationHistoryPolicy) GetRules() []*OperationHistoryPolicy_Rule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *OperationHistoryPolicy) GetCompaction() *OperationHistoryPolicy_Compaction {
+	if x != nil {
+		return x.Compaction
+	}
+	return nil
+}
+
+type GlobalHook struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Hook  *Hook                  `protobuf:"bytes,1,opt,name=hook,proto3" json:"hook,omitempty"`
+	// repos and plans the hook applies to in scope set syntax e.g. "*", "repo:<id>", "plan:<id>", "!plan:<id>". Applies to all if empty.
+	Scopes        []string `protobuf:"bytes,2,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GlobalHook) Reset() {
+	*x = GlobalHook{}
+	mi := &file_v1_config_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GlobalHook) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GlobalHook) ProtoMessage() {}
+
+func (x *GlobalHook) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_config_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GlobalHook.ProtoReflect.Descriptor instead.
+func (*GlobalHook) Descriptor() ([]byte, []int) {
+	return file_v1_config_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GlobalHook) GetHook() *Hook {
+	if x != nil {
+		return x.Hook
+	}
+	return nil
+}
+
+func (x *GlobalHook) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
 type Multihost struct {
 	state             protoimpl.MessageState `protogen:"open.v1"`
 	Identity          *PrivateKey            `protobuf:"bytes,1,opt,name=identity,proto3" json:"identity,omitempty"`
@@ -540,7 +835,7 @@ type Multihost struct {
 
 func (x *Multihost) Reset() {
 	*x = Multihost{}
-	mi := &file_v1_config_proto_msgTypes[1]
+	mi := &file_v1_config_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -552,7 +847,7 @@ func (x *Multihost) String() string {
 func (*Multihost) ProtoMessage() {}
 
 func (x *Multihost) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_config_proto_msgTypes[1]
+	mi := &file_v1_config_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -565,7 +860,7 @@ func (x *Multihost) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Multihost.ProtoReflect.Descriptor instead.
 func (*Multihost) Descriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{1}
+	return file_v1_config_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *Multihost) GetIdentity() *PrivateKey {
@@ -590,26 +885,27 @@ func (x *Multihost) GetAuthorizedClients() []*Multihost_Peer {
 }
 
 type Repo struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                                 // unique but human readable ID for this repo.
-	Uri            string                 `protobuf:"bytes,2,opt,name=uri,proto3" json:"uri,omitempty"`                                               // URI of the repo.
-	Guid           string                 `protobuf:"bytes,11,opt,name=guid,proto3" json:"guid,omitempty"`                                            // a globally unique ID for this repo. Should be derived as the 'id' field in `restic cat config --json`.
-	Password       string                 `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`                                     // plaintext password
-	Env            []string               `protobuf:"bytes,4,rep,name=env,proto3" json:"env,omitempty"`                                               // extra environment variables to set for restic.
-	Flags          []string               `protobuf:"bytes,5,rep,name=flags,proto3" json:"flags,omitempty"`                                           // extra flags set on the restic command.
-	PrunePolicy    *PrunePolicy           `protobuf:"bytes,6,opt,name=prune_policy,json=prunePolicy,proto3" json:"prune_policy,omitempty"`            // policy for when to run prune.
-	CheckPolicy    *CheckPolicy           `protobuf:"bytes,9,opt,name=check_policy,json=checkPolicy,proto3" json:"check_policy,omitempty"`            // policy for when to run check.
-	Hooks          []*Hook                `protobuf:"bytes,7,rep,name=hooks,proto3" json:"hooks,omitempty"`                                           // hooks to run on events for this repo.
-	AutoUnlock     bool                   `protobuf:"varint,8,opt,name=auto_unlock,json=autoUnlock,proto3" json:"auto_unlock,omitempty"`              // automatically unlock the repo when needed.
-	AutoInitialize bool                   `protobuf:"varint,12,opt,name=auto_initialize,json=autoInitialize,proto3" json:"auto_initialize,omitempty"` // whether the repo should be auto-initialized if not found.
-	CommandPrefix  *CommandPrefix         `protobuf:"bytes,10,opt,name=command_prefix,json=commandPrefix,proto3" json:"command_prefix,omitempty"`     // modifiers for the restic commands
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                                   // unique but human readable ID for this repo.
+	Uri             string                 `protobuf:"bytes,2,opt,name=uri,proto3" json:"uri,omitempty"`                                                 // URI of the repo.
+	Guid            string                 `protobuf:"bytes,11,opt,name=guid,proto3" json:"guid,omitempty"`                                              // a globally unique ID for this repo. Should be derived as the 'id' field in `restic cat config --json`.
+	Password        string                 `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`                                       // plaintext password
+	Env             []string               `protobuf:"bytes,4,rep,name=env,proto3" json:"env,omitempty"`                                                 // extra environment variables to set for restic.
+	Flags           []string               `protobuf:"bytes,5,rep,name=flags,proto3" json:"flags,omitempty"`                                             // extra flags set on the restic command.
+	PrunePolicy     *PrunePolicy           `protobuf:"bytes,6,opt,name=prune_policy,json=prunePolicy,proto3" json:"prune_policy,omitempty"`              // policy for when to run prune.
+	CheckPolicy     *CheckPolicy           `protobuf:"bytes,9,opt,name=check_policy,json=checkPolicy,proto3" json:"check_policy,omitempty"`              // policy for when to run check.
+	Hooks           []*Hook                `protobuf:"bytes,7,rep,name=hooks,proto3" json:"hooks,omitempty"`                                             // hooks to run on events for this repo.
+	AutoUnlock      bool                   `protobuf:"varint,8,opt,name=auto_unlock,json=autoUnlock,proto3" json:"auto_unlock,omitempty"`                // automatically unlock the repo when needed.
+	AutoInitialize  bool                   `protobuf:"varint,12,opt,name=auto_initialize,json=autoInitialize,proto3" json:"auto_initialize,omitempty"`   // whether the repo should be auto-initialized if not found.
+	CommandPrefix   *CommandPrefix         `protobuf:"bytes,10,opt,name=command_prefix,json=commandPrefix,proto3" json:"command_prefix,omitempty"`       // modifiers for the restic commands
+	RetentionPolicy *RepoRetentionPolicy   `protobuf:"bytes,13,opt,name=retention_policy,json=retentionPolicy,proto3" json:"retention_policy,omitempty"` // optional, retention applied to snapshots across the repo e.g. those not owned by any plan.
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *Repo) Reset() {
 	*x = Repo{}
-	mi := &file_v1_config_proto_msgTypes[2]
+	mi := &file_v1_config_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -621,7 +917,7 @@ func (x *Repo) String() string {
 func (*Repo) ProtoMessage() {}
 
 func (x *Repo) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_config_proto_msgTypes[2]
+	mi := &file_v1_config_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -634,7 +930,7 @@ func (x *Repo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Repo.ProtoReflect.Descriptor instead.
 func (*Repo) Descriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{2}
+	return file_v1_config_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *Repo) GetId() string {
@@ -721,6 +1017,13 @@ func (x *Repo) GetCommandPrefix() *CommandPrefix {
 	return nil
 }
 
+func (x *Repo) GetRetentionPolicy() *RepoRetentionPolicy {
+	if x != nil {
+		return x.RetentionPolicy
+	}
+	return nil
+}
+
 type Plan struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                                      // unique but human readable ID for this plan.
@@ -733,13 +1036,14 @@ type Plan struct {
 	Hooks           []*Hook                `protobuf:"bytes,8,rep,name=hooks,proto3" json:"hooks,omitempty"`                                                // hooks to run on events for this plan.
 	BackupFlags     []string               `protobuf:"bytes,10,rep,name=backup_flags,proto3" json:"backup_flags,omitempty"`                                 // extra flags to set when running a backup command.
 	SkipIfUnchanged bool                   `protobuf:"varint,13,opt,name=skip_if_unchanged,json=skipIfUnchanged,proto3" json:"skip_if_unchanged,omitempty"` // skip the backup if no changes are detected.
+	StaleAfterHours int32                  `protobuf:"varint,14,opt,name=stale_after_hours,json=staleAfterHours,proto3" json:"stale_after_hours,omitempty"` // fire CONDITION_PLAN_STALE hooks if no backup succeeds for this many hours, 0 disables.
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
 
 func (x *Plan) Reset() {
 	*x = Plan{}
-	mi := &file_v1_config_proto_msgTypes[3]
+	mi := &file_v1_config_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -751,7 +1055,7 @@ func (x *Plan) String() string {
 func (*Plan) ProtoMessage() {}
 
 func (x *Plan) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_config_proto_msgTypes[3]
+	mi := &file_v1_config_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -764,7 +1068,7 @@ func (x *Plan) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Plan.ProtoReflect.Descriptor instead.
 func (*Plan) Descriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{3}
+	return file_v1_config_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *Plan) GetId() string {
@@ -837,6 +1141,13 @@ func (x *Plan) GetSkipIfUnchanged() bool {
 	return false
 }
 
+func (x *Plan) GetStaleAfterHours() int32 {
+	if x != nil {
+		return x.StaleAfterHours
+	}
+	return 0
+}
+
 type CommandPrefix struct {
 	state         protoimpl.MessageState     `protogen:"open.v1"`
 	IoNice        CommandPrefix_IONiceLevel  `protobuf:"varint,1,opt,name=io_nice,json=ioNice,proto3,enum=v1.CommandPrefix_IONiceLevel" json:"io_nice,omitempty"`     // ionice level to set.
@@ -847,7 +1158,7 @@ type CommandPrefix struct {
 
 func (x *CommandPrefix) Reset() {
 	*x = CommandPrefix{}
-	mi := &file_v1_config_proto_msgTypes[4]
+	mi := &file_v1_config_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -859,7 +1170,7 @@ func (x *CommandPrefix) String() string {
 func (*CommandPrefix) ProtoMessage() {}
 
 func (x *CommandPrefix) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_config_proto_msgTypes[4]
+	mi := &file_v1_config_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -872,7 +1183,7 @@ func (x *CommandPrefix) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CommandPrefix.ProtoReflect.Descriptor instead.
 func (*CommandPrefix) Descriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{4}
+	return file_v1_config_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *CommandPrefix) GetIoNice() CommandPrefix_IONiceLevel {
@@ -896,14 +1207,19 @@ type RetentionPolicy struct {
 	//	*RetentionPolicy_PolicyKeepLastN
 	//	*RetentionPolicy_PolicyTimeBucketed
 	//	*RetentionPolicy_PolicyKeepAll
-	Policy        isRetentionPolicy_Policy `protobuf_oneof:"policy"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	Policy isRetentionPolicy_Policy `protobuf_oneof:"policy"`
+	// Rules below are applied in addition to the policy above, a snapshot matched by any rule is kept. They have no effect with policy_keep_all.
+	KeepWithin        string                                 `protobuf:"bytes,13,opt,name=keep_within,json=keepWithin,proto3" json:"keep_within,omitempty"`                        // keep all snapshots within this duration of the newest snapshot e.g. "14d" or "1y6m". Durations use restic's format of years (y), months (m), days (d) and hours (h).
+	KeepWithinBuckets *RetentionPolicy_TimeBucketedDurations `protobuf:"bytes,14,opt,name=keep_within_buckets,json=keepWithinBuckets,proto3" json:"keep_within_buckets,omitempty"` // keep the newest snapshot of each bucket within the given durations.
+	KeepTags          []string                               `protobuf:"bytes,15,rep,name=keep_tags,json=keepTags,proto3" json:"keep_tags,omitempty"`                              // keep snapshots carrying all the tags of any entry, each entry is a comma separated tag list.
+	GroupBy           string                                 `protobuf:"bytes,16,opt,name=group_by,json=groupBy,proto3" json:"group_by,omitempty"`                                 // group snapshots by any of "host", "paths" and "tags" (comma separated) before applying the policy. If empty all snapshots of the plan form a single group.
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *RetentionPolicy) Reset() {
 	*x = RetentionPolicy{}
-	mi := &file_v1_config_proto_msgTypes[5]
+	mi := &file_v1_config_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -915,7 +1231,7 @@ func (x *RetentionPolicy) String() string {
 func (*RetentionPolicy) ProtoMessage() {}
 
 func (x *RetentionPolicy) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_config_proto_msgTypes[5]
+	mi := &file_v1_config_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -928,7 +1244,7 @@ func (x *RetentionPolicy) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RetentionPolicy.ProtoReflect.Descriptor instead.
 func (*RetentionPolicy) Descriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{5}
+	return file_v1_config_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *RetentionPolicy) GetPolicy() isRetentionPolicy_Policy {
@@ -965,6 +1281,34 @@ func (x *RetentionPolicy) GetPolicyKeepAll() bool {
 	return false
 }
 
+func (x *RetentionPolicy) GetKeepWithin() string {
+	if x != nil {
+		return x.KeepWithin
+	}
+	return ""
+}
+
+func (x *RetentionPolicy) GetKeepWithinBuckets() *RetentionPolicy_TimeBucketedDurations {
+	if x != nil {
+		return x.KeepWithinBuckets
+	}
+	return nil
+}
+
+func (x *RetentionPolicy) GetKeepTags() []string {
+	if x != nil {
+		return x.KeepTags
+	}
+	return nil
+}
+
+func (x *RetentionPolicy) GetGroupBy() string {
+	if x != nil {
+		return x.GroupBy
+	}
+	return ""
+}
+
 type isRetentionPolicy_Policy interface {
 	isRetentionPolicy_Policy()
 }
@@ -987,30 +1331,108 @@ func (*RetentionPolicy_PolicyTimeBucketed) isRetentionPolicy_Policy() {}
 
 func (*RetentionPolicy_PolicyKeepAll) isRetentionPolicy_Policy() {}
 
-type PrunePolicy struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	Schedule         *Schedule              `protobuf:"bytes,2,opt,name=schedule,proto3" json:"schedule,omitempty"`
-	MaxUnusedBytes   int64                  `protobuf:"varint,3,opt,name=max_unused_bytes,json=maxUnusedBytes,proto3" json:"max_unused_bytes,omitempty"`        // max unused bytes before running prune.
-	MaxUnusedPercent float64                `protobuf:"fixed64,4,opt,name=max_unused_percent,json=maxUnusedPercent,proto3" json:"max_unused_percent,omitempty"` // max unused percent before running prune.
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+// RepoRetentionPolicy applies retention to snapshots of the repo that no plan manages e.g. snapshots of plans that were
+// removed or of tools other than backrest. Held snapshots and self backups are never forgotten.
+type RepoRetentionPolicy struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schedule      *Schedule              `protobuf:"bytes,1,opt,name=schedule,proto3" json:"schedule,omitempty"`                           // schedule for applying the policy.
+	Retention     *RetentionPolicy       `protobuf:"bytes,2,opt,name=retention,proto3" json:"retention,omitempty"`                         // retention policy applied to the selected snapshots.
+	Tags          []string               `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`                                   // optional, only snapshots with all of these tags are selected.
+	Hosts         []string               `protobuf:"bytes,4,rep,name=hosts,proto3" json:"hosts,omitempty"`                                 // optional, only snapshots from one of these hosts are selected.
+	OnlyUnowned   bool                   `protobuf:"varint,5,opt,name=only_unowned,json=onlyUnowned,proto3" json:"only_unowned,omitempty"` // only select snapshots that are not owned by a current plan or by another backrest instance, required if any plan backs up to the repo.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PrunePolicy) Reset() {
-	*x = PrunePolicy{}
-	mi := &file_v1_config_proto_msgTypes[6]
+func (x *RepoRetentionPolicy) Reset() {
+	*x = RepoRetentionPolicy{}
+	mi := &file_v1_config_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PrunePolicy) String() string {
+func (x *RepoRetentionPolicy) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PrunePolicy) ProtoMessage() {}
+func (*RepoRetentionPolicy) ProtoMessage() {}
 
-func (x *PrunePolicy) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_config_proto_msgTypes[6]
+func (x *RepoRetentionPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_config_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RepoRetentionPolicy.ProtoReflect.Descriptor instead.
+func (*RepoRetentionPolicy) Descriptor() ([]byte, []int) {
+	return file_v1_config_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RepoRetentionPolicy) GetSchedule() *Schedule {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
+func (x *RepoRetentionPolicy) GetRetention() *RetentionPolicy {
+	if x != nil {
+		return x.Retention
+	}
+	return nil
+}
+
+func (x *RepoRetentionPolicy) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *RepoRetentionPolicy) GetHosts() []string {
+	if x != nil {
+		return x.Hosts
+	}
+	return nil
+}
+
+func (x *RepoRetentionPolicy) GetOnlyUnowned() bool {
+	if x != nil {
+		return x.OnlyUnowned
+	}
+	return false
+}
+
+type PrunePolicy struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Schedule         *Schedule              `protobuf:"bytes,2,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	MaxUnusedBytes   int64                  `protobuf:"varint,3,opt,name=max_unused_bytes,json=maxUnusedBytes,proto3" json:"max_unused_bytes,omitempty"`        // max unused bytes before running prune.
+	MaxUnusedPercent float64                `protobuf:"fixed64,4,opt,name=max_unused_percent,json=maxUnusedPercent,proto3" json:"max_unused_percent,omitempty"` // max unused percent before running prune.
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *PrunePolicy) Reset() {
+	*x = PrunePolicy{}
+	mi := &file_v1_config_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PrunePolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrunePolicy) ProtoMessage() {}
+
+func (x *PrunePolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_config_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1023,7 +1445,7 @@ func (x *PrunePolicy) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PrunePolicy.ProtoReflect.Descriptor instead.
 func (*PrunePolicy) Descriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{6}
+	return file_v1_config_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *PrunePolicy) GetSchedule() *Schedule {
@@ -1061,7 +1483,7 @@ type CheckPolicy struct {
 
 func (x *CheckPolicy) Reset() {
 	*x = CheckPolicy{}
-	mi := &file_v1_config_proto_msgTypes[7]
+	mi := &file_v1_config_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1073,7 +1495,7 @@ func (x *CheckPolicy) String() string {
 func (*CheckPolicy) ProtoMessage() {}
 
 func (x *CheckPolicy) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_config_proto_msgTypes[7]
+	mi := &file_v1_config_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1086,7 +1508,7 @@ func (x *CheckPolicy) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CheckPolicy.ProtoReflect.Descriptor instead.
 func (*CheckPolicy) Descriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{7}
+	return file_v1_config_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *CheckPolicy) GetSchedule() *Schedule {
@@ -1153,7 +1575,7 @@ type Schedule struct {
 
 func (x *Schedule) Reset() {
 	*x = Schedule{}
-	mi := &file_v1_config_proto_msgTypes[8]
+	mi := &file_v1_config_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1165,7 +1587,7 @@ func (x *Schedule) String() string {
 func (*Schedule) ProtoMessage() {}
 
 func (x *Schedule) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_config_proto_msgTypes[8]
+	mi := &file_v1_config_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1178,7 +1600,7 @@ func (x *Schedule) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Schedule.ProtoReflect.Descriptor instead.
 func (*Schedule) Descriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{8}
+	return file_v1_config_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *Schedule) GetSchedule() isSchedule_Schedule {
@@ -1260,9 +1682,12 @@ func (*Schedule_MaxFrequencyDays) isSchedule_Schedule() {}
 func (*Schedule_MaxFrequencyHours) isSchedule_Schedule() {}
 
 type Hook struct {
-	state      protoimpl.MessageState `protogen:"open.v1"`
-	Conditions []Hook_Condition       `protobuf:"varint,1,rep,packed,name=conditions,proto3,enum=v1.Hook_Condition" json:"conditions,omitempty"`
-	OnError    Hook_OnError           `protobuf:"varint,2,opt,name=on_error,json=onError,proto3,enum=v1.Hook_OnError" json:"on_error,omitempty"`
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Conditions     []Hook_Condition       `protobuf:"varint,1,rep,packed,name=conditions,proto3,enum=v1.Hook_Condition" json:"conditions,omitempty"`
+	OnError        Hook_OnError           `protobuf:"varint,2,opt,name=on_error,json=onError,proto3,enum=v1.Hook_OnError" json:"on_error,omitempty"`
+	Digest         *Hook_Digest           `protobuf:"bytes,3,opt,name=digest,proto3" json:"digest,omitempty"`                                        // if set, events are buffered and sent as a single summary on the digest's schedule.
+	TimeoutSeconds int32                  `protobuf:"varint,4,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"` // if > 0, the hook is cancelled after this many seconds and the timeout is handled by on_error.
+	Parallel       bool                   `protobuf:"varint,5,opt,name=parallel,proto3" json:"parallel,omitempty"`                                   // if set, the hook may run concurrently with adjacent parallel hooks triggered by the same event.
 	// Types that are valid to be assigned to Action:
 	//
 	//	*Hook_ActionCommand
@@ -1273,6 +1698,10 @@ type Hook struct {
 	//	*Hook_ActionShoutrrr
 	//	*Hook_ActionHealthchecks
 	//	*Hook_ActionTelegram
+	//	*Hook_ActionEmail
+	//	*Hook_ActionNtfy
+	//	*Hook_ActionPushover
+	//	*Hook_ActionMatrix
 	Action        isHook_Action `protobuf_oneof:"action"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -1280,7 +1709,7 @@ type Hook struct {
 
 func (x *Hook) Reset() {
 	*x = Hook{}
-	mi := &file_v1_config_proto_msgTypes[9]
+	mi := &file_v1_config_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1292,7 +1721,7 @@ func (x *Hook) String() string {
 func (*Hook) ProtoMessage() {}
 
 func (x *Hook) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_config_proto_msgTypes[9]
+	mi := &file_v1_config_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1305,7 +1734,7 @@ func (x *Hook) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Hook.ProtoReflect.Descriptor instead.
 func (*Hook) Descriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{9}
+	return file_v1_config_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *Hook) GetConditions() []Hook_Condition {
@@ -1322,6 +1751,27 @@ func (x *Hook) GetOnError() Hook_OnError {
 	return Hook_ON_ERROR_IGNORE
 }
 
+func (x *Hook) GetDigest() *Hook_Digest {
+	if x != nil {
+		return x.Digest
+	}
+	return nil
+}
+
+func (x *Hook) GetTimeoutSeconds() int32 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
+func (x *Hook) GetParallel() bool {
+	if x != nil {
+		return x.Parallel
+	}
+	return false
+}
+
 func (x *Hook) GetAction() isHook_Action {
 	if x != nil {
 		return x.Action
@@ -1401,6 +1851,42 @@ func (x *Hook) GetActionTelegram() *Hook_Telegram {
 	return nil
 }
 
+func (x *Hook) GetActionEmail() *Hook_Email {
+	if x != nil {
+		if x, ok := x.Action.(*Hook_ActionEmail); ok {
+			return x.ActionEmail
+		}
+	}
+	return nil
+}
+
+func (x *Hook) GetActionNtfy() *Hook_Ntfy {
+	if x != nil {
+		if x, ok := x.Action.(*Hook_ActionNtfy); ok {
+			return x.ActionNtfy
+		}
+	}
+	return nil
+}
+
+func (x *Hook) GetActionPushover() *Hook_Pushover {
+	if x != nil {
+		if x, ok := x.Action.(*Hook_ActionPushover); ok {
+			return x.ActionPushover
+		}
+	}
+	return nil
+}
+
+func (x *Hook) GetActionMatrix() *Hook_Matrix {
+	if x != nil {
+		if x, ok := x.Action.(*Hook_ActionMatrix); ok {
+			return x.ActionMatrix
+		}
+	}
+	return nil
+}
+
 type isHook_Action interface {
 	isHook_Action()
 }
@@ -1437,6 +1923,22 @@ type Hook_ActionTelegram struct {
 	ActionTelegram *Hook_Telegram `protobuf:"bytes,107,opt,name=action_telegram,json=actionTelegram,proto3,oneof"`
 }
 
+type Hook_ActionEmail struct {
+	ActionEmail *Hook_Email `protobuf:"bytes,108,opt,name=action_email,json=actionEmail,proto3,oneof"`
+}
+
+type Hook_ActionNtfy struct {
+	ActionNtfy *Hook_Ntfy `protobuf:"bytes,109,opt,name=action_ntfy,json=actionNtfy,proto3,oneof"`
+}
+
+type Hook_ActionPushover struct {
+	ActionPushover *Hook_Pushover `protobuf:"bytes,110,opt,name=action_pushover,json=actionPushover,proto3,oneof"`
+}
+
+type Hook_ActionMatrix struct {
+	ActionMatrix *Hook_Matrix `protobuf:"bytes,111,opt,name=action_matrix,json=actionMatrix,proto3,oneof"`
+}
+
 func (*Hook_ActionCommand) isHook_Action() {}
 
 func (*Hook_ActionWebhook) isHook_Action() {}
@@ -1453,6 +1955,14 @@ func (*Hook_ActionHealthchecks) isHook_Action() {}
 
 func (*Hook_ActionTelegram) isHook_Action() {}
 
+func (*Hook_ActionEmail) isHook_Action() {}
+
+func (*Hook_ActionNtfy) isHook_Action() {}
+
+func (*Hook_ActionPushover) isHook_Action() {}
+
+func (*Hook_ActionMatrix) isHook_Action() {}
+
 type Auth struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Disabled      bool                   `protobuf:"varint,1,opt,name=disabled,proto3" json:"disabled,omitempty"` // disable authentication.
@@ -1463,7 +1973,7 @@ type Auth struct {
 
 func (x *Auth) Reset() {
 	*x = Auth{}
-	mi := &file_v1_config_proto_msgTypes[10]
+	mi := &file_v1_config_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1475,7 +1985,7 @@ func (x *Auth) String() string {
 func (*Auth) ProtoMessage() {}
 
 func (x *Auth) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_config_proto_msgTypes[10]
+	mi := &file_v1_config_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1488,7 +1998,7 @@ func (x *Auth) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Auth.ProtoReflect.Descriptor instead.
 func (*Auth) Descriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{10}
+	return file_v1_config_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *Auth) GetDisabled() bool {
@@ -1518,7 +2028,7 @@ type User struct {
 
 func (x *User) Reset() {
 	*x = User{}
-	mi := &file_v1_config_proto_msgTypes[11]
+	mi := &file_v1_config_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1530,7 +2040,7 @@ func (x *User) String() string {
 func (*User) ProtoMessage() {}
 
 func (x *User) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_config_proto_msgTypes[11]
+	mi := &file_v1_config_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1543,7 +2053,7 @@ func (x *User) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use User.ProtoReflect.Descriptor instead.
 func (*User) Descriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{11}
+	return file_v1_config_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *User) GetName() string {
@@ -1579,6 +2089,152 @@ type User_PasswordBcrypt struct {
 
 func (*User_PasswordBcrypt) isUser_Password() {}
 
+type OperationHistoryPolicy_Rule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OpType        string                 `protobuf:"bytes,1,opt,name=op_type,json=opType,proto3" json:"op_type,omitempty"`                // type of operation the rule applies to e.g. "backup", "check", "prune". Applies to all types if empty.
+	PlanId        string                 `protobuf:"bytes,2,opt,name=plan_id,json=planId,proto3" json:"plan_id,omitempty"`                // plan the rule applies to. Applies to all plans if empty.
+	MaxAgeDays    int32                  `protobuf:"varint,3,opt,name=max_age_days,json=maxAgeDays,proto3" json:"max_age_days,omitempty"` // operations older than this are removed once more than keep_min are kept. The built in setting for the type applies if 0.
+	KeepMin       int32                  `protobuf:"varint,4,opt,name=keep_min,json=keepMin,proto3" json:"keep_min,omitempty"`            // number of operations kept regardless of age. The built in setting for the type applies if 0.
+	KeepMax       int32                  `protobuf:"varint,5,opt,name=keep_max,json=keepMax,proto3" json:"keep_max,omitempty"`            // number of operations kept regardless of age, older operations are removed. The built in setting for the type applies if 0.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OperationHistoryPolicy_Rule) Reset() {
+	*x = OperationHistoryPolicy_Rule{}
+	mi := &file_v1_config_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OperationHistoryPolicy_Rule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperationHistoryPolicy_Rule) ProtoMessage() {}
+
+func (x *OperationHistoryPolicy_Rule) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_config_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperationHistoryPolicy_Rule.ProtoReflect.Descriptor instead.
+func (*OperationHistoryPolicy_Rule) Descriptor() ([]byte, []int) {
+	return file_v1_config_proto_rawDescGZIP(), []int{2, 0}
+}
+
+func (x *OperationHistoryPolicy_Rule) GetOpType() string {
+	if x != nil {
+		return x.OpType
+	}
+	return ""
+}
+
+func (x *OperationHistoryPolicy_Rule) GetPlanId() string {
+	if x != nil {
+		return x.PlanId
+	}
+	return ""
+}
+
+func (x *OperationHistoryPolicy_Rule) GetMaxAgeDays() int32 {
+	if x != nil {
+		return x.MaxAgeDays
+	}
+	return 0
+}
+
+func (x *OperationHistoryPolicy_Rule) GetKeepMin() int32 {
+	if x != nil {
+		return x.KeepMin
+	}
+	return 0
+}
+
+func (x *OperationHistoryPolicy_Rule) GetKeepMax() int32 {
+	if x != nil {
+		return x.KeepMax
+	}
+	return 0
+}
+
+// Compaction collapses old successful operations into one rollup operation per plan and day instead of removing them, preserving long term history.
+// Successful operations of the compacted types are exempt from the rules above, operations whose snapshot is still in the repo aren't compacted.
+type OperationHistoryPolicy_Compaction struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Enabled          bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	AfterDays        int32                  `protobuf:"varint,2,opt,name=after_days,json=afterDays,proto3" json:"after_days,omitempty"`                          // age at which operations are compacted, defaults to 90 days if 0.
+	OpTypes          []string               `protobuf:"bytes,3,rep,name=op_types,json=opTypes,proto3" json:"op_types,omitempty"`                                 // types of operations to compact, defaults to ["backup"] if empty.
+	RollupMaxAgeDays int32                  `protobuf:"varint,4,opt,name=rollup_max_age_days,json=rollupMaxAgeDays,proto3" json:"rollup_max_age_days,omitempty"` // rollups older than this are removed, rollups are kept indefinitely if 0.
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *OperationHistoryPolicy_Compaction) Reset() {
+	*x = OperationHistoryPolicy_Compaction{}
+	mi := &file_v1_config_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OperationHistoryPolicy_Compaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperationHistoryPolicy_Compaction) ProtoMessage() {}
+
+func (x *OperationHistoryPolicy_Compaction) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_config_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperationHistoryPolicy_Compaction.ProtoReflect.Descriptor instead.
+func (*OperationHistoryPolicy_Compaction) Descriptor() ([]byte, []int) {
+	return file_v1_config_proto_rawDescGZIP(), []int{2, 1}
+}
+
+func (x *OperationHistoryPolicy_Compaction) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *OperationHistoryPolicy_Compaction) GetAfterDays() int32 {
+	if x != nil {
+		return x.AfterDays
+	}
+	return 0
+}
+
+func (x *OperationHistoryPolicy_Compaction) GetOpTypes() []string {
+	if x != nil {
+		return x.OpTypes
+	}
+	return nil
+}
+
+func (x *OperationHistoryPolicy_Compaction) GetRollupMaxAgeDays() int32 {
+	if x != nil {
+		return x.RollupMaxAgeDays
+	}
+	return 0
+}
+
 type Multihost_Peer struct {
 	state         protoimpl.MessageState  `protogen:"open.v1"`
 	InstanceId    string                  `protobuf:"bytes,1,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`           // a human readable name for the peer, typically the same as its instance ID.
@@ -1593,7 +2249,7 @@ type Multihost_Peer struct {
 
 func (x *Multihost_Peer) Reset() {
 	*x = Multihost_Peer{}
-	mi := &file_v1_config_proto_msgTypes[12]
+	mi := &file_v1_config_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1605,7 +2261,7 @@ func (x *Multihost_Peer) String() string {
 func (*Multihost_Peer) ProtoMessage() {}
 
 func (x *Multihost_Peer) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_config_proto_msgTypes[12]
+	mi := &file_v1_config_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1618,7 +2274,7 @@ func (x *Multihost_Peer) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Multihost_Peer.ProtoReflect.Descriptor instead.
 func (*Multihost_Peer) Descriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{1, 0}
+	return file_v1_config_proto_rawDescGZIP(), []int{4, 0}
 }
 
 func (x *Multihost_Peer) GetInstanceId() string {
@@ -1669,7 +2325,7 @@ type Multihost_Permission struct {
 
 func (x *Multihost_Permission) Reset() {
 	*x = Multihost_Permission{}
-	mi := &file_v1_config_proto_msgTypes[13]
+	mi := &file_v1_config_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1681,7 +2337,7 @@ func (x *Multihost_Permission) String() string {
 func (*Multihost_Permission) ProtoMessage() {}
 
 func (x *Multihost_Permission) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_config_proto_msgTypes[13]
+	mi := &file_v1_config_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1694,7 +2350,7 @@ func (x *Multihost_Permission) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Multihost_Permission.ProtoReflect.Descriptor instead.
 func (*Multihost_Permission) Descriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{1, 1}
+	return file_v1_config_proto_rawDescGZIP(), []int{4, 1}
 }
 
 func (x *Multihost_Permission) GetType() Multihost_Permission_Type {
@@ -1725,7 +2381,7 @@ type RetentionPolicy_TimeBucketedCounts struct {
 
 func (x *RetentionPolicy_TimeBucketedCounts) Reset() {
 	*x = RetentionPolicy_TimeBucketedCounts{}
-	mi := &file_v1_config_proto_msgTypes[14]
+	mi := &file_v1_config_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1737,7 +2393,7 @@ func (x *RetentionPolicy_TimeBucketedCounts) String() string {
 func (*RetentionPolicy_TimeBucketedCounts) ProtoMessage() {}
 
 func (x *RetentionPolicy_TimeBucketedCounts) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_config_proto_msgTypes[14]
+	mi := &file_v1_config_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1750,7 +2406,7 @@ func (x *RetentionPolicy_TimeBucketedCounts) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use RetentionPolicy_TimeBucketedCounts.ProtoReflect.Descriptor instead.
 func (*RetentionPolicy_TimeBucketedCounts) Descriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{5, 0}
+	return file_v1_config_proto_rawDescGZIP(), []int{8, 0}
 }
 
 func (x *RetentionPolicy_TimeBucketedCounts) GetHourly() int32 {
@@ -1795,16 +2451,140 @@ func (x *RetentionPolicy_TimeBucketedCounts) GetKeepLastN() int32 {
 	return 0
 }
 
-type Hook_Command struct {
+type RetentionPolicy_TimeBucketedDurations struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hourly        string                 `protobuf:"bytes,1,opt,name=hourly,proto3" json:"hourly,omitempty"`   // keep hourly snapshots within this duration.
+	Daily         string                 `protobuf:"bytes,2,opt,name=daily,proto3" json:"daily,omitempty"`     // keep daily snapshots within this duration.
+	Weekly        string                 `protobuf:"bytes,3,opt,name=weekly,proto3" json:"weekly,omitempty"`   // keep weekly snapshots within this duration.
+	Monthly       string                 `protobuf:"bytes,4,opt,name=monthly,proto3" json:"monthly,omitempty"` // keep monthly snapshots within this duration.
+	Yearly        string                 `protobuf:"bytes,5,opt,name=yearly,proto3" json:"yearly,omitempty"`   // keep yearly snapshots within this duration.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetentionPolicy_TimeBucketedDurations) Reset() {
+	*x = RetentionPolicy_TimeBucketedDurations{}
+	mi := &file_v1_config_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetentionPolicy_TimeBucketedDurations) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetentionPolicy_TimeBucketedDurations) ProtoMessage() {}
+
+func (x *RetentionPolicy_TimeBucketedDurations) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_config_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetentionPolicy_TimeBucketedDurations.ProtoReflect.Descriptor instead.
+func (*RetentionPolicy_TimeBucketedDurations) Descriptor() ([]byte, []int) {
+	return file_v1_config_proto_rawDescGZIP(), []int{8, 1}
+}
+
+func (x *RetentionPolicy_TimeBucketedDurations) GetHourly() string {
+	if x != nil {
+		return x.Hourly
+	}
+	return ""
+}
+
+func (x *RetentionPolicy_TimeBucketedDurations) GetDaily() string {
+	if x != nil {
+		return x.Daily
+	}
+	return ""
+}
+
+func (x *RetentionPolicy_TimeBucketedDurations) GetWeekly() string {
+	if x != nil {
+		return x.Weekly
+	}
+	return ""
+}
+
+func (x *RetentionPolicy_TimeBucketedDurations) GetMonthly() string {
+	if x != nil {
+		return x.Monthly
+	}
+	return ""
+}
+
+func (x *RetentionPolicy_TimeBucketedDurations) GetYearly() string {
+	if x != nil {
+		return x.Yearly
+	}
+	return ""
+}
+
+type Hook_Digest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Command       string                 `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
+	Schedule      *Schedule              `protobuf:"bytes,1,opt,name=schedule,proto3" json:"schedule,omitempty"` // when buffered events are flushed, nothing is sent if no events occurred.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
+func (x *Hook_Digest) Reset() {
+	*x = Hook_Digest{}
+	mi := &file_v1_config_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Hook_Digest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Hook_Digest) ProtoMessage() {}
+
+func (x *Hook_Digest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_config_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Hook_Digest.ProtoReflect.Descriptor instead.
+func (*Hook_Digest) Descriptor() ([]byte, []int) {
+	return file_v1_config_proto_rawDescGZIP(), []int{13, 0}
+}
+
+func (x *Hook_Digest) GetSchedule() *Schedule {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
+type Hook_Command struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Command        string                 `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
+	WorkingDir     string                 `protobuf:"bytes,2,opt,name=working_dir,json=workingDir,proto3" json:"working_dir,omitempty"`                // directory the command runs in, defaults to backrest's working directory.
+	Env            []string               `protobuf:"bytes,3,rep,name=env,proto3" json:"env,omitempty"`                                                // additional environment variables in KEY=VALUE form.
+	User           string                 `protobuf:"bytes,4,opt,name=user,proto3" json:"user,omitempty"`                                              // if set, the command runs as this user. Unix only and requires backrest to run as root.
+	MaxOutputBytes int64                  `protobuf:"varint,5,opt,name=max_output_bytes,json=maxOutputBytes,proto3" json:"max_output_bytes,omitempty"` // if > 0, output beyond this many bytes is dropped from the log.
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
 func (x *Hook_Command) Reset() {
 	*x = Hook_Command{}
-	mi := &file_v1_config_proto_msgTypes[15]
+	mi := &file_v1_config_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1816,7 +2596,7 @@ func (x *Hook_Command) String() string {
 func (*Hook_Command) ProtoMessage() {}
 
 func (x *Hook_Command) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_config_proto_msgTypes[15]
+	mi := &file_v1_config_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1829,7 +2609,7 @@ func (x *Hook_Command) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Hook_Command.ProtoReflect.Descriptor instead.
 func (*Hook_Command) Descriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{9, 0}
+	return file_v1_config_proto_rawDescGZIP(), []int{13, 1}
 }
 
 func (x *Hook_Command) GetCommand() string {
@@ -1839,18 +2619,55 @@ func (x *Hook_Command) GetCommand() string {
 	return ""
 }
 
+func (x *Hook_Command) GetWorkingDir() string {
+	if x != nil {
+		return x.WorkingDir
+	}
+	return ""
+}
+
+func (x *Hook_Command) GetEnv() []string {
+	if x != nil {
+		return x.Env
+	}
+	return nil
+}
+
+func (x *Hook_Command) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *Hook_Command) GetMaxOutputBytes() int64 {
+	if x != nil {
+		return x.MaxOutputBytes
+	}
+	return 0
+}
+
 type Hook_Webhook struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	WebhookUrl    string                 `protobuf:"bytes,1,opt,name=webhook_url,json=webhookUrl,proto3" json:"webhook_url,omitempty"`
-	Method        Hook_Webhook_Method    `protobuf:"varint,2,opt,name=method,proto3,enum=v1.Hook_Webhook_Method" json:"method,omitempty"`
-	Template      string                 `protobuf:"bytes,100,opt,name=template,proto3" json:"template,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	WebhookUrl        string                 `protobuf:"bytes,1,opt,name=webhook_url,json=webhookUrl,proto3" json:"webhook_url,omitempty"`
+	Method            Hook_Webhook_Method    `protobuf:"varint,2,opt,name=method,proto3,enum=v1.Hook_Webhook_Method" json:"method,omitempty"`
+	Headers           []string               `protobuf:"bytes,3,rep,name=headers,proto3" json:"headers,omitempty"`                                                // extra headers in the format "Name: value".
+	ContentType       string                 `protobuf:"bytes,4,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`                     // content type of the rendered template, defaults to text/plain.
+	BearerToken       string                 `protobuf:"bytes,5,opt,name=bearer_token,json=bearerToken,proto3" json:"bearer_token,omitempty"`                     // optional, sent as an "Authorization: Bearer" header.
+	BasicAuthUsername string                 `protobuf:"bytes,6,opt,name=basic_auth_username,json=basicAuthUsername,proto3" json:"basic_auth_username,omitempty"` // optional, basic auth credentials.
+	BasicAuthPassword string                 `protobuf:"bytes,7,opt,name=basic_auth_password,json=basicAuthPassword,proto3" json:"basic_auth_password,omitempty"`
+	TlsCaCert         string                 `protobuf:"bytes,8,opt,name=tls_ca_cert,json=tlsCaCert,proto3" json:"tls_ca_cert,omitempty"`                // optional, PEM encoded CA certificates used to verify the server.
+	TlsSkipVerify     bool                   `protobuf:"varint,9,opt,name=tls_skip_verify,json=tlsSkipVerify,proto3" json:"tls_skip_verify,omitempty"`   // skip verification of the server's certificate.
+	TimeoutSeconds    int32                  `protobuf:"varint,10,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"` // request timeout, defaults to 30 seconds.
+	MaxRetries        int32                  `protobuf:"varint,11,opt,name=max_retries,json=maxRetries,proto3" json:"max_retries,omitempty"`             // retries with exponential backoff on 5xx responses or connection errors.
+	Template          string                 `protobuf:"bytes,100,opt,name=template,proto3" json:"template,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *Hook_Webhook) Reset() {
 	*x = Hook_Webhook{}
-	mi := &file_v1_config_proto_msgTypes[16]
+	mi := &file_v1_config_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1862,7 +2679,7 @@ func (x *Hook_Webhook) String() string {
 func (*Hook_Webhook) ProtoMessage() {}
 
 func (x *Hook_Webhook) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_config_proto_msgTypes[16]
+	mi := &file_v1_config_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1875,7 +2692,7 @@ func (x *Hook_Webhook) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Hook_Webhook.ProtoReflect.Descriptor instead.
 func (*Hook_Webhook) Descriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{9, 1}
+	return file_v1_config_proto_rawDescGZIP(), []int{13, 2}
 }
 
 func (x *Hook_Webhook) GetWebhookUrl() string {
@@ -1892,6 +2709,69 @@ func (x *Hook_Webhook) GetMethod() Hook_Webhook_Method {
 	return Hook_Webhook_UNKNOWN
 }
 
+func (x *Hook_Webhook) GetHeaders() []string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *Hook_Webhook) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *Hook_Webhook) GetBearerToken() string {
+	if x != nil {
+		return x.BearerToken
+	}
+	return ""
+}
+
+func (x *Hook_Webhook) GetBasicAuthUsername() string {
+	if x != nil {
+		return x.BasicAuthUsername
+	}
+	return ""
+}
+
+func (x *Hook_Webhook) GetBasicAuthPassword() string {
+	if x != nil {
+		return x.BasicAuthPassword
+	}
+	return ""
+}
+
+func (x *Hook_Webhook) GetTlsCaCert() string {
+	if x != nil {
+		return x.TlsCaCert
+	}
+	return ""
+}
+
+func (x *Hook_Webhook) GetTlsSkipVerify() bool {
+	if x != nil {
+		return x.TlsSkipVerify
+	}
+	return false
+}
+
+func (x *Hook_Webhook) GetTimeoutSeconds() int32 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
+func (x *Hook_Webhook) GetMaxRetries() int32 {
+	if x != nil {
+		return x.MaxRetries
+	}
+	return 0
+}
+
 func (x *Hook_Webhook) GetTemplate() string {
 	if x != nil {
 		return x.Template
@@ -1909,7 +2789,7 @@ type Hook_Discord struct {
 
 func (x *Hook_Discord) Reset() {
 	*x = Hook_Discord{}
-	mi := &file_v1_config_proto_msgTypes[17]
+	mi := &file_v1_config_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1921,7 +2801,7 @@ func (x *Hook_Discord) String() string {
 func (*Hook_Discord) ProtoMessage() {}
 
 func (x *Hook_Discord) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_config_proto_msgTypes[17]
+	mi := &file_v1_config_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1934,7 +2814,7 @@ func (x *Hook_Discord) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Hook_Discord.ProtoReflect.Descriptor instead.
 func (*Hook_Discord) Descriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{9, 2}
+	return file_v1_config_proto_rawDescGZIP(), []int{13, 3}
 }
 
 func (x *Hook_Discord) GetWebhookUrl() string {
@@ -1964,7 +2844,7 @@ type Hook_Gotify struct {
 
 func (x *Hook_Gotify) Reset() {
 	*x = Hook_Gotify{}
-	mi := &file_v1_config_proto_msgTypes[18]
+	mi := &file_v1_config_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1976,7 +2856,7 @@ func (x *Hook_Gotify) String() string {
 func (*Hook_Gotify) ProtoMessage() {}
 
 func (x *Hook_Gotify) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_config_proto_msgTypes[18]
+	mi := &file_v1_config_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1989,7 +2869,7 @@ func (x *Hook_Gotify) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Hook_Gotify.ProtoReflect.Descriptor instead.
 func (*Hook_Gotify) Descriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{9, 3}
+	return file_v1_config_proto_rawDescGZIP(), []int{13, 4}
 }
 
 func (x *Hook_Gotify) GetBaseUrl() string {
@@ -2037,7 +2917,7 @@ type Hook_Slack struct {
 
 func (x *Hook_Slack) Reset() {
 	*x = Hook_Slack{}
-	mi := &file_v1_config_proto_msgTypes[19]
+	mi := &file_v1_config_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2049,7 +2929,7 @@ func (x *Hook_Slack) String() string {
 func (*Hook_Slack) ProtoMessage() {}
 
 func (x *Hook_Slack) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_config_proto_msgTypes[19]
+	mi := &file_v1_config_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2062,7 +2942,7 @@ func (x *Hook_Slack) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Hook_Slack.ProtoReflect.Descriptor instead.
 func (*Hook_Slack) Descriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{9, 4}
+	return file_v1_config_proto_rawDescGZIP(), []int{13, 5}
 }
 
 func (x *Hook_Slack) GetWebhookUrl() string {
@@ -2089,7 +2969,7 @@ type Hook_Shoutrrr struct {
 
 func (x *Hook_Shoutrrr) Reset() {
 	*x = Hook_Shoutrrr{}
-	mi := &file_v1_config_proto_msgTypes[20]
+	mi := &file_v1_config_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2101,7 +2981,7 @@ func (x *Hook_Shoutrrr) String() string {
 func (*Hook_Shoutrrr) ProtoMessage() {}
 
 func (x *Hook_Shoutrrr) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_config_proto_msgTypes[20]
+	mi := &file_v1_config_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2114,7 +2994,7 @@ func (x *Hook_Shoutrrr) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Hook_Shoutrrr.ProtoReflect.Descriptor instead.
 func (*Hook_Shoutrrr) Descriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{9, 5}
+	return file_v1_config_proto_rawDescGZIP(), []int{13, 6}
 }
 
 func (x *Hook_Shoutrrr) GetShoutrrrUrl() string {
@@ -2141,7 +3021,7 @@ type Hook_Healthchecks struct {
 
 func (x *Hook_Healthchecks) Reset() {
 	*x = Hook_Healthchecks{}
-	mi := &file_v1_config_proto_msgTypes[21]
+	mi := &file_v1_config_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2153,7 +3033,7 @@ func (x *Hook_Healthchecks) String() string {
 func (*Hook_Healthchecks) ProtoMessage() {}
 
 func (x *Hook_Healthchecks) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_config_proto_msgTypes[21]
+	mi := &file_v1_config_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2166,7 +3046,7 @@ func (x *Hook_Healthchecks) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Hook_Healthchecks.ProtoReflect.Descriptor instead.
 func (*Hook_Healthchecks) Descriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{9, 6}
+	return file_v1_config_proto_rawDescGZIP(), []int{13, 7}
 }
 
 func (x *Hook_Healthchecks) GetWebhookUrl() string {
@@ -2194,7 +3074,7 @@ type Hook_Telegram struct {
 
 func (x *Hook_Telegram) Reset() {
 	*x = Hook_Telegram{}
-	mi := &file_v1_config_proto_msgTypes[22]
+	mi := &file_v1_config_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2206,7 +3086,7 @@ func (x *Hook_Telegram) String() string {
 func (*Hook_Telegram) ProtoMessage() {}
 
 func (x *Hook_Telegram) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_config_proto_msgTypes[22]
+	mi := &file_v1_config_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2219,7 +3099,7 @@ func (x *Hook_Telegram) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Hook_Telegram.ProtoReflect.Descriptor instead.
 func (*Hook_Telegram) Descriptor() ([]byte, []int) {
-	return file_v1_config_proto_rawDescGZIP(), []int{9, 7}
+	return file_v1_config_proto_rawDescGZIP(), []int{13, 8}
 }
 
 func (x *Hook_Telegram) GetBotToken() string {
@@ -2243,11 +3123,411 @@ func (x *Hook_Telegram) GetTemplate() string {
 	return ""
 }
 
+type Hook_Email struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Host            string                 `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`  // SMTP server host.
+	Port            int32                  `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"` // SMTP server port, defaults to 465 for implicit TLS and 587 otherwise.
+	Security        Hook_Email_Security    `protobuf:"varint,3,opt,name=security,proto3,enum=v1.Hook_Email_Security" json:"security,omitempty"`
+	TlsSkipVerify   bool                   `protobuf:"varint,4,opt,name=tls_skip_verify,json=tlsSkipVerify,proto3" json:"tls_skip_verify,omitempty"` // skip verification of the server's certificate.
+	Username        string                 `protobuf:"bytes,5,opt,name=username,proto3" json:"username,omitempty"`                                   // optional, PLAIN auth credentials.
+	Password        string                 `protobuf:"bytes,6,opt,name=password,proto3" json:"password,omitempty"`
+	From            string                 `protobuf:"bytes,7,opt,name=from,proto3" json:"from,omitempty"`                                                // sender address.
+	To              []string               `protobuf:"bytes,8,rep,name=to,proto3" json:"to,omitempty"`                                                    // recipient addresses.
+	Html            bool                   `protobuf:"varint,9,opt,name=html,proto3" json:"html,omitempty"`                                               // send the rendered body as text/html instead of text/plain.
+	AttachLogBytes  int32                  `protobuf:"varint,10,opt,name=attach_log_bytes,json=attachLogBytes,proto3" json:"attach_log_bytes,omitempty"`  // if set, attach up to this many bytes from the end of the triggering operation's log.
+	Template        string                 `protobuf:"bytes,100,opt,name=template,proto3" json:"template,omitempty"`                                      // template for the message body.
+	SubjectTemplate string                 `protobuf:"bytes,101,opt,name=subject_template,json=subjectTemplate,proto3" json:"subject_template,omitempty"` // template for the message subject.
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Hook_Email) Reset() {
+	*x = Hook_Email{}
+	mi := &file_v1_config_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Hook_Email) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Hook_Email) ProtoMessage() {}
+
+func (x *Hook_Email) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_config_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Hook_Email.ProtoReflect.Descriptor instead.
+func (*Hook_Email) Descriptor() ([]byte, []int) {
+	return file_v1_config_proto_rawDescGZIP(), []int{13, 9}
+}
+
+func (x *Hook_Email) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *Hook_Email) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *Hook_Email) GetSecurity() Hook_Email_Security {
+	if x != nil {
+		return x.Security
+	}
+	return Hook_Email_SECURITY_STARTTLS
+}
+
+func (x *Hook_Email) GetTlsSkipVerify() bool {
+	if x != nil {
+		return x.TlsSkipVerify
+	}
+	return false
+}
+
+func (x *Hook_Email) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *Hook_Email) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *Hook_Email) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *Hook_Email) GetTo() []string {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *Hook_Email) GetHtml() bool {
+	if x != nil {
+		return x.Html
+	}
+	return false
+}
+
+func (x *Hook_Email) GetAttachLogBytes() int32 {
+	if x != nil {
+		return x.AttachLogBytes
+	}
+	return 0
+}
+
+func (x *Hook_Email) GetTemplate() string {
+	if x != nil {
+		return x.Template
+	}
+	return ""
+}
+
+func (x *Hook_Email) GetSubjectTemplate() string {
+	if x != nil {
+		return x.SubjectTemplate
+	}
+	return ""
+}
+
+type Hook_Ntfy struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BaseUrl       string                 `protobuf:"bytes,1,opt,name=base_url,json=baseUrl,proto3" json:"base_url,omitempty"` // ntfy server, defaults to https://ntfy.sh.
+	Topic         string                 `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`
+	Token         string                 `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`                                        // optional access token.
+	Priority      int32                  `protobuf:"varint,4,opt,name=priority,proto3" json:"priority,omitempty"`                                 // 1 (min) to 5 (max), the server default is used if unset.
+	Tags          []string               `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`                                          // tags or emoji shortcodes shown with the notification.
+	ClickUrl      string                 `protobuf:"bytes,6,opt,name=click_url,json=clickUrl,proto3" json:"click_url,omitempty"`                  // optional URL opened when the notification is clicked.
+	Template      string                 `protobuf:"bytes,100,opt,name=template,proto3" json:"template,omitempty"`                                // template for the message.
+	TitleTemplate string                 `protobuf:"bytes,101,opt,name=title_template,json=titleTemplate,proto3" json:"title_template,omitempty"` // template for the message title.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Hook_Ntfy) Reset() {
+	*x = Hook_Ntfy{}
+	mi := &file_v1_config_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Hook_Ntfy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Hook_Ntfy) ProtoMessage() {}
+
+func (x *Hook_Ntfy) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_config_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Hook_Ntfy.ProtoReflect.Descriptor instead.
+func (*Hook_Ntfy) Descriptor() ([]byte, []int) {
+	return file_v1_config_proto_rawDescGZIP(), []int{13, 10}
+}
+
+func (x *Hook_Ntfy) GetBaseUrl() string {
+	if x != nil {
+		return x.BaseUrl
+	}
+	return ""
+}
+
+func (x *Hook_Ntfy) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *Hook_Ntfy) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *Hook_Ntfy) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *Hook_Ntfy) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Hook_Ntfy) GetClickUrl() string {
+	if x != nil {
+		return x.ClickUrl
+	}
+	return ""
+}
+
+func (x *Hook_Ntfy) GetTemplate() string {
+	if x != nil {
+		return x.Template
+	}
+	return ""
+}
+
+func (x *Hook_Ntfy) GetTitleTemplate() string {
+	if x != nil {
+		return x.TitleTemplate
+	}
+	return ""
+}
+
+type Hook_Pushover struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`                                        // application API token.
+	UserKey       string                 `protobuf:"bytes,2,opt,name=user_key,json=userKey,proto3" json:"user_key,omitempty"`                     // user or group key.
+	Device        string                 `protobuf:"bytes,3,opt,name=device,proto3" json:"device,omitempty"`                                      // optional, sends to a single device.
+	Priority      int32                  `protobuf:"varint,4,opt,name=priority,proto3" json:"priority,omitempty"`                                 // -2 (lowest) to 2 (emergency).
+	Sound         string                 `protobuf:"bytes,5,opt,name=sound,proto3" json:"sound,omitempty"`                                        // optional notification sound.
+	Url           string                 `protobuf:"bytes,6,opt,name=url,proto3" json:"url,omitempty"`                                            // optional supplementary URL.
+	Template      string                 `protobuf:"bytes,100,opt,name=template,proto3" json:"template,omitempty"`                                // template for the message.
+	TitleTemplate string                 `protobuf:"bytes,101,opt,name=title_template,json=titleTemplate,proto3" json:"title_template,omitempty"` // template for the message title.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Hook_Pushover) Reset() {
+	*x = Hook_Pushover{}
+	mi := &file_v1_config_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Hook_Pushover) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Hook_Pushover) ProtoMessage() {}
+
+func (x *Hook_Pushover) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_config_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Hook_Pushover.ProtoReflect.Descriptor instead.
+func (*Hook_Pushover) Descriptor() ([]byte, []int) {
+	return file_v1_config_proto_rawDescGZIP(), []int{13, 11}
+}
+
+func (x *Hook_Pushover) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *Hook_Pushover) GetUserKey() string {
+	if x != nil {
+		return x.UserKey
+	}
+	return ""
+}
+
+func (x *Hook_Pushover) GetDevice() string {
+	if x != nil {
+		return x.Device
+	}
+	return ""
+}
+
+func (x *Hook_Pushover) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *Hook_Pushover) GetSound() string {
+	if x != nil {
+		return x.Sound
+	}
+	return ""
+}
+
+func (x *Hook_Pushover) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Hook_Pushover) GetTemplate() string {
+	if x != nil {
+		return x.Template
+	}
+	return ""
+}
+
+func (x *Hook_Pushover) GetTitleTemplate() string {
+	if x != nil {
+		return x.TitleTemplate
+	}
+	return ""
+}
+
+type Hook_Matrix struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	HomeserverUrl string                 `protobuf:"bytes,1,opt,name=homeserver_url,json=homeserverUrl,proto3" json:"homeserver_url,omitempty"` // e.g. https://matrix.org
+	RoomId        string                 `protobuf:"bytes,2,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`                      // internal room ID e.g. !abc123:matrix.org, the access token's user must have joined the room.
+	AccessToken   string                 `protobuf:"bytes,3,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	Template      string                 `protobuf:"bytes,100,opt,name=template,proto3" json:"template,omitempty"` // template for the message.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Hook_Matrix) Reset() {
+	*x = Hook_Matrix{}
+	mi := &file_v1_config_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Hook_Matrix) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Hook_Matrix) ProtoMessage() {}
+
+func (x *Hook_Matrix) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_config_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Hook_Matrix.ProtoReflect.Descriptor instead.
+func (*Hook_Matrix) Descriptor() ([]byte, []int) {
+	return file_v1_config_proto_rawDescGZIP(), []int{13, 12}
+}
+
+func (x *Hook_Matrix) GetHomeserverUrl() string {
+	if x != nil {
+		return x.HomeserverUrl
+	}
+	return ""
+}
+
+func (x *Hook_Matrix) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *Hook_Matrix) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *Hook_Matrix) GetTemplate() string {
+	if x != nil {
+		return x.Template
+	}
+	return ""
+}
+
 var File_v1_config_proto protoreflect.FileDescriptor
 
 const file_v1_config_proto_rawDesc = "" +
 	"\n" +
-	"\x0fv1/config.proto\x12\x02v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x0fv1/crypto.proto\"\xda\x01\n" +
+	"\x0fv1/config.proto\x12\x02v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x0fv1/crypto.proto\"\x87\x03\n" +
 	"\x06Config\x12\x14\n" +
 	"\x05modno\x18\x01 \x01(\x05R\x05modno\x12\x18\n" +
 	"\aversion\x18\x06 \x01(\x05R\aversion\x12\x1a\n" +
@@ -2255,7 +3535,40 @@ const file_v1_config_proto_rawDesc = "" +
 	"\x05repos\x18\x03 \x03(\v2\b.v1.RepoR\x05repos\x12\x1e\n" +
 	"\x05plans\x18\x04 \x03(\v2\b.v1.PlanR\x05plans\x12\x1c\n" +
 	"\x04auth\x18\x05 \x01(\v2\b.v1.AuthR\x04auth\x12&\n" +
-	"\tmultihost\x18\a \x01(\v2\r.v1.MultihostR\x04sync\"\xcd\x04\n" +
+	"\tmultihost\x18\a \x01(\v2\r.v1.MultihostR\x04sync\x121\n" +
+	"\fglobal_hooks\x18\b \x03(\v2\x0e.v1.GlobalHookR\vglobalHooks\x12G\n" +
+	"\x11operation_history\x18\t \x01(\v2\x1a.v1.OperationHistoryPolicyR\x10operationHistory\x12/\n" +
+	"\vself_backup\x18\n" +
+	" \x01(\v2\x0e.v1.SelfBackupR\n" +
+	"selfBackup\"g\n" +
+	"\n" +
+	"SelfBackup\x12\x12\n" +
+	"\x04repo\x18\x01 \x01(\tR\x04repo\x12(\n" +
+	"\bschedule\x18\x02 \x01(\v2\f.v1.ScheduleR\bschedule\x12\x1b\n" +
+	"\tkeep_last\x18\x03 \x01(\x05R\bkeepLast\"\xbb\x03\n" +
+	"\x16OperationHistoryPolicy\x125\n" +
+	"\x05rules\x18\x01 \x03(\v2\x1f.v1.OperationHistoryPolicy.RuleR\x05rules\x12E\n" +
+	"\n" +
+	"compaction\x18\x02 \x01(\v2%.v1.OperationHistoryPolicy.CompactionR\n" +
+	"compaction\x1a\x90\x01\n" +
+	"\x04Rule\x12\x17\n" +
+	"\aop_type\x18\x01 \x01(\tR\x06opType\x12\x17\n" +
+	"\aplan_id\x18\x02 \x01(\tR\x06planId\x12 \n" +
+	"\fmax_age_days\x18\x03 \x01(\x05R\n" +
+	"maxAgeDays\x12\x19\n" +
+	"\bkeep_min\x18\x04 \x01(\x05R\akeepMin\x12\x19\n" +
+	"\bkeep_max\x18\x05 \x01(\x05R\akeepMax\x1a\x8f\x01\n" +
+	"\n" +
+	"Compaction\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x1d\n" +
+	"\n" +
+	"after_days\x18\x02 \x01(\x05R\tafterDays\x12\x19\n" +
+	"\bop_types\x18\x03 \x03(\tR\aopTypes\x12-\n" +
+	"\x13rollup_max_age_days\x18\x04 \x01(\x05R\x10rollupMaxAgeDays\"B\n" +
+	"\n" +
+	"GlobalHook\x12\x1c\n" +
+	"\x04hook\x18\x01 \x01(\v2\b.v1.HookR\x04hook\x12\x16\n" +
+	"\x06scopes\x18\x02 \x03(\tR\x06scopes\"\xcd\x04\n" +
 	"\tMultihost\x12*\n" +
 	"\bidentity\x18\x01 \x01(\v2\x0e.v1.PrivateKeyR\bidentity\x123\n" +
 	"\vknown_hosts\x18\x02 \x03(\v2\x12.v1.Multihost.PeerR\n" +
@@ -2276,7 +3589,7 @@ const file_v1_config_proto_rawDesc = "" +
 	"\x12PERMISSION_UNKNOWN\x10\x00\x12\x1e\n" +
 	"\x1aPERMISSION_READ_OPERATIONS\x10\x01\x12\x1a\n" +
 	"\x16PERMISSION_READ_CONFIG\x10\x02\x12 \n" +
-	"\x1cPERMISSION_READ_WRITE_CONFIG\x10\x03\"\x8c\x03\n" +
+	"\x1cPERMISSION_READ_WRITE_CONFIG\x10\x03\"\xd0\x03\n" +
 	"\x04Repo\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x10\n" +
 	"\x03uri\x18\x02 \x01(\tR\x03uri\x12\x12\n" +
@@ -2291,7 +3604,8 @@ const file_v1_config_proto_rawDesc = "" +
 	"autoUnlock\x12'\n" +
 	"\x0fauto_initialize\x18\f \x01(\bR\x0eautoInitialize\x128\n" +
 	"\x0ecommand_prefix\x18\n" +
-	" \x01(\v2\x11.v1.CommandPrefixR\rcommandPrefix\"\xd9\x02\n" +
+	" \x01(\v2\x11.v1.CommandPrefixR\rcommandPrefix\x12B\n" +
+	"\x10retention_policy\x18\r \x01(\v2\x17.v1.RepoRetentionPolicyR\x0fretentionPolicy\"\x85\x03\n" +
 	"\x04Plan\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04repo\x18\x02 \x01(\tR\x04repo\x12\x14\n" +
@@ -2303,7 +3617,8 @@ const file_v1_config_proto_rawDesc = "" +
 	"\x05hooks\x18\b \x03(\v2\b.v1.HookR\x05hooks\x12\"\n" +
 	"\fbackup_flags\x18\n" +
 	" \x03(\tR\fbackup_flags\x12*\n" +
-	"\x11skip_if_unchanged\x18\r \x01(\bR\x0fskipIfUnchangedJ\x04\b\x03\x10\x04J\x04\b\x06\x10\aJ\x04\b\v\x10\f\"\x9b\x02\n" +
+	"\x11skip_if_unchanged\x18\r \x01(\bR\x0fskipIfUnchanged\x12*\n" +
+	"\x11stale_after_hours\x18\x0e \x01(\x05R\x0fstaleAfterHoursJ\x04\b\x03\x10\x04J\x04\b\x06\x10\aJ\x04\b\v\x10\f\"\x9b\x02\n" +
 	"\rCommandPrefix\x126\n" +
 	"\aio_nice\x18\x01 \x01(\x0e2\x1d.v1.CommandPrefix.IONiceLevelR\x06ioNice\x129\n" +
 	"\bcpu_nice\x18\x02 \x01(\x0e2\x1e.v1.CommandPrefix.CPUNiceLevelR\acpuNice\"[\n" +
@@ -2316,20 +3631,37 @@ const file_v1_config_proto_rawDesc = "" +
 	"\fCPUNiceLevel\x12\x0f\n" +
 	"\vCPU_DEFAULT\x10\x00\x12\f\n" +
 	"\bCPU_HIGH\x10\x01\x12\v\n" +
-	"\aCPU_LOW\x10\x02\"\xff\x02\n" +
+	"\aCPU_LOW\x10\x02\"\xc5\x05\n" +
 	"\x0fRetentionPolicy\x12-\n" +
 	"\x12policy_keep_last_n\x18\n" +
 	" \x01(\x05H\x00R\x0fpolicyKeepLastN\x12Z\n" +
 	"\x14policy_time_bucketed\x18\v \x01(\v2&.v1.RetentionPolicy.TimeBucketedCountsH\x00R\x12policyTimeBucketed\x12(\n" +
-	"\x0fpolicy_keep_all\x18\f \x01(\bH\x00R\rpolicyKeepAll\x1a\xac\x01\n" +
+	"\x0fpolicy_keep_all\x18\f \x01(\bH\x00R\rpolicyKeepAll\x12\x1f\n" +
+	"\vkeep_within\x18\r \x01(\tR\n" +
+	"keepWithin\x12Y\n" +
+	"\x13keep_within_buckets\x18\x0e \x01(\v2).v1.RetentionPolicy.TimeBucketedDurationsR\x11keepWithinBuckets\x12\x1b\n" +
+	"\tkeep_tags\x18\x0f \x03(\tR\bkeepTags\x12\x19\n" +
+	"\bgroup_by\x18\x10 \x01(\tR\agroupBy\x1a\xac\x01\n" +
 	"\x12TimeBucketedCounts\x12\x16\n" +
 	"\x06hourly\x18\x01 \x01(\x05R\x06hourly\x12\x14\n" +
 	"\x05daily\x18\x02 \x01(\x05R\x05daily\x12\x16\n" +
 	"\x06weekly\x18\x03 \x01(\x05R\x06weekly\x12\x18\n" +
 	"\amonthly\x18\x04 \x01(\x05R\amonthly\x12\x16\n" +
 	"\x06yearly\x18\x05 \x01(\x05R\x06yearly\x12\x1e\n" +
-	"\vkeep_last_n\x18\x06 \x01(\x05R\tkeepLastNB\b\n" +
-	"\x06policy\"\x8f\x01\n" +
+	"\vkeep_last_n\x18\x06 \x01(\x05R\tkeepLastN\x1a\x8f\x01\n" +
+	"\x15TimeBucketedDurations\x12\x16\n" +
+	"\x06hourly\x18\x01 \x01(\tR\x06hourly\x12\x14\n" +
+	"\x05daily\x18\x02 \x01(\tR\x05daily\x12\x16\n" +
+	"\x06weekly\x18\x03 \x01(\tR\x06weekly\x12\x18\n" +
+	"\amonthly\x18\x04 \x01(\tR\amonthly\x12\x16\n" +
+	"\x06yearly\x18\x05 \x01(\tR\x06yearlyB\b\n" +
+	"\x06policy\"\xbf\x01\n" +
+	"\x13RepoRetentionPolicy\x12(\n" +
+	"\bschedule\x18\x01 \x01(\v2\f.v1.ScheduleR\bschedule\x121\n" +
+	"\tretention\x18\x02 \x01(\v2\x13.v1.RetentionPolicyR\tretention\x12\x12\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tags\x12\x14\n" +
+	"\x05hosts\x18\x04 \x03(\tR\x05hosts\x12!\n" +
+	"\fonly_unowned\x18\x05 \x01(\bR\vonlyUnowned\"\x8f\x01\n" +
 	"\vPrunePolicy\x12(\n" +
 	"\bschedule\x18\x02 \x01(\v2\f.v1.ScheduleR\bschedule\x12(\n" +
 	"\x10max_unused_bytes\x18\x03 \x01(\x03R\x0emaxUnusedBytes\x12,\n" +
@@ -2351,12 +3683,15 @@ const file_v1_config_proto_rawDesc = "" +
 	"\tCLOCK_UTC\x10\x02\x12\x17\n" +
 	"\x13CLOCK_LAST_RUN_TIME\x10\x03B\n" +
 	"\n" +
-	"\bschedule\"\xe1\x0f\n" +
+	"\bschedule\"\xff!\n" +
 	"\x04Hook\x122\n" +
 	"\n" +
 	"conditions\x18\x01 \x03(\x0e2\x12.v1.Hook.ConditionR\n" +
 	"conditions\x12+\n" +
-	"\bon_error\x18\x02 \x01(\x0e2\x10.v1.Hook.OnErrorR\aonError\x129\n" +
+	"\bon_error\x18\x02 \x01(\x0e2\x10.v1.Hook.OnErrorR\aonError\x12'\n" +
+	"\x06digest\x18\x03 \x01(\v2\x0f.v1.Hook.DigestR\x06digest\x12'\n" +
+	"\x0ftimeout_seconds\x18\x04 \x01(\x05R\x0etimeoutSeconds\x12\x1a\n" +
+	"\bparallel\x18\x05 \x01(\bR\bparallel\x129\n" +
 	"\x0eaction_command\x18d \x01(\v2\x10.v1.Hook.CommandH\x00R\ractionCommand\x129\n" +
 	"\x0eaction_webhook\x18e \x01(\v2\x10.v1.Hook.WebhookH\x00R\ractionWebhook\x129\n" +
 	"\x0eaction_discord\x18f \x01(\v2\x10.v1.Hook.DiscordH\x00R\ractionDiscord\x126\n" +
@@ -2364,18 +3699,43 @@ const file_v1_config_proto_rawDesc = "" +
 	"\faction_slack\x18h \x01(\v2\x0e.v1.Hook.SlackH\x00R\vactionSlack\x12<\n" +
 	"\x0faction_shoutrrr\x18i \x01(\v2\x11.v1.Hook.ShoutrrrH\x00R\x0eactionShoutrrr\x12H\n" +
 	"\x13action_healthchecks\x18j \x01(\v2\x15.v1.Hook.HealthchecksH\x00R\x12actionHealthchecks\x12<\n" +
-	"\x0faction_telegram\x18k \x01(\v2\x11.v1.Hook.TelegramH\x00R\x0eactionTelegram\x1a#\n" +
+	"\x0faction_telegram\x18k \x01(\v2\x11.v1.Hook.TelegramH\x00R\x0eactionTelegram\x123\n" +
+	"\faction_email\x18l \x01(\v2\x0e.v1.Hook.EmailH\x00R\vactionEmail\x120\n" +
+	"\vaction_ntfy\x18m \x01(\v2\r.v1.Hook.NtfyH\x00R\n" +
+	"actionNtfy\x12<\n" +
+	"\x0faction_pushover\x18n \x01(\v2\x11.v1.Hook.PushoverH\x00R\x0eactionPushover\x126\n" +
+	"\raction_matrix\x18o \x01(\v2\x0f.v1.Hook.MatrixH\x00R\factionMatrix\x1a2\n" +
+	"\x06Digest\x12(\n" +
+	"\bschedule\x18\x01 \x01(\v2\f.v1.ScheduleR\bschedule\x1a\x94\x01\n" +
 	"\aCommand\x12\x18\n" +
-	"\acommand\x18\x01 \x01(\tR\acommand\x1a\xa1\x01\n" +
+	"\acommand\x18\x01 \x01(\tR\acommand\x12\x1f\n" +
+	"\vworking_dir\x18\x02 \x01(\tR\n" +
+	"workingDir\x12\x10\n" +
+	"\x03env\x18\x03 \x03(\tR\x03env\x12\x12\n" +
+	"\x04user\x18\x04 \x01(\tR\x04user\x12(\n" +
+	"\x10max_output_bytes\x18\x05 \x01(\x03R\x0emaxOutputBytes\x1a\x87\x04\n" +
 	"\aWebhook\x12\x1f\n" +
 	"\vwebhook_url\x18\x01 \x01(\tR\n" +
 	"webhookUrl\x12/\n" +
-	"\x06method\x18\x02 \x01(\x0e2\x17.v1.Hook.Webhook.MethodR\x06method\x12\x1a\n" +
-	"\btemplate\x18d \x01(\tR\btemplate\"(\n" +
+	"\x06method\x18\x02 \x01(\x0e2\x17.v1.Hook.Webhook.MethodR\x06method\x12\x18\n" +
+	"\aheaders\x18\x03 \x03(\tR\aheaders\x12!\n" +
+	"\fcontent_type\x18\x04 \x01(\tR\vcontentType\x12!\n" +
+	"\fbearer_token\x18\x05 \x01(\tR\vbearerToken\x12.\n" +
+	"\x13basic_auth_username\x18\x06 \x01(\tR\x11basicAuthUsername\x12.\n" +
+	"\x13basic_auth_password\x18\a \x01(\tR\x11basicAuthPassword\x12\x1e\n" +
+	"\vtls_ca_cert\x18\b \x01(\tR\ttlsCaCert\x12&\n" +
+	"\x0ftls_skip_verify\x18\t \x01(\bR\rtlsSkipVerify\x12'\n" +
+	"\x0ftimeout_seconds\x18\n" +
+	" \x01(\x05R\x0etimeoutSeconds\x12\x1f\n" +
+	"\vmax_retries\x18\v \x01(\x05R\n" +
+	"maxRetries\x12\x1a\n" +
+	"\btemplate\x18d \x01(\tR\btemplate\"<\n" +
 	"\x06Method\x12\v\n" +
 	"\aUNKNOWN\x10\x00\x12\a\n" +
 	"\x03GET\x10\x01\x12\b\n" +
-	"\x04POST\x10\x02\x1aF\n" +
+	"\x04POST\x10\x02\x12\a\n" +
+	"\x03PUT\x10\x03\x12\t\n" +
+	"\x05PATCH\x10\x04\x1aF\n" +
 	"\aDiscord\x12\x1f\n" +
 	"\vwebhook_url\x18\x01 \x01(\tR\n" +
 	"webhookUrl\x12\x1a\n" +
@@ -2400,7 +3760,48 @@ const file_v1_config_proto_rawDesc = "" +
 	"\bTelegram\x12\x1b\n" +
 	"\tbot_token\x18\x01 \x01(\tR\bbotToken\x12\x17\n" +
 	"\achat_id\x18\x02 \x01(\tR\x06chatId\x12\x1a\n" +
-	"\btemplate\x18\x03 \x01(\tR\btemplate\"\xf5\x03\n" +
+	"\btemplate\x18\x03 \x01(\tR\btemplate\x1a\xb5\x03\n" +
+	"\x05Email\x12\x12\n" +
+	"\x04host\x18\x01 \x01(\tR\x04host\x12\x12\n" +
+	"\x04port\x18\x02 \x01(\x05R\x04port\x123\n" +
+	"\bsecurity\x18\x03 \x01(\x0e2\x17.v1.Hook.Email.SecurityR\bsecurity\x12&\n" +
+	"\x0ftls_skip_verify\x18\x04 \x01(\bR\rtlsSkipVerify\x12\x1a\n" +
+	"\busername\x18\x05 \x01(\tR\busername\x12\x1a\n" +
+	"\bpassword\x18\x06 \x01(\tR\bpassword\x12\x12\n" +
+	"\x04from\x18\a \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\b \x03(\tR\x02to\x12\x12\n" +
+	"\x04html\x18\t \x01(\bR\x04html\x12(\n" +
+	"\x10attach_log_bytes\x18\n" +
+	" \x01(\x05R\x0eattachLogBytes\x12\x1a\n" +
+	"\btemplate\x18d \x01(\tR\btemplate\x12)\n" +
+	"\x10subject_template\x18e \x01(\tR\x0fsubjectTemplate\"F\n" +
+	"\bSecurity\x12\x15\n" +
+	"\x11SECURITY_STARTTLS\x10\x00\x12\x10\n" +
+	"\fSECURITY_TLS\x10\x01\x12\x11\n" +
+	"\rSECURITY_NONE\x10\x02\x1a\xdd\x01\n" +
+	"\x04Ntfy\x12\x19\n" +
+	"\bbase_url\x18\x01 \x01(\tR\abaseUrl\x12\x14\n" +
+	"\x05topic\x18\x02 \x01(\tR\x05topic\x12\x14\n" +
+	"\x05token\x18\x03 \x01(\tR\x05token\x12\x1a\n" +
+	"\bpriority\x18\x04 \x01(\x05R\bpriority\x12\x12\n" +
+	"\x04tags\x18\x05 \x03(\tR\x04tags\x12\x1b\n" +
+	"\tclick_url\x18\x06 \x01(\tR\bclickUrl\x12\x1a\n" +
+	"\btemplate\x18d \x01(\tR\btemplate\x12%\n" +
+	"\x0etitle_template\x18e \x01(\tR\rtitleTemplate\x1a\xda\x01\n" +
+	"\bPushover\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x19\n" +
+	"\buser_key\x18\x02 \x01(\tR\auserKey\x12\x16\n" +
+	"\x06device\x18\x03 \x01(\tR\x06device\x12\x1a\n" +
+	"\bpriority\x18\x04 \x01(\x05R\bpriority\x12\x14\n" +
+	"\x05sound\x18\x05 \x01(\tR\x05sound\x12\x10\n" +
+	"\x03url\x18\x06 \x01(\tR\x03url\x12\x1a\n" +
+	"\btemplate\x18d \x01(\tR\btemplate\x12%\n" +
+	"\x0etitle_template\x18e \x01(\tR\rtitleTemplate\x1a\x87\x01\n" +
+	"\x06Matrix\x12%\n" +
+	"\x0ehomeserver_url\x18\x01 \x01(\tR\rhomeserverUrl\x12\x17\n" +
+	"\aroom_id\x18\x02 \x01(\tR\x06roomId\x12!\n" +
+	"\faccess_token\x18\x03 \x01(\tR\vaccessToken\x12\x1a\n" +
+	"\btemplate\x18d \x01(\tR\btemplate\"\xbd\a\n" +
 	"\tCondition\x12\x15\n" +
 	"\x11CONDITION_UNKNOWN\x10\x00\x12\x17\n" +
 	"\x13CONDITION_ANY_ERROR\x10\x01\x12\x1c\n" +
@@ -2418,7 +3819,21 @@ const file_v1_config_proto_rawDesc = "" +
 	"\x17CONDITION_CHECK_SUCCESS\x10\xca\x01\x12\x1b\n" +
 	"\x16CONDITION_FORGET_START\x10\xac\x02\x12\x1b\n" +
 	"\x16CONDITION_FORGET_ERROR\x10\xad\x02\x12\x1d\n" +
-	"\x18CONDITION_FORGET_SUCCESS\x10\xae\x02\"\xa9\x01\n" +
+	"\x18CONDITION_FORGET_SUCCESS\x10\xae\x02\x12\x19\n" +
+	"\x14CONDITION_PLAN_STALE\x10\x90\x03\x12\x1d\n" +
+	"\x18CONDITION_PLAN_RECOVERED\x10\x91\x03\x12\x1c\n" +
+	"\x17CONDITION_RESTORE_START\x10\xf4\x03\x12\x1c\n" +
+	"\x17CONDITION_RESTORE_ERROR\x10\xf5\x03\x12\x1e\n" +
+	"\x19CONDITION_RESTORE_SUCCESS\x10\xf6\x03\x12\x1a\n" +
+	"\x15CONDITION_STATS_START\x10\xd8\x04\x12\x1a\n" +
+	"\x15CONDITION_STATS_ERROR\x10\xd9\x04\x12\x1c\n" +
+	"\x17CONDITION_STATS_SUCCESS\x10\xda\x04\x12$\n" +
+	"\x1fCONDITION_INDEX_SNAPSHOTS_START\x10\xbc\x05\x12$\n" +
+	"\x1fCONDITION_INDEX_SNAPSHOTS_ERROR\x10\xbd\x05\x12&\n" +
+	"!CONDITION_INDEX_SNAPSHOTS_SUCCESS\x10\xbe\x05\x12 \n" +
+	"\x1bCONDITION_RUN_COMMAND_START\x10\xa0\x06\x12 \n" +
+	"\x1bCONDITION_RUN_COMMAND_ERROR\x10\xa1\x06\x12\"\n" +
+	"\x1dCONDITION_RUN_COMMAND_SUCCESS\x10\xa2\x06\"\xa9\x01\n" +
 	"\aOnError\x12\x13\n" +
 	"\x0fON_ERROR_IGNORE\x10\x00\x12\x13\n" +
 	"\x0fON_ERROR_CANCEL\x10\x01\x12\x12\n" +
@@ -2448,81 +3863,112 @@ func file_v1_config_proto_rawDescGZIP() []byte {
 	return file_v1_config_proto_rawDescData
 }
 
-var file_v1_config_proto_enumTypes = make([]protoimpl.EnumInfo, 7)
-var file_v1_config_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_v1_config_proto_enumTypes = make([]protoimpl.EnumInfo, 8)
+var file_v1_config_proto_msgTypes = make([]protoimpl.MessageInfo, 35)
 var file_v1_config_proto_goTypes = []any{
-	(Multihost_Permission_Type)(0),             // 0: v1.Multihost.Permission.Type
-	(CommandPrefix_IONiceLevel)(0),             // 1: v1.CommandPrefix.IONiceLevel
-	(CommandPrefix_CPUNiceLevel)(0),            // 2: v1.CommandPrefix.CPUNiceLevel
-	(Schedule_Clock)(0),                        // 3: v1.Schedule.Clock
-	(Hook_Condition)(0),                        // 4: v1.Hook.Condition
-	(Hook_OnError)(0),                          // 5: v1.Hook.OnError
-	(Hook_Webhook_Method)(0),                   // 6: v1.Hook.Webhook.Method
-	(*Config)(nil),                             // 7: v1.Config
-	(*Multihost)(nil),                          // 8: v1.Multihost
-	(*Repo)(nil),                               // 9: v1.Repo
-	(*Plan)(nil),                               // 10: v1.Plan
-	(*CommandPrefix)(nil),                      // 11: v1.CommandPrefix
-	(*RetentionPolicy)(nil),                    // 12: v1.RetentionPolicy
-	(*PrunePolicy)(nil),                        // 13: v1.PrunePolicy
-	(*CheckPolicy)(nil),                        // 14: v1.CheckPolicy
-	(*Schedule)(nil),                           // 15: v1.Schedule
-	(*Hook)(nil),                               // 16: v1.Hook
-	(*Auth)(nil),                               // 17: v1.Auth
-	(*User)(nil),                               // 18: v1.User
-	(*Multihost_Peer)(nil),                     // 19: v1.Multihost.Peer
-	(*Multihost_Permission)(nil),               // 20: v1.Multihost.Permission
-	(*RetentionPolicy_TimeBucketedCounts)(nil), // 21: v1.RetentionPolicy.TimeBucketedCounts
-	(*Hook_Command)(nil),                       // 22: v1.Hook.Command
-	(*Hook_Webhook)(nil),                       // 23: v1.Hook.Webhook
-	(*Hook_Discord)(nil),                       // 24: v1.Hook.Discord
-	(*Hook_Gotify)(nil),                        // 25: v1.Hook.Gotify
-	(*Hook_Slack)(nil),                         // 26: v1.Hook.Slack
-	(*Hook_Shoutrrr)(nil),                      // 27: v1.Hook.Shoutrrr
-	(*Hook_Healthchecks)(nil),                  // 28: v1.Hook.Healthchecks
-	(*Hook_Telegram)(nil),                      // 29: v1.Hook.Telegram
-	(*PrivateKey)(nil),                         // 30: v1.PrivateKey
+	(Multihost_Permission_Type)(0),                // 0: v1.Multihost.Permission.Type
+	(CommandPrefix_IONiceLevel)(0),                // 1: v1.CommandPrefix.IONiceLevel
+	(CommandPrefix_CPUNiceLevel)(0),               // 2: v1.CommandPrefix.CPUNiceLevel
+	(Schedule_Clock)(0),                           // 3: v1.Schedule.Clock
+	(Hook_Condition)(0),                           // 4: v1.Hook.Condition
+	(Hook_OnError)(0),                             // 5: v1.Hook.OnError
+	(Hook_Webhook_Method)(0),                      // 6: v1.Hook.Webhook.Method
+	(Hook_Email_Security)(0),                      // 7: v1.Hook.Email.Security
+	(*Config)(nil),                                // 8: v1.Config
+	(*SelfBackup)(nil),                            // 9: v1.SelfBackup
+	(*OperationHistoryPolicy)(nil),                // 10: v1.OperationHistoryPolicy
+	(*GlobalHook)(nil),                            // 11: v1.GlobalHook
+	(*Multihost)(nil),                             // 12: v1.Multihost
+	(*Repo)(nil),                                  // 13: v1.Repo
+	(*Plan)(nil),                                  // 14: v1.Plan
+	(*CommandPrefix)(nil),                         // 15: v1.CommandPrefix
+	(*RetentionPolicy)(nil),                       // 16: v1.RetentionPolicy
+	(*RepoRetentionPolicy)(nil),                   // 17: v1.RepoRetentionPolicy
+	(*PrunePolicy)(nil),                           // 18: v1.PrunePolicy
+	(*CheckPolicy)(nil),                           // 19: v1.CheckPolicy
+	(*Schedule)(nil),                              // 20: v1.Schedule
+	(*Hook)(nil),                                  // 21: v1.Hook
+	(*Auth)(nil),                                  // 22: v1.Auth
+	(*User)(nil),                                  // 23: v1.User
+	(*OperationHistoryPolicy_Rule)(nil),           // 24: v1.OperationHistoryPolicy.Rule
+	(*OperationHistoryPolicy_Compaction)(nil),     // 25: v1.OperationHistoryPolicy.Compaction
+	(*Multihost_Peer)(nil),                        // 26: v1.Multihost.Peer
+	(*Multihost_Permission)(nil),                  // 27: v1.Multihost.Permission
+	(*RetentionPolicy_TimeBucketedCounts)(nil),    // 28: v1.RetentionPolicy.TimeBucketedCounts
+	(*RetentionPolicy_TimeBucketedDurations)(nil), // 29: v1.RetentionPolicy.TimeBucketedDurations
+	(*Hook_Digest)(nil),                           // 30: v1.Hook.Digest
+	(*Hook_Command)(nil),                          // 31: v1.Hook.Command
+	(*Hook_Webhook)(nil),                          // 32: v1.Hook.Webhook
+	(*Hook_Discord)(nil),                          // 33: v1.Hook.Discord
+	(*Hook_Gotify)(nil),                           // 34: v1.Hook.Gotify
+	(*Hook_Slack)(nil),                            // 35: v1.Hook.Slack
+	(*Hook_Shoutrrr)(nil),                         // 36: v1.Hook.Shoutrrr
+	(*Hook_Healthchecks)(nil),                     // 37: v1.Hook.Healthchecks
+	(*Hook_Telegram)(nil),                         // 38: v1.Hook.Telegram
+	(*Hook_Email)(nil),                            // 39: v1.Hook.Email
+	(*Hook_Ntfy)(nil),                             // 40: v1.Hook.Ntfy
+	(*Hook_Pushover)(nil),                         // 41: v1.Hook.Pushover
+	(*Hook_Matrix)(nil),                           // 42: v1.Hook.Matrix
+	(*PrivateKey)(nil),                            // 43: v1.PrivateKey
 }
 var file_v1_config_proto_depIdxs = []int32{
-	9,  // 0: v1.Config.repos:type_name -> v1.Repo
-	10, // 1: v1.Config.plans:type_name -> v1.Plan
-	17, // 2: v1.Config.auth:type_name -> v1.Auth
-	8,  // 3: v1.Config.multihost:type_name -> v1.Multihost
-	30, // 4: v1.Multihost.identity:type_name -> v1.PrivateKey
-	19, // 5: v1.Multihost.known_hosts:type_name -> v1.Multihost.Peer
-	19, // 6: v1.Multihost.authorized_clients:type_name -> v1.Multihost.Peer
-	13, // 7: v1.Repo.prune_policy:type_name -> v1.PrunePolicy
-	14, // 8: v1.Repo.check_policy:type_name -> v1.CheckPolicy
-	16, // 9: v1.Repo.hooks:type_name -> v1.Hook
-	11, // 10: v1.Repo.command_prefix:type_name -> v1.CommandPrefix
-	15, // 11: v1.Plan.schedule:type_name -> v1.Schedule
-	12, // 12: v1.Plan.retention:type_name -> v1.RetentionPolicy
-	16, // 13: v1.Plan.hooks:type_name -> v1.Hook
-	1,  // 14: v1.CommandPrefix.io_nice:type_name -> v1.CommandPrefix.IONiceLevel
-	2,  // 15: v1.CommandPrefix.cpu_nice:type_name -> v1.CommandPrefix.CPUNiceLevel
-	21, // 16: v1.RetentionPolicy.policy_time_bucketed:type_name -> v1.RetentionPolicy.TimeBucketedCounts
-	15, // 17: v1.PrunePolicy.schedule:type_name -> v1.Schedule
-	15, // 18: v1.CheckPolicy.schedule:type_name -> v1.Schedule
-	3,  // 19: v1.Schedule.clock:type_name -> v1.Schedule.Clock
-	4,  // 20: v1.Hook.conditions:type_name -> v1.Hook.Condition
-	5,  // 21: v1.Hook.on_error:type_name -> v1.Hook.OnError
-	22, // 22: v1.Hook.action_command:type_name -> v1.Hook.Command
-	23, // 23: v1.Hook.action_webhook:type_name -> v1.Hook.Webhook
-	24, // 24: v1.Hook.action_discord:type_name -> v1.Hook.Discord
-	25, // 25: v1.Hook.action_gotify:type_name -> v1.Hook.Gotify
-	26, // 26: v1.Hook.action_slack:type_name -> v1.Hook.Slack
-	27, // 27: v1.Hook.action_shoutrrr:type_name -> v1.Hook.Shoutrrr
-	28, // 28: v1.Hook.action_healthchecks:type_name -> v1.Hook.Healthchecks
-	29, // 29: v1.Hook.action_telegram:type_name -> v1.Hook.Telegram
-	18, // 30: v1.Auth.users:type_name -> v1.User
-	20, // 31: v1.Multihost.Peer.permissions:type_name -> v1.Multihost.Permission
-	0,  // 32: v1.Multihost.Permission.type:type_name -> v1.Multihost.Permission.Type
-	6,  // 33: v1.Hook.Webhook.method:type_name -> v1.Hook.Webhook.Method
-	34, // [34:34] is the sub-list for method output_type
-	34, // [34:34] is the sub-list for method input_type
-	34, // [34:34] is the sub-list for extension type_name
-	34, // [34:34] is the sub-list for extension extendee
-	0,  // [0:34] is the sub-list for field type_name
+	13, // 0: v1.Config.repos:type_name -> v1.Repo
+	14, // 1: v1.Config.plans:type_name -> v1.Plan
+	22, // 2: v1.Config.auth:type_name -> v1.Auth
+	12, // 3: v1.Config.multihost:type_name -> v1.Multihost
+	11, // 4: v1.Config.global_hooks:type_name -> v1.GlobalHook
+	10, // 5: v1.Config.operation_history:type_name -> v1.OperationHistoryPolicy
+	9,  // 6: v1.Config.self_backup:type_name -> v1.SelfBackup
+	20, // 7: v1.SelfBackup.schedule:type_name -> v1.Schedule
+	24, // 8: v1.OperationHistoryPolicy.rules:type_name -> v1.OperationHistoryPolicy.Rule
+	25, // 9: v1.OperationHistoryPolicy.compaction:type_name -> v1.OperationHistoryPolicy.Compaction
+	21, // 10: v1.GlobalHook.hook:type_name -> v1.Hook
+	43, // 11: v1.Multihost.identity:type_name -> v1.PrivateKey
+	26, // 12: v1.Multihost.known_hosts:type_name -> v1.Multihost.Peer
+	26, // 13: v1.Multihost.authorized_clients:type_name -> v1.Multihost.Peer
+	18, // 14: v1.Repo.prune_policy:type_name -> v1.PrunePolicy
+	19, // 15: v1.Repo.check_policy:type_name -> v1.CheckPolicy
+	21, // 16: v1.Repo.hooks:type_name -> v1.Hook
+	15, // 17: v1.Repo.command_prefix:type_name -> v1.CommandPrefix
+	17, // 18: v1.Repo.retention_policy:type_name -> v1.RepoRetentionPolicy
+	20, // 19: v1.Plan.schedule:type_name -> v1.Schedule
+	16, // 20: v1.Plan.retention:type_name -> v1.RetentionPolicy
+	21, // 21: v1.Plan.hooks:type_name -> v1.Hook
+	1,  // 22: v1.CommandPrefix.io_nice:type_name -> v1.CommandPrefix.IONiceLevel
+	2,  // 23: v1.CommandPrefix.cpu_nice:type_name -> v1.CommandPrefix.CPUNiceLevel
+	28, // 24: v1.RetentionPolicy.policy_time_bucketed:type_name -> v1.RetentionPolicy.TimeBucketedCounts
+	29, // 25: v1.RetentionPolicy.keep_within_buckets:type_name -> v1.RetentionPolicy.TimeBucketedDurations
+	20, // 26: v1.RepoRetentionPolicy.schedule:type_name -> v1.Schedule
+	16, // 27: v1.RepoRetentionPolicy.retention:type_name -> v1.RetentionPolicy
+	20, // 28: v1.PrunePolicy.schedule:type_name -> v1.Schedule
+	20, // 29: v1.CheckPolicy.schedule:type_name -> v1.Schedule
+	3,  // 30: v1.Schedule.clock:type_name -> v1.Schedule.Clock
+	4,  // 31: v1.Hook.conditions:type_name -> v1.Hook.Condition
+	5,  // 32: v1.Hook.on_error:type_name -> v1.Hook.OnError
+	30, // 33: v1.Hook.digest:type_name -> v1.Hook.Digest
+	31, // 34: v1.Hook.action_command:type_name -> v1.Hook.Command
+	32, // 35: v1.Hook.action_webhook:type_name -> v1.Hook.Webhook
+	33, // 36: v1.Hook.action_discord:type_name -> v1.Hook.Discord
+	34, // 37: v1.Hook.action_gotify:type_name -> v1.Hook.Gotify
+	35, // 38: v1.Hook.action_slack:type_name -> v1.Hook.Slack
+	36, // 39: v1.Hook.action_shoutrrr:type_name -> v1.Hook.Shoutrrr
+	37, // 40: v1.Hook.action_healthchecks:type_name -> v1.Hook.Healthchecks
+	38, // 41: v1.Hook.action_telegram:type_name -> v1.Hook.Telegram
+	39, // 42: v1.Hook.action_email:type_name -> v1.Hook.Email
+	40, // 43: v1.Hook.action_ntfy:type_name -> v1.Hook.Ntfy
+	41, // 44: v1.Hook.action_pushover:type_name -> v1.Hook.Pushover
+	42, // 45: v1.Hook.action_matrix:type_name -> v1.Hook.Matrix
+	23, // 46: v1.Auth.users:type_name -> v1.User
+	27, // 47: v1.Multihost.Peer.permissions:type_name -> v1.Multihost.Permission
+	0,  // 48: v1.Multihost.Permission.type:type_name -> v1.Multihost.Permission.Type
+	20, // 49: v1.Hook.Digest.schedule:type_name -> v1.Schedule
+	6,  // 50: v1.Hook.Webhook.method:type_name -> v1.Hook.Webhook.Method
+	7,  // 51: v1.Hook.Email.security:type_name -> v1.Hook.Email.Security
+	52, // [52:52] is the sub-list for method output_type
+	52, // [52:52] is the sub-list for method input_type
+	52, // [52:52] is the sub-list for extension type_name
+	52, // [52:52] is the sub-list for extension extendee
+	0,  // [0:52] is the sub-list for field type_name
 }
 
 func init() { file_v1_config_proto_init() }
@@ -2531,22 +3977,22 @@ func file_v1_config_proto_init() {
 		return
 	}
 	file_v1_crypto_proto_init()
-	file_v1_config_proto_msgTypes[5].OneofWrappers = []any{
+	file_v1_config_proto_msgTypes[8].OneofWrappers = []any{
 		(*RetentionPolicy_PolicyKeepLastN)(nil),
 		(*RetentionPolicy_PolicyTimeBucketed)(nil),
 		(*RetentionPolicy_PolicyKeepAll)(nil),
 	}
-	file_v1_config_proto_msgTypes[7].OneofWrappers = []any{
+	file_v1_config_proto_msgTypes[11].OneofWrappers = []any{
 		(*CheckPolicy_StructureOnly)(nil),
 		(*CheckPolicy_ReadDataSubsetPercent)(nil),
 	}
-	file_v1_config_proto_msgTypes[8].OneofWrappers = []any{
+	file_v1_config_proto_msgTypes[12].OneofWrappers = []any{
 		(*Schedule_Disabled)(nil),
 		(*Schedule_Cron)(nil),
 		(*Schedule_MaxFrequencyDays)(nil),
 		(*Schedule_MaxFrequencyHours)(nil),
 	}
-	file_v1_config_proto_msgTypes[9].OneofWrappers = []any{
+	file_v1_config_proto_msgTypes[13].OneofWrappers = []any{
 		(*Hook_ActionCommand)(nil),
 		(*Hook_ActionWebhook)(nil),
 		(*Hook_ActionDiscord)(nil),
@@ -2555,8 +4001,12 @@ func file_v1_config_proto_init() {
 		(*Hook_ActionShoutrrr)(nil),
 		(*Hook_ActionHealthchecks)(nil),
 		(*Hook_ActionTelegram)(nil),
+		(*Hook_ActionEmail)(nil),
+		(*Hook_ActionNtfy)(nil),
+		(*Hook_ActionPushover)(nil),
+		(*Hook_ActionMatrix)(nil),
 	}
-	file_v1_config_proto_msgTypes[11].OneofWrappers = []any{
+	file_v1_config_proto_msgTypes[15].OneofWrappers = []any{
 		(*User_PasswordBcrypt)(nil),
 	}
 	type x struct{}
@@ -2564,8 +4014,8 @@ func file_v1_config_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_v1_config_proto_rawDesc), len(file_v1_config_proto_rawDesc)),
-			NumEnums:      7,
-			NumMessages:   23,
+			NumEnums:      8,
+			NumMessages:   35,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
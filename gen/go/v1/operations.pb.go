@@ -139,6 +139,77 @@ func (OperationStatus) EnumDescriptor() ([]byte, []int) {
 	return file_v1_operations_proto_rawDescGZIP(), []int{1}
 }
 
+// OperationType identifies the kind of an operation, values match the field numbers of the Operation.op oneof.
+type OperationType int32
+
+const (
+	OperationType_OPERATION_TYPE_UNKNOWN        OperationType = 0
+	OperationType_OPERATION_TYPE_BACKUP         OperationType = 100
+	OperationType_OPERATION_TYPE_INDEX_SNAPSHOT OperationType = 101
+	OperationType_OPERATION_TYPE_FORGET         OperationType = 102
+	OperationType_OPERATION_TYPE_PRUNE          OperationType = 103
+	OperationType_OPERATION_TYPE_RESTORE        OperationType = 104
+	OperationType_OPERATION_TYPE_STATS          OperationType = 105
+	OperationType_OPERATION_TYPE_RUN_HOOK       OperationType = 106
+	OperationType_OPERATION_TYPE_CHECK          OperationType = 107
+	OperationType_OPERATION_TYPE_RUN_COMMAND    OperationType = 108
+)
+
+// Enum value maps for OperationType.
+var (
+	OperationType_name = map[int32]string{
+		0:   "OPERATION_TYPE_UNKNOWN",
+		100: "OPERATION_TYPE_BACKUP",
+		101: "OPERATION_TYPE_INDEX_SNAPSHOT",
+		102: "OPERATION_TYPE_FORGET",
+		103: "OPERATION_TYPE_PRUNE",
+		104: "OPERATION_TYPE_RESTORE",
+		105: "OPERATION_TYPE_STATS",
+		106: "OPERATION_TYPE_RUN_HOOK",
+		107: "OPERATION_TYPE_CHECK",
+		108: "OPERATION_TYPE_RUN_COMMAND",
+	}
+	OperationType_value = map[string]int32{
+		"OPERATION_TYPE_UNKNOWN":        0,
+		"OPERATION_TYPE_BACKUP":         100,
+		"OPERATION_TYPE_INDEX_SNAPSHOT": 101,
+		"OPERATION_TYPE_FORGET":         102,
+		"OPERATION_TYPE_PRUNE":          103,
+		"OPERATION_TYPE_RESTORE":        104,
+		"OPERATION_TYPE_STATS":          105,
+		"OPERATION_TYPE_RUN_HOOK":       106,
+		"OPERATION_TYPE_CHECK":          107,
+		"OPERATION_TYPE_RUN_COMMAND":    108,
+	}
+)
+
+func (x OperationType) Enum() *OperationType {
+	p := new(OperationType)
+	*p = x
+	return p
+}
+
+func (x OperationType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OperationType) Descriptor() protoreflect.EnumDescriptor {
+	return file_v1_operations_proto_enumTypes[2].Descriptor()
+}
+
+func (OperationType) Type() protoreflect.EnumType {
+	return &file_v1_operations_proto_enumTypes[2]
+}
+
+func (x OperationType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OperationType.Descriptor instead.
+func (OperationType) EnumDescriptor() ([]byte, []int) {
+	return file_v1_operations_proto_rawDescGZIP(), []int{2}
+}
+
 type OperationList struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Operations    []*Operation           `protobuf:"bytes,1,rep,name=operations,proto3" json:"operations,omitempty"`
@@ -215,6 +286,8 @@ type Operation struct {
 	DisplayMessage string `protobuf:"bytes,7,opt,name=display_message,json=displayMessage,proto3" json:"display_message,omitempty"`
 	// logref can point to arbitrary logs associated with the operation.
 	Logref string `protobuf:"bytes,9,opt,name=logref,proto3" json:"logref,omitempty"`
+	// optional, set if the operation is a rollup of operations compacted by garbage collection.
+	Rollup *OperationRollup `protobuf:"bytes,17,opt,name=rollup,proto3" json:"rollup,omitempty"`
 	// Types that are valid to be assigned to Op:
 	//
 	//	*Operation_OperationBackup
@@ -373,6 +446,13 @@ func (x *Operation) GetLogref() string {
 	return ""
 }
 
+func (x *Operation) GetRollup() *OperationRollup {
+	if x != nil {
+		return x.Rollup
+	}
+	return nil
+}
+
 func (x *Operation) GetOp() isOperation_Op {
 	if x != nil {
 		return x.Op
@@ -519,6 +599,92 @@ func (*Operation_OperationCheck) isOperation_Op() {}
 
 func (*Operation_OperationRunCommand) isOperation_Op() {}
 
+// OperationRollup summarizes the operations of a day that history compaction collapsed into a single operation.
+// The rollup operation otherwise holds the details of the latest compacted operation.
+type OperationRollup struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Count               int64                  `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`                                                          // number of operations compacted into the rollup.
+	FirstStartTimeMs    int64                  `protobuf:"varint,2,opt,name=first_start_time_ms,json=firstStartTimeMs,proto3" json:"first_start_time_ms,omitempty"`        // start time of the earliest compacted operation.
+	TotalDurationMs     int64                  `protobuf:"varint,3,opt,name=total_duration_ms,json=totalDurationMs,proto3" json:"total_duration_ms,omitempty"`             // sum of the durations of the compacted operations.
+	TotalFilesProcessed int64                  `protobuf:"varint,4,opt,name=total_files_processed,json=totalFilesProcessed,proto3" json:"total_files_processed,omitempty"` // backup only, sum of the files processed by the compacted backups.
+	TotalBytesProcessed int64                  `protobuf:"varint,5,opt,name=total_bytes_processed,json=totalBytesProcessed,proto3" json:"total_bytes_processed,omitempty"` // backup only, sum of the bytes processed by the compacted backups.
+	TotalBytesAdded     int64                  `protobuf:"varint,6,opt,name=total_bytes_added,json=totalBytesAdded,proto3" json:"total_bytes_added,omitempty"`             // backup only, sum of the bytes added to the repo by the compacted backups.
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *OperationRollup) Reset() {
+	*x = OperationRollup{}
+	mi := &file_v1_operations_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OperationRollup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperationRollup) ProtoMessage() {}
+
+func (x *OperationRollup) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_operations_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperationRollup.ProtoReflect.Descriptor instead.
+func (*OperationRollup) Descriptor() ([]byte, []int) {
+	return file_v1_operations_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *OperationRollup) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *OperationRollup) GetFirstStartTimeMs() int64 {
+	if x != nil {
+		return x.FirstStartTimeMs
+	}
+	return 0
+}
+
+func (x *OperationRollup) GetTotalDurationMs() int64 {
+	if x != nil {
+		return x.TotalDurationMs
+	}
+	return 0
+}
+
+func (x *OperationRollup) GetTotalFilesProcessed() int64 {
+	if x != nil {
+		return x.TotalFilesProcessed
+	}
+	return 0
+}
+
+func (x *OperationRollup) GetTotalBytesProcessed() int64 {
+	if x != nil {
+		return x.TotalBytesProcessed
+	}
+	return 0
+}
+
+func (x *OperationRollup) GetTotalBytesAdded() int64 {
+	if x != nil {
+		return x.TotalBytesAdded
+	}
+	return 0
+}
+
 // OperationEvent is used in the wireformat to stream operation changes to clients
 type OperationEvent struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -535,7 +701,7 @@ type OperationEvent struct {
 
 func (x *OperationEvent) Reset() {
 	*x = OperationEvent{}
-	mi := &file_v1_operations_proto_msgTypes[2]
+	mi := &file_v1_operations_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -547,7 +713,7 @@ func (x *OperationEvent) String() string {
 func (*OperationEvent) ProtoMessage() {}
 
 func (x *OperationEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_operations_proto_msgTypes[2]
+	mi := &file_v1_operations_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -560,7 +726,7 @@ func (x *OperationEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OperationEvent.ProtoReflect.Descriptor instead.
 func (*OperationEvent) Descriptor() ([]byte, []int) {
-	return file_v1_operations_proto_rawDescGZIP(), []int{2}
+	return file_v1_operations_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *OperationEvent) GetEvent() isOperationEvent_Event {
@@ -635,16 +801,17 @@ func (*OperationEvent_UpdatedOperations) isOperationEvent_Event() {}
 func (*OperationEvent_DeletedOperations) isOperationEvent_Event() {}
 
 type OperationBackup struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	LastStatus    *BackupProgressEntry   `protobuf:"bytes,3,opt,name=last_status,json=lastStatus,proto3" json:"last_status,omitempty"`
-	Errors        []*BackupProgressError `protobuf:"bytes,4,rep,name=errors,proto3" json:"errors,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	LastStatus     *BackupProgressEntry   `protobuf:"bytes,3,opt,name=last_status,json=lastStatus,proto3" json:"last_status,omitempty"`
+	Errors         []*BackupProgressError `protobuf:"bytes,4,rep,name=errors,proto3" json:"errors,omitempty"`
+	TimelineLogref string                 `protobuf:"bytes,5,opt,name=timeline_logref,json=timelineLogref,proto3" json:"timeline_logref,omitempty"` // logref of the backup's progress timeline, one JSON encoded BackupTimelinePoint per line.
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *OperationBackup) Reset() {
 	*x = OperationBackup{}
-	mi := &file_v1_operations_proto_msgTypes[3]
+	mi := &file_v1_operations_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -656,7 +823,7 @@ func (x *OperationBackup) String() string {
 func (*OperationBackup) ProtoMessage() {}
 
 func (x *OperationBackup) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_operations_proto_msgTypes[3]
+	mi := &file_v1_operations_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -669,7 +836,7 @@ func (x *OperationBackup) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OperationBackup.ProtoReflect.Descriptor instead.
 func (*OperationBackup) Descriptor() ([]byte, []int) {
-	return file_v1_operations_proto_rawDescGZIP(), []int{3}
+	return file_v1_operations_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *OperationBackup) GetLastStatus() *BackupProgressEntry {
@@ -686,6 +853,114 @@ func (x *OperationBackup) GetErrors() []*BackupProgressError {
 	return nil
 }
 
+func (x *OperationBackup) GetTimelineLogref() string {
+	if x != nil {
+		return x.TimelineLogref
+	}
+	return ""
+}
+
+// BackupTimelinePoint is a sample of a backup's progress. Samples are recorded less often as a backup runs.
+type BackupTimelinePoint struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UnixTimeMs     int64                  `protobuf:"varint,1,opt,name=unix_time_ms,json=unixTimeMs,proto3" json:"unix_time_ms,omitempty"`
+	FilesDone      int64                  `protobuf:"varint,2,opt,name=files_done,json=filesDone,proto3" json:"files_done,omitempty"`
+	BytesDone      int64                  `protobuf:"varint,3,opt,name=bytes_done,json=bytesDone,proto3" json:"bytes_done,omitempty"`
+	TotalFiles     int64                  `protobuf:"varint,4,opt,name=total_files,json=totalFiles,proto3" json:"total_files,omitempty"` // total files found so far, restic scans the source while backing it up.
+	TotalBytes     int64                  `protobuf:"varint,5,opt,name=total_bytes,json=totalBytes,proto3" json:"total_bytes,omitempty"`
+	CurrentFiles   []string               `protobuf:"bytes,6,rep,name=current_files,json=currentFiles,proto3" json:"current_files,omitempty"`           // files being read at the time of the sample.
+	FilesPerSecond float64                `protobuf:"fixed64,7,opt,name=files_per_second,json=filesPerSecond,proto3" json:"files_per_second,omitempty"` // throughput since the previous point, computed when the timeline is served.
+	BytesPerSecond float64                `protobuf:"fixed64,8,opt,name=bytes_per_second,json=bytesPerSecond,proto3" json:"bytes_per_second,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *BackupTimelinePoint) Reset() {
+	*x = BackupTimelinePoint{}
+	mi := &file_v1_operations_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BackupTimelinePoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackupTimelinePoint) ProtoMessage() {}
+
+func (x *BackupTimelinePoint) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_operations_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackupTimelinePoint.ProtoReflect.Descriptor instead.
+func (*BackupTimelinePoint) Descriptor() ([]byte, []int) {
+	return file_v1_operations_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BackupTimelinePoint) GetUnixTimeMs() int64 {
+	if x != nil {
+		return x.UnixTimeMs
+	}
+	return 0
+}
+
+func (x *BackupTimelinePoint) GetFilesDone() int64 {
+	if x != nil {
+		return x.FilesDone
+	}
+	return 0
+}
+
+func (x *BackupTimelinePoint) GetBytesDone() int64 {
+	if x != nil {
+		return x.BytesDone
+	}
+	return 0
+}
+
+func (x *BackupTimelinePoint) GetTotalFiles() int64 {
+	if x != nil {
+		return x.TotalFiles
+	}
+	return 0
+}
+
+func (x *BackupTimelinePoint) GetTotalBytes() int64 {
+	if x != nil {
+		return x.TotalBytes
+	}
+	return 0
+}
+
+func (x *BackupTimelinePoint) GetCurrentFiles() []string {
+	if x != nil {
+		return x.CurrentFiles
+	}
+	return nil
+}
+
+func (x *BackupTimelinePoint) GetFilesPerSecond() float64 {
+	if x != nil {
+		return x.FilesPerSecond
+	}
+	return 0
+}
+
+func (x *BackupTimelinePoint) GetBytesPerSecond() float64 {
+	if x != nil {
+		return x.BytesPerSecond
+	}
+	return 0
+}
+
 // OperationIndexSnapshot tracks that a snapshot was detected by backrest.
 type OperationIndexSnapshot struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -697,7 +972,7 @@ type OperationIndexSnapshot struct {
 
 func (x *OperationIndexSnapshot) Reset() {
 	*x = OperationIndexSnapshot{}
-	mi := &file_v1_operations_proto_msgTypes[4]
+	mi := &file_v1_operations_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -709,7 +984,7 @@ func (x *OperationIndexSnapshot) String() string {
 func (*OperationIndexSnapshot) ProtoMessage() {}
 
 func (x *OperationIndexSnapshot) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_operations_proto_msgTypes[4]
+	mi := &file_v1_operations_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -722,7 +997,7 @@ func (x *OperationIndexSnapshot) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OperationIndexSnapshot.ProtoReflect.Descriptor instead.
 func (*OperationIndexSnapshot) Descriptor() ([]byte, []int) {
-	return file_v1_operations_proto_rawDescGZIP(), []int{4}
+	return file_v1_operations_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *OperationIndexSnapshot) GetSnapshot() *ResticSnapshot {
@@ -750,7 +1025,7 @@ type OperationForget struct {
 
 func (x *OperationForget) Reset() {
 	*x = OperationForget{}
-	mi := &file_v1_operations_proto_msgTypes[5]
+	mi := &file_v1_operations_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -762,7 +1037,7 @@ func (x *OperationForget) String() string {
 func (*OperationForget) ProtoMessage() {}
 
 func (x *OperationForget) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_operations_proto_msgTypes[5]
+	mi := &file_v1_operations_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -775,7 +1050,7 @@ func (x *OperationForget) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OperationForget.ProtoReflect.Descriptor instead.
 func (*OperationForget) Descriptor() ([]byte, []int) {
-	return file_v1_operations_proto_rawDescGZIP(), []int{5}
+	return file_v1_operations_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *OperationForget) GetForget() []*ResticSnapshot {
@@ -796,15 +1071,16 @@ func (x *OperationForget) GetPolicy() *RetentionPolicy {
 type OperationPrune struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Deprecated: Marked as deprecated in v1/operations.proto.
-	Output        string `protobuf:"bytes,1,opt,name=output,proto3" json:"output,omitempty"`                                 // output of the prune.
-	OutputLogref  string `protobuf:"bytes,2,opt,name=output_logref,json=outputLogref,proto3" json:"output_logref,omitempty"` // logref of the prune output.
+	Output        string `protobuf:"bytes,1,opt,name=output,proto3" json:"output,omitempty"`                                  // output of the prune.
+	OutputLogref  string `protobuf:"bytes,2,opt,name=output_logref,json=outputLogref,proto3" json:"output_logref,omitempty"`  // logref of the prune output.
+	UnusedSize    *int64 `protobuf:"varint,3,opt,name=unused_size,json=unusedSize,proto3,oneof" json:"unused_size,omitempty"` // bytes of unused data left in the repo after the prune, as reported by restic. Unset if unknown.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *OperationPrune) Reset() {
 	*x = OperationPrune{}
-	mi := &file_v1_operations_proto_msgTypes[6]
+	mi := &file_v1_operations_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -816,7 +1092,7 @@ func (x *OperationPrune) String() string {
 func (*OperationPrune) ProtoMessage() {}
 
 func (x *OperationPrune) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_operations_proto_msgTypes[6]
+	mi := &file_v1_operations_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -829,7 +1105,7 @@ func (x *OperationPrune) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OperationPrune.ProtoReflect.Descriptor instead.
 func (*OperationPrune) Descriptor() ([]byte, []int) {
-	return file_v1_operations_proto_rawDescGZIP(), []int{6}
+	return file_v1_operations_proto_rawDescGZIP(), []int{8}
 }
 
 // Deprecated: Marked as deprecated in v1/operations.proto.
@@ -847,6 +1123,13 @@ func (x *OperationPrune) GetOutputLogref() string {
 	return ""
 }
 
+func (x *OperationPrune) GetUnusedSize() int64 {
+	if x != nil && x.UnusedSize != nil {
+		return *x.UnusedSize
+	}
+	return 0
+}
+
 // OperationCheck tracks a check operation.
 type OperationCheck struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -859,7 +1142,7 @@ type OperationCheck struct {
 
 func (x *OperationCheck) Reset() {
 	*x = OperationCheck{}
-	mi := &file_v1_operations_proto_msgTypes[7]
+	mi := &file_v1_operations_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -871,7 +1154,7 @@ func (x *OperationCheck) String() string {
 func (*OperationCheck) ProtoMessage() {}
 
 func (x *OperationCheck) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_operations_proto_msgTypes[7]
+	mi := &file_v1_operations_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -884,7 +1167,7 @@ func (x *OperationCheck) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OperationCheck.ProtoReflect.Descriptor instead.
 func (*OperationCheck) Descriptor() ([]byte, []int) {
-	return file_v1_operations_proto_rawDescGZIP(), []int{7}
+	return file_v1_operations_proto_rawDescGZIP(), []int{9}
 }
 
 // Deprecated: Marked as deprecated in v1/operations.proto.
@@ -914,7 +1197,7 @@ type OperationRunCommand struct {
 
 func (x *OperationRunCommand) Reset() {
 	*x = OperationRunCommand{}
-	mi := &file_v1_operations_proto_msgTypes[8]
+	mi := &file_v1_operations_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -926,7 +1209,7 @@ func (x *OperationRunCommand) String() string {
 func (*OperationRunCommand) ProtoMessage() {}
 
 func (x *OperationRunCommand) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_operations_proto_msgTypes[8]
+	mi := &file_v1_operations_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -939,7 +1222,7 @@ func (x *OperationRunCommand) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OperationRunCommand.ProtoReflect.Descriptor instead.
 func (*OperationRunCommand) Descriptor() ([]byte, []int) {
-	return file_v1_operations_proto_rawDescGZIP(), []int{8}
+	return file_v1_operations_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *OperationRunCommand) GetCommand() string {
@@ -975,7 +1258,7 @@ type OperationRestore struct {
 
 func (x *OperationRestore) Reset() {
 	*x = OperationRestore{}
-	mi := &file_v1_operations_proto_msgTypes[9]
+	mi := &file_v1_operations_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -987,7 +1270,7 @@ func (x *OperationRestore) String() string {
 func (*OperationRestore) ProtoMessage() {}
 
 func (x *OperationRestore) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_operations_proto_msgTypes[9]
+	mi := &file_v1_operations_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1000,7 +1283,7 @@ func (x *OperationRestore) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OperationRestore.ProtoReflect.Descriptor instead.
 func (*OperationRestore) Descriptor() ([]byte, []int) {
-	return file_v1_operations_proto_rawDescGZIP(), []int{9}
+	return file_v1_operations_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *OperationRestore) GetPath() string {
@@ -1034,7 +1317,7 @@ type OperationStats struct {
 
 func (x *OperationStats) Reset() {
 	*x = OperationStats{}
-	mi := &file_v1_operations_proto_msgTypes[10]
+	mi := &file_v1_operations_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1046,7 +1329,7 @@ func (x *OperationStats) String() string {
 func (*OperationStats) ProtoMessage() {}
 
 func (x *OperationStats) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_operations_proto_msgTypes[10]
+	mi := &file_v1_operations_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1059,7 +1342,7 @@ func (x *OperationStats) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OperationStats.ProtoReflect.Descriptor instead.
 func (*OperationStats) Descriptor() ([]byte, []int) {
-	return file_v1_operations_proto_rawDescGZIP(), []int{10}
+	return file_v1_operations_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *OperationStats) GetStats() *RepoStats {
@@ -1082,7 +1365,7 @@ type OperationRunHook struct {
 
 func (x *OperationRunHook) Reset() {
 	*x = OperationRunHook{}
-	mi := &file_v1_operations_proto_msgTypes[11]
+	mi := &file_v1_operations_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1094,7 +1377,7 @@ func (x *OperationRunHook) String() string {
 func (*OperationRunHook) ProtoMessage() {}
 
 func (x *OperationRunHook) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_operations_proto_msgTypes[11]
+	mi := &file_v1_operations_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1107,7 +1390,7 @@ func (x *OperationRunHook) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OperationRunHook.ProtoReflect.Descriptor instead.
 func (*OperationRunHook) Descriptor() ([]byte, []int) {
-	return file_v1_operations_proto_rawDescGZIP(), []int{11}
+	return file_v1_operations_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *OperationRunHook) GetParentOp() int64 {
@@ -1146,7 +1429,7 @@ const file_v1_operations_proto_rawDesc = "" +
 	"\rOperationList\x12-\n" +
 	"\n" +
 	"operations\x18\x01 \x03(\v2\r.v1.OperationR\n" +
-	"operations\"\x9b\t\n" +
+	"operations\"\xc8\t\n" +
 	"\tOperation\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1f\n" +
 	"\voriginal_id\x18\r \x01(\x03R\n" +
@@ -1167,7 +1450,8 @@ const file_v1_operations_proto_rawDesc = "" +
 	"\x12unix_time_start_ms\x18\x05 \x01(\x03R\x0funixTimeStartMs\x12'\n" +
 	"\x10unix_time_end_ms\x18\x06 \x01(\x03R\runixTimeEndMs\x12'\n" +
 	"\x0fdisplay_message\x18\a \x01(\tR\x0edisplayMessage\x12\x16\n" +
-	"\x06logref\x18\t \x01(\tR\x06logref\x12@\n" +
+	"\x06logref\x18\t \x01(\tR\x06logref\x12+\n" +
+	"\x06rollup\x18\x11 \x01(\v2\x13.v1.OperationRollupR\x06rollup\x12@\n" +
 	"\x10operation_backup\x18d \x01(\v2\x13.v1.OperationBackupH\x00R\x0foperationBackup\x12V\n" +
 	"\x18operation_index_snapshot\x18e \x01(\v2\x1a.v1.OperationIndexSnapshotH\x00R\x16operationIndexSnapshot\x12@\n" +
 	"\x10operation_forget\x18f \x01(\v2\x13.v1.OperationForgetH\x00R\x0foperationForget\x12=\n" +
@@ -1177,27 +1461,52 @@ const file_v1_operations_proto_rawDesc = "" +
 	"\x12operation_run_hook\x18j \x01(\v2\x14.v1.OperationRunHookH\x00R\x10operationRunHook\x12=\n" +
 	"\x0foperation_check\x18k \x01(\v2\x12.v1.OperationCheckH\x00R\x0eoperationCheck\x12M\n" +
 	"\x15operation_run_command\x18l \x01(\v2\x17.v1.OperationRunCommandH\x00R\x13operationRunCommandB\x04\n" +
-	"\x02op\"\x93\x02\n" +
+	"\x02op\"\x96\x02\n" +
+	"\x0fOperationRollup\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x03R\x05count\x12-\n" +
+	"\x13first_start_time_ms\x18\x02 \x01(\x03R\x10firstStartTimeMs\x12*\n" +
+	"\x11total_duration_ms\x18\x03 \x01(\x03R\x0ftotalDurationMs\x122\n" +
+	"\x15total_files_processed\x18\x04 \x01(\x03R\x13totalFilesProcessed\x122\n" +
+	"\x15total_bytes_processed\x18\x05 \x01(\x03R\x13totalBytesProcessed\x12*\n" +
+	"\x11total_bytes_added\x18\x06 \x01(\x03R\x0ftotalBytesAdded\"\x93\x02\n" +
 	"\x0eOperationEvent\x12-\n" +
 	"\n" +
 	"keep_alive\x18\x01 \x01(\v2\f.types.EmptyH\x00R\tkeepAlive\x12B\n" +
 	"\x12created_operations\x18\x02 \x01(\v2\x11.v1.OperationListH\x00R\x11createdOperations\x12B\n" +
 	"\x12updated_operations\x18\x03 \x01(\v2\x11.v1.OperationListH\x00R\x11updatedOperations\x12A\n" +
 	"\x12deleted_operations\x18\x04 \x01(\v2\x10.types.Int64ListH\x00R\x11deletedOperationsB\a\n" +
-	"\x05event\"|\n" +
+	"\x05event\"\xa5\x01\n" +
 	"\x0fOperationBackup\x128\n" +
 	"\vlast_status\x18\x03 \x01(\v2\x17.v1.BackupProgressEntryR\n" +
 	"lastStatus\x12/\n" +
-	"\x06errors\x18\x04 \x03(\v2\x17.v1.BackupProgressErrorR\x06errors\"`\n" +
+	"\x06errors\x18\x04 \x03(\v2\x17.v1.BackupProgressErrorR\x06errors\x12'\n" +
+	"\x0ftimeline_logref\x18\x05 \x01(\tR\x0etimelineLogref\"\xb0\x02\n" +
+	"\x13BackupTimelinePoint\x12 \n" +
+	"\funix_time_ms\x18\x01 \x01(\x03R\n" +
+	"unixTimeMs\x12\x1d\n" +
+	"\n" +
+	"files_done\x18\x02 \x01(\x03R\tfilesDone\x12\x1d\n" +
+	"\n" +
+	"bytes_done\x18\x03 \x01(\x03R\tbytesDone\x12\x1f\n" +
+	"\vtotal_files\x18\x04 \x01(\x03R\n" +
+	"totalFiles\x12\x1f\n" +
+	"\vtotal_bytes\x18\x05 \x01(\x03R\n" +
+	"totalBytes\x12#\n" +
+	"\rcurrent_files\x18\x06 \x03(\tR\fcurrentFiles\x12(\n" +
+	"\x10files_per_second\x18\a \x01(\x01R\x0efilesPerSecond\x12(\n" +
+	"\x10bytes_per_second\x18\b \x01(\x01R\x0ebytesPerSecond\"`\n" +
 	"\x16OperationIndexSnapshot\x12.\n" +
 	"\bsnapshot\x18\x02 \x01(\v2\x12.v1.ResticSnapshotR\bsnapshot\x12\x16\n" +
 	"\x06forgot\x18\x03 \x01(\bR\x06forgot\"j\n" +
 	"\x0fOperationForget\x12*\n" +
 	"\x06forget\x18\x01 \x03(\v2\x12.v1.ResticSnapshotR\x06forget\x12+\n" +
-	"\x06policy\x18\x02 \x01(\v2\x13.v1.RetentionPolicyR\x06policy\"Q\n" +
+	"\x06policy\x18\x02 \x01(\v2\x13.v1.RetentionPolicyR\x06policy\"\x87\x01\n" +
 	"\x0eOperationPrune\x12\x1a\n" +
 	"\x06output\x18\x01 \x01(\tB\x02\x18\x01R\x06output\x12#\n" +
-	"\routput_logref\x18\x02 \x01(\tR\foutputLogref\"Q\n" +
+	"\routput_logref\x18\x02 \x01(\tR\foutputLogref\x12$\n" +
+	"\vunused_size\x18\x03 \x01(\x03H\x00R\n" +
+	"unusedSize\x88\x01\x01B\x0e\n" +
+	"\f_unused_size\"Q\n" +
 	"\x0eOperationCheck\x12\x1a\n" +
 	"\x06output\x18\x01 \x01(\tB\x02\x18\x01R\x06output\x12#\n" +
 	"\routput_logref\x18\x02 \x01(\tR\foutputLogref\"\x80\x01\n" +
@@ -1230,7 +1539,18 @@ const file_v1_operations_proto_rawDesc = "" +
 	"\x0eSTATUS_WARNING\x10\a\x12\x10\n" +
 	"\fSTATUS_ERROR\x10\x04\x12\x1b\n" +
 	"\x17STATUS_SYSTEM_CANCELLED\x10\x05\x12\x19\n" +
-	"\x15STATUS_USER_CANCELLED\x10\x06B,Z*github.com/garethgeorge/backrest/gen/go/v1b\x06proto3"
+	"\x15STATUS_USER_CANCELLED\x10\x06*\xab\x02\n" +
+	"\rOperationType\x12\x1a\n" +
+	"\x16OPERATION_TYPE_UNKNOWN\x10\x00\x12\x19\n" +
+	"\x15OPERATION_TYPE_BACKUP\x10d\x12!\n" +
+	"\x1dOPERATION_TYPE_INDEX_SNAPSHOT\x10e\x12\x19\n" +
+	"\x15OPERATION_TYPE_FORGET\x10f\x12\x18\n" +
+	"\x14OPERATION_TYPE_PRUNE\x10g\x12\x1a\n" +
+	"\x16OPERATION_TYPE_RESTORE\x10h\x12\x18\n" +
+	"\x14OPERATION_TYPE_STATS\x10i\x12\x1b\n" +
+	"\x17OPERATION_TYPE_RUN_HOOK\x10j\x12\x18\n" +
+	"\x14OPERATION_TYPE_CHECK\x10k\x12\x1e\n" +
+	"\x1aOPERATION_TYPE_RUN_COMMAND\x10lB,Z*github.com/garethgeorge/backrest/gen/go/v1b\x06proto3"
 
 var (
 	file_v1_operations_proto_rawDescOnce sync.Once
@@ -1244,62 +1564,66 @@ func file_v1_operations_proto_rawDescGZIP() []byte {
 	return file_v1_operations_proto_rawDescData
 }
 
-var file_v1_operations_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_v1_operations_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_v1_operations_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_v1_operations_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
 var file_v1_operations_proto_goTypes = []any{
 	(OperationEventType)(0),        // 0: v1.OperationEventType
 	(OperationStatus)(0),           // 1: v1.OperationStatus
-	(*OperationList)(nil),          // 2: v1.OperationList
-	(*Operation)(nil),              // 3: v1.Operation
-	(*OperationEvent)(nil),         // 4: v1.OperationEvent
-	(*OperationBackup)(nil),        // 5: v1.OperationBackup
-	(*OperationIndexSnapshot)(nil), // 6: v1.OperationIndexSnapshot
-	(*OperationForget)(nil),        // 7: v1.OperationForget
-	(*OperationPrune)(nil),         // 8: v1.OperationPrune
-	(*OperationCheck)(nil),         // 9: v1.OperationCheck
-	(*OperationRunCommand)(nil),    // 10: v1.OperationRunCommand
-	(*OperationRestore)(nil),       // 11: v1.OperationRestore
-	(*OperationStats)(nil),         // 12: v1.OperationStats
-	(*OperationRunHook)(nil),       // 13: v1.OperationRunHook
-	(*types.Empty)(nil),            // 14: types.Empty
-	(*types.Int64List)(nil),        // 15: types.Int64List
-	(*BackupProgressEntry)(nil),    // 16: v1.BackupProgressEntry
-	(*BackupProgressError)(nil),    // 17: v1.BackupProgressError
-	(*ResticSnapshot)(nil),         // 18: v1.ResticSnapshot
-	(*RetentionPolicy)(nil),        // 19: v1.RetentionPolicy
-	(*RestoreProgressEntry)(nil),   // 20: v1.RestoreProgressEntry
-	(*RepoStats)(nil),              // 21: v1.RepoStats
-	(Hook_Condition)(0),            // 22: v1.Hook.Condition
+	(OperationType)(0),             // 2: v1.OperationType
+	(*OperationList)(nil),          // 3: v1.OperationList
+	(*Operation)(nil),              // 4: v1.Operation
+	(*OperationRollup)(nil),        // 5: v1.OperationRollup
+	(*OperationEvent)(nil),         // 6: v1.OperationEvent
+	(*OperationBackup)(nil),        // 7: v1.OperationBackup
+	(*BackupTimelinePoint)(nil),    // 8: v1.BackupTimelinePoint
+	(*OperationIndexSnapshot)(nil), // 9: v1.OperationIndexSnapshot
+	(*OperationForget)(nil),        // 10: v1.OperationForget
+	(*OperationPrune)(nil),         // 11: v1.OperationPrune
+	(*OperationCheck)(nil),         // 12: v1.OperationCheck
+	(*OperationRunCommand)(nil),    // 13: v1.OperationRunCommand
+	(*OperationRestore)(nil),       // 14: v1.OperationRestore
+	(*OperationStats)(nil),         // 15: v1.OperationStats
+	(*OperationRunHook)(nil),       // 16: v1.OperationRunHook
+	(*types.Empty)(nil),            // 17: types.Empty
+	(*types.Int64List)(nil),        // 18: types.Int64List
+	(*BackupProgressEntry)(nil),    // 19: v1.BackupProgressEntry
+	(*BackupProgressError)(nil),    // 20: v1.BackupProgressError
+	(*ResticSnapshot)(nil),         // 21: v1.ResticSnapshot
+	(*RetentionPolicy)(nil),        // 22: v1.RetentionPolicy
+	(*RestoreProgressEntry)(nil),   // 23: v1.RestoreProgressEntry
+	(*RepoStats)(nil),              // 24: v1.RepoStats
+	(Hook_Condition)(0),            // 25: v1.Hook.Condition
 }
 var file_v1_operations_proto_depIdxs = []int32{
-	3,  // 0: v1.OperationList.operations:type_name -> v1.Operation
+	4,  // 0: v1.OperationList.operations:type_name -> v1.Operation
 	1,  // 1: v1.Operation.status:type_name -> v1.OperationStatus
-	5,  // 2: v1.Operation.operation_backup:type_name -> v1.OperationBackup
-	6,  // 3: v1.Operation.operation_index_snapshot:type_name -> v1.OperationIndexSnapshot
-	7,  // 4: v1.Operation.operation_forget:type_name -> v1.OperationForget
-	8,  // 5: v1.Operation.operation_prune:type_name -> v1.OperationPrune
-	11, // 6: v1.Operation.operation_restore:type_name -> v1.OperationRestore
-	12, // 7: v1.Operation.operation_stats:type_name -> v1.OperationStats
-	13, // 8: v1.Operation.operation_run_hook:type_name -> v1.OperationRunHook
-	9,  // 9: v1.Operation.operation_check:type_name -> v1.OperationCheck
-	10, // 10: v1.Operation.operation_run_command:type_name -> v1.OperationRunCommand
-	14, // 11: v1.OperationEvent.keep_alive:type_name -> types.Empty
-	2,  // 12: v1.OperationEvent.created_operations:type_name -> v1.OperationList
-	2,  // 13: v1.OperationEvent.updated_operations:type_name -> v1.OperationList
-	15, // 14: v1.OperationEvent.deleted_operations:type_name -> types.Int64List
-	16, // 15: v1.OperationBackup.last_status:type_name -> v1.BackupProgressEntry
-	17, // 16: v1.OperationBackup.errors:type_name -> v1.BackupProgressError
-	18, // 17: v1.OperationIndexSnapshot.snapshot:type_name -> v1.ResticSnapshot
-	18, // 18: v1.OperationForget.forget:type_name -> v1.ResticSnapshot
-	19, // 19: v1.OperationForget.policy:type_name -> v1.RetentionPolicy
-	20, // 20: v1.OperationRestore.last_status:type_name -> v1.RestoreProgressEntry
-	21, // 21: v1.OperationStats.stats:type_name -> v1.RepoStats
-	22, // 22: v1.OperationRunHook.condition:type_name -> v1.Hook.Condition
-	23, // [23:23] is the sub-list for method output_type
-	23, // [23:23] is the sub-list for method input_type
-	23, // [23:23] is the sub-list for extension type_name
-	23, // [23:23] is the sub-list for extension extendee
-	0,  // [0:23] is the sub-list for field type_name
+	5,  // 2: v1.Operation.rollup:type_name -> v1.OperationRollup
+	7,  // 3: v1.Operation.operation_backup:type_name -> v1.OperationBackup
+	9,  // 4: v1.Operation.operation_index_snapshot:type_name -> v1.OperationIndexSnapshot
+	10, // 5: v1.Operation.operation_forget:type_name -> v1.OperationForget
+	11, // 6: v1.Operation.operation_prune:type_name -> v1.OperationPrune
+	14, // 7: v1.Operation.operation_restore:type_name -> v1.OperationRestore
+	15, // 8: v1.Operation.operation_stats:type_name -> v1.OperationStats
+	16, // 9: v1.Operation.operation_run_hook:type_name -> v1.OperationRunHook
+	12, // 10: v1.Operation.operation_check:type_name -> v1.OperationCheck
+	13, // 11: v1.Operation.operation_run_command:type_name -> v1.OperationRunCommand
+	17, // 12: v1.OperationEvent.keep_alive:type_name -> types.Empty
+	3,  // 13: v1.OperationEvent.created_operations:type_name -> v1.OperationList
+	3,  // 14: v1.OperationEvent.updated_operations:type_name -> v1.OperationList
+	18, // 15: v1.OperationEvent.deleted_operations:type_name -> types.Int64List
+	19, // 16: v1.OperationBackup.last_status:type_name -> v1.BackupProgressEntry
+	20, // 17: v1.OperationBackup.errors:type_name -> v1.BackupProgressError
+	21, // 18: v1.OperationIndexSnapshot.snapshot:type_name -> v1.ResticSnapshot
+	21, // 19: v1.OperationForget.forget:type_name -> v1.ResticSnapshot
+	22, // 20: v1.OperationForget.policy:type_name -> v1.RetentionPolicy
+	23, // 21: v1.OperationRestore.last_status:type_name -> v1.RestoreProgressEntry
+	24, // 22: v1.OperationStats.stats:type_name -> v1.RepoStats
+	25, // 23: v1.OperationRunHook.condition:type_name -> v1.Hook.Condition
+	24, // [24:24] is the sub-list for method output_type
+	24, // [24:24] is the sub-list for method input_type
+	24, // [24:24] is the sub-list for extension type_name
+	24, // [24:24] is the sub-list for extension extendee
+	0,  // [0:24] is the sub-list for field type_name
 }
 
 func init() { file_v1_operations_proto_init() }
@@ -1320,19 +1644,20 @@ func file_v1_operations_proto_init() {
 		(*Operation_OperationCheck)(nil),
 		(*Operation_OperationRunCommand)(nil),
 	}
-	file_v1_operations_proto_msgTypes[2].OneofWrappers = []any{
+	file_v1_operations_proto_msgTypes[3].OneofWrappers = []any{
 		(*OperationEvent_KeepAlive)(nil),
 		(*OperationEvent_CreatedOperations)(nil),
 		(*OperationEvent_UpdatedOperations)(nil),
 		(*OperationEvent_DeletedOperations)(nil),
 	}
+	file_v1_operations_proto_msgTypes[8].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_v1_operations_proto_rawDesc), len(file_v1_operations_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   12,
+			NumEnums:      3,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -33,6 +33,7 @@ const (
 	DoRepoTaskRequest_TASK_CHECK           DoRepoTaskRequest_Task = 3
 	DoRepoTaskRequest_TASK_STATS           DoRepoTaskRequest_Task = 4
 	DoRepoTaskRequest_TASK_UNLOCK          DoRepoTaskRequest_Task = 5
+	DoRepoTaskRequest_TASK_FORGET          DoRepoTaskRequest_Task = 6 // applies the repo's retention policy.
 )
 
 // Enum value maps for DoRepoTaskRequest_Task.
@@ -44,6 +45,7 @@ var (
 		3: "TASK_CHECK",
 		4: "TASK_STATS",
 		5: "TASK_UNLOCK",
+		6: "TASK_FORGET",
 	}
 	DoRepoTaskRequest_Task_value = map[string]int32{
 		"TASK_NONE":            0,
@@ -52,6 +54,7 @@ var (
 		"TASK_CHECK":           3,
 		"TASK_STATS":           4,
 		"TASK_UNLOCK":          5,
+		"TASK_FORGET":          6,
 	}
 )
 
@@ -79,7 +82,53 @@ func (x DoRepoTaskRequest_Task) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use DoRepoTaskRequest_Task.Descriptor instead.
 func (DoRepoTaskRequest_Task) EnumDescriptor() ([]byte, []int) {
-	return file_v1_service_proto_rawDescGZIP(), []int{1, 0}
+	return file_v1_service_proto_rawDescGZIP(), []int{2, 0}
+}
+
+type ExportOperationsRequest_Format int32
+
+const (
+	ExportOperationsRequest_FORMAT_JSONL ExportOperationsRequest_Format = 0 // one JSON encoded Operation per line, can be imported with ImportOperations.
+	ExportOperationsRequest_FORMAT_CSV   ExportOperationsRequest_Format = 1 // a summary of each operation for use in spreadsheets.
+)
+
+// Enum value maps for ExportOperationsRequest_Format.
+var (
+	ExportOperationsRequest_Format_name = map[int32]string{
+		0: "FORMAT_JSONL",
+		1: "FORMAT_CSV",
+	}
+	ExportOperationsRequest_Format_value = map[string]int32{
+		"FORMAT_JSONL": 0,
+		"FORMAT_CSV":   1,
+	}
+)
+
+func (x ExportOperationsRequest_Format) Enum() *ExportOperationsRequest_Format {
+	p := new(ExportOperationsRequest_Format)
+	*p = x
+	return p
+}
+
+func (x ExportOperationsRequest_Format) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExportOperationsRequest_Format) Descriptor() protoreflect.EnumDescriptor {
+	return file_v1_service_proto_enumTypes[1].Descriptor()
+}
+
+func (ExportOperationsRequest_Format) Type() protoreflect.EnumType {
+	return &file_v1_service_proto_enumTypes[1]
+}
+
+func (x ExportOperationsRequest_Format) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExportOperationsRequest_Format.Descriptor instead.
+func (ExportOperationsRequest_Format) EnumDescriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{27, 0}
 }
 
 // OpSelector is a message that can be used to select operations e.g. by query.
@@ -93,6 +142,10 @@ type OpSelector struct {
 	SnapshotId            *string                `protobuf:"bytes,4,opt,name=snapshot_id,json=snapshotId,proto3,oneof" json:"snapshot_id,omitempty"`
 	FlowId                *int64                 `protobuf:"varint,5,opt,name=flow_id,json=flowId,proto3,oneof" json:"flow_id,omitempty"`
 	ModnoGte              *int64                 `protobuf:"varint,9,opt,name=modno_gte,json=modnoGte,proto3,oneof" json:"modno_gte,omitempty"`
+	StartTimeGteMs        *int64                 `protobuf:"varint,10,opt,name=start_time_gte_ms,json=startTimeGteMs,proto3,oneof" json:"start_time_gte_ms,omitempty"` // selects operations that started at or after this time.
+	StartTimeLtMs         *int64                 `protobuf:"varint,11,opt,name=start_time_lt_ms,json=startTimeLtMs,proto3,oneof" json:"start_time_lt_ms,omitempty"`    // selects operations that started before this time.
+	Statuses              []OperationStatus      `protobuf:"varint,12,rep,packed,name=statuses,proto3,enum=v1.OperationStatus" json:"statuses,omitempty"`              // selects operations with any of these statuses.
+	OpTypes               []OperationType        `protobuf:"varint,13,rep,packed,name=op_types,json=opTypes,proto3,enum=v1.OperationType" json:"op_types,omitempty"`   // selects operations of any of these types.
 	unknownFields         protoimpl.UnknownFields
 	sizeCache             protoimpl.SizeCache
 }
@@ -183,6 +236,86 @@ func (x *OpSelector) GetModnoGte() int64 {
 	return 0
 }
 
+func (x *OpSelector) GetStartTimeGteMs() int64 {
+	if x != nil && x.StartTimeGteMs != nil {
+		return *x.StartTimeGteMs
+	}
+	return 0
+}
+
+func (x *OpSelector) GetStartTimeLtMs() int64 {
+	if x != nil && x.StartTimeLtMs != nil {
+		return *x.StartTimeLtMs
+	}
+	return 0
+}
+
+func (x *OpSelector) GetStatuses() []OperationStatus {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *OpSelector) GetOpTypes() []OperationType {
+	if x != nil {
+		return x.OpTypes
+	}
+	return nil
+}
+
+type SetConfigRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Config           *Config                `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	ConfirmRetention bool                   `protobuf:"varint,2,opt,name=confirm_retention,json=confirmRetention,proto3" json:"confirm_retention,omitempty"` // applies retention policy changes that would remove more snapshots than the confirmation threshold.
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *SetConfigRequest) Reset() {
+	*x = SetConfigRequest{}
+	mi := &file_v1_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetConfigRequest) ProtoMessage() {}
+
+func (x *SetConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetConfigRequest.ProtoReflect.Descriptor instead.
+func (*SetConfigRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SetConfigRequest) GetConfig() *Config {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *SetConfigRequest) GetConfirmRetention() bool {
+	if x != nil {
+		return x.ConfirmRetention
+	}
+	return false
+}
+
 type DoRepoTaskRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	RepoId        string                 `protobuf:"bytes,1,opt,name=repo_id,json=repoId,proto3" json:"repo_id,omitempty"`
@@ -193,7 +326,7 @@ type DoRepoTaskRequest struct {
 
 func (x *DoRepoTaskRequest) Reset() {
 	*x = DoRepoTaskRequest{}
-	mi := &file_v1_service_proto_msgTypes[1]
+	mi := &file_v1_service_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -205,7 +338,7 @@ func (x *DoRepoTaskRequest) String() string {
 func (*DoRepoTaskRequest) ProtoMessage() {}
 
 func (x *DoRepoTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_service_proto_msgTypes[1]
+	mi := &file_v1_service_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -218,7 +351,7 @@ func (x *DoRepoTaskRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DoRepoTaskRequest.ProtoReflect.Descriptor instead.
 func (*DoRepoTaskRequest) Descriptor() ([]byte, []int) {
-	return file_v1_service_proto_rawDescGZIP(), []int{1}
+	return file_v1_service_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *DoRepoTaskRequest) GetRepoId() string {
@@ -245,7 +378,7 @@ type ClearHistoryRequest struct {
 
 func (x *ClearHistoryRequest) Reset() {
 	*x = ClearHistoryRequest{}
-	mi := &file_v1_service_proto_msgTypes[2]
+	mi := &file_v1_service_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -257,7 +390,7 @@ func (x *ClearHistoryRequest) String() string {
 func (*ClearHistoryRequest) ProtoMessage() {}
 
 func (x *ClearHistoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_service_proto_msgTypes[2]
+	mi := &file_v1_service_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -270,7 +403,7 @@ func (x *ClearHistoryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ClearHistoryRequest.ProtoReflect.Descriptor instead.
 func (*ClearHistoryRequest) Descriptor() ([]byte, []int) {
-	return file_v1_service_proto_rawDescGZIP(), []int{2}
+	return file_v1_service_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *ClearHistoryRequest) GetSelector() *OpSelector {
@@ -298,7 +431,7 @@ type ForgetRequest struct {
 
 func (x *ForgetRequest) Reset() {
 	*x = ForgetRequest{}
-	mi := &file_v1_service_proto_msgTypes[3]
+	mi := &file_v1_service_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -310,7 +443,7 @@ func (x *ForgetRequest) String() string {
 func (*ForgetRequest) ProtoMessage() {}
 
 func (x *ForgetRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_service_proto_msgTypes[3]
+	mi := &file_v1_service_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -323,7 +456,7 @@ func (x *ForgetRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ForgetRequest.ProtoReflect.Descriptor instead.
 func (*ForgetRequest) Descriptor() ([]byte, []int) {
-	return file_v1_service_proto_rawDescGZIP(), []int{3}
+	return file_v1_service_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *ForgetRequest) GetRepoId() string {
@@ -347,29 +480,29 @@ func (x *ForgetRequest) GetSnapshotId() string {
 	return ""
 }
 
-type ListSnapshotsRequest struct {
+type PreviewRetentionRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	RepoId        string                 `protobuf:"bytes,1,opt,name=repo_id,json=repoId,proto3" json:"repo_id,omitempty"`
-	PlanId        string                 `protobuf:"bytes,2,opt,name=plan_id,json=planId,proto3" json:"plan_id,omitempty"`
+	PlanId        string                 `protobuf:"bytes,1,opt,name=plan_id,json=planId,proto3" json:"plan_id,omitempty"`
+	Policy        *RetentionPolicy       `protobuf:"bytes,2,opt,name=policy,proto3" json:"policy,omitempty"` // optional, the candidate policy to evaluate. Defaults to the plan's current policy.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListSnapshotsRequest) Reset() {
-	*x = ListSnapshotsRequest{}
-	mi := &file_v1_service_proto_msgTypes[4]
+func (x *PreviewRetentionRequest) Reset() {
+	*x = PreviewRetentionRequest{}
+	mi := &file_v1_service_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListSnapshotsRequest) String() string {
+func (x *PreviewRetentionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListSnapshotsRequest) ProtoMessage() {}
+func (*PreviewRetentionRequest) ProtoMessage() {}
 
-func (x *ListSnapshotsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_service_proto_msgTypes[4]
+func (x *PreviewRetentionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -380,48 +513,47 @@ func (x *ListSnapshotsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListSnapshotsRequest.ProtoReflect.Descriptor instead.
-func (*ListSnapshotsRequest) Descriptor() ([]byte, []int) {
-	return file_v1_service_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use PreviewRetentionRequest.ProtoReflect.Descriptor instead.
+func (*PreviewRetentionRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *ListSnapshotsRequest) GetRepoId() string {
+func (x *PreviewRetentionRequest) GetPlanId() string {
 	if x != nil {
-		return x.RepoId
+		return x.PlanId
 	}
 	return ""
 }
 
-func (x *ListSnapshotsRequest) GetPlanId() string {
+func (x *PreviewRetentionRequest) GetPolicy() *RetentionPolicy {
 	if x != nil {
-		return x.PlanId
+		return x.Policy
 	}
-	return ""
+	return nil
 }
 
-type GetOperationsRequest struct {
+type PreviewRetentionResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Selector      *OpSelector            `protobuf:"bytes,1,opt,name=selector,proto3" json:"selector,omitempty"`
-	LastN         int64                  `protobuf:"varint,2,opt,name=last_n,json=lastN,proto3" json:"last_n,omitempty"` // limit to the last n operations
+	Decisions     []*RetentionDecision   `protobuf:"bytes,1,rep,name=decisions,proto3" json:"decisions,omitempty"` // one decision per snapshot, newest first.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetOperationsRequest) Reset() {
-	*x = GetOperationsRequest{}
-	mi := &file_v1_service_proto_msgTypes[5]
+func (x *PreviewRetentionResponse) Reset() {
+	*x = PreviewRetentionResponse{}
+	mi := &file_v1_service_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetOperationsRequest) String() string {
+func (x *PreviewRetentionResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOperationsRequest) ProtoMessage() {}
+func (*PreviewRetentionResponse) ProtoMessage() {}
 
-func (x *GetOperationsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_service_proto_msgTypes[5]
+func (x *PreviewRetentionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -432,51 +564,104 @@ func (x *GetOperationsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOperationsRequest.ProtoReflect.Descriptor instead.
-func (*GetOperationsRequest) Descriptor() ([]byte, []int) {
-	return file_v1_service_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use PreviewRetentionResponse.ProtoReflect.Descriptor instead.
+func (*PreviewRetentionResponse) Descriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *GetOperationsRequest) GetSelector() *OpSelector {
+func (x *PreviewRetentionResponse) GetDecisions() []*RetentionDecision {
 	if x != nil {
-		return x.Selector
+		return x.Decisions
 	}
 	return nil
 }
 
-func (x *GetOperationsRequest) GetLastN() int64 {
+type RetentionDecision struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Snapshot      *ResticSnapshot        `protobuf:"bytes,1,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	Keep          bool                   `protobuf:"varint,2,opt,name=keep,proto3" json:"keep,omitempty"`
+	Reasons       []string               `protobuf:"bytes,3,rep,name=reasons,proto3" json:"reasons,omitempty"` // the rules that kept the snapshot e.g. "daily snapshot", empty if the snapshot would be removed.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetentionDecision) Reset() {
+	*x = RetentionDecision{}
+	mi := &file_v1_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetentionDecision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetentionDecision) ProtoMessage() {}
+
+func (x *RetentionDecision) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_proto_msgTypes[7]
 	if x != nil {
-		return x.LastN
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-type RestoreSnapshotRequest struct {
+// Deprecated: Use RetentionDecision.ProtoReflect.Descriptor instead.
+func (*RetentionDecision) Descriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RetentionDecision) GetSnapshot() *ResticSnapshot {
+	if x != nil {
+		return x.Snapshot
+	}
+	return nil
+}
+
+func (x *RetentionDecision) GetKeep() bool {
+	if x != nil {
+		return x.Keep
+	}
+	return false
+}
+
+func (x *RetentionDecision) GetReasons() []string {
+	if x != nil {
+		return x.Reasons
+	}
+	return nil
+}
+
+type AdoptSnapshotsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PlanId        string                 `protobuf:"bytes,1,opt,name=plan_id,json=planId,proto3" json:"plan_id,omitempty"`
-	RepoId        string                 `protobuf:"bytes,5,opt,name=repo_id,json=repoId,proto3" json:"repo_id,omitempty"`
-	SnapshotId    string                 `protobuf:"bytes,2,opt,name=snapshot_id,json=snapshotId,proto3" json:"snapshot_id,omitempty"`
-	Path          string                 `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
-	Target        string                 `protobuf:"bytes,4,opt,name=target,proto3" json:"target,omitempty"`
+	PlanId        string                 `protobuf:"bytes,1,opt,name=plan_id,json=planId,proto3" json:"plan_id,omitempty"`  // the plan to assign snapshots to, snapshots are selected from the plan's repo.
+	Hosts         []string               `protobuf:"bytes,2,rep,name=hosts,proto3" json:"hosts,omitempty"`                  // optional, only select snapshots from one of these hosts.
+	Paths         []string               `protobuf:"bytes,3,rep,name=paths,proto3" json:"paths,omitempty"`                  // optional, only select snapshots that include all of these paths.
+	Tags          []string               `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`                    // optional, only select snapshots that have all of these tags.
+	DryRun        bool                   `protobuf:"varint,5,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"` // if set, the selected snapshots are returned but not adopted.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RestoreSnapshotRequest) Reset() {
-	*x = RestoreSnapshotRequest{}
-	mi := &file_v1_service_proto_msgTypes[6]
+func (x *AdoptSnapshotsRequest) Reset() {
+	*x = AdoptSnapshotsRequest{}
+	mi := &file_v1_service_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RestoreSnapshotRequest) String() string {
+func (x *AdoptSnapshotsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RestoreSnapshotRequest) ProtoMessage() {}
+func (*AdoptSnapshotsRequest) ProtoMessage() {}
 
-func (x *RestoreSnapshotRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_service_proto_msgTypes[6]
+func (x *AdoptSnapshotsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -487,70 +672,68 @@ func (x *RestoreSnapshotRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RestoreSnapshotRequest.ProtoReflect.Descriptor instead.
-func (*RestoreSnapshotRequest) Descriptor() ([]byte, []int) {
-	return file_v1_service_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use AdoptSnapshotsRequest.ProtoReflect.Descriptor instead.
+func (*AdoptSnapshotsRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *RestoreSnapshotRequest) GetPlanId() string {
+func (x *AdoptSnapshotsRequest) GetPlanId() string {
 	if x != nil {
 		return x.PlanId
 	}
 	return ""
 }
 
-func (x *RestoreSnapshotRequest) GetRepoId() string {
+func (x *AdoptSnapshotsRequest) GetHosts() []string {
 	if x != nil {
-		return x.RepoId
+		return x.Hosts
 	}
-	return ""
+	return nil
 }
 
-func (x *RestoreSnapshotRequest) GetSnapshotId() string {
+func (x *AdoptSnapshotsRequest) GetPaths() []string {
 	if x != nil {
-		return x.SnapshotId
+		return x.Paths
 	}
-	return ""
+	return nil
 }
 
-func (x *RestoreSnapshotRequest) GetPath() string {
+func (x *AdoptSnapshotsRequest) GetTags() []string {
 	if x != nil {
-		return x.Path
+		return x.Tags
 	}
-	return ""
+	return nil
 }
 
-func (x *RestoreSnapshotRequest) GetTarget() string {
+func (x *AdoptSnapshotsRequest) GetDryRun() bool {
 	if x != nil {
-		return x.Target
+		return x.DryRun
 	}
-	return ""
+	return false
 }
 
-type ListSnapshotFilesRequest struct {
+type AdoptSnapshotsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	RepoGuid      string                 `protobuf:"bytes,1,opt,name=repo_guid,json=repoGuid,proto3" json:"repo_guid,omitempty"`
-	SnapshotId    string                 `protobuf:"bytes,2,opt,name=snapshot_id,json=snapshotId,proto3" json:"snapshot_id,omitempty"`
-	Path          string                 `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+	Snapshots     []*ResticSnapshot      `protobuf:"bytes,1,rep,name=snapshots,proto3" json:"snapshots,omitempty"` // the snapshots that were adopted (or would be adopted for a dry run).
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListSnapshotFilesRequest) Reset() {
-	*x = ListSnapshotFilesRequest{}
-	mi := &file_v1_service_proto_msgTypes[7]
+func (x *AdoptSnapshotsResponse) Reset() {
+	*x = AdoptSnapshotsResponse{}
+	mi := &file_v1_service_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListSnapshotFilesRequest) String() string {
+func (x *AdoptSnapshotsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListSnapshotFilesRequest) ProtoMessage() {}
+func (*AdoptSnapshotsResponse) ProtoMessage() {}
 
-func (x *ListSnapshotFilesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_service_proto_msgTypes[7]
+func (x *AdoptSnapshotsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -561,55 +744,1085 @@ func (x *ListSnapshotFilesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListSnapshotFilesRequest.ProtoReflect.Descriptor instead.
-func (*ListSnapshotFilesRequest) Descriptor() ([]byte, []int) {
-	return file_v1_service_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use AdoptSnapshotsResponse.ProtoReflect.Descriptor instead.
+func (*AdoptSnapshotsResponse) Descriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *ListSnapshotFilesRequest) GetRepoGuid() string {
+func (x *AdoptSnapshotsResponse) GetSnapshots() []*ResticSnapshot {
 	if x != nil {
-		return x.RepoGuid
+		return x.Snapshots
 	}
-	return ""
+	return nil
 }
 
-func (x *ListSnapshotFilesRequest) GetSnapshotId() string {
+type HoldSnapshotRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RepoId        string                 `protobuf:"bytes,1,opt,name=repo_id,json=repoId,proto3" json:"repo_id,omitempty"`
+	SnapshotId    string                 `protobuf:"bytes,2,opt,name=snapshot_id,json=snapshotId,proto3" json:"snapshot_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`                                    // optional, a human readable reason for the hold.
+	ExpireUnixMs  int64                  `protobuf:"varint,4,opt,name=expire_unix_ms,json=expireUnixMs,proto3" json:"expire_unix_ms,omitempty"` // optional, the time at which the hold expires. If unset the snapshot is held indefinitely.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HoldSnapshotRequest) Reset() {
+	*x = HoldSnapshotRequest{}
+	mi := &file_v1_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HoldSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HoldSnapshotRequest) ProtoMessage() {}
+
+func (x *HoldSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_proto_msgTypes[10]
 	if x != nil {
-		return x.SnapshotId
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HoldSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*HoldSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *HoldSnapshotRequest) GetRepoId() string {
+	if x != nil {
+		return x.RepoId
+	}
+	return ""
+}
+
+func (x *HoldSnapshotRequest) GetSnapshotId() string {
+	if x != nil {
+		return x.SnapshotId
+	}
+	return ""
+}
+
+func (x *HoldSnapshotRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *HoldSnapshotRequest) GetExpireUnixMs() int64 {
+	if x != nil {
+		return x.ExpireUnixMs
+	}
+	return 0
+}
+
+type ReleaseSnapshotHoldRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RepoId        string                 `protobuf:"bytes,1,opt,name=repo_id,json=repoId,proto3" json:"repo_id,omitempty"`
+	SnapshotId    string                 `protobuf:"bytes,2,opt,name=snapshot_id,json=snapshotId,proto3" json:"snapshot_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseSnapshotHoldRequest) Reset() {
+	*x = ReleaseSnapshotHoldRequest{}
+	mi := &file_v1_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseSnapshotHoldRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseSnapshotHoldRequest) ProtoMessage() {}
+
+func (x *ReleaseSnapshotHoldRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseSnapshotHoldRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseSnapshotHoldRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ReleaseSnapshotHoldRequest) GetRepoId() string {
+	if x != nil {
+		return x.RepoId
+	}
+	return ""
+}
+
+func (x *ReleaseSnapshotHoldRequest) GetSnapshotId() string {
+	if x != nil {
+		return x.SnapshotId
+	}
+	return ""
+}
+
+type ListSnapshotsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RepoId        string                 `protobuf:"bytes,1,opt,name=repo_id,json=repoId,proto3" json:"repo_id,omitempty"`
+	PlanId        string                 `protobuf:"bytes,2,opt,name=plan_id,json=planId,proto3" json:"plan_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSnapshotsRequest) Reset() {
+	*x = ListSnapshotsRequest{}
+	mi := &file_v1_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSnapshotsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSnapshotsRequest) ProtoMessage() {}
+
+func (x *ListSnapshotsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSnapshotsRequest.ProtoReflect.Descriptor instead.
+func (*ListSnapshotsRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListSnapshotsRequest) GetRepoId() string {
+	if x != nil {
+		return x.RepoId
+	}
+	return ""
+}
+
+func (x *ListSnapshotsRequest) GetPlanId() string {
+	if x != nil {
+		return x.PlanId
+	}
+	return ""
+}
+
+type GetOperationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Selector      *OpSelector            `protobuf:"bytes,1,opt,name=selector,proto3" json:"selector,omitempty"`
+	LastN         int64                  `protobuf:"varint,2,opt,name=last_n,json=lastN,proto3" json:"last_n,omitempty"` // limit to the last n operations
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOperationsRequest) Reset() {
+	*x = GetOperationsRequest{}
+	mi := &file_v1_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOperationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOperationsRequest) ProtoMessage() {}
+
+func (x *GetOperationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOperationsRequest.ProtoReflect.Descriptor instead.
+func (*GetOperationsRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetOperationsRequest) GetSelector() *OpSelector {
+	if x != nil {
+		return x.Selector
+	}
+	return nil
+}
+
+func (x *GetOperationsRequest) GetLastN() int64 {
+	if x != nil {
+		return x.LastN
+	}
+	return 0
+}
+
+type RestoreSnapshotRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlanId        string                 `protobuf:"bytes,1,opt,name=plan_id,json=planId,proto3" json:"plan_id,omitempty"`
+	RepoId        string                 `protobuf:"bytes,5,opt,name=repo_id,json=repoId,proto3" json:"repo_id,omitempty"`
+	SnapshotId    string                 `protobuf:"bytes,2,opt,name=snapshot_id,json=snapshotId,proto3" json:"snapshot_id,omitempty"`
+	Path          string                 `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+	Target        string                 `protobuf:"bytes,4,opt,name=target,proto3" json:"target,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreSnapshotRequest) Reset() {
+	*x = RestoreSnapshotRequest{}
+	mi := &file_v1_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreSnapshotRequest) ProtoMessage() {}
+
+func (x *RestoreSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*RestoreSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RestoreSnapshotRequest) GetPlanId() string {
+	if x != nil {
+		return x.PlanId
+	}
+	return ""
+}
+
+func (x *RestoreSnapshotRequest) GetRepoId() string {
+	if x != nil {
+		return x.RepoId
+	}
+	return ""
+}
+
+func (x *RestoreSnapshotRequest) GetSnapshotId() string {
+	if x != nil {
+		return x.SnapshotId
+	}
+	return ""
+}
+
+func (x *RestoreSnapshotRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *RestoreSnapshotRequest) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+type ListSnapshotFilesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RepoGuid      string                 `protobuf:"bytes,1,opt,name=repo_guid,json=repoGuid,proto3" json:"repo_guid,omitempty"`
+	SnapshotId    string                 `protobuf:"bytes,2,opt,name=snapshot_id,json=snapshotId,proto3" json:"snapshot_id,omitempty"`
+	Path          string                 `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSnapshotFilesRequest) Reset() {
+	*x = ListSnapshotFilesRequest{}
+	mi := &file_v1_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSnapshotFilesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSnapshotFilesRequest) ProtoMessage() {}
+
+func (x *ListSnapshotFilesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSnapshotFilesRequest.ProtoReflect.Descriptor instead.
+func (*ListSnapshotFilesRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListSnapshotFilesRequest) GetRepoGuid() string {
+	if x != nil {
+		return x.RepoGuid
+	}
+	return ""
+}
+
+func (x *ListSnapshotFilesRequest) GetSnapshotId() string {
+	if x != nil {
+		return x.SnapshotId
+	}
+	return ""
+}
+
+func (x *ListSnapshotFilesRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type ListSnapshotFilesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Entries       []*LsEntry             `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSnapshotFilesResponse) Reset() {
+	*x = ListSnapshotFilesResponse{}
+	mi := &file_v1_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSnapshotFilesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSnapshotFilesResponse) ProtoMessage() {}
+
+func (x *ListSnapshotFilesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSnapshotFilesResponse.ProtoReflect.Descriptor instead.
+func (*ListSnapshotFilesResponse) Descriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListSnapshotFilesResponse) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ListSnapshotFilesResponse) GetEntries() []*LsEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type LogDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ref           string                 `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogDataRequest) Reset() {
+	*x = LogDataRequest{}
+	mi := &file_v1_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogDataRequest) ProtoMessage() {}
+
+func (x *LogDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogDataRequest.ProtoReflect.Descriptor instead.
+func (*LogDataRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *LogDataRequest) GetRef() string {
+	if x != nil {
+		return x.Ref
+	}
+	return ""
+}
+
+type GetDownloadURLRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OpId          int64                  `protobuf:"varint,1,opt,name=op_id,json=opId,proto3" json:"op_id,omitempty"`
+	FilePath      string                 `protobuf:"bytes,2,opt,name=file_path,json=filePath,proto3" json:"file_path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDownloadURLRequest) Reset() {
+	*x = GetDownloadURLRequest{}
+	mi := &file_v1_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDownloadURLRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDownloadURLRequest) ProtoMessage() {}
+
+func (x *GetDownloadURLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDownloadURLRequest.ProtoReflect.Descriptor instead.
+func (*GetDownloadURLRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetDownloadURLRequest) GetOpId() int64 {
+	if x != nil {
+		return x.OpId
+	}
+	return 0
+}
+
+func (x *GetDownloadURLRequest) GetFilePath() string {
+	if x != nil {
+		return x.FilePath
+	}
+	return ""
+}
+
+type LsEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Path          string                 `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+	Uid           int64                  `protobuf:"varint,4,opt,name=uid,proto3" json:"uid,omitempty"`
+	Gid           int64                  `protobuf:"varint,5,opt,name=gid,proto3" json:"gid,omitempty"`
+	Size          int64                  `protobuf:"varint,6,opt,name=size,proto3" json:"size,omitempty"`
+	Mode          int64                  `protobuf:"varint,7,opt,name=mode,proto3" json:"mode,omitempty"`
+	Mtime         string                 `protobuf:"bytes,8,opt,name=mtime,proto3" json:"mtime,omitempty"`
+	Atime         string                 `protobuf:"bytes,9,opt,name=atime,proto3" json:"atime,omitempty"`
+	Ctime         string                 `protobuf:"bytes,10,opt,name=ctime,proto3" json:"ctime,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LsEntry) Reset() {
+	*x = LsEntry{}
+	mi := &file_v1_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LsEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LsEntry) ProtoMessage() {}
+
+func (x *LsEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LsEntry.ProtoReflect.Descriptor instead.
+func (*LsEntry) Descriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *LsEntry) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *LsEntry) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *LsEntry) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *LsEntry) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *LsEntry) GetGid() int64 {
+	if x != nil {
+		return x.Gid
+	}
+	return 0
+}
+
+func (x *LsEntry) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *LsEntry) GetMode() int64 {
+	if x != nil {
+		return x.Mode
+	}
+	return 0
+}
+
+func (x *LsEntry) GetMtime() string {
+	if x != nil {
+		return x.Mtime
+	}
+	return ""
+}
+
+func (x *LsEntry) GetAtime() string {
+	if x != nil {
+		return x.Atime
+	}
+	return ""
+}
+
+func (x *LsEntry) GetCtime() string {
+	if x != nil {
+		return x.Ctime
+	}
+	return ""
+}
+
+type RunCommandRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RepoId        string                 `protobuf:"bytes,1,opt,name=repo_id,json=repoId,proto3" json:"repo_id,omitempty"`
+	Command       string                 `protobuf:"bytes,2,opt,name=command,proto3" json:"command,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunCommandRequest) Reset() {
+	*x = RunCommandRequest{}
+	mi := &file_v1_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunCommandRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunCommandRequest) ProtoMessage() {}
+
+func (x *RunCommandRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunCommandRequest.ProtoReflect.Descriptor instead.
+func (*RunCommandRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RunCommandRequest) GetRepoId() string {
+	if x != nil {
+		return x.RepoId
+	}
+	return ""
+}
+
+func (x *RunCommandRequest) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+type TestHookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hook          *Hook                  `protobuf:"bytes,1,opt,name=hook,proto3" json:"hook,omitempty"`
+	Condition     Hook_Condition         `protobuf:"varint,2,opt,name=condition,proto3,enum=v1.Hook_Condition" json:"condition,omitempty"` // the event the hook is run for.
+	RepoId        string                 `protobuf:"bytes,3,opt,name=repo_id,json=repoId,proto3" json:"repo_id,omitempty"`                 // the repo the hook runs for, its operations provide the example variables.
+	PlanId        string                 `protobuf:"bytes,4,opt,name=plan_id,json=planId,proto3" json:"plan_id,omitempty"`                 // optional, the plan the hook runs for, its operations are preferred for the example variables.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TestHookRequest) Reset() {
+	*x = TestHookRequest{}
+	mi := &file_v1_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestHookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestHookRequest) ProtoMessage() {}
+
+func (x *TestHookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestHookRequest.ProtoReflect.Descriptor instead.
+func (*TestHookRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *TestHookRequest) GetHook() *Hook {
+	if x != nil {
+		return x.Hook
+	}
+	return nil
+}
+
+func (x *TestHookRequest) GetCondition() Hook_Condition {
+	if x != nil {
+		return x.Condition
+	}
+	return Hook_CONDITION_UNKNOWN
+}
+
+func (x *TestHookRequest) GetRepoId() string {
+	if x != nil {
+		return x.RepoId
+	}
+	return ""
+}
+
+func (x *TestHookRequest) GetPlanId() string {
+	if x != nil {
+		return x.PlanId
+	}
+	return ""
+}
+
+type TestHookResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Payload            string                 `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`                                                    // the hook's rendered template, or the rendered script for command hooks.
+	Output             string                 `protobuf:"bytes,2,opt,name=output,proto3" json:"output,omitempty"`                                                      // the log written by the hook, includes responses from notification services.
+	Error              string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`                                                        // the error returned by the hook e.g. a template or delivery error, empty on success.
+	ExampleOperationId int64                  `protobuf:"varint,4,opt,name=example_operation_id,json=exampleOperationId,proto3" json:"example_operation_id,omitempty"` // the operation the example variables were built from, 0 if none was found.
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *TestHookResponse) Reset() {
+	*x = TestHookResponse{}
+	mi := &file_v1_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestHookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestHookResponse) ProtoMessage() {}
+
+func (x *TestHookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestHookResponse.ProtoReflect.Descriptor instead.
+func (*TestHookResponse) Descriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *TestHookResponse) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *TestHookResponse) GetOutput() string {
+	if x != nil {
+		return x.Output
+	}
+	return ""
+}
+
+func (x *TestHookResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *TestHookResponse) GetExampleOperationId() int64 {
+	if x != nil {
+		return x.ExampleOperationId
+	}
+	return 0
+}
+
+type SearchOperationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`                                 // words that must all match, or an SQLite FTS5 query if raw_query is set.
+	RawQuery      bool                   `protobuf:"varint,2,opt,name=raw_query,json=rawQuery,proto3" json:"raw_query,omitempty"`          // query uses the FTS5 query syntax e.g. "permission NEAR denied" or "lock* OR timeout".
+	Selector      *OpSelector            `protobuf:"bytes,3,opt,name=selector,proto3" json:"selector,omitempty"`                           // optional, restricts results to the selected operations.
+	IncludeLogs   bool                   `protobuf:"varint,4,opt,name=include_logs,json=includeLogs,proto3" json:"include_logs,omitempty"` // also match operation logs, only available if the server indexes logs.
+	Limit         int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`                                // maximum number of results, defaults to 100.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchOperationsRequest) Reset() {
+	*x = SearchOperationsRequest{}
+	mi := &file_v1_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchOperationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchOperationsRequest) ProtoMessage() {}
+
+func (x *SearchOperationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchOperationsRequest.ProtoReflect.Descriptor instead.
+func (*SearchOperationsRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SearchOperationsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchOperationsRequest) GetRawQuery() bool {
+	if x != nil {
+		return x.RawQuery
+	}
+	return false
+}
+
+func (x *SearchOperationsRequest) GetSelector() *OpSelector {
+	if x != nil {
+		return x.Selector
+	}
+	return nil
+}
+
+func (x *SearchOperationsRequest) GetIncludeLogs() bool {
+	if x != nil {
+		return x.IncludeLogs
+	}
+	return false
+}
+
+func (x *SearchOperationsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type SearchOperationsResponse struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Results       []*SearchOperationsResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchOperationsResponse) Reset() {
+	*x = SearchOperationsResponse{}
+	mi := &file_v1_service_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchOperationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchOperationsResponse) ProtoMessage() {}
+
+func (x *SearchOperationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchOperationsResponse.ProtoReflect.Descriptor instead.
+func (*SearchOperationsResponse) Descriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SearchOperationsResponse) GetResults() []*SearchOperationsResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type SearchOperationsResult struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Operation     *Operation              `protobuf:"bytes,1,opt,name=operation,proto3" json:"operation,omitempty"`
+	Field         string                  `protobuf:"bytes,2,opt,name=field,proto3" json:"field,omitempty"`     // the field the snippet is taken from, one of "display_message", "errors" or "logs".
+	Snippet       []*SearchSnippetSegment `protobuf:"bytes,3,rep,name=snippet,proto3" json:"snippet,omitempty"` // excerpt of the field around the match.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchOperationsResult) Reset() {
+	*x = SearchOperationsResult{}
+	mi := &file_v1_service_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchOperationsResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchOperationsResult) ProtoMessage() {}
+
+func (x *SearchOperationsResult) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchOperationsResult.ProtoReflect.Descriptor instead.
+func (*SearchOperationsResult) Descriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *SearchOperationsResult) GetOperation() *Operation {
+	if x != nil {
+		return x.Operation
+	}
+	return nil
+}
+
+func (x *SearchOperationsResult) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *SearchOperationsResult) GetSnippet() []*SearchSnippetSegment {
+	if x != nil {
+		return x.Snippet
+	}
+	return nil
+}
+
+type SearchSnippetSegment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	Highlight     bool                   `protobuf:"varint,2,opt,name=highlight,proto3" json:"highlight,omitempty"` // the text matches the query.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchSnippetSegment) Reset() {
+	*x = SearchSnippetSegment{}
+	mi := &file_v1_service_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchSnippetSegment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchSnippetSegment) ProtoMessage() {}
+
+func (x *SearchSnippetSegment) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchSnippetSegment.ProtoReflect.Descriptor instead.
+func (*SearchSnippetSegment) Descriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *SearchSnippetSegment) GetText() string {
+	if x != nil {
+		return x.Text
 	}
 	return ""
 }
 
-func (x *ListSnapshotFilesRequest) GetPath() string {
+func (x *SearchSnippetSegment) GetHighlight() bool {
 	if x != nil {
-		return x.Path
+		return x.Highlight
 	}
-	return ""
+	return false
 }
 
-type ListSnapshotFilesResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
-	Entries       []*LsEntry             `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+type ExportOperationsRequest struct {
+	state         protoimpl.MessageState         `protogen:"open.v1"`
+	Selector      *OpSelector                    `protobuf:"bytes,1,opt,name=selector,proto3" json:"selector,omitempty"` // the operations to export, all operations if unset.
+	Format        ExportOperationsRequest_Format `protobuf:"varint,2,opt,name=format,proto3,enum=v1.ExportOperationsRequest_Format" json:"format,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListSnapshotFilesResponse) Reset() {
-	*x = ListSnapshotFilesResponse{}
-	mi := &file_v1_service_proto_msgTypes[8]
+func (x *ExportOperationsRequest) Reset() {
+	*x = ExportOperationsRequest{}
+	mi := &file_v1_service_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListSnapshotFilesResponse) String() string {
+func (x *ExportOperationsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListSnapshotFilesResponse) ProtoMessage() {}
+func (*ExportOperationsRequest) ProtoMessage() {}
 
-func (x *ListSnapshotFilesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_service_proto_msgTypes[8]
+func (x *ExportOperationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -620,47 +1833,47 @@ func (x *ListSnapshotFilesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListSnapshotFilesResponse.ProtoReflect.Descriptor instead.
-func (*ListSnapshotFilesResponse) Descriptor() ([]byte, []int) {
-	return file_v1_service_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use ExportOperationsRequest.ProtoReflect.Descriptor instead.
+func (*ExportOperationsRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *ListSnapshotFilesResponse) GetPath() string {
+func (x *ExportOperationsRequest) GetSelector() *OpSelector {
 	if x != nil {
-		return x.Path
+		return x.Selector
 	}
-	return ""
+	return nil
 }
 
-func (x *ListSnapshotFilesResponse) GetEntries() []*LsEntry {
+func (x *ExportOperationsRequest) GetFormat() ExportOperationsRequest_Format {
 	if x != nil {
-		return x.Entries
+		return x.Format
 	}
-	return nil
+	return ExportOperationsRequest_FORMAT_JSONL
 }
 
-type LogDataRequest struct {
+type ImportOperationsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Ref           string                 `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref,omitempty"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"` // operations in the JSONL format written by ExportOperations.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LogDataRequest) Reset() {
-	*x = LogDataRequest{}
-	mi := &file_v1_service_proto_msgTypes[9]
+func (x *ImportOperationsRequest) Reset() {
+	*x = ImportOperationsRequest{}
+	mi := &file_v1_service_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LogDataRequest) String() string {
+func (x *ImportOperationsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LogDataRequest) ProtoMessage() {}
+func (*ImportOperationsRequest) ProtoMessage() {}
 
-func (x *LogDataRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_service_proto_msgTypes[9]
+func (x *ImportOperationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -671,41 +1884,41 @@ func (x *LogDataRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LogDataRequest.ProtoReflect.Descriptor instead.
-func (*LogDataRequest) Descriptor() ([]byte, []int) {
-	return file_v1_service_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use ImportOperationsRequest.ProtoReflect.Descriptor instead.
+func (*ImportOperationsRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{28}
 }
 
-func (x *LogDataRequest) GetRef() string {
+func (x *ImportOperationsRequest) GetData() []byte {
 	if x != nil {
-		return x.Ref
+		return x.Data
 	}
-	return ""
+	return nil
 }
 
-type GetDownloadURLRequest struct {
+type ImportOperationsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	OpId          int64                  `protobuf:"varint,1,opt,name=op_id,json=opId,proto3" json:"op_id,omitempty"`
-	FilePath      string                 `protobuf:"bytes,2,opt,name=file_path,json=filePath,proto3" json:"file_path,omitempty"`
+	Imported      int64                  `protobuf:"varint,1,opt,name=imported,proto3" json:"imported,omitempty"` // number of operations added to the log.
+	Skipped       int64                  `protobuf:"varint,2,opt,name=skipped,proto3" json:"skipped,omitempty"`   // number of operations already in the log or incomplete when exported.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetDownloadURLRequest) Reset() {
-	*x = GetDownloadURLRequest{}
-	mi := &file_v1_service_proto_msgTypes[10]
+func (x *ImportOperationsResponse) Reset() {
+	*x = ImportOperationsResponse{}
+	mi := &file_v1_service_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetDownloadURLRequest) String() string {
+func (x *ImportOperationsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetDownloadURLRequest) ProtoMessage() {}
+func (*ImportOperationsResponse) ProtoMessage() {}
 
-func (x *GetDownloadURLRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_service_proto_msgTypes[10]
+func (x *ImportOperationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -716,56 +1929,52 @@ func (x *GetDownloadURLRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetDownloadURLRequest.ProtoReflect.Descriptor instead.
-func (*GetDownloadURLRequest) Descriptor() ([]byte, []int) {
-	return file_v1_service_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use ImportOperationsResponse.ProtoReflect.Descriptor instead.
+func (*ImportOperationsResponse) Descriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{29}
 }
 
-func (x *GetDownloadURLRequest) GetOpId() int64 {
+func (x *ImportOperationsResponse) GetImported() int64 {
 	if x != nil {
-		return x.OpId
+		return x.Imported
 	}
 	return 0
 }
 
-func (x *GetDownloadURLRequest) GetFilePath() string {
+func (x *ImportOperationsResponse) GetSkipped() int64 {
 	if x != nil {
-		return x.FilePath
+		return x.Skipped
 	}
-	return ""
+	return 0
 }
 
-type LsEntry struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
-	Path          string                 `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
-	Uid           int64                  `protobuf:"varint,4,opt,name=uid,proto3" json:"uid,omitempty"`
-	Gid           int64                  `protobuf:"varint,5,opt,name=gid,proto3" json:"gid,omitempty"`
-	Size          int64                  `protobuf:"varint,6,opt,name=size,proto3" json:"size,omitempty"`
-	Mode          int64                  `protobuf:"varint,7,opt,name=mode,proto3" json:"mode,omitempty"`
-	Mtime         string                 `protobuf:"bytes,8,opt,name=mtime,proto3" json:"mtime,omitempty"`
-	Atime         string                 `protobuf:"bytes,9,opt,name=atime,proto3" json:"atime,omitempty"`
-	Ctime         string                 `protobuf:"bytes,10,opt,name=ctime,proto3" json:"ctime,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type LogStoreStats struct {
+	state           protoimpl.MessageState              `protogen:"open.v1"`
+	LogCount        int64                               `protobuf:"varint,1,opt,name=log_count,json=logCount,proto3" json:"log_count,omitempty"`                       // number of stored logs.
+	TotalBytes      int64                               `protobuf:"varint,2,opt,name=total_bytes,json=totalBytes,proto3" json:"total_bytes,omitempty"`                 // compressed size of the stored logs, the size the quota applies to.
+	InprogressCount int64                               `protobuf:"varint,3,opt,name=inprogress_count,json=inprogressCount,proto3" json:"inprogress_count,omitempty"`  // number of logs still being written.
+	InprogressBytes int64                               `protobuf:"varint,4,opt,name=inprogress_bytes,json=inprogressBytes,proto3" json:"inprogress_bytes,omitempty"`  // uncompressed size of the logs still being written.
+	QuotaBytes      int64                               `protobuf:"varint,5,opt,name=quota_bytes,json=quotaBytes,proto3" json:"quota_bytes,omitempty"`                 // limit on total_bytes, 0 if unlimited.
+	ByOperationType []*LogStoreStats_OperationTypeUsage `protobuf:"bytes,6,rep,name=by_operation_type,json=byOperationType,proto3" json:"by_operation_type,omitempty"` // usage of the stored logs, largest first.
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *LsEntry) Reset() {
-	*x = LsEntry{}
-	mi := &file_v1_service_proto_msgTypes[11]
+func (x *LogStoreStats) Reset() {
+	*x = LogStoreStats{}
+	mi := &file_v1_service_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LsEntry) String() string {
+func (x *LogStoreStats) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LsEntry) ProtoMessage() {}
+func (*LogStoreStats) ProtoMessage() {}
 
-func (x *LsEntry) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_service_proto_msgTypes[11]
+func (x *LogStoreStats) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -776,104 +1985,129 @@ func (x *LsEntry) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LsEntry.ProtoReflect.Descriptor instead.
-func (*LsEntry) Descriptor() ([]byte, []int) {
-	return file_v1_service_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use LogStoreStats.ProtoReflect.Descriptor instead.
+func (*LogStoreStats) Descriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *LsEntry) GetName() string {
+func (x *LogStoreStats) GetLogCount() int64 {
 	if x != nil {
-		return x.Name
+		return x.LogCount
 	}
-	return ""
+	return 0
 }
 
-func (x *LsEntry) GetType() string {
+func (x *LogStoreStats) GetTotalBytes() int64 {
 	if x != nil {
-		return x.Type
+		return x.TotalBytes
 	}
-	return ""
+	return 0
 }
 
-func (x *LsEntry) GetPath() string {
+func (x *LogStoreStats) GetInprogressCount() int64 {
 	if x != nil {
-		return x.Path
+		return x.InprogressCount
 	}
-	return ""
+	return 0
 }
 
-func (x *LsEntry) GetUid() int64 {
+func (x *LogStoreStats) GetInprogressBytes() int64 {
 	if x != nil {
-		return x.Uid
+		return x.InprogressBytes
 	}
 	return 0
 }
 
-func (x *LsEntry) GetGid() int64 {
+func (x *LogStoreStats) GetQuotaBytes() int64 {
 	if x != nil {
-		return x.Gid
+		return x.QuotaBytes
 	}
 	return 0
 }
 
-func (x *LsEntry) GetSize() int64 {
+func (x *LogStoreStats) GetByOperationType() []*LogStoreStats_OperationTypeUsage {
 	if x != nil {
-		return x.Size
+		return x.ByOperationType
 	}
-	return 0
+	return nil
 }
 
-func (x *LsEntry) GetMode() int64 {
-	if x != nil {
-		return x.Mode
-	}
-	return 0
+type GetBackupTimelineRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OpId          int64                  `protobuf:"varint,1,opt,name=op_id,json=opId,proto3" json:"op_id,omitempty"`                // ID of the backup operation.
+	MaxPoints     int32                  `protobuf:"varint,2,opt,name=max_points,json=maxPoints,proto3" json:"max_points,omitempty"` // downsample the timeline to at most this many points, all recorded points are returned if 0.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LsEntry) GetMtime() string {
+func (x *GetBackupTimelineRequest) Reset() {
+	*x = GetBackupTimelineRequest{}
+	mi := &file_v1_service_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBackupTimelineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBackupTimelineRequest) ProtoMessage() {}
+
+func (x *GetBackupTimelineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_proto_msgTypes[31]
 	if x != nil {
-		return x.Mtime
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *LsEntry) GetAtime() string {
+// Deprecated: Use GetBackupTimelineRequest.ProtoReflect.Descriptor instead.
+func (*GetBackupTimelineRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetBackupTimelineRequest) GetOpId() int64 {
 	if x != nil {
-		return x.Atime
+		return x.OpId
 	}
-	return ""
+	return 0
 }
 
-func (x *LsEntry) GetCtime() string {
+func (x *GetBackupTimelineRequest) GetMaxPoints() int32 {
 	if x != nil {
-		return x.Ctime
+		return x.MaxPoints
 	}
-	return ""
+	return 0
 }
 
-type RunCommandRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	RepoId        string                 `protobuf:"bytes,1,opt,name=repo_id,json=repoId,proto3" json:"repo_id,omitempty"`
-	Command       string                 `protobuf:"bytes,2,opt,name=command,proto3" json:"command,omitempty"`
+type GetBackupTimelineResponse struct {
+	state         protoimpl.MessageState                `protogen:"open.v1"`
+	Points        []*BackupTimelinePoint                `protobuf:"bytes,1,rep,name=points,proto3" json:"points,omitempty"`
+	SlowestFiles  []*GetBackupTimelineResponse_FileTime `protobuf:"bytes,2,rep,name=slowest_files,json=slowestFiles,proto3" json:"slowest_files,omitempty"` // files the backup spent the most time reading, longest first.
+	Complete      bool                                  `protobuf:"varint,3,opt,name=complete,proto3" json:"complete,omitempty"`                            // false while the backup is running, later requests return more points.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RunCommandRequest) Reset() {
-	*x = RunCommandRequest{}
-	mi := &file_v1_service_proto_msgTypes[12]
+func (x *GetBackupTimelineResponse) Reset() {
+	*x = GetBackupTimelineResponse{}
+	mi := &file_v1_service_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RunCommandRequest) String() string {
+func (x *GetBackupTimelineResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RunCommandRequest) ProtoMessage() {}
+func (*GetBackupTimelineResponse) ProtoMessage() {}
 
-func (x *RunCommandRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_service_proto_msgTypes[12]
+func (x *GetBackupTimelineResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -884,23 +2118,30 @@ func (x *RunCommandRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RunCommandRequest.ProtoReflect.Descriptor instead.
-func (*RunCommandRequest) Descriptor() ([]byte, []int) {
-	return file_v1_service_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use GetBackupTimelineResponse.ProtoReflect.Descriptor instead.
+func (*GetBackupTimelineResponse) Descriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{32}
 }
 
-func (x *RunCommandRequest) GetRepoId() string {
+func (x *GetBackupTimelineResponse) GetPoints() []*BackupTimelinePoint {
 	if x != nil {
-		return x.RepoId
+		return x.Points
 	}
-	return ""
+	return nil
 }
 
-func (x *RunCommandRequest) GetCommand() string {
+func (x *GetBackupTimelineResponse) GetSlowestFiles() []*GetBackupTimelineResponse_FileTime {
 	if x != nil {
-		return x.Command
+		return x.SlowestFiles
 	}
-	return ""
+	return nil
+}
+
+func (x *GetBackupTimelineResponse) GetComplete() bool {
+	if x != nil {
+		return x.Complete
+	}
+	return false
 }
 
 type SummaryDashboardResponse struct {
@@ -915,7 +2156,7 @@ type SummaryDashboardResponse struct {
 
 func (x *SummaryDashboardResponse) Reset() {
 	*x = SummaryDashboardResponse{}
-	mi := &file_v1_service_proto_msgTypes[13]
+	mi := &file_v1_service_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -927,7 +2168,7 @@ func (x *SummaryDashboardResponse) String() string {
 func (*SummaryDashboardResponse) ProtoMessage() {}
 
 func (x *SummaryDashboardResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_service_proto_msgTypes[13]
+	mi := &file_v1_service_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -940,7 +2181,7 @@ func (x *SummaryDashboardResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SummaryDashboardResponse.ProtoReflect.Descriptor instead.
 func (*SummaryDashboardResponse) Descriptor() ([]byte, []int) {
-	return file_v1_service_proto_rawDescGZIP(), []int{13}
+	return file_v1_service_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *SummaryDashboardResponse) GetRepoSummaries() []*SummaryDashboardResponse_Summary {
@@ -971,6 +2212,118 @@ func (x *SummaryDashboardResponse) GetDataPath() string {
 	return ""
 }
 
+type LogStoreStats_OperationTypeUsage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OpType        OperationType          `protobuf:"varint,1,opt,name=op_type,json=opType,proto3,enum=v1.OperationType" json:"op_type,omitempty"` // OPERATION_TYPE_UNKNOWN for logs whose operation is no longer in the log.
+	LogCount      int64                  `protobuf:"varint,2,opt,name=log_count,json=logCount,proto3" json:"log_count,omitempty"`
+	TotalBytes    int64                  `protobuf:"varint,3,opt,name=total_bytes,json=totalBytes,proto3" json:"total_bytes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogStoreStats_OperationTypeUsage) Reset() {
+	*x = LogStoreStats_OperationTypeUsage{}
+	mi := &file_v1_service_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogStoreStats_OperationTypeUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogStoreStats_OperationTypeUsage) ProtoMessage() {}
+
+func (x *LogStoreStats_OperationTypeUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogStoreStats_OperationTypeUsage.ProtoReflect.Descriptor instead.
+func (*LogStoreStats_OperationTypeUsage) Descriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{30, 0}
+}
+
+func (x *LogStoreStats_OperationTypeUsage) GetOpType() OperationType {
+	if x != nil {
+		return x.OpType
+	}
+	return OperationType_OPERATION_TYPE_UNKNOWN
+}
+
+func (x *LogStoreStats_OperationTypeUsage) GetLogCount() int64 {
+	if x != nil {
+		return x.LogCount
+	}
+	return 0
+}
+
+func (x *LogStoreStats_OperationTypeUsage) GetTotalBytes() int64 {
+	if x != nil {
+		return x.TotalBytes
+	}
+	return 0
+}
+
+type GetBackupTimelineResponse_FileTime struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	DurationMs    int64                  `protobuf:"varint,2,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"` // approximate time the file was being read, at the resolution of the timeline.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBackupTimelineResponse_FileTime) Reset() {
+	*x = GetBackupTimelineResponse_FileTime{}
+	mi := &file_v1_service_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBackupTimelineResponse_FileTime) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBackupTimelineResponse_FileTime) ProtoMessage() {}
+
+func (x *GetBackupTimelineResponse_FileTime) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBackupTimelineResponse_FileTime.ProtoReflect.Descriptor instead.
+func (*GetBackupTimelineResponse_FileTime) Descriptor() ([]byte, []int) {
+	return file_v1_service_proto_rawDescGZIP(), []int{32, 0}
+}
+
+func (x *GetBackupTimelineResponse_FileTime) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *GetBackupTimelineResponse_FileTime) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
 type SummaryDashboardResponse_Summary struct {
 	state                     protoimpl.MessageState `protogen:"open.v1"`
 	Id                        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -991,7 +2344,7 @@ type SummaryDashboardResponse_Summary struct {
 
 func (x *SummaryDashboardResponse_Summary) Reset() {
 	*x = SummaryDashboardResponse_Summary{}
-	mi := &file_v1_service_proto_msgTypes[14]
+	mi := &file_v1_service_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1003,7 +2356,7 @@ func (x *SummaryDashboardResponse_Summary) String() string {
 func (*SummaryDashboardResponse_Summary) ProtoMessage() {}
 
 func (x *SummaryDashboardResponse_Summary) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_service_proto_msgTypes[14]
+	mi := &file_v1_service_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1016,7 +2369,7 @@ func (x *SummaryDashboardResponse_Summary) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SummaryDashboardResponse_Summary.ProtoReflect.Descriptor instead.
 func (*SummaryDashboardResponse_Summary) Descriptor() ([]byte, []int) {
-	return file_v1_service_proto_rawDescGZIP(), []int{13, 0}
+	return file_v1_service_proto_rawDescGZIP(), []int{33, 0}
 }
 
 func (x *SummaryDashboardResponse_Summary) GetId() string {
@@ -1109,7 +2462,7 @@ type SummaryDashboardResponse_BackupChart struct {
 
 func (x *SummaryDashboardResponse_BackupChart) Reset() {
 	*x = SummaryDashboardResponse_BackupChart{}
-	mi := &file_v1_service_proto_msgTypes[15]
+	mi := &file_v1_service_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1121,7 +2474,7 @@ func (x *SummaryDashboardResponse_BackupChart) String() string {
 func (*SummaryDashboardResponse_BackupChart) ProtoMessage() {}
 
 func (x *SummaryDashboardResponse_BackupChart) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_service_proto_msgTypes[15]
+	mi := &file_v1_service_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1134,7 +2487,7 @@ func (x *SummaryDashboardResponse_BackupChart) ProtoReflect() protoreflect.Messa
 
 // Deprecated: Use SummaryDashboardResponse_BackupChart.ProtoReflect.Descriptor instead.
 func (*SummaryDashboardResponse_BackupChart) Descriptor() ([]byte, []int) {
-	return file_v1_service_proto_rawDescGZIP(), []int{13, 1}
+	return file_v1_service_proto_rawDescGZIP(), []int{33, 1}
 }
 
 func (x *SummaryDashboardResponse_BackupChart) GetFlowId() []int64 {
@@ -1176,7 +2529,7 @@ var File_v1_service_proto protoreflect.FileDescriptor
 
 const file_v1_service_proto_rawDesc = "" +
 	"\n" +
-	"\x10v1/service.proto\x12\x02v1\x1a\x0fv1/config.proto\x1a\x0fv1/restic.proto\x1a\x13v1/operations.proto\x1a\x11types/value.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1cgoogle/api/annotations.proto\"\x97\x03\n" +
+	"\x10v1/service.proto\x12\x02v1\x1a\x0fv1/config.proto\x1a\x0fv1/restic.proto\x1a\x13v1/operations.proto\x1a\x11types/value.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1cgoogle/api/annotations.proto\"\xff\x04\n" +
 	"\n" +
 	"OpSelector\x12\x10\n" +
 	"\x03ids\x18\x01 \x03(\x03R\x03ids\x12$\n" +
@@ -1188,7 +2541,12 @@ const file_v1_service_proto_rawDesc = "" +
 	"\vsnapshot_id\x18\x04 \x01(\tH\x04R\n" +
 	"snapshotId\x88\x01\x01\x12\x1c\n" +
 	"\aflow_id\x18\x05 \x01(\x03H\x05R\x06flowId\x88\x01\x01\x12 \n" +
-	"\tmodno_gte\x18\t \x01(\x03H\x06R\bmodnoGte\x88\x01\x01B\x0e\n" +
+	"\tmodno_gte\x18\t \x01(\x03H\x06R\bmodnoGte\x88\x01\x01\x12.\n" +
+	"\x11start_time_gte_ms\x18\n" +
+	" \x01(\x03H\aR\x0estartTimeGteMs\x88\x01\x01\x12,\n" +
+	"\x10start_time_lt_ms\x18\v \x01(\x03H\bR\rstartTimeLtMs\x88\x01\x01\x12/\n" +
+	"\bstatuses\x18\f \x03(\x0e2\x13.v1.OperationStatusR\bstatuses\x12,\n" +
+	"\bop_types\x18\r \x03(\x0e2\x11.v1.OperationTypeR\aopTypesB\x0e\n" +
 	"\f_instance_idB\x1a\n" +
 	"\x18_original_instance_keyidB\f\n" +
 	"\n" +
@@ -1199,10 +2557,16 @@ const file_v1_service_proto_rawDesc = "" +
 	"\n" +
 	"\b_flow_idB\f\n" +
 	"\n" +
-	"_modno_gte\"\xce\x01\n" +
+	"_modno_gteB\x14\n" +
+	"\x12_start_time_gte_msB\x13\n" +
+	"\x11_start_time_lt_ms\"c\n" +
+	"\x10SetConfigRequest\x12\"\n" +
+	"\x06config\x18\x01 \x01(\v2\n" +
+	".v1.ConfigR\x06config\x12+\n" +
+	"\x11confirm_retention\x18\x02 \x01(\bR\x10confirmRetention\"\xe0\x01\n" +
 	"\x11DoRepoTaskRequest\x12\x17\n" +
 	"\arepo_id\x18\x01 \x01(\tR\x06repoId\x12.\n" +
-	"\x04task\x18\x02 \x01(\x0e2\x1a.v1.DoRepoTaskRequest.TaskR\x04task\"p\n" +
+	"\x04task\x18\x02 \x01(\x0e2\x1a.v1.DoRepoTaskRequest.TaskR\x04task\"\x81\x01\n" +
 	"\x04Task\x12\r\n" +
 	"\tTASK_NONE\x10\x00\x12\x18\n" +
 	"\x14TASK_INDEX_SNAPSHOTS\x10\x01\x12\x0e\n" +
@@ -1212,7 +2576,8 @@ const file_v1_service_proto_rawDesc = "" +
 	"TASK_CHECK\x10\x03\x12\x0e\n" +
 	"\n" +
 	"TASK_STATS\x10\x04\x12\x0f\n" +
-	"\vTASK_UNLOCK\x10\x05\"b\n" +
+	"\vTASK_UNLOCK\x10\x05\x12\x0f\n" +
+	"\vTASK_FORGET\x10\x06\"b\n" +
 	"\x13ClearHistoryRequest\x12*\n" +
 	"\bselector\x18\x01 \x01(\v2\x0e.v1.OpSelectorR\bselector\x12\x1f\n" +
 	"\vonly_failed\x18\x02 \x01(\bR\n" +
@@ -1221,6 +2586,33 @@ const file_v1_service_proto_rawDesc = "" +
 	"\arepo_id\x18\x01 \x01(\tR\x06repoId\x12\x17\n" +
 	"\aplan_id\x18\x02 \x01(\tR\x06planId\x12\x1f\n" +
 	"\vsnapshot_id\x18\x03 \x01(\tR\n" +
+	"snapshotId\"_\n" +
+	"\x17PreviewRetentionRequest\x12\x17\n" +
+	"\aplan_id\x18\x01 \x01(\tR\x06planId\x12+\n" +
+	"\x06policy\x18\x02 \x01(\v2\x13.v1.RetentionPolicyR\x06policy\"O\n" +
+	"\x18PreviewRetentionResponse\x123\n" +
+	"\tdecisions\x18\x01 \x03(\v2\x15.v1.RetentionDecisionR\tdecisions\"q\n" +
+	"\x11RetentionDecision\x12.\n" +
+	"\bsnapshot\x18\x01 \x01(\v2\x12.v1.ResticSnapshotR\bsnapshot\x12\x12\n" +
+	"\x04keep\x18\x02 \x01(\bR\x04keep\x12\x18\n" +
+	"\areasons\x18\x03 \x03(\tR\areasons\"\x89\x01\n" +
+	"\x15AdoptSnapshotsRequest\x12\x17\n" +
+	"\aplan_id\x18\x01 \x01(\tR\x06planId\x12\x14\n" +
+	"\x05hosts\x18\x02 \x03(\tR\x05hosts\x12\x14\n" +
+	"\x05paths\x18\x03 \x03(\tR\x05paths\x12\x12\n" +
+	"\x04tags\x18\x04 \x03(\tR\x04tags\x12\x17\n" +
+	"\adry_run\x18\x05 \x01(\bR\x06dryRun\"J\n" +
+	"\x16AdoptSnapshotsResponse\x120\n" +
+	"\tsnapshots\x18\x01 \x03(\v2\x12.v1.ResticSnapshotR\tsnapshots\"\x8d\x01\n" +
+	"\x13HoldSnapshotRequest\x12\x17\n" +
+	"\arepo_id\x18\x01 \x01(\tR\x06repoId\x12\x1f\n" +
+	"\vsnapshot_id\x18\x02 \x01(\tR\n" +
+	"snapshotId\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12$\n" +
+	"\x0eexpire_unix_ms\x18\x04 \x01(\x03R\fexpireUnixMs\"V\n" +
+	"\x1aReleaseSnapshotHoldRequest\x12\x17\n" +
+	"\arepo_id\x18\x01 \x01(\tR\x06repoId\x12\x1f\n" +
+	"\vsnapshot_id\x18\x02 \x01(\tR\n" +
 	"snapshotId\"H\n" +
 	"\x14ListSnapshotsRequest\x12\x17\n" +
 	"\arepo_id\x18\x01 \x01(\tR\x06repoId\x12\x17\n" +
@@ -1262,7 +2654,70 @@ const file_v1_service_proto_rawDesc = "" +
 	" \x01(\tR\x05ctime\"F\n" +
 	"\x11RunCommandRequest\x12\x17\n" +
 	"\arepo_id\x18\x01 \x01(\tR\x06repoId\x12\x18\n" +
-	"\acommand\x18\x02 \x01(\tR\acommand\"\xea\a\n" +
+	"\acommand\x18\x02 \x01(\tR\acommand\"\x93\x01\n" +
+	"\x0fTestHookRequest\x12\x1c\n" +
+	"\x04hook\x18\x01 \x01(\v2\b.v1.HookR\x04hook\x120\n" +
+	"\tcondition\x18\x02 \x01(\x0e2\x12.v1.Hook.ConditionR\tcondition\x12\x17\n" +
+	"\arepo_id\x18\x03 \x01(\tR\x06repoId\x12\x17\n" +
+	"\aplan_id\x18\x04 \x01(\tR\x06planId\"\x8c\x01\n" +
+	"\x10TestHookResponse\x12\x18\n" +
+	"\apayload\x18\x01 \x01(\tR\apayload\x12\x16\n" +
+	"\x06output\x18\x02 \x01(\tR\x06output\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x120\n" +
+	"\x14example_operation_id\x18\x04 \x01(\x03R\x12exampleOperationId\"\xb1\x01\n" +
+	"\x17SearchOperationsRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x1b\n" +
+	"\traw_query\x18\x02 \x01(\bR\brawQuery\x12*\n" +
+	"\bselector\x18\x03 \x01(\v2\x0e.v1.OpSelectorR\bselector\x12!\n" +
+	"\finclude_logs\x18\x04 \x01(\bR\vincludeLogs\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\"P\n" +
+	"\x18SearchOperationsResponse\x124\n" +
+	"\aresults\x18\x01 \x03(\v2\x1a.v1.SearchOperationsResultR\aresults\"\x8f\x01\n" +
+	"\x16SearchOperationsResult\x12+\n" +
+	"\toperation\x18\x01 \x01(\v2\r.v1.OperationR\toperation\x12\x14\n" +
+	"\x05field\x18\x02 \x01(\tR\x05field\x122\n" +
+	"\asnippet\x18\x03 \x03(\v2\x18.v1.SearchSnippetSegmentR\asnippet\"H\n" +
+	"\x14SearchSnippetSegment\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12\x1c\n" +
+	"\thighlight\x18\x02 \x01(\bR\thighlight\"\xad\x01\n" +
+	"\x17ExportOperationsRequest\x12*\n" +
+	"\bselector\x18\x01 \x01(\v2\x0e.v1.OpSelectorR\bselector\x12:\n" +
+	"\x06format\x18\x02 \x01(\x0e2\".v1.ExportOperationsRequest.FormatR\x06format\"*\n" +
+	"\x06Format\x12\x10\n" +
+	"\fFORMAT_JSONL\x10\x00\x12\x0e\n" +
+	"\n" +
+	"FORMAT_CSV\x10\x01\"-\n" +
+	"\x17ImportOperationsRequest\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"P\n" +
+	"\x18ImportOperationsResponse\x12\x1a\n" +
+	"\bimported\x18\x01 \x01(\x03R\bimported\x12\x18\n" +
+	"\askipped\x18\x02 \x01(\x03R\askipped\"\x96\x03\n" +
+	"\rLogStoreStats\x12\x1b\n" +
+	"\tlog_count\x18\x01 \x01(\x03R\blogCount\x12\x1f\n" +
+	"\vtotal_bytes\x18\x02 \x01(\x03R\n" +
+	"totalBytes\x12)\n" +
+	"\x10inprogress_count\x18\x03 \x01(\x03R\x0finprogressCount\x12)\n" +
+	"\x10inprogress_bytes\x18\x04 \x01(\x03R\x0finprogressBytes\x12\x1f\n" +
+	"\vquota_bytes\x18\x05 \x01(\x03R\n" +
+	"quotaBytes\x12P\n" +
+	"\x11by_operation_type\x18\x06 \x03(\v2$.v1.LogStoreStats.OperationTypeUsageR\x0fbyOperationType\x1a~\n" +
+	"\x12OperationTypeUsage\x12*\n" +
+	"\aop_type\x18\x01 \x01(\x0e2\x11.v1.OperationTypeR\x06opType\x12\x1b\n" +
+	"\tlog_count\x18\x02 \x01(\x03R\blogCount\x12\x1f\n" +
+	"\vtotal_bytes\x18\x03 \x01(\x03R\n" +
+	"totalBytes\"N\n" +
+	"\x18GetBackupTimelineRequest\x12\x13\n" +
+	"\x05op_id\x18\x01 \x01(\x03R\x04opId\x12\x1d\n" +
+	"\n" +
+	"max_points\x18\x02 \x01(\x05R\tmaxPoints\"\xf6\x01\n" +
+	"\x19GetBackupTimelineResponse\x12/\n" +
+	"\x06points\x18\x01 \x03(\v2\x17.v1.BackupTimelinePointR\x06points\x12K\n" +
+	"\rslowest_files\x18\x02 \x03(\v2&.v1.GetBackupTimelineResponse.FileTimeR\fslowestFiles\x12\x1a\n" +
+	"\bcomplete\x18\x03 \x01(\bR\bcomplete\x1a?\n" +
+	"\bFileTime\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x1f\n" +
+	"\vduration_ms\x18\x02 \x01(\x03R\n" +
+	"durationMs\"\xea\a\n" +
 	"\x18SummaryDashboardResponse\x12K\n" +
 	"\x0erepo_summaries\x18\x01 \x03(\v2$.v1.SummaryDashboardResponse.SummaryR\rrepoSummaries\x12K\n" +
 	"\x0eplan_summaries\x18\x02 \x03(\v2$.v1.SummaryDashboardResponse.SummaryR\rplanSummaries\x12\x1f\n" +
@@ -1290,12 +2745,14 @@ const file_v1_service_proto_rawDesc = "" +
 	"durationMs\x12+\n" +
 	"\x06status\x18\x04 \x03(\x0e2\x13.v1.OperationStatusR\x06status\x12\x1f\n" +
 	"\vbytes_added\x18\x05 \x03(\x03R\n" +
-	"bytesAdded2\xaf\t\n" +
+	"bytesAdded2\xd3\x0f\n" +
 	"\bBackrest\x121\n" +
 	"\tGetConfig\x12\x16.google.protobuf.Empty\x1a\n" +
 	".v1.Config\"\x00\x12%\n" +
 	"\tSetConfig\x12\n" +
 	".v1.Config\x1a\n" +
+	".v1.Config\"\x00\x12:\n" +
+	"\x14SetConfigWithOptions\x12\x14.v1.SetConfigRequest\x1a\n" +
 	".v1.Config\"\x00\x12/\n" +
 	"\x0fCheckRepoExists\x12\b.v1.Repo\x1a\x10.types.BoolValue\"\x00\x12!\n" +
 	"\aAddRepo\x12\b.v1.Repo\x1a\n" +
@@ -1310,7 +2767,11 @@ const file_v1_service_proto_rawDesc = "" +
 	"\x06Backup\x12\x12.types.StringValue\x1a\x16.google.protobuf.Empty\"\x00\x12=\n" +
 	"\n" +
 	"DoRepoTask\x12\x15.v1.DoRepoTaskRequest\x1a\x16.google.protobuf.Empty\"\x00\x125\n" +
-	"\x06Forget\x12\x11.v1.ForgetRequest\x1a\x16.google.protobuf.Empty\"\x00\x12?\n" +
+	"\x06Forget\x12\x11.v1.ForgetRequest\x1a\x16.google.protobuf.Empty\"\x00\x12O\n" +
+	"\x10PreviewRetention\x12\x1b.v1.PreviewRetentionRequest\x1a\x1c.v1.PreviewRetentionResponse\"\x00\x12I\n" +
+	"\x0eAdoptSnapshots\x12\x19.v1.AdoptSnapshotsRequest\x1a\x1a.v1.AdoptSnapshotsResponse\"\x00\x12A\n" +
+	"\fHoldSnapshot\x12\x17.v1.HoldSnapshotRequest\x1a\x16.google.protobuf.Empty\"\x00\x12O\n" +
+	"\x13ReleaseSnapshotHold\x12\x1e.v1.ReleaseSnapshotHoldRequest\x1a\x16.google.protobuf.Empty\"\x00\x12?\n" +
 	"\aRestore\x12\x1a.v1.RestoreSnapshotRequest\x1a\x16.google.protobuf.Empty\"\x00\x125\n" +
 	"\x06Cancel\x12\x11.types.Int64Value\x1a\x16.google.protobuf.Empty\"\x00\x124\n" +
 	"\aGetLogs\x12\x12.v1.LogDataRequest\x1a\x11.types.BytesValue\"\x000\x01\x128\n" +
@@ -1319,7 +2780,13 @@ const file_v1_service_proto_rawDesc = "" +
 	"\x0eGetDownloadURL\x12\x19.v1.GetDownloadURLRequest\x1a\x12.types.StringValue\"\x00\x12A\n" +
 	"\fClearHistory\x12\x17.v1.ClearHistoryRequest\x1a\x16.google.protobuf.Empty\"\x00\x12;\n" +
 	"\x10PathAutocomplete\x12\x12.types.StringValue\x1a\x11.types.StringList\"\x00\x12M\n" +
-	"\x13GetSummaryDashboard\x12\x16.google.protobuf.Empty\x1a\x1c.v1.SummaryDashboardResponse\"\x00B,Z*github.com/garethgeorge/backrest/gen/go/v1b\x06proto3"
+	"\x13GetSummaryDashboard\x12\x16.google.protobuf.Empty\x1a\x1c.v1.SummaryDashboardResponse\"\x00\x127\n" +
+	"\bTestHook\x12\x13.v1.TestHookRequest\x1a\x14.v1.TestHookResponse\"\x00\x12O\n" +
+	"\x10SearchOperations\x12\x1b.v1.SearchOperationsRequest\x1a\x1c.v1.SearchOperationsResponse\"\x00\x12F\n" +
+	"\x10ExportOperations\x12\x1b.v1.ExportOperationsRequest\x1a\x11.types.BytesValue\"\x000\x01\x12O\n" +
+	"\x10ImportOperations\x12\x1b.v1.ImportOperationsRequest\x1a\x1c.v1.ImportOperationsResponse\"\x00\x12?\n" +
+	"\x10GetLogStoreStats\x12\x16.google.protobuf.Empty\x1a\x11.v1.LogStoreStats\"\x00\x12R\n" +
+	"\x11GetBackupTimeline\x12\x1c.v1.GetBackupTimelineRequest\x1a\x1d.v1.GetBackupTimelineResponse\"\x00B,Z*github.com/garethgeorge/backrest/gen/go/v1b\x06proto3"
 
 var (
 	file_v1_service_proto_rawDescOnce sync.Once
@@ -1333,93 +2800,164 @@ func file_v1_service_proto_rawDescGZIP() []byte {
 	return file_v1_service_proto_rawDescData
 }
 
-var file_v1_service_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_v1_service_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_v1_service_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_v1_service_proto_msgTypes = make([]protoimpl.MessageInfo, 38)
 var file_v1_service_proto_goTypes = []any{
 	(DoRepoTaskRequest_Task)(0),                  // 0: v1.DoRepoTaskRequest.Task
-	(*OpSelector)(nil),                           // 1: v1.OpSelector
-	(*DoRepoTaskRequest)(nil),                    // 2: v1.DoRepoTaskRequest
-	(*ClearHistoryRequest)(nil),                  // 3: v1.ClearHistoryRequest
-	(*ForgetRequest)(nil),                        // 4: v1.ForgetRequest
-	(*ListSnapshotsRequest)(nil),                 // 5: v1.ListSnapshotsRequest
-	(*GetOperationsRequest)(nil),                 // 6: v1.GetOperationsRequest
-	(*RestoreSnapshotRequest)(nil),               // 7: v1.RestoreSnapshotRequest
-	(*ListSnapshotFilesRequest)(nil),             // 8: v1.ListSnapshotFilesRequest
-	(*ListSnapshotFilesResponse)(nil),            // 9: v1.ListSnapshotFilesResponse
-	(*LogDataRequest)(nil),                       // 10: v1.LogDataRequest
-	(*GetDownloadURLRequest)(nil),                // 11: v1.GetDownloadURLRequest
-	(*LsEntry)(nil),                              // 12: v1.LsEntry
-	(*RunCommandRequest)(nil),                    // 13: v1.RunCommandRequest
-	(*SummaryDashboardResponse)(nil),             // 14: v1.SummaryDashboardResponse
-	(*SummaryDashboardResponse_Summary)(nil),     // 15: v1.SummaryDashboardResponse.Summary
-	(*SummaryDashboardResponse_BackupChart)(nil), // 16: v1.SummaryDashboardResponse.BackupChart
-	(OperationStatus)(0),                         // 17: v1.OperationStatus
-	(*emptypb.Empty)(nil),                        // 18: google.protobuf.Empty
-	(*Config)(nil),                               // 19: v1.Config
-	(*Repo)(nil),                                 // 20: v1.Repo
-	(*types.StringValue)(nil),                    // 21: types.StringValue
-	(*types.Int64Value)(nil),                     // 22: types.Int64Value
-	(*types.BoolValue)(nil),                      // 23: types.BoolValue
-	(*OperationEvent)(nil),                       // 24: v1.OperationEvent
-	(*OperationList)(nil),                        // 25: v1.OperationList
-	(*ResticSnapshotList)(nil),                   // 26: v1.ResticSnapshotList
-	(*types.BytesValue)(nil),                     // 27: types.BytesValue
-	(*types.StringList)(nil),                     // 28: types.StringList
+	(ExportOperationsRequest_Format)(0),          // 1: v1.ExportOperationsRequest.Format
+	(*OpSelector)(nil),                           // 2: v1.OpSelector
+	(*SetConfigRequest)(nil),                     // 3: v1.SetConfigRequest
+	(*DoRepoTaskRequest)(nil),                    // 4: v1.DoRepoTaskRequest
+	(*ClearHistoryRequest)(nil),                  // 5: v1.ClearHistoryRequest
+	(*ForgetRequest)(nil),                        // 6: v1.ForgetRequest
+	(*PreviewRetentionRequest)(nil),              // 7: v1.PreviewRetentionRequest
+	(*PreviewRetentionResponse)(nil),             // 8: v1.PreviewRetentionResponse
+	(*RetentionDecision)(nil),                    // 9: v1.RetentionDecision
+	(*AdoptSnapshotsRequest)(nil),                // 10: v1.AdoptSnapshotsRequest
+	(*AdoptSnapshotsResponse)(nil),               // 11: v1.AdoptSnapshotsResponse
+	(*HoldSnapshotRequest)(nil),                  // 12: v1.HoldSnapshotRequest
+	(*ReleaseSnapshotHoldRequest)(nil),           // 13: v1.ReleaseSnapshotHoldRequest
+	(*ListSnapshotsRequest)(nil),                 // 14: v1.ListSnapshotsRequest
+	(*GetOperationsRequest)(nil),                 // 15: v1.GetOperationsRequest
+	(*RestoreSnapshotRequest)(nil),               // 16: v1.RestoreSnapshotRequest
+	(*ListSnapshotFilesRequest)(nil),             // 17: v1.ListSnapshotFilesRequest
+	(*ListSnapshotFilesResponse)(nil),            // 18: v1.ListSnapshotFilesResponse
+	(*LogDataRequest)(nil),                       // 19: v1.LogDataRequest
+	(*GetDownloadURLRequest)(nil),                // 20: v1.GetDownloadURLRequest
+	(*LsEntry)(nil),                              // 21: v1.LsEntry
+	(*RunCommandRequest)(nil),                    // 22: v1.RunCommandRequest
+	(*TestHookRequest)(nil),                      // 23: v1.TestHookRequest
+	(*TestHookResponse)(nil),                     // 24: v1.TestHookResponse
+	(*SearchOperationsRequest)(nil),              // 25: v1.SearchOperationsRequest
+	(*SearchOperationsResponse)(nil),             // 26: v1.SearchOperationsResponse
+	(*SearchOperationsResult)(nil),               // 27: v1.SearchOperationsResult
+	(*SearchSnippetSegment)(nil),                 // 28: v1.SearchSnippetSegment
+	(*ExportOperationsRequest)(nil),              // 29: v1.ExportOperationsRequest
+	(*ImportOperationsRequest)(nil),              // 30: v1.ImportOperationsRequest
+	(*ImportOperationsResponse)(nil),             // 31: v1.ImportOperationsResponse
+	(*LogStoreStats)(nil),                        // 32: v1.LogStoreStats
+	(*GetBackupTimelineRequest)(nil),             // 33: v1.GetBackupTimelineRequest
+	(*GetBackupTimelineResponse)(nil),            // 34: v1.GetBackupTimelineResponse
+	(*SummaryDashboardResponse)(nil),             // 35: v1.SummaryDashboardResponse
+	(*LogStoreStats_OperationTypeUsage)(nil),     // 36: v1.LogStoreStats.OperationTypeUsage
+	(*GetBackupTimelineResponse_FileTime)(nil),   // 37: v1.GetBackupTimelineResponse.FileTime
+	(*SummaryDashboardResponse_Summary)(nil),     // 38: v1.SummaryDashboardResponse.Summary
+	(*SummaryDashboardResponse_BackupChart)(nil), // 39: v1.SummaryDashboardResponse.BackupChart
+	(OperationStatus)(0),                         // 40: v1.OperationStatus
+	(OperationType)(0),                           // 41: v1.OperationType
+	(*Config)(nil),                               // 42: v1.Config
+	(*RetentionPolicy)(nil),                      // 43: v1.RetentionPolicy
+	(*ResticSnapshot)(nil),                       // 44: v1.ResticSnapshot
+	(*Hook)(nil),                                 // 45: v1.Hook
+	(Hook_Condition)(0),                          // 46: v1.Hook.Condition
+	(*Operation)(nil),                            // 47: v1.Operation
+	(*BackupTimelinePoint)(nil),                  // 48: v1.BackupTimelinePoint
+	(*emptypb.Empty)(nil),                        // 49: google.protobuf.Empty
+	(*Repo)(nil),                                 // 50: v1.Repo
+	(*types.StringValue)(nil),                    // 51: types.StringValue
+	(*types.Int64Value)(nil),                     // 52: types.Int64Value
+	(*types.BoolValue)(nil),                      // 53: types.BoolValue
+	(*OperationEvent)(nil),                       // 54: v1.OperationEvent
+	(*OperationList)(nil),                        // 55: v1.OperationList
+	(*ResticSnapshotList)(nil),                   // 56: v1.ResticSnapshotList
+	(*types.BytesValue)(nil),                     // 57: types.BytesValue
+	(*types.StringList)(nil),                     // 58: types.StringList
 }
 var file_v1_service_proto_depIdxs = []int32{
-	0,  // 0: v1.DoRepoTaskRequest.task:type_name -> v1.DoRepoTaskRequest.Task
-	1,  // 1: v1.ClearHistoryRequest.selector:type_name -> v1.OpSelector
-	1,  // 2: v1.GetOperationsRequest.selector:type_name -> v1.OpSelector
-	12, // 3: v1.ListSnapshotFilesResponse.entries:type_name -> v1.LsEntry
-	15, // 4: v1.SummaryDashboardResponse.repo_summaries:type_name -> v1.SummaryDashboardResponse.Summary
-	15, // 5: v1.SummaryDashboardResponse.plan_summaries:type_name -> v1.SummaryDashboardResponse.Summary
-	16, // 6: v1.SummaryDashboardResponse.Summary.recent_backups:type_name -> v1.SummaryDashboardResponse.BackupChart
-	17, // 7: v1.SummaryDashboardResponse.BackupChart.status:type_name -> v1.OperationStatus
-	18, // 8: v1.Backrest.GetConfig:input_type -> google.protobuf.Empty
-	19, // 9: v1.Backrest.SetConfig:input_type -> v1.Config
-	20, // 10: v1.Backrest.CheckRepoExists:input_type -> v1.Repo
-	20, // 11: v1.Backrest.AddRepo:input_type -> v1.Repo
-	21, // 12: v1.Backrest.RemoveRepo:input_type -> types.StringValue
-	18, // 13: v1.Backrest.GetOperationEvents:input_type -> google.protobuf.Empty
-	6,  // 14: v1.Backrest.GetOperations:input_type -> v1.GetOperationsRequest
-	5,  // 15: v1.Backrest.ListSnapshots:input_type -> v1.ListSnapshotsRequest
-	8,  // 16: v1.Backrest.ListSnapshotFiles:input_type -> v1.ListSnapshotFilesRequest
-	21, // 17: v1.Backrest.Backup:input_type -> types.StringValue
-	2,  // 18: v1.Backrest.DoRepoTask:input_type -> v1.DoRepoTaskRequest
-	4,  // 19: v1.Backrest.Forget:input_type -> v1.ForgetRequest
-	7,  // 20: v1.Backrest.Restore:input_type -> v1.RestoreSnapshotRequest
-	22, // 21: v1.Backrest.Cancel:input_type -> types.Int64Value
-	10, // 22: v1.Backrest.GetLogs:input_type -> v1.LogDataRequest
-	13, // 23: v1.Backrest.RunCommand:input_type -> v1.RunCommandRequest
-	11, // 24: v1.Backrest.GetDownloadURL:input_type -> v1.GetDownloadURLRequest
-	3,  // 25: v1.Backrest.ClearHistory:input_type -> v1.ClearHistoryRequest
-	21, // 26: v1.Backrest.PathAutocomplete:input_type -> types.StringValue
-	18, // 27: v1.Backrest.GetSummaryDashboard:input_type -> google.protobuf.Empty
-	19, // 28: v1.Backrest.GetConfig:output_type -> v1.Config
-	19, // 29: v1.Backrest.SetConfig:output_type -> v1.Config
-	23, // 30: v1.Backrest.CheckRepoExists:output_type -> types.BoolValue
-	19, // 31: v1.Backrest.AddRepo:output_type -> v1.Config
-	19, // 32: v1.Backrest.RemoveRepo:output_type -> v1.Config
-	24, // 33: v1.Backrest.GetOperationEvents:output_type -> v1.OperationEvent
-	25, // 34: v1.Backrest.GetOperations:output_type -> v1.OperationList
-	26, // 35: v1.Backrest.ListSnapshots:output_type -> v1.ResticSnapshotList
-	9,  // 36: v1.Backrest.ListSnapshotFiles:output_type -> v1.ListSnapshotFilesResponse
-	18, // 37: v1.Backrest.Backup:output_type -> google.protobuf.Empty
-	18, // 38: v1.Backrest.DoRepoTask:output_type -> google.protobuf.Empty
-	18, // 39: v1.Backrest.Forget:output_type -> google.protobuf.Empty
-	18, // 40: v1.Backrest.Restore:output_type -> google.protobuf.Empty
-	18, // 41: v1.Backrest.Cancel:output_type -> google.protobuf.Empty
-	27, // 42: v1.Backrest.GetLogs:output_type -> types.BytesValue
-	22, // 43: v1.Backrest.RunCommand:output_type -> types.Int64Value
-	21, // 44: v1.Backrest.GetDownloadURL:output_type -> types.StringValue
-	18, // 45: v1.Backrest.ClearHistory:output_type -> google.protobuf.Empty
-	28, // 46: v1.Backrest.PathAutocomplete:output_type -> types.StringList
-	14, // 47: v1.Backrest.GetSummaryDashboard:output_type -> v1.SummaryDashboardResponse
-	28, // [28:48] is the sub-list for method output_type
-	8,  // [8:28] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+	40, // 0: v1.OpSelector.statuses:type_name -> v1.OperationStatus
+	41, // 1: v1.OpSelector.op_types:type_name -> v1.OperationType
+	42, // 2: v1.SetConfigRequest.config:type_name -> v1.Config
+	0,  // 3: v1.DoRepoTaskRequest.task:type_name -> v1.DoRepoTaskRequest.Task
+	2,  // 4: v1.ClearHistoryRequest.selector:type_name -> v1.OpSelector
+	43, // 5: v1.PreviewRetentionRequest.policy:type_name -> v1.RetentionPolicy
+	9,  // 6: v1.PreviewRetentionResponse.decisions:type_name -> v1.RetentionDecision
+	44, // 7: v1.RetentionDecision.snapshot:type_name -> v1.ResticSnapshot
+	44, // 8: v1.AdoptSnapshotsResponse.snapshots:type_name -> v1.ResticSnapshot
+	2,  // 9: v1.GetOperationsRequest.selector:type_name -> v1.OpSelector
+	21, // 10: v1.ListSnapshotFilesResponse.entries:type_name -> v1.LsEntry
+	45, // 11: v1.TestHookRequest.hook:type_name -> v1.Hook
+	46, // 12: v1.TestHookRequest.condition:type_name -> v1.Hook.Condition
+	2,  // 13: v1.SearchOperationsRequest.selector:type_name -> v1.OpSelector
+	27, // 14: v1.SearchOperationsResponse.results:type_name -> v1.SearchOperationsResult
+	47, // 15: v1.SearchOperationsResult.operation:type_name -> v1.Operation
+	28, // 16: v1.SearchOperationsResult.snippet:type_name -> v1.SearchSnippetSegment
+	2,  // 17: v1.ExportOperationsRequest.selector:type_name -> v1.OpSelector
+	1,  // 18: v1.ExportOperationsRequest.format:type_name -> v1.ExportOperationsRequest.Format
+	36, // 19: v1.LogStoreStats.by_operation_type:type_name -> v1.LogStoreStats.OperationTypeUsage
+	48, // 20: v1.GetBackupTimelineResponse.points:type_name -> v1.BackupTimelinePoint
+	37, // 21: v1.GetBackupTimelineResponse.slowest_files:type_name -> v1.GetBackupTimelineResponse.FileTime
+	38, // 22: v1.SummaryDashboardResponse.repo_summaries:type_name -> v1.SummaryDashboardResponse.Summary
+	38, // 23: v1.SummaryDashboardResponse.plan_summaries:type_name -> v1.SummaryDashboardResponse.Summary
+	41, // 24: v1.LogStoreStats.OperationTypeUsage.op_type:type_name -> v1.OperationType
+	39, // 25: v1.SummaryDashboardResponse.Summary.recent_backups:type_name -> v1.SummaryDashboardResponse.BackupChart
+	40, // 26: v1.SummaryDashboardResponse.BackupChart.status:type_name -> v1.OperationStatus
+	49, // 27: v1.Backrest.GetConfig:input_type -> google.protobuf.Empty
+	42, // 28: v1.Backrest.SetConfig:input_type -> v1.Config
+	3,  // 29: v1.Backrest.SetConfigWithOptions:input_type -> v1.SetConfigRequest
+	50, // 30: v1.Backrest.CheckRepoExists:input_type -> v1.Repo
+	50, // 31: v1.Backrest.AddRepo:input_type -> v1.Repo
+	51, // 32: v1.Backrest.RemoveRepo:input_type -> types.StringValue
+	49, // 33: v1.Backrest.GetOperationEvents:input_type -> google.protobuf.Empty
+	15, // 34: v1.Backrest.GetOperations:input_type -> v1.GetOperationsRequest
+	14, // 35: v1.Backrest.ListSnapshots:input_type -> v1.ListSnapshotsRequest
+	17, // 36: v1.Backrest.ListSnapshotFiles:input_type -> v1.ListSnapshotFilesRequest
+	51, // 37: v1.Backrest.Backup:input_type -> types.StringValue
+	4,  // 38: v1.Backrest.DoRepoTask:input_type -> v1.DoRepoTaskRequest
+	6,  // 39: v1.Backrest.Forget:input_type -> v1.ForgetRequest
+	7,  // 40: v1.Backrest.PreviewRetention:input_type -> v1.PreviewRetentionRequest
+	10, // 41: v1.Backrest.AdoptSnapshots:input_type -> v1.AdoptSnapshotsRequest
+	12, // 42: v1.Backrest.HoldSnapshot:input_type -> v1.HoldSnapshotRequest
+	13, // 43: v1.Backrest.ReleaseSnapshotHold:input_type -> v1.ReleaseSnapshotHoldRequest
+	16, // 44: v1.Backrest.Restore:input_type -> v1.RestoreSnapshotRequest
+	52, // 45: v1.Backrest.Cancel:input_type -> types.Int64Value
+	19, // 46: v1.Backrest.GetLogs:input_type -> v1.LogDataRequest
+	22, // 47: v1.Backrest.RunCommand:input_type -> v1.RunCommandRequest
+	20, // 48: v1.Backrest.GetDownloadURL:input_type -> v1.GetDownloadURLRequest
+	5,  // 49: v1.Backrest.ClearHistory:input_type -> v1.ClearHistoryRequest
+	51, // 50: v1.Backrest.PathAutocomplete:input_type -> types.StringValue
+	49, // 51: v1.Backrest.GetSummaryDashboard:input_type -> google.protobuf.Empty
+	23, // 52: v1.Backrest.TestHook:input_type -> v1.TestHookRequest
+	25, // 53: v1.Backrest.SearchOperations:input_type -> v1.SearchOperationsRequest
+	29, // 54: v1.Backrest.ExportOperations:input_type -> v1.ExportOperationsRequest
+	30, // 55: v1.Backrest.ImportOperations:input_type -> v1.ImportOperationsRequest
+	49, // 56: v1.Backrest.GetLogStoreStats:input_type -> google.protobuf.Empty
+	33, // 57: v1.Backrest.GetBackupTimeline:input_type -> v1.GetBackupTimelineRequest
+	42, // 58: v1.Backrest.GetConfig:output_type -> v1.Config
+	42, // 59: v1.Backrest.SetConfig:output_type -> v1.Config
+	42, // 60: v1.Backrest.SetConfigWithOptions:output_type -> v1.Config
+	53, // 61: v1.Backrest.CheckRepoExists:output_type -> types.BoolValue
+	42, // 62: v1.Backrest.AddRepo:output_type -> v1.Config
+	42, // 63: v1.Backrest.RemoveRepo:output_type -> v1.Config
+	54, // 64: v1.Backrest.GetOperationEvents:output_type -> v1.OperationEvent
+	55, // 65: v1.Backrest.GetOperations:output_type -> v1.OperationList
+	56, // 66: v1.Backrest.ListSnapshots:output_type -> v1.ResticSnapshotList
+	18, // 67: v1.Backrest.ListSnapshotFiles:output_type -> v1.ListSnapshotFilesResponse
+	49, // 68: v1.Backrest.Backup:output_type -> google.protobuf.Empty
+	49, // 69: v1.Backrest.DoRepoTask:output_type -> google.protobuf.Empty
+	49, // 70: v1.Backrest.Forget:output_type -> google.protobuf.Empty
+	8,  // 71: v1.Backrest.PreviewRetention:output_type -> v1.PreviewRetentionResponse
+	11, // 72: v1.Backrest.AdoptSnapshots:output_type -> v1.AdoptSnapshotsResponse
+	49, // 73: v1.Backrest.HoldSnapshot:output_type -> google.protobuf.Empty
+	49, // 74: v1.Backrest.ReleaseSnapshotHold:output_type -> google.protobuf.Empty
+	49, // 75: v1.Backrest.Restore:output_type -> google.protobuf.Empty
+	49, // 76: v1.Backrest.Cancel:output_type -> google.protobuf.Empty
+	57, // 77: v1.Backrest.GetLogs:output_type -> types.BytesValue
+	52, // 78: v1.Backrest.RunCommand:output_type -> types.Int64Value
+	51, // 79: v1.Backrest.GetDownloadURL:output_type -> types.StringValue
+	49, // 80: v1.Backrest.ClearHistory:output_type -> google.protobuf.Empty
+	58, // 81: v1.Backrest.PathAutocomplete:output_type -> types.StringList
+	35, // 82: v1.Backrest.GetSummaryDashboard:output_type -> v1.SummaryDashboardResponse
+	24, // 83: v1.Backrest.TestHook:output_type -> v1.TestHookResponse
+	26, // 84: v1.Backrest.SearchOperations:output_type -> v1.SearchOperationsResponse
+	57, // 85: v1.Backrest.ExportOperations:output_type -> types.BytesValue
+	31, // 86: v1.Backrest.ImportOperations:output_type -> v1.ImportOperationsResponse
+	32, // 87: v1.Backrest.GetLogStoreStats:output_type -> v1.LogStoreStats
+	34, // 88: v1.Backrest.GetBackupTimeline:output_type -> v1.GetBackupTimelineResponse
+	58, // [58:89] is the sub-list for method output_type
+	27, // [27:58] is the sub-list for method input_type
+	27, // [27:27] is the sub-list for extension type_name
+	27, // [27:27] is the sub-list for extension extendee
+	0,  // [0:27] is the sub-list for field type_name
 }
 
 func init() { file_v1_service_proto_init() }
@@ -1436,8 +2974,8 @@ func file_v1_service_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_v1_service_proto_rawDesc), len(file_v1_service_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   16,
+			NumEnums:      2,
+			NumMessages:   38,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
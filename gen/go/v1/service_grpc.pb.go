@@ -21,26 +21,37 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Backrest_GetConfig_FullMethodName           = "/v1.Backrest/GetConfig"
-	Backrest_SetConfig_FullMethodName           = "/v1.Backrest/SetConfig"
-	Backrest_CheckRepoExists_FullMethodName     = "/v1.Backrest/CheckRepoExists"
-	Backrest_AddRepo_FullMethodName             = "/v1.Backrest/AddRepo"
-	Backrest_RemoveRepo_FullMethodName          = "/v1.Backrest/RemoveRepo"
-	Backrest_GetOperationEvents_FullMethodName  = "/v1.Backrest/GetOperationEvents"
-	Backrest_GetOperations_FullMethodName       = "/v1.Backrest/GetOperations"
-	Backrest_ListSnapshots_FullMethodName       = "/v1.Backrest/ListSnapshots"
-	Backrest_ListSnapshotFiles_FullMethodName   = "/v1.Backrest/ListSnapshotFiles"
-	Backrest_Backup_FullMethodName              = "/v1.Backrest/Backup"
-	Backrest_DoRepoTask_FullMethodName          = "/v1.Backrest/DoRepoTask"
-	Backrest_Forget_FullMethodName              = "/v1.Backrest/Forget"
-	Backrest_Restore_FullMethodName             = "/v1.Backrest/Restore"
-	Backrest_Cancel_FullMethodName              = "/v1.Backrest/Cancel"
-	Backrest_GetLogs_FullMethodName             = "/v1.Backrest/GetLogs"
-	Backrest_RunCommand_FullMethodName          = "/v1.Backrest/RunCommand"
-	Backrest_GetDownloadURL_FullMethodName      = "/v1.Backrest/GetDownloadURL"
-	Backrest_ClearHistory_FullMethodName        = "/v1.Backrest/ClearHistory"
-	Backrest_PathAutocomplete_FullMethodName    = "/v1.Backrest/PathAutocomplete"
-	Backrest_GetSummaryDashboard_FullMethodName = "/v1.Backrest/GetSummaryDashboard"
+	Backrest_GetConfig_FullMethodName            = "/v1.Backrest/GetConfig"
+	Backrest_SetConfig_FullMethodName            = "/v1.Backrest/SetConfig"
+	Backrest_SetConfigWithOptions_FullMethodName = "/v1.Backrest/SetConfigWithOptions"
+	Backrest_CheckRepoExists_FullMethodName      = "/v1.Backrest/CheckRepoExists"
+	Backrest_AddRepo_FullMethodName              = "/v1.Backrest/AddRepo"
+	Backrest_RemoveRepo_FullMethodName           = "/v1.Backrest/RemoveRepo"
+	Backrest_GetOperationEvents_FullMethodName   = "/v1.Backrest/GetOperationEvents"
+	Backrest_GetOperations_FullMethodName        = "/v1.Backrest/GetOperations"
+	Backrest_ListSnapshots_FullMethodName        = "/v1.Backrest/ListSnapshots"
+	Backrest_ListSnapshotFiles_FullMethodName    = "/v1.Backrest/ListSnapshotFiles"
+	Backrest_Backup_FullMethodName               = "/v1.Backrest/Backup"
+	Backrest_DoRepoTask_FullMethodName           = "/v1.Backrest/DoRepoTask"
+	Backrest_Forget_FullMethodName               = "/v1.Backrest/Forget"
+	Backrest_PreviewRetention_FullMethodName     = "/v1.Backrest/PreviewRetention"
+	Backrest_AdoptSnapshots_FullMethodName       = "/v1.Backrest/AdoptSnapshots"
+	Backrest_HoldSnapshot_FullMethodName         = "/v1.Backrest/HoldSnapshot"
+	Backrest_ReleaseSnapshotHold_FullMethodName  = "/v1.Backrest/ReleaseSnapshotHold"
+	Backrest_Restore_FullMethodName              = "/v1.Backrest/Restore"
+	Backrest_Cancel_FullMethodName               = "/v1.Backrest/Cancel"
+	Backrest_GetLogs_FullMethodName              = "/v1.Backrest/GetLogs"
+	Backrest_RunCommand_FullMethodName           = "/v1.Backrest/RunCommand"
+	Backrest_GetDownloadURL_FullMethodName       = "/v1.Backrest/GetDownloadURL"
+	Backrest_ClearHistory_FullMethodName         = "/v1.Backrest/ClearHistory"
+	Backrest_PathAutocomplete_FullMethodName     = "/v1.Backrest/PathAutocomplete"
+	Backrest_GetSummaryDashboard_FullMethodName  = "/v1.Backrest/GetSummaryDashboard"
+	Backrest_TestHook_FullMethodName             = "/v1.Backrest/TestHook"
+	Backrest_SearchOperations_FullMethodName     = "/v1.Backrest/SearchOperations"
+	Backrest_ExportOperations_FullMethodName     = "/v1.Backrest/ExportOperations"
+	Backrest_ImportOperations_FullMethodName     = "/v1.Backrest/ImportOperations"
+	Backrest_GetLogStoreStats_FullMethodName     = "/v1.Backrest/GetLogStoreStats"
+	Backrest_GetBackupTimeline_FullMethodName    = "/v1.Backrest/GetBackupTimeline"
 )
 
 // BackrestClient is the client API for Backrest service.
@@ -49,6 +60,8 @@ const (
 type BackrestClient interface {
 	GetConfig(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config, error)
 	SetConfig(ctx context.Context, in *Config, opts ...grpc.CallOption) (*Config, error)
+	// SetConfigWithOptions sets the config like SetConfig, the options allow changes that SetConfig rejects e.g. retention policies removing many snapshots.
+	SetConfigWithOptions(ctx context.Context, in *SetConfigRequest, opts ...grpc.CallOption) (*Config, error)
 	CheckRepoExists(ctx context.Context, in *Repo, opts ...grpc.CallOption) (*types.BoolValue, error)
 	AddRepo(ctx context.Context, in *Repo, opts ...grpc.CallOption) (*Config, error)
 	RemoveRepo(ctx context.Context, in *types.StringValue, opts ...grpc.CallOption) (*Config, error)
@@ -62,6 +75,14 @@ type BackrestClient interface {
 	DoRepoTask(ctx context.Context, in *DoRepoTaskRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// Forget schedules a forget operation. It accepts a plan id and returns empty if the task is enqueued.
 	Forget(ctx context.Context, in *ForgetRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// PreviewRetention evaluates a candidate retention policy against a plan's snapshots without forgetting anything.
+	PreviewRetention(ctx context.Context, in *PreviewRetentionRequest, opts ...grpc.CallOption) (*PreviewRetentionResponse, error)
+	// AdoptSnapshots assigns snapshots created outside of backrest to a plan by tagging them and reindexing the repo.
+	AdoptSnapshots(ctx context.Context, in *AdoptSnapshotsRequest, opts ...grpc.CallOption) (*AdoptSnapshotsResponse, error)
+	// HoldSnapshot places a hold on a snapshot, exempting it from forget operations until the hold is released or expires.
+	HoldSnapshot(ctx context.Context, in *HoldSnapshotRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// ReleaseSnapshotHold removes the hold on a snapshot, making it eligible for forget operations again.
+	ReleaseSnapshotHold(ctx context.Context, in *ReleaseSnapshotHoldRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// Restore schedules a restore operation.
 	Restore(ctx context.Context, in *RestoreSnapshotRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// Cancel attempts to cancel a task with the given operation ID. Not guaranteed to succeed.
@@ -78,6 +99,18 @@ type BackrestClient interface {
 	PathAutocomplete(ctx context.Context, in *types.StringValue, opts ...grpc.CallOption) (*types.StringList, error)
 	// GetSummaryDashboard returns data for the dashboard view.
 	GetSummaryDashboard(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SummaryDashboardResponse, error)
+	// TestHook runs a hook immediately with example variables built from the latest relevant operation and returns the rendered payload and outcome.
+	TestHook(ctx context.Context, in *TestHookRequest, opts ...grpc.CallOption) (*TestHookResponse, error)
+	// SearchOperations returns operations whose display message, backup errors or logs match a full-text query, best matches first.
+	SearchOperations(ctx context.Context, in *SearchOperationsRequest, opts ...grpc.CallOption) (*SearchOperationsResponse, error)
+	// ExportOperations streams the selected operations as JSONL, or as a CSV summary with one row per operation.
+	ExportOperations(ctx context.Context, in *ExportOperationsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[types.BytesValue], error)
+	// ImportOperations merges operations exported as JSONL into the log, assigning them new operation and flow IDs.
+	ImportOperations(ctx context.Context, in *ImportOperationsRequest, opts ...grpc.CallOption) (*ImportOperationsResponse, error)
+	// GetLogStoreStats reports the disk space used by operation logs, grouped by the type of the operation owning them.
+	GetLogStoreStats(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*LogStoreStats, error)
+	// GetBackupTimeline returns the progress timeline of a running or completed backup operation.
+	GetBackupTimeline(ctx context.Context, in *GetBackupTimelineRequest, opts ...grpc.CallOption) (*GetBackupTimelineResponse, error)
 }
 
 type backrestClient struct {
@@ -108,6 +141,16 @@ func (c *backrestClient) SetConfig(ctx context.Context, in *Config, opts ...grpc
 	return out, nil
 }
 
+func (c *backrestClient) SetConfigWithOptions(ctx context.Context, in *SetConfigRequest, opts ...grpc.CallOption) (*Config, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Config)
+	err := c.cc.Invoke(ctx, Backrest_SetConfigWithOptions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *backrestClient) CheckRepoExists(ctx context.Context, in *Repo, opts ...grpc.CallOption) (*types.BoolValue, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(types.BoolValue)
@@ -217,6 +260,46 @@ func (c *backrestClient) Forget(ctx context.Context, in *ForgetRequest, opts ...
 	return out, nil
 }
 
+func (c *backrestClient) PreviewRetention(ctx context.Context, in *PreviewRetentionRequest, opts ...grpc.CallOption) (*PreviewRetentionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PreviewRetentionResponse)
+	err := c.cc.Invoke(ctx, Backrest_PreviewRetention_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) AdoptSnapshots(ctx context.Context, in *AdoptSnapshotsRequest, opts ...grpc.CallOption) (*AdoptSnapshotsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdoptSnapshotsResponse)
+	err := c.cc.Invoke(ctx, Backrest_AdoptSnapshots_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) HoldSnapshot(ctx context.Context, in *HoldSnapshotRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Backrest_HoldSnapshot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) ReleaseSnapshotHold(ctx context.Context, in *ReleaseSnapshotHoldRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Backrest_ReleaseSnapshotHold_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *backrestClient) Restore(ctx context.Context, in *RestoreSnapshotRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
@@ -306,12 +389,83 @@ func (c *backrestClient) GetSummaryDashboard(ctx context.Context, in *emptypb.Em
 	return out, nil
 }
 
+func (c *backrestClient) TestHook(ctx context.Context, in *TestHookRequest, opts ...grpc.CallOption) (*TestHookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TestHookResponse)
+	err := c.cc.Invoke(ctx, Backrest_TestHook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) SearchOperations(ctx context.Context, in *SearchOperationsRequest, opts ...grpc.CallOption) (*SearchOperationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchOperationsResponse)
+	err := c.cc.Invoke(ctx, Backrest_SearchOperations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) ExportOperations(ctx context.Context, in *ExportOperationsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[types.BytesValue], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Backrest_ServiceDesc.Streams[2], Backrest_ExportOperations_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportOperationsRequest, types.BytesValue]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Backrest_ExportOperationsClient = grpc.ServerStreamingClient[types.BytesValue]
+
+func (c *backrestClient) ImportOperations(ctx context.Context, in *ImportOperationsRequest, opts ...grpc.CallOption) (*ImportOperationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportOperationsResponse)
+	err := c.cc.Invoke(ctx, Backrest_ImportOperations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) GetLogStoreStats(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*LogStoreStats, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LogStoreStats)
+	err := c.cc.Invoke(ctx, Backrest_GetLogStoreStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) GetBackupTimeline(ctx context.Context, in *GetBackupTimelineRequest, opts ...grpc.CallOption) (*GetBackupTimelineResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBackupTimelineResponse)
+	err := c.cc.Invoke(ctx, Backrest_GetBackupTimeline_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BackrestServer is the server API for Backrest service.
 // All implementations must embed UnimplementedBackrestServer
 // for forward compatibility.
 type BackrestServer interface {
 	GetConfig(context.Context, *emptypb.Empty) (*Config, error)
 	SetConfig(context.Context, *Config) (*Config, error)
+	// SetConfigWithOptions sets the config like SetConfig, the options allow changes that SetConfig rejects e.g. retention policies removing many snapshots.
+	SetConfigWithOptions(context.Context, *SetConfigRequest) (*Config, error)
 	CheckRepoExists(context.Context, *Repo) (*types.BoolValue, error)
 	AddRepo(context.Context, *Repo) (*Config, error)
 	RemoveRepo(context.Context, *types.StringValue) (*Config, error)
@@ -325,6 +479,14 @@ type BackrestServer interface {
 	DoRepoTask(context.Context, *DoRepoTaskRequest) (*emptypb.Empty, error)
 	// Forget schedules a forget operation. It accepts a plan id and returns empty if the task is enqueued.
 	Forget(context.Context, *ForgetRequest) (*emptypb.Empty, error)
+	// PreviewRetention evaluates a candidate retention policy against a plan's snapshots without forgetting anything.
+	PreviewRetention(context.Context, *PreviewRetentionRequest) (*PreviewRetentionResponse, error)
+	// AdoptSnapshots assigns snapshots created outside of backrest to a plan by tagging them and reindexing the repo.
+	AdoptSnapshots(context.Context, *AdoptSnapshotsRequest) (*AdoptSnapshotsResponse, error)
+	// HoldSnapshot places a hold on a snapshot, exempting it from forget operations until the hold is released or expires.
+	HoldSnapshot(context.Context, *HoldSnapshotRequest) (*emptypb.Empty, error)
+	// ReleaseSnapshotHold removes the hold on a snapshot, making it eligible for forget operations again.
+	ReleaseSnapshotHold(context.Context, *ReleaseSnapshotHoldRequest) (*emptypb.Empty, error)
 	// Restore schedules a restore operation.
 	Restore(context.Context, *RestoreSnapshotRequest) (*emptypb.Empty, error)
 	// Cancel attempts to cancel a task with the given operation ID. Not guaranteed to succeed.
@@ -341,6 +503,18 @@ type BackrestServer interface {
 	PathAutocomplete(context.Context, *types.StringValue) (*types.StringList, error)
 	// GetSummaryDashboard returns data for the dashboard view.
 	GetSummaryDashboard(context.Context, *emptypb.Empty) (*SummaryDashboardResponse, error)
+	// TestHook runs a hook immediately with example variables built from the latest relevant operation and returns the rendered payload and outcome.
+	TestHook(context.Context, *TestHookRequest) (*TestHookResponse, error)
+	// SearchOperations returns operations whose display message, backup errors or logs match a full-text query, best matches first.
+	SearchOperations(context.Context, *SearchOperationsRequest) (*SearchOperationsResponse, error)
+	// ExportOperations streams the selected operations as JSONL, or as a CSV summary with one row per operation.
+	ExportOperations(*ExportOperationsRequest, grpc.ServerStreamingServer[types.BytesValue]) error
+	// ImportOperations merges operations exported as JSONL into the log, assigning them new operation and flow IDs.
+	ImportOperations(context.Context, *ImportOperationsRequest) (*ImportOperationsResponse, error)
+	// GetLogStoreStats reports the disk space used by operation logs, grouped by the type of the operation owning them.
+	GetLogStoreStats(context.Context, *emptypb.Empty) (*LogStoreStats, error)
+	// GetBackupTimeline returns the progress timeline of a running or completed backup operation.
+	GetBackupTimeline(context.Context, *GetBackupTimelineRequest) (*GetBackupTimelineResponse, error)
 	mustEmbedUnimplementedBackrestServer()
 }
 
@@ -357,6 +531,9 @@ func (UnimplementedBackrestServer) GetConfig(context.Context, *emptypb.Empty) (*
 func (UnimplementedBackrestServer) SetConfig(context.Context, *Config) (*Config, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SetConfig not implemented")
 }
+func (UnimplementedBackrestServer) SetConfigWithOptions(context.Context, *SetConfigRequest) (*Config, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetConfigWithOptions not implemented")
+}
 func (UnimplementedBackrestServer) CheckRepoExists(context.Context, *Repo) (*types.BoolValue, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CheckRepoExists not implemented")
 }
@@ -387,6 +564,18 @@ func (UnimplementedBackrestServer) DoRepoTask(context.Context, *DoRepoTaskReques
 func (UnimplementedBackrestServer) Forget(context.Context, *ForgetRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Forget not implemented")
 }
+func (UnimplementedBackrestServer) PreviewRetention(context.Context, *PreviewRetentionRequest) (*PreviewRetentionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreviewRetention not implemented")
+}
+func (UnimplementedBackrestServer) AdoptSnapshots(context.Context, *AdoptSnapshotsRequest) (*AdoptSnapshotsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdoptSnapshots not implemented")
+}
+func (UnimplementedBackrestServer) HoldSnapshot(context.Context, *HoldSnapshotRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HoldSnapshot not implemented")
+}
+func (UnimplementedBackrestServer) ReleaseSnapshotHold(context.Context, *ReleaseSnapshotHoldRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseSnapshotHold not implemented")
+}
 func (UnimplementedBackrestServer) Restore(context.Context, *RestoreSnapshotRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Restore not implemented")
 }
@@ -411,6 +600,24 @@ func (UnimplementedBackrestServer) PathAutocomplete(context.Context, *types.Stri
 func (UnimplementedBackrestServer) GetSummaryDashboard(context.Context, *emptypb.Empty) (*SummaryDashboardResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetSummaryDashboard not implemented")
 }
+func (UnimplementedBackrestServer) TestHook(context.Context, *TestHookRequest) (*TestHookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TestHook not implemented")
+}
+func (UnimplementedBackrestServer) SearchOperations(context.Context, *SearchOperationsRequest) (*SearchOperationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchOperations not implemented")
+}
+func (UnimplementedBackrestServer) ExportOperations(*ExportOperationsRequest, grpc.ServerStreamingServer[types.BytesValue]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportOperations not implemented")
+}
+func (UnimplementedBackrestServer) ImportOperations(context.Context, *ImportOperationsRequest) (*ImportOperationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportOperations not implemented")
+}
+func (UnimplementedBackrestServer) GetLogStoreStats(context.Context, *emptypb.Empty) (*LogStoreStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLogStoreStats not implemented")
+}
+func (UnimplementedBackrestServer) GetBackupTimeline(context.Context, *GetBackupTimelineRequest) (*GetBackupTimelineResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBackupTimeline not implemented")
+}
 func (UnimplementedBackrestServer) mustEmbedUnimplementedBackrestServer() {}
 func (UnimplementedBackrestServer) testEmbeddedByValue()                  {}
 
@@ -468,6 +675,24 @@ func _Backrest_SetConfig_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Backrest_SetConfigWithOptions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).SetConfigWithOptions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_SetConfigWithOptions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).SetConfigWithOptions(ctx, req.(*SetConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Backrest_CheckRepoExists_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Repo)
 	if err := dec(in); err != nil {
@@ -641,6 +866,78 @@ func _Backrest_Forget_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Backrest_PreviewRetention_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreviewRetentionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).PreviewRetention(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_PreviewRetention_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).PreviewRetention(ctx, req.(*PreviewRetentionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_AdoptSnapshots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdoptSnapshotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).AdoptSnapshots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_AdoptSnapshots_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).AdoptSnapshots(ctx, req.(*AdoptSnapshotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_HoldSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HoldSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).HoldSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_HoldSnapshot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).HoldSnapshot(ctx, req.(*HoldSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_ReleaseSnapshotHold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseSnapshotHoldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).ReleaseSnapshotHold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_ReleaseSnapshotHold_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).ReleaseSnapshotHold(ctx, req.(*ReleaseSnapshotHoldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Backrest_Restore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RestoreSnapshotRequest)
 	if err := dec(in); err != nil {
@@ -778,6 +1075,107 @@ func _Backrest_GetSummaryDashboard_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Backrest_TestHook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TestHookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).TestHook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_TestHook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).TestHook(ctx, req.(*TestHookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_SearchOperations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchOperationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).SearchOperations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_SearchOperations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).SearchOperations(ctx, req.(*SearchOperationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_ExportOperations_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportOperationsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BackrestServer).ExportOperations(m, &grpc.GenericServerStream[ExportOperationsRequest, types.BytesValue]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Backrest_ExportOperationsServer = grpc.ServerStreamingServer[types.BytesValue]
+
+func _Backrest_ImportOperations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportOperationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).ImportOperations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_ImportOperations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).ImportOperations(ctx, req.(*ImportOperationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_GetLogStoreStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).GetLogStoreStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_GetLogStoreStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).GetLogStoreStats(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_GetBackupTimeline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBackupTimelineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).GetBackupTimeline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_GetBackupTimeline_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).GetBackupTimeline(ctx, req.(*GetBackupTimelineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Backrest_ServiceDesc is the grpc.ServiceDesc for Backrest service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -793,6 +1191,10 @@ var Backrest_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SetConfig",
 			Handler:    _Backrest_SetConfig_Handler,
 		},
+		{
+			MethodName: "SetConfigWithOptions",
+			Handler:    _Backrest_SetConfigWithOptions_Handler,
+		},
 		{
 			MethodName: "CheckRepoExists",
 			Handler:    _Backrest_CheckRepoExists_Handler,
@@ -829,6 +1231,22 @@ var Backrest_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Forget",
 			Handler:    _Backrest_Forget_Handler,
 		},
+		{
+			MethodName: "PreviewRetention",
+			Handler:    _Backrest_PreviewRetention_Handler,
+		},
+		{
+			MethodName: "AdoptSnapshots",
+			Handler:    _Backrest_AdoptSnapshots_Handler,
+		},
+		{
+			MethodName: "HoldSnapshot",
+			Handler:    _Backrest_HoldSnapshot_Handler,
+		},
+		{
+			MethodName: "ReleaseSnapshotHold",
+			Handler:    _Backrest_ReleaseSnapshotHold_Handler,
+		},
 		{
 			MethodName: "Restore",
 			Handler:    _Backrest_Restore_Handler,
@@ -857,6 +1275,26 @@ var Backrest_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetSummaryDashboard",
 			Handler:    _Backrest_GetSummaryDashboard_Handler,
 		},
+		{
+			MethodName: "TestHook",
+			Handler:    _Backrest_TestHook_Handler,
+		},
+		{
+			MethodName: "SearchOperations",
+			Handler:    _Backrest_SearchOperations_Handler,
+		},
+		{
+			MethodName: "ImportOperations",
+			Handler:    _Backrest_ImportOperations_Handler,
+		},
+		{
+			MethodName: "GetLogStoreStats",
+			Handler:    _Backrest_GetLogStoreStats_Handler,
+		},
+		{
+			MethodName: "GetBackupTimeline",
+			Handler:    _Backrest_GetBackupTimeline_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -869,6 +1307,11 @@ var Backrest_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Backrest_GetLogs_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ExportOperations",
+			Handler:       _Backrest_ExportOperations_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "v1/service.proto",
 }
@@ -39,6 +39,9 @@ const (
 	BackrestGetConfigProcedure = "/v1.Backrest/GetConfig"
 	// BackrestSetConfigProcedure is the fully-qualified name of the Backrest's SetConfig RPC.
 	BackrestSetConfigProcedure = "/v1.Backrest/SetConfig"
+	// BackrestSetConfigWithOptionsProcedure is the fully-qualified name of the Backrest's
+	// SetConfigWithOptions RPC.
+	BackrestSetConfigWithOptionsProcedure = "/v1.Backrest/SetConfigWithOptions"
 	// BackrestCheckRepoExistsProcedure is the fully-qualified name of the Backrest's CheckRepoExists
 	// RPC.
 	BackrestCheckRepoExistsProcedure = "/v1.Backrest/CheckRepoExists"
@@ -62,6 +65,16 @@ const (
 	BackrestDoRepoTaskProcedure = "/v1.Backrest/DoRepoTask"
 	// BackrestForgetProcedure is the fully-qualified name of the Backrest's Forget RPC.
 	BackrestForgetProcedure = "/v1.Backrest/Forget"
+	// BackrestPreviewRetentionProcedure is the fully-qualified name of the Backrest's PreviewRetention
+	// RPC.
+	BackrestPreviewRetentionProcedure = "/v1.Backrest/PreviewRetention"
+	// BackrestAdoptSnapshotsProcedure is the fully-qualified name of the Backrest's AdoptSnapshots RPC.
+	BackrestAdoptSnapshotsProcedure = "/v1.Backrest/AdoptSnapshots"
+	// BackrestHoldSnapshotProcedure is the fully-qualified name of the Backrest's HoldSnapshot RPC.
+	BackrestHoldSnapshotProcedure = "/v1.Backrest/HoldSnapshot"
+	// BackrestReleaseSnapshotHoldProcedure is the fully-qualified name of the Backrest's
+	// ReleaseSnapshotHold RPC.
+	BackrestReleaseSnapshotHoldProcedure = "/v1.Backrest/ReleaseSnapshotHold"
 	// BackrestRestoreProcedure is the fully-qualified name of the Backrest's Restore RPC.
 	BackrestRestoreProcedure = "/v1.Backrest/Restore"
 	// BackrestCancelProcedure is the fully-qualified name of the Backrest's Cancel RPC.
@@ -80,12 +93,31 @@ const (
 	// BackrestGetSummaryDashboardProcedure is the fully-qualified name of the Backrest's
 	// GetSummaryDashboard RPC.
 	BackrestGetSummaryDashboardProcedure = "/v1.Backrest/GetSummaryDashboard"
+	// BackrestTestHookProcedure is the fully-qualified name of the Backrest's TestHook RPC.
+	BackrestTestHookProcedure = "/v1.Backrest/TestHook"
+	// BackrestSearchOperationsProcedure is the fully-qualified name of the Backrest's SearchOperations
+	// RPC.
+	BackrestSearchOperationsProcedure = "/v1.Backrest/SearchOperations"
+	// BackrestExportOperationsProcedure is the fully-qualified name of the Backrest's ExportOperations
+	// RPC.
+	BackrestExportOperationsProcedure = "/v1.Backrest/ExportOperations"
+	// BackrestImportOperationsProcedure is the fully-qualified name of the Backrest's ImportOperations
+	// RPC.
+	BackrestImportOperationsProcedure = "/v1.Backrest/ImportOperations"
+	// BackrestGetLogStoreStatsProcedure is the fully-qualified name of the Backrest's GetLogStoreStats
+	// RPC.
+	BackrestGetLogStoreStatsProcedure = "/v1.Backrest/GetLogStoreStats"
+	// BackrestGetBackupTimelineProcedure is the fully-qualified name of the Backrest's
+	// GetBackupTimeline RPC.
+	BackrestGetBackupTimelineProcedure = "/v1.Backrest/GetBackupTimeline"
 )
 
 // BackrestClient is a client for the v1.Backrest service.
 type BackrestClient interface {
 	GetConfig(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.Config], error)
 	SetConfig(context.Context, *connect.Request[v1.Config]) (*connect.Response[v1.Config], error)
+	// SetConfigWithOptions sets the config like SetConfig, the options allow changes that SetConfig rejects e.g. retention policies removing many snapshots.
+	SetConfigWithOptions(context.Context, *connect.Request[v1.SetConfigRequest]) (*connect.Response[v1.Config], error)
 	CheckRepoExists(context.Context, *connect.Request[v1.Repo]) (*connect.Response[types.BoolValue], error)
 	AddRepo(context.Context, *connect.Request[v1.Repo]) (*connect.Response[v1.Config], error)
 	RemoveRepo(context.Context, *connect.Request[types.StringValue]) (*connect.Response[v1.Config], error)
@@ -99,6 +131,14 @@ type BackrestClient interface {
 	DoRepoTask(context.Context, *connect.Request[v1.DoRepoTaskRequest]) (*connect.Response[emptypb.Empty], error)
 	// Forget schedules a forget operation. It accepts a plan id and returns empty if the task is enqueued.
 	Forget(context.Context, *connect.Request[v1.ForgetRequest]) (*connect.Response[emptypb.Empty], error)
+	// PreviewRetention evaluates a candidate retention policy against a plan's snapshots without forgetting anything.
+	PreviewRetention(context.Context, *connect.Request[v1.PreviewRetentionRequest]) (*connect.Response[v1.PreviewRetentionResponse], error)
+	// AdoptSnapshots assigns snapshots created outside of backrest to a plan by tagging them and reindexing the repo.
+	AdoptSnapshots(context.Context, *connect.Request[v1.AdoptSnapshotsRequest]) (*connect.Response[v1.AdoptSnapshotsResponse], error)
+	// HoldSnapshot places a hold on a snapshot, exempting it from forget operations until the hold is released or expires.
+	HoldSnapshot(context.Context, *connect.Request[v1.HoldSnapshotRequest]) (*connect.Response[emptypb.Empty], error)
+	// ReleaseSnapshotHold removes the hold on a snapshot, making it eligible for forget operations again.
+	ReleaseSnapshotHold(context.Context, *connect.Request[v1.ReleaseSnapshotHoldRequest]) (*connect.Response[emptypb.Empty], error)
 	// Restore schedules a restore operation.
 	Restore(context.Context, *connect.Request[v1.RestoreSnapshotRequest]) (*connect.Response[emptypb.Empty], error)
 	// Cancel attempts to cancel a task with the given operation ID. Not guaranteed to succeed.
@@ -115,6 +155,18 @@ type BackrestClient interface {
 	PathAutocomplete(context.Context, *connect.Request[types.StringValue]) (*connect.Response[types.StringList], error)
 	// GetSummaryDashboard returns data for the dashboard view.
 	GetSummaryDashboard(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.SummaryDashboardResponse], error)
+	// TestHook runs a hook immediately with example variables built from the latest relevant operation and returns the rendered payload and outcome.
+	TestHook(context.Context, *connect.Request[v1.TestHookRequest]) (*connect.Response[v1.TestHookResponse], error)
+	// SearchOperations returns operations whose display message, backup errors or logs match a full-text query, best matches first.
+	SearchOperations(context.Context, *connect.Request[v1.SearchOperationsRequest]) (*connect.Response[v1.SearchOperationsResponse], error)
+	// ExportOperations streams the selected operations as JSONL, or as a CSV summary with one row per operation.
+	ExportOperations(context.Context, *connect.Request[v1.ExportOperationsRequest]) (*connect.ServerStreamForClient[types.BytesValue], error)
+	// ImportOperations merges operations exported as JSONL into the log, assigning them new operation and flow IDs.
+	ImportOperations(context.Context, *connect.Request[v1.ImportOperationsRequest]) (*connect.Response[v1.ImportOperationsResponse], error)
+	// GetLogStoreStats reports the disk space used by operation logs, grouped by the type of the operation owning them.
+	GetLogStoreStats(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.LogStoreStats], error)
+	// GetBackupTimeline returns the progress timeline of a running or completed backup operation.
+	GetBackupTimeline(context.Context, *connect.Request[v1.GetBackupTimelineRequest]) (*connect.Response[v1.GetBackupTimelineResponse], error)
 }
 
 // NewBackrestClient constructs a client for the v1.Backrest service. By default, it uses the
@@ -140,6 +192,12 @@ func NewBackrestClient(httpClient connect.HTTPClient, baseURL string, opts ...co
 			connect.WithSchema(backrestMethods.ByName("SetConfig")),
 			connect.WithClientOptions(opts...),
 		),
+		setConfigWithOptions: connect.NewClient[v1.SetConfigRequest, v1.Config](
+			httpClient,
+			baseURL+BackrestSetConfigWithOptionsProcedure,
+			connect.WithSchema(backrestMethods.ByName("SetConfigWithOptions")),
+			connect.WithClientOptions(opts...),
+		),
 		checkRepoExists: connect.NewClient[v1.Repo, types.BoolValue](
 			httpClient,
 			baseURL+BackrestCheckRepoExistsProcedure,
@@ -200,6 +258,30 @@ func NewBackrestClient(httpClient connect.HTTPClient, baseURL string, opts ...co
 			connect.WithSchema(backrestMethods.ByName("Forget")),
 			connect.WithClientOptions(opts...),
 		),
+		previewRetention: connect.NewClient[v1.PreviewRetentionRequest, v1.PreviewRetentionResponse](
+			httpClient,
+			baseURL+BackrestPreviewRetentionProcedure,
+			connect.WithSchema(backrestMethods.ByName("PreviewRetention")),
+			connect.WithClientOptions(opts...),
+		),
+		adoptSnapshots: connect.NewClient[v1.AdoptSnapshotsRequest, v1.AdoptSnapshotsResponse](
+			httpClient,
+			baseURL+BackrestAdoptSnapshotsProcedure,
+			connect.WithSchema(backrestMethods.ByName("AdoptSnapshots")),
+			connect.WithClientOptions(opts...),
+		),
+		holdSnapshot: connect.NewClient[v1.HoldSnapshotRequest, emptypb.Empty](
+			httpClient,
+			baseURL+BackrestHoldSnapshotProcedure,
+			connect.WithSchema(backrestMethods.ByName("HoldSnapshot")),
+			connect.WithClientOptions(opts...),
+		),
+		releaseSnapshotHold: connect.NewClient[v1.ReleaseSnapshotHoldRequest, emptypb.Empty](
+			httpClient,
+			baseURL+BackrestReleaseSnapshotHoldProcedure,
+			connect.WithSchema(backrestMethods.ByName("ReleaseSnapshotHold")),
+			connect.WithClientOptions(opts...),
+		),
 		restore: connect.NewClient[v1.RestoreSnapshotRequest, emptypb.Empty](
 			httpClient,
 			baseURL+BackrestRestoreProcedure,
@@ -248,31 +330,78 @@ func NewBackrestClient(httpClient connect.HTTPClient, baseURL string, opts ...co
 			connect.WithSchema(backrestMethods.ByName("GetSummaryDashboard")),
 			connect.WithClientOptions(opts...),
 		),
+		testHook: connect.NewClient[v1.TestHookRequest, v1.TestHookResponse](
+			httpClient,
+			baseURL+BackrestTestHookProcedure,
+			connect.WithSchema(backrestMethods.ByName("TestHook")),
+			connect.WithClientOptions(opts...),
+		),
+		searchOperations: connect.NewClient[v1.SearchOperationsRequest, v1.SearchOperationsResponse](
+			httpClient,
+			baseURL+BackrestSearchOperationsProcedure,
+			connect.WithSchema(backrestMethods.ByName("SearchOperations")),
+			connect.WithClientOptions(opts...),
+		),
+		exportOperations: connect.NewClient[v1.ExportOperationsRequest, types.BytesValue](
+			httpClient,
+			baseURL+BackrestExportOperationsProcedure,
+			connect.WithSchema(backrestMethods.ByName("ExportOperations")),
+			connect.WithClientOptions(opts...),
+		),
+		importOperations: connect.NewClient[v1.ImportOperationsRequest, v1.ImportOperationsResponse](
+			httpClient,
+			baseURL+BackrestImportOperationsProcedure,
+			connect.WithSchema(backrestMethods.ByName("ImportOperations")),
+			connect.WithClientOptions(opts...),
+		),
+		getLogStoreStats: connect.NewClient[emptypb.Empty, v1.LogStoreStats](
+			httpClient,
+			baseURL+BackrestGetLogStoreStatsProcedure,
+			connect.WithSchema(backrestMethods.ByName("GetLogStoreStats")),
+			connect.WithClientOptions(opts...),
+		),
+		getBackupTimeline: connect.NewClient[v1.GetBackupTimelineRequest, v1.GetBackupTimelineResponse](
+			httpClient,
+			baseURL+BackrestGetBackupTimelineProcedure,
+			connect.WithSchema(backrestMethods.ByName("GetBackupTimeline")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // backrestClient implements BackrestClient.
 type backrestClient struct {
-	getConfig           *connect.Client[emptypb.Empty, v1.Config]
-	setConfig           *connect.Client[v1.Config, v1.Config]
-	checkRepoExists     *connect.Client[v1.Repo, types.BoolValue]
-	addRepo             *connect.Client[v1.Repo, v1.Config]
-	removeRepo          *connect.Client[types.StringValue, v1.Config]
-	getOperationEvents  *connect.Client[emptypb.Empty, v1.OperationEvent]
-	getOperations       *connect.Client[v1.GetOperationsRequest, v1.OperationList]
-	listSnapshots       *connect.Client[v1.ListSnapshotsRequest, v1.ResticSnapshotList]
-	listSnapshotFiles   *connect.Client[v1.ListSnapshotFilesRequest, v1.ListSnapshotFilesResponse]
-	backup              *connect.Client[types.StringValue, emptypb.Empty]
-	doRepoTask          *connect.Client[v1.DoRepoTaskRequest, emptypb.Empty]
-	forget              *connect.Client[v1.ForgetRequest, emptypb.Empty]
-	restore             *connect.Client[v1.RestoreSnapshotRequest, emptypb.Empty]
-	cancel              *connect.Client[types.Int64Value, emptypb.Empty]
-	getLogs             *connect.Client[v1.LogDataRequest, types.BytesValue]
-	runCommand          *connect.Client[v1.RunCommandRequest, types.Int64Value]
-	getDownloadURL      *connect.Client[v1.GetDownloadURLRequest, types.StringValue]
-	clearHistory        *connect.Client[v1.ClearHistoryRequest, emptypb.Empty]
-	pathAutocomplete    *connect.Client[types.StringValue, types.StringList]
-	getSummaryDashboard *connect.Client[emptypb.Empty, v1.SummaryDashboardResponse]
+	getConfig            *connect.Client[emptypb.Empty, v1.Config]
+	setConfig            *connect.Client[v1.Config, v1.Config]
+	setConfigWithOptions *connect.Client[v1.SetConfigRequest, v1.Config]
+	checkRepoExists      *connect.Client[v1.Repo, types.BoolValue]
+	addRepo              *connect.Client[v1.Repo, v1.Config]
+	removeRepo           *connect.Client[types.StringValue, v1.Config]
+	getOperationEvents   *connect.Client[emptypb.Empty, v1.OperationEvent]
+	getOperations        *connect.Client[v1.GetOperationsRequest, v1.OperationList]
+	listSnapshots        *connect.Client[v1.ListSnapshotsRequest, v1.ResticSnapshotList]
+	listSnapshotFiles    *connect.Client[v1.ListSnapshotFilesRequest, v1.ListSnapshotFilesResponse]
+	backup               *connect.Client[types.StringValue, emptypb.Empty]
+	doRepoTask           *connect.Client[v1.DoRepoTaskRequest, emptypb.Empty]
+	forget               *connect.Client[v1.ForgetRequest, emptypb.Empty]
+	previewRetention     *connect.Client[v1.PreviewRetentionRequest, v1.PreviewRetentionResponse]
+	adoptSnapshots       *connect.Client[v1.AdoptSnapshotsRequest, v1.AdoptSnapshotsResponse]
+	holdSnapshot         *connect.Client[v1.HoldSnapshotRequest, emptypb.Empty]
+	releaseSnapshotHold  *connect.Client[v1.ReleaseSnapshotHoldRequest, emptypb.Empty]
+	restore              *connect.Client[v1.RestoreSnapshotRequest, emptypb.Empty]
+	cancel               *connect.Client[types.Int64Value, emptypb.Empty]
+	getLogs              *connect.Client[v1.LogDataRequest, types.BytesValue]
+	runCommand           *connect.Client[v1.RunCommandRequest, types.Int64Value]
+	getDownloadURL       *connect.Client[v1.GetDownloadURLRequest, types.StringValue]
+	clearHistory         *connect.Client[v1.ClearHistoryRequest, emptypb.Empty]
+	pathAutocomplete     *connect.Client[types.StringValue, types.StringList]
+	getSummaryDashboard  *connect.Client[emptypb.Empty, v1.SummaryDashboardResponse]
+	testHook             *connect.Client[v1.TestHookRequest, v1.TestHookResponse]
+	searchOperations     *connect.Client[v1.SearchOperationsRequest, v1.SearchOperationsResponse]
+	exportOperations     *connect.Client[v1.ExportOperationsRequest, types.BytesValue]
+	importOperations     *connect.Client[v1.ImportOperationsRequest, v1.ImportOperationsResponse]
+	getLogStoreStats     *connect.Client[emptypb.Empty, v1.LogStoreStats]
+	getBackupTimeline    *connect.Client[v1.GetBackupTimelineRequest, v1.GetBackupTimelineResponse]
 }
 
 // GetConfig calls v1.Backrest.GetConfig.
@@ -285,6 +414,11 @@ func (c *backrestClient) SetConfig(ctx context.Context, req *connect.Request[v1.
 	return c.setConfig.CallUnary(ctx, req)
 }
 
+// SetConfigWithOptions calls v1.Backrest.SetConfigWithOptions.
+func (c *backrestClient) SetConfigWithOptions(ctx context.Context, req *connect.Request[v1.SetConfigRequest]) (*connect.Response[v1.Config], error) {
+	return c.setConfigWithOptions.CallUnary(ctx, req)
+}
+
 // CheckRepoExists calls v1.Backrest.CheckRepoExists.
 func (c *backrestClient) CheckRepoExists(ctx context.Context, req *connect.Request[v1.Repo]) (*connect.Response[types.BoolValue], error) {
 	return c.checkRepoExists.CallUnary(ctx, req)
@@ -335,6 +469,26 @@ func (c *backrestClient) Forget(ctx context.Context, req *connect.Request[v1.For
 	return c.forget.CallUnary(ctx, req)
 }
 
+// PreviewRetention calls v1.Backrest.PreviewRetention.
+func (c *backrestClient) PreviewRetention(ctx context.Context, req *connect.Request[v1.PreviewRetentionRequest]) (*connect.Response[v1.PreviewRetentionResponse], error) {
+	return c.previewRetention.CallUnary(ctx, req)
+}
+
+// AdoptSnapshots calls v1.Backrest.AdoptSnapshots.
+func (c *backrestClient) AdoptSnapshots(ctx context.Context, req *connect.Request[v1.AdoptSnapshotsRequest]) (*connect.Response[v1.AdoptSnapshotsResponse], error) {
+	return c.adoptSnapshots.CallUnary(ctx, req)
+}
+
+// HoldSnapshot calls v1.Backrest.HoldSnapshot.
+func (c *backrestClient) HoldSnapshot(ctx context.Context, req *connect.Request[v1.HoldSnapshotRequest]) (*connect.Response[emptypb.Empty], error) {
+	return c.holdSnapshot.CallUnary(ctx, req)
+}
+
+// ReleaseSnapshotHold calls v1.Backrest.ReleaseSnapshotHold.
+func (c *backrestClient) ReleaseSnapshotHold(ctx context.Context, req *connect.Request[v1.ReleaseSnapshotHoldRequest]) (*connect.Response[emptypb.Empty], error) {
+	return c.releaseSnapshotHold.CallUnary(ctx, req)
+}
+
 // Restore calls v1.Backrest.Restore.
 func (c *backrestClient) Restore(ctx context.Context, req *connect.Request[v1.RestoreSnapshotRequest]) (*connect.Response[emptypb.Empty], error) {
 	return c.restore.CallUnary(ctx, req)
@@ -375,10 +529,42 @@ func (c *backrestClient) GetSummaryDashboard(ctx context.Context, req *connect.R
 	return c.getSummaryDashboard.CallUnary(ctx, req)
 }
 
+// TestHook calls v1.Backrest.TestHook.
+func (c *backrestClient) TestHook(ctx context.Context, req *connect.Request[v1.TestHookRequest]) (*connect.Response[v1.TestHookResponse], error) {
+	return c.testHook.CallUnary(ctx, req)
+}
+
+// SearchOperations calls v1.Backrest.SearchOperations.
+func (c *backrestClient) SearchOperations(ctx context.Context, req *connect.Request[v1.SearchOperationsRequest]) (*connect.Response[v1.SearchOperationsResponse], error) {
+	return c.searchOperations.CallUnary(ctx, req)
+}
+
+// ExportOperations calls v1.Backrest.ExportOperations.
+func (c *backrestClient) ExportOperations(ctx context.Context, req *connect.Request[v1.ExportOperationsRequest]) (*connect.ServerStreamForClient[types.BytesValue], error) {
+	return c.exportOperations.CallServerStream(ctx, req)
+}
+
+// ImportOperations calls v1.Backrest.ImportOperations.
+func (c *backrestClient) ImportOperations(ctx context.Context, req *connect.Request[v1.ImportOperationsRequest]) (*connect.Response[v1.ImportOperationsResponse], error) {
+	return c.importOperations.CallUnary(ctx, req)
+}
+
+// GetLogStoreStats calls v1.Backrest.GetLogStoreStats.
+func (c *backrestClient) GetLogStoreStats(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[v1.LogStoreStats], error) {
+	return c.getLogStoreStats.CallUnary(ctx, req)
+}
+
+// GetBackupTimeline calls v1.Backrest.GetBackupTimeline.
+func (c *backrestClient) GetBackupTimeline(ctx context.Context, req *connect.Request[v1.GetBackupTimelineRequest]) (*connect.Response[v1.GetBackupTimelineResponse], error) {
+	return c.getBackupTimeline.CallUnary(ctx, req)
+}
+
 // BackrestHandler is an implementation of the v1.Backrest service.
 type BackrestHandler interface {
 	GetConfig(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.Config], error)
 	SetConfig(context.Context, *connect.Request[v1.Config]) (*connect.Response[v1.Config], error)
+	// SetConfigWithOptions sets the config like SetConfig, the options allow changes that SetConfig rejects e.g. retention policies removing many snapshots.
+	SetConfigWithOptions(context.Context, *connect.Request[v1.SetConfigRequest]) (*connect.Response[v1.Config], error)
 	CheckRepoExists(context.Context, *connect.Request[v1.Repo]) (*connect.Response[types.BoolValue], error)
 	AddRepo(context.Context, *connect.Request[v1.Repo]) (*connect.Response[v1.Config], error)
 	RemoveRepo(context.Context, *connect.Request[types.StringValue]) (*connect.Response[v1.Config], error)
@@ -392,6 +578,14 @@ type BackrestHandler interface {
 	DoRepoTask(context.Context, *connect.Request[v1.DoRepoTaskRequest]) (*connect.Response[emptypb.Empty], error)
 	// Forget schedules a forget operation. It accepts a plan id and returns empty if the task is enqueued.
 	Forget(context.Context, *connect.Request[v1.ForgetRequest]) (*connect.Response[emptypb.Empty], error)
+	// PreviewRetention evaluates a candidate retention policy against a plan's snapshots without forgetting anything.
+	PreviewRetention(context.Context, *connect.Request[v1.PreviewRetentionRequest]) (*connect.Response[v1.PreviewRetentionResponse], error)
+	// AdoptSnapshots assigns snapshots created outside of backrest to a plan by tagging them and reindexing the repo.
+	AdoptSnapshots(context.Context, *connect.Request[v1.AdoptSnapshotsRequest]) (*connect.Response[v1.AdoptSnapshotsResponse], error)
+	// HoldSnapshot places a hold on a snapshot, exempting it from forget operations until the hold is released or expires.
+	HoldSnapshot(context.Context, *connect.Request[v1.HoldSnapshotRequest]) (*connect.Response[emptypb.Empty], error)
+	// ReleaseSnapshotHold removes the hold on a snapshot, making it eligible for forget operations again.
+	ReleaseSnapshotHold(context.Context, *connect.Request[v1.ReleaseSnapshotHoldRequest]) (*connect.Response[emptypb.Empty], error)
 	// Restore schedules a restore operation.
 	Restore(context.Context, *connect.Request[v1.RestoreSnapshotRequest]) (*connect.Response[emptypb.Empty], error)
 	// Cancel attempts to cancel a task with the given operation ID. Not guaranteed to succeed.
@@ -408,6 +602,18 @@ type BackrestHandler interface {
 	PathAutocomplete(context.Context, *connect.Request[types.StringValue]) (*connect.Response[types.StringList], error)
 	// GetSummaryDashboard returns data for the dashboard view.
 	GetSummaryDashboard(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.SummaryDashboardResponse], error)
+	// TestHook runs a hook immediately with example variables built from the latest relevant operation and returns the rendered payload and outcome.
+	TestHook(context.Context, *connect.Request[v1.TestHookRequest]) (*connect.Response[v1.TestHookResponse], error)
+	// SearchOperations returns operations whose display message, backup errors or logs match a full-text query, best matches first.
+	SearchOperations(context.Context, *connect.Request[v1.SearchOperationsRequest]) (*connect.Response[v1.SearchOperationsResponse], error)
+	// ExportOperations streams the selected operations as JSONL, or as a CSV summary with one row per operation.
+	ExportOperations(context.Context, *connect.Request[v1.ExportOperationsRequest], *connect.ServerStream[types.BytesValue]) error
+	// ImportOperations merges operations exported as JSONL into the log, assigning them new operation and flow IDs.
+	ImportOperations(context.Context, *connect.Request[v1.ImportOperationsRequest]) (*connect.Response[v1.ImportOperationsResponse], error)
+	// GetLogStoreStats reports the disk space used by operation logs, grouped by the type of the operation owning them.
+	GetLogStoreStats(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.LogStoreStats], error)
+	// GetBackupTimeline returns the progress timeline of a running or completed backup operation.
+	GetBackupTimeline(context.Context, *connect.Request[v1.GetBackupTimelineRequest]) (*connect.Response[v1.GetBackupTimelineResponse], error)
 }
 
 // NewBackrestHandler builds an HTTP handler from the service implementation. It returns the path on
@@ -429,6 +635,12 @@ func NewBackrestHandler(svc BackrestHandler, opts ...connect.HandlerOption) (str
 		connect.WithSchema(backrestMethods.ByName("SetConfig")),
 		connect.WithHandlerOptions(opts...),
 	)
+	backrestSetConfigWithOptionsHandler := connect.NewUnaryHandler(
+		BackrestSetConfigWithOptionsProcedure,
+		svc.SetConfigWithOptions,
+		connect.WithSchema(backrestMethods.ByName("SetConfigWithOptions")),
+		connect.WithHandlerOptions(opts...),
+	)
 	backrestCheckRepoExistsHandler := connect.NewUnaryHandler(
 		BackrestCheckRepoExistsProcedure,
 		svc.CheckRepoExists,
@@ -489,6 +701,30 @@ func NewBackrestHandler(svc BackrestHandler, opts ...connect.HandlerOption) (str
 		connect.WithSchema(backrestMethods.ByName("Forget")),
 		connect.WithHandlerOptions(opts...),
 	)
+	backrestPreviewRetentionHandler := connect.NewUnaryHandler(
+		BackrestPreviewRetentionProcedure,
+		svc.PreviewRetention,
+		connect.WithSchema(backrestMethods.ByName("PreviewRetention")),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestAdoptSnapshotsHandler := connect.NewUnaryHandler(
+		BackrestAdoptSnapshotsProcedure,
+		svc.AdoptSnapshots,
+		connect.WithSchema(backrestMethods.ByName("AdoptSnapshots")),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestHoldSnapshotHandler := connect.NewUnaryHandler(
+		BackrestHoldSnapshotProcedure,
+		svc.HoldSnapshot,
+		connect.WithSchema(backrestMethods.ByName("HoldSnapshot")),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestReleaseSnapshotHoldHandler := connect.NewUnaryHandler(
+		BackrestReleaseSnapshotHoldProcedure,
+		svc.ReleaseSnapshotHold,
+		connect.WithSchema(backrestMethods.ByName("ReleaseSnapshotHold")),
+		connect.WithHandlerOptions(opts...),
+	)
 	backrestRestoreHandler := connect.NewUnaryHandler(
 		BackrestRestoreProcedure,
 		svc.Restore,
@@ -537,12 +773,50 @@ func NewBackrestHandler(svc BackrestHandler, opts ...connect.HandlerOption) (str
 		connect.WithSchema(backrestMethods.ByName("GetSummaryDashboard")),
 		connect.WithHandlerOptions(opts...),
 	)
+	backrestTestHookHandler := connect.NewUnaryHandler(
+		BackrestTestHookProcedure,
+		svc.TestHook,
+		connect.WithSchema(backrestMethods.ByName("TestHook")),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestSearchOperationsHandler := connect.NewUnaryHandler(
+		BackrestSearchOperationsProcedure,
+		svc.SearchOperations,
+		connect.WithSchema(backrestMethods.ByName("SearchOperations")),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestExportOperationsHandler := connect.NewServerStreamHandler(
+		BackrestExportOperationsProcedure,
+		svc.ExportOperations,
+		connect.WithSchema(backrestMethods.ByName("ExportOperations")),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestImportOperationsHandler := connect.NewUnaryHandler(
+		BackrestImportOperationsProcedure,
+		svc.ImportOperations,
+		connect.WithSchema(backrestMethods.ByName("ImportOperations")),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestGetLogStoreStatsHandler := connect.NewUnaryHandler(
+		BackrestGetLogStoreStatsProcedure,
+		svc.GetLogStoreStats,
+		connect.WithSchema(backrestMethods.ByName("GetLogStoreStats")),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestGetBackupTimelineHandler := connect.NewUnaryHandler(
+		BackrestGetBackupTimelineProcedure,
+		svc.GetBackupTimeline,
+		connect.WithSchema(backrestMethods.ByName("GetBackupTimeline")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/v1.Backrest/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case BackrestGetConfigProcedure:
 			backrestGetConfigHandler.ServeHTTP(w, r)
 		case BackrestSetConfigProcedure:
 			backrestSetConfigHandler.ServeHTTP(w, r)
+		case BackrestSetConfigWithOptionsProcedure:
+			backrestSetConfigWithOptionsHandler.ServeHTTP(w, r)
 		case BackrestCheckRepoExistsProcedure:
 			backrestCheckRepoExistsHandler.ServeHTTP(w, r)
 		case BackrestAddRepoProcedure:
@@ -563,6 +837,14 @@ func NewBackrestHandler(svc BackrestHandler, opts ...connect.HandlerOption) (str
 			backrestDoRepoTaskHandler.ServeHTTP(w, r)
 		case BackrestForgetProcedure:
 			backrestForgetHandler.ServeHTTP(w, r)
+		case BackrestPreviewRetentionProcedure:
+			backrestPreviewRetentionHandler.ServeHTTP(w, r)
+		case BackrestAdoptSnapshotsProcedure:
+			backrestAdoptSnapshotsHandler.ServeHTTP(w, r)
+		case BackrestHoldSnapshotProcedure:
+			backrestHoldSnapshotHandler.ServeHTTP(w, r)
+		case BackrestReleaseSnapshotHoldProcedure:
+			backrestReleaseSnapshotHoldHandler.ServeHTTP(w, r)
 		case BackrestRestoreProcedure:
 			backrestRestoreHandler.ServeHTTP(w, r)
 		case BackrestCancelProcedure:
@@ -579,6 +861,18 @@ func NewBackrestHandler(svc BackrestHandler, opts ...connect.HandlerOption) (str
 			backrestPathAutocompleteHandler.ServeHTTP(w, r)
 		case BackrestGetSummaryDashboardProcedure:
 			backrestGetSummaryDashboardHandler.ServeHTTP(w, r)
+		case BackrestTestHookProcedure:
+			backrestTestHookHandler.ServeHTTP(w, r)
+		case BackrestSearchOperationsProcedure:
+			backrestSearchOperationsHandler.ServeHTTP(w, r)
+		case BackrestExportOperationsProcedure:
+			backrestExportOperationsHandler.ServeHTTP(w, r)
+		case BackrestImportOperationsProcedure:
+			backrestImportOperationsHandler.ServeHTTP(w, r)
+		case BackrestGetLogStoreStatsProcedure:
+			backrestGetLogStoreStatsHandler.ServeHTTP(w, r)
+		case BackrestGetBackupTimelineProcedure:
+			backrestGetBackupTimelineHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -596,6 +890,10 @@ func (UnimplementedBackrestHandler) SetConfig(context.Context, *connect.Request[
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.SetConfig is not implemented"))
 }
 
+func (UnimplementedBackrestHandler) SetConfigWithOptions(context.Context, *connect.Request[v1.SetConfigRequest]) (*connect.Response[v1.Config], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.SetConfigWithOptions is not implemented"))
+}
+
 func (UnimplementedBackrestHandler) CheckRepoExists(context.Context, *connect.Request[v1.Repo]) (*connect.Response[types.BoolValue], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.CheckRepoExists is not implemented"))
 }
@@ -636,6 +934,22 @@ func (UnimplementedBackrestHandler) Forget(context.Context, *connect.Request[v1.
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.Forget is not implemented"))
 }
 
+func (UnimplementedBackrestHandler) PreviewRetention(context.Context, *connect.Request[v1.PreviewRetentionRequest]) (*connect.Response[v1.PreviewRetentionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.PreviewRetention is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) AdoptSnapshots(context.Context, *connect.Request[v1.AdoptSnapshotsRequest]) (*connect.Response[v1.AdoptSnapshotsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.AdoptSnapshots is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) HoldSnapshot(context.Context, *connect.Request[v1.HoldSnapshotRequest]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.HoldSnapshot is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) ReleaseSnapshotHold(context.Context, *connect.Request[v1.ReleaseSnapshotHoldRequest]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.ReleaseSnapshotHold is not implemented"))
+}
+
 func (UnimplementedBackrestHandler) Restore(context.Context, *connect.Request[v1.RestoreSnapshotRequest]) (*connect.Response[emptypb.Empty], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.Restore is not implemented"))
 }
@@ -667,3 +981,27 @@ func (UnimplementedBackrestHandler) PathAutocomplete(context.Context, *connect.R
 func (UnimplementedBackrestHandler) GetSummaryDashboard(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.SummaryDashboardResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.GetSummaryDashboard is not implemented"))
 }
+
+func (UnimplementedBackrestHandler) TestHook(context.Context, *connect.Request[v1.TestHookRequest]) (*connect.Response[v1.TestHookResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.TestHook is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) SearchOperations(context.Context, *connect.Request[v1.SearchOperationsRequest]) (*connect.Response[v1.SearchOperationsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.SearchOperations is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) ExportOperations(context.Context, *connect.Request[v1.ExportOperationsRequest], *connect.ServerStream[types.BytesValue]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.ExportOperations is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) ImportOperations(context.Context, *connect.Request[v1.ImportOperationsRequest]) (*connect.Response[v1.ImportOperationsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.ImportOperations is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) GetLogStoreStats(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.LogStoreStats], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.GetLogStoreStats is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) GetBackupTimeline(context.Context, *connect.Request[v1.GetBackupTimelineRequest]) (*connect.Response[v1.GetBackupTimelineResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.GetBackupTimeline is not implemented"))
+}
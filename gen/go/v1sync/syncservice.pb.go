@@ -1427,6 +1427,7 @@ func (x *SyncStreamItem_SyncActionRequestOperations) GetHighModno() int64 {
 type SyncStreamItem_SyncActionReceiveOperations struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Event         *v1.OperationEvent     `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	TraceContext  map[string]string      `protobuf:"bytes,2,rep,name=trace_context,json=traceContext,proto3" json:"trace_context,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // W3C trace context of the span that forwarded the operations, if traced.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1468,6 +1469,13 @@ func (x *SyncStreamItem_SyncActionReceiveOperations) GetEvent() *v1.OperationEve
 	return nil
 }
 
+func (x *SyncStreamItem_SyncActionReceiveOperations) GetTraceContext() map[string]string {
+	if x != nil {
+		return x.TraceContext
+	}
+	return nil
+}
+
 type SyncStreamItem_SyncActionRequestLog struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	LogId         string                 `protobuf:"bytes,1,opt,name=log_id,json=logId,proto3" json:"log_id,omitempty"`
@@ -1734,7 +1742,7 @@ const file_v1sync_syncservice_proto_rawDesc = "" +
 	"\n" +
 	"public_key\x18\x01 \x01(\v2\r.v1.PublicKeyR\tpublicKey\x122\n" +
 	"\vinstance_id\x18\x02 \x01(\v2\x11.v1.SignedMessageR\n" +
-	"instanceId\"\xbd\x12\n" +
+	"instanceId\"\xea\x13\n" +
 	"\x0eSyncStreamItem\x12:\n" +
 	"\x0esigned_message\x18\x01 \x01(\v2\x11.v1.SignedMessageH\x00R\rsignedMessage\x12J\n" +
 	"\thandshake\x18\x03 \x01(\v2*.v1sync.SyncStreamItem.SyncActionHandshakeH\x00R\thandshake\x12J\n" +
@@ -1773,9 +1781,13 @@ const file_v1sync_syncservice_proto_rawDesc = "" +
 	"\x1bSyncActionRequestOperations\x12\x1b\n" +
 	"\thigh_opid\x18\x01 \x01(\x03R\bhighOpid\x12\x1d\n" +
 	"\n" +
-	"high_modno\x18\x02 \x01(\x03R\thighModno\x1aG\n" +
+	"high_modno\x18\x02 \x01(\x03R\thighModno\x1a\xf3\x01\n" +
 	"\x1bSyncActionReceiveOperations\x12(\n" +
-	"\x05event\x18\x01 \x01(\v2\x12.v1.OperationEventR\x05event\x1a-\n" +
+	"\x05event\x18\x01 \x01(\v2\x12.v1.OperationEventR\x05event\x12i\n" +
+	"\rtrace_context\x18\x02 \x03(\v2D.v1sync.SyncStreamItem.SyncActionReceiveOperations.TraceContextEntryR\ftraceContext\x1a?\n" +
+	"\x11TraceContextEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a-\n" +
 	"\x14SyncActionRequestLog\x12\x15\n" +
 	"\x06log_id\x18\x01 \x01(\tR\x05logId\x1a\xb9\x01\n" +
 	"\x18SyncActionReceiveLogData\x12\x15\n" +
@@ -1825,7 +1837,7 @@ func file_v1sync_syncservice_proto_rawDescGZIP() []byte {
 }
 
 var file_v1sync_syncservice_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_v1sync_syncservice_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
+var file_v1sync_syncservice_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
 var file_v1sync_syncservice_proto_goTypes = []any{
 	(ConnectionState)(0),                               // 0: v1sync.ConnectionState
 	(SyncStreamItem_RepoConnectionState)(0),            // 1: v1sync.SyncStreamItem.RepoConnectionState
@@ -1854,27 +1866,28 @@ var file_v1sync_syncservice_proto_goTypes = []any{
 	(*SyncStreamItem_SyncActionReceiveLogData)(nil),    // 24: v1sync.SyncStreamItem.SyncActionReceiveLogData
 	(*SyncStreamItem_SyncActionThrottle)(nil),          // 25: v1sync.SyncStreamItem.SyncActionThrottle
 	(*SyncStreamItem_SyncEstablishSharedSecret)(nil),   // 26: v1sync.SyncStreamItem.SyncEstablishSharedSecret
-	(*v1.SignedMessage)(nil),                           // 27: v1.SignedMessage
-	(*v1.Plan)(nil),                                    // 28: v1.Plan
-	(*v1.Repo)(nil),                                    // 29: v1.Repo
-	(*v1.PublicKey)(nil),                               // 30: v1.PublicKey
-	(*v1.OperationEvent)(nil),                          // 31: v1.OperationEvent
+	nil,                       // 27: v1sync.SyncStreamItem.SyncActionReceiveOperations.TraceContextEntry
+	(*v1.SignedMessage)(nil),  // 28: v1.SignedMessage
+	(*v1.Plan)(nil),           // 29: v1.Plan
+	(*v1.Repo)(nil),           // 30: v1.Repo
+	(*v1.PublicKey)(nil),      // 31: v1.PublicKey
+	(*v1.OperationEvent)(nil), // 32: v1.OperationEvent
 }
 var file_v1sync_syncservice_proto_depIdxs = []int32{
 	0,  // 0: v1sync.PeerState.state:type_name -> v1sync.ConnectionState
 	9,  // 1: v1sync.PeerState.known_plans:type_name -> v1sync.PlanMetadata
 	8,  // 2: v1sync.PeerState.known_repos:type_name -> v1sync.RepoMetadata
 	11, // 3: v1sync.PeerState.remote_config:type_name -> v1sync.RemoteConfig
-	27, // 4: v1sync.AuthenticateRequest.instance_id:type_name -> v1.SignedMessage
+	28, // 4: v1sync.AuthenticateRequest.instance_id:type_name -> v1.SignedMessage
 	9,  // 5: v1sync.SetAvailableResourcesRequest.repos:type_name -> v1sync.PlanMetadata
 	8,  // 6: v1sync.SetAvailableResourcesRequest.plans:type_name -> v1sync.RepoMetadata
-	28, // 7: v1sync.SetConfigRequest.plans:type_name -> v1.Plan
-	29, // 8: v1sync.SetConfigRequest.repos:type_name -> v1.Repo
-	29, // 9: v1sync.RemoteConfig.repos:type_name -> v1.Repo
-	28, // 10: v1sync.RemoteConfig.plans:type_name -> v1.Plan
-	30, // 11: v1sync.AuthorizationToken.public_key:type_name -> v1.PublicKey
-	27, // 12: v1sync.AuthorizationToken.instance_id:type_name -> v1.SignedMessage
-	27, // 13: v1sync.SyncStreamItem.signed_message:type_name -> v1.SignedMessage
+	29, // 7: v1sync.SetConfigRequest.plans:type_name -> v1.Plan
+	30, // 8: v1sync.SetConfigRequest.repos:type_name -> v1.Repo
+	30, // 9: v1sync.RemoteConfig.repos:type_name -> v1.Repo
+	29, // 10: v1sync.RemoteConfig.plans:type_name -> v1.Plan
+	31, // 11: v1sync.AuthorizationToken.public_key:type_name -> v1.PublicKey
+	28, // 12: v1sync.AuthorizationToken.instance_id:type_name -> v1.SignedMessage
+	28, // 13: v1sync.SyncStreamItem.signed_message:type_name -> v1.SignedMessage
 	14, // 14: v1sync.SyncStreamItem.handshake:type_name -> v1sync.SyncStreamItem.SyncActionHandshake
 	15, // 15: v1sync.SyncStreamItem.heartbeat:type_name -> v1sync.SyncStreamItem.SyncActionHeartbeat
 	21, // 16: v1sync.SyncStreamItem.request_operations:type_name -> v1sync.SyncStreamItem.SyncActionRequestOperations
@@ -1886,23 +1899,24 @@ var file_v1sync_syncservice_proto_depIdxs = []int32{
 	23, // 22: v1sync.SyncStreamItem.request_log:type_name -> v1sync.SyncStreamItem.SyncActionRequestLog
 	24, // 23: v1sync.SyncStreamItem.receive_log_data:type_name -> v1sync.SyncStreamItem.SyncActionReceiveLogData
 	25, // 24: v1sync.SyncStreamItem.throttle:type_name -> v1sync.SyncStreamItem.SyncActionThrottle
-	30, // 25: v1sync.SyncStreamItem.SyncActionHandshake.public_key:type_name -> v1.PublicKey
-	27, // 26: v1sync.SyncStreamItem.SyncActionHandshake.instance_id:type_name -> v1.SignedMessage
+	31, // 25: v1sync.SyncStreamItem.SyncActionHandshake.public_key:type_name -> v1.PublicKey
+	28, // 26: v1sync.SyncStreamItem.SyncActionHandshake.instance_id:type_name -> v1.SignedMessage
 	11, // 27: v1sync.SyncStreamItem.SyncActionReceiveConfig.config:type_name -> v1sync.RemoteConfig
-	29, // 28: v1sync.SyncStreamItem.SyncActionSetConfig.repos:type_name -> v1.Repo
-	28, // 29: v1sync.SyncStreamItem.SyncActionSetConfig.plans:type_name -> v1.Plan
+	30, // 28: v1sync.SyncStreamItem.SyncActionSetConfig.repos:type_name -> v1.Repo
+	29, // 29: v1sync.SyncStreamItem.SyncActionSetConfig.plans:type_name -> v1.Plan
 	8,  // 30: v1sync.SyncStreamItem.SyncActionReceiveResources.repos:type_name -> v1sync.RepoMetadata
 	9,  // 31: v1sync.SyncStreamItem.SyncActionReceiveResources.plans:type_name -> v1sync.PlanMetadata
-	31, // 32: v1sync.SyncStreamItem.SyncActionReceiveOperations.event:type_name -> v1.OperationEvent
-	13, // 33: v1sync.BackrestSyncService.Sync:input_type -> v1sync.SyncStreamItem
-	2,  // 34: v1sync.BackrestSyncStateService.GetPeerSyncStatesStream:input_type -> v1sync.SyncStateStreamRequest
-	13, // 35: v1sync.BackrestSyncService.Sync:output_type -> v1sync.SyncStreamItem
-	3,  // 36: v1sync.BackrestSyncStateService.GetPeerSyncStatesStream:output_type -> v1sync.PeerState
-	35, // [35:37] is the sub-list for method output_type
-	33, // [33:35] is the sub-list for method input_type
-	33, // [33:33] is the sub-list for extension type_name
-	33, // [33:33] is the sub-list for extension extendee
-	0,  // [0:33] is the sub-list for field type_name
+	32, // 32: v1sync.SyncStreamItem.SyncActionReceiveOperations.event:type_name -> v1.OperationEvent
+	27, // 33: v1sync.SyncStreamItem.SyncActionReceiveOperations.trace_context:type_name -> v1sync.SyncStreamItem.SyncActionReceiveOperations.TraceContextEntry
+	13, // 34: v1sync.BackrestSyncService.Sync:input_type -> v1sync.SyncStreamItem
+	2,  // 35: v1sync.BackrestSyncStateService.GetPeerSyncStatesStream:input_type -> v1sync.SyncStateStreamRequest
+	13, // 36: v1sync.BackrestSyncService.Sync:output_type -> v1sync.SyncStreamItem
+	3,  // 37: v1sync.BackrestSyncStateService.GetPeerSyncStatesStream:output_type -> v1sync.PeerState
+	36, // [36:38] is the sub-list for method output_type
+	34, // [34:36] is the sub-list for method input_type
+	34, // [34:34] is the sub-list for extension type_name
+	34, // [34:34] is the sub-list for extension extendee
+	0,  // [0:34] is the sub-list for field type_name
 }
 
 func init() { file_v1sync_syncservice_proto_init() }
@@ -1930,7 +1944,7 @@ func file_v1sync_syncservice_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_v1sync_syncservice_proto_rawDesc), len(file_v1sync_syncservice_proto_rawDesc)),
 			NumEnums:      2,
-			NumMessages:   25,
+			NumMessages:   26,
 			NumExtensions: 0,
 			NumServices:   2,
 		},
@@ -1,7 +1,9 @@
 package api
 
 import (
+	"bufio"
 	"bytes"
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
@@ -21,13 +23,17 @@ import (
 	"github.com/garethgeorge/backrest/internal/config"
 	"github.com/garethgeorge/backrest/internal/cryptoutil"
 	"github.com/garethgeorge/backrest/internal/env"
+	hooktypes "github.com/garethgeorge/backrest/internal/hook/types"
+	"github.com/garethgeorge/backrest/internal/ioutil"
 	"github.com/garethgeorge/backrest/internal/logstore"
 	"github.com/garethgeorge/backrest/internal/oplog"
 	"github.com/garethgeorge/backrest/internal/orchestrator"
+	"github.com/garethgeorge/backrest/internal/orchestrator/logging"
 	"github.com/garethgeorge/backrest/internal/orchestrator/repo"
 	"github.com/garethgeorge/backrest/internal/orchestrator/tasks"
 	"github.com/garethgeorge/backrest/internal/protoutil"
 	"github.com/garethgeorge/backrest/internal/resticinstaller"
+	"github.com/garethgeorge/backrest/internal/search"
 	"github.com/garethgeorge/backrest/pkg/restic"
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/proto"
@@ -40,18 +46,24 @@ type BackrestHandler struct {
 	orchestrator     *orchestrator.Orchestrator
 	oplog            *oplog.OpLog
 	logStore         *logstore.LogStore
+	searchIndex      *search.Index
 	peerStateManager syncapi.PeerStateManager
+
+	retentionConfirmThreshold int // retention changes removing more snapshots than this must be confirmed, 0 disables the check.
 }
 
 var _ v1connect.BackrestHandler = &BackrestHandler{}
 
-func NewBackrestHandler(config config.ConfigStore, peerStateManager syncapi.PeerStateManager, orchestrator *orchestrator.Orchestrator, oplog *oplog.OpLog, logStore *logstore.LogStore) *BackrestHandler {
+func NewBackrestHandler(config config.ConfigStore, peerStateManager syncapi.PeerStateManager, orchestrator *orchestrator.Orchestrator, oplog *oplog.OpLog, logStore *logstore.LogStore, searchIndex *search.Index) *BackrestHandler {
 	s := &BackrestHandler{
 		config:           config,
 		orchestrator:     orchestrator,
 		oplog:            oplog,
 		logStore:         logStore,
+		searchIndex:      searchIndex,
 		peerStateManager: peerStateManager,
+
+		retentionConfirmThreshold: env.RetentionConfirmThreshold(),
 	}
 
 	return s
@@ -68,23 +80,40 @@ func (s *BackrestHandler) GetConfig(ctx context.Context, req *connect.Request[em
 
 // SetConfig implements POST /v1/config
 func (s *BackrestHandler) SetConfig(ctx context.Context, req *connect.Request[v1.Config]) (*connect.Response[v1.Config], error) {
+	return s.setConfig(ctx, &v1.SetConfigRequest{Config: req.Msg})
+}
+
+func (s *BackrestHandler) SetConfigWithOptions(ctx context.Context, req *connect.Request[v1.SetConfigRequest]) (*connect.Response[v1.Config], error) {
+	if req.Msg.Config == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("config is required"))
+	}
+	return s.setConfig(ctx, req.Msg)
+}
+
+func (s *BackrestHandler) setConfig(ctx context.Context, req *v1.SetConfigRequest) (*connect.Response[v1.Config], error) {
 	existing, err := s.config.Get()
 	if err != nil {
 		return nil, fmt.Errorf("failed to check current config: %w", err)
 	}
 
 	// Compare and increment modno
-	if existing.Modno != req.Msg.Modno {
+	if existing.Modno != req.Config.Modno {
 		return nil, errors.New("config modno mismatch, reload and try again")
 	}
 
 	// Rehydrate the network sanitized config
-	rehydratedConfig := config.RehydrateNetworkSanitizedConfig(req.Msg, existing)
+	rehydratedConfig := config.RehydrateNetworkSanitizedConfig(req.Config, existing)
 
 	if err := config.ValidateConfig(rehydratedConfig); err != nil {
 		return nil, fmt.Errorf("validation error: %w", err)
 	}
 
+	if s.retentionConfirmThreshold > 0 && !req.ConfirmRetention {
+		if err := s.checkRetentionChanges(ctx, existing, rehydratedConfig, s.retentionConfirmThreshold); err != nil {
+			return nil, err
+		}
+	}
+
 	rehydratedConfig.Modno++
 
 	if err := s.config.Update(rehydratedConfig); err != nil {
@@ -98,6 +127,37 @@ func (s *BackrestHandler) SetConfig(ctx context.Context, req *connect.Request[v1
 	return connect.NewResponse(newConfig), nil
 }
 
+// checkRetentionChanges previews the retention policy of each existing plan whose policy changed and returns
+// a FailedPrecondition error if the new policy would remove more than threshold snapshots.
+func (s *BackrestHandler) checkRetentionChanges(ctx context.Context, existing, updated *v1.Config, threshold int) error {
+	for _, plan := range updated.Plans {
+		idx := slices.IndexFunc(existing.Plans, func(p *v1.Plan) bool { return p.Id == plan.Id })
+		if idx == -1 || plan.Retention.GetPolicy() == nil || proto.Equal(existing.Plans[idx].Retention, plan.Retention) {
+			continue
+		}
+
+		r, err := s.orchestrator.GetRepoOrchestrator(plan.Repo)
+		if err != nil {
+			continue // the repo is new, there are no snapshots to remove.
+		}
+
+		decisions, err := r.PreviewForget(ctx, plan.Retention, []string{repo.TagForPlan(plan.Id), repo.TagForInstance(updated.Instance)})
+		if err != nil {
+			return fmt.Errorf("failed to preview retention for plan %q: %w", plan.Id, err)
+		}
+		removed := 0
+		for _, d := range decisions {
+			if !d.Keep {
+				removed++
+			}
+		}
+		if removed > threshold {
+			return connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("new retention policy for plan %q would remove %d snapshots, confirm the change to apply it", plan.Id, removed))
+		}
+	}
+	return nil
+}
+
 func (s *BackrestHandler) CheckRepoExists(ctx context.Context, req *connect.Request[v1.Repo]) (*connect.Response[types.BoolValue], error) {
 	c, err := s.config.Get()
 	if err != nil {
@@ -484,7 +544,7 @@ func (s *BackrestHandler) Forget(ctx context.Context, req *connect.Request[v1.Fo
 	at := time.Now()
 	var err error
 
-	repo, err := s.orchestrator.GetRepo(req.Msg.RepoId)
+	repoCfg, err := s.orchestrator.GetRepo(req.Msg.RepoId)
 	if err != nil {
 		return nil, err
 	}
@@ -492,7 +552,7 @@ func (s *BackrestHandler) Forget(ctx context.Context, req *connect.Request[v1.Fo
 	if req.Msg.SnapshotId != "" && req.Msg.PlanId != "" && req.Msg.RepoId != "" {
 		wait := make(chan struct{})
 		if err := s.orchestrator.ScheduleTask(
-			tasks.NewOneoffForgetSnapshotTask(repo, req.Msg.PlanId, 0, at, req.Msg.SnapshotId),
+			tasks.NewOneoffForgetSnapshotTask(repoCfg, req.Msg.PlanId, 0, at, req.Msg.SnapshotId),
 			tasks.TaskPriorityInteractive+tasks.TaskPriorityForget, func(e error) {
 				err = e
 				close(wait)
@@ -503,7 +563,7 @@ func (s *BackrestHandler) Forget(ctx context.Context, req *connect.Request[v1.Fo
 	} else if req.Msg.RepoId != "" && req.Msg.PlanId != "" {
 		wait := make(chan struct{})
 		if err := s.orchestrator.ScheduleTask(
-			tasks.NewOneoffForgetTask(repo, req.Msg.PlanId, 0, at),
+			tasks.NewOneoffForgetTask(repoCfg, req.Msg.PlanId, 0, at),
 			tasks.TaskPriorityInteractive+tasks.TaskPriorityForget, func(e error) {
 				err = e
 				close(wait)
@@ -514,12 +574,206 @@ func (s *BackrestHandler) Forget(ctx context.Context, req *connect.Request[v1.Fo
 	} else {
 		return nil, errors.New("must specify repoId and planId and (optionally) snapshotId")
 	}
-	if err != nil {
+	if errors.Is(err, repo.ErrSnapshotHeld) {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, err)
+	} else if err != nil {
 		return nil, err
 	}
 	return connect.NewResponse(&emptypb.Empty{}), nil
 }
 
+func (s *BackrestHandler) PreviewRetention(ctx context.Context, req *connect.Request[v1.PreviewRetentionRequest]) (*connect.Response[v1.PreviewRetentionResponse], error) {
+	plan, err := s.orchestrator.GetPlan(req.Msg.PlanId)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("plan %q not found", req.Msg.PlanId))
+	}
+
+	policy := req.Msg.Policy
+	if policy == nil {
+		policy = plan.Retention
+	}
+	if policy.GetPolicy() == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("retention policy must specify a policy"))
+	}
+
+	r, err := s.orchestrator.GetRepoOrchestrator(plan.Repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo %q: %w", plan.Repo, err)
+	}
+
+	config, err := s.config.Get()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config: %w", err)
+	}
+
+	decisions, err := r.PreviewForget(ctx, policy, []string{repo.TagForPlan(plan.Id), repo.TagForInstance(config.Instance)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to preview retention for plan %q: %w", plan.Id, err)
+	}
+
+	return connect.NewResponse(&v1.PreviewRetentionResponse{
+		Decisions: decisions,
+	}), nil
+}
+
+func (s *BackrestHandler) AdoptSnapshots(ctx context.Context, req *connect.Request[v1.AdoptSnapshotsRequest]) (*connect.Response[v1.AdoptSnapshotsResponse], error) {
+	if len(req.Msg.Hosts) == 0 && len(req.Msg.Paths) == 0 && len(req.Msg.Tags) == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("must select snapshots by at least one of hosts, paths or tags"))
+	}
+
+	plan, err := s.orchestrator.GetPlan(req.Msg.PlanId)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("plan %q not found", req.Msg.PlanId))
+	}
+	repoCfg, err := s.orchestrator.GetRepo(plan.Repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo %q: %w", plan.Repo, err)
+	}
+	r, err := s.orchestrator.GetRepoOrchestrator(plan.Repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo %q: %w", plan.Repo, err)
+	}
+	config, err := s.config.Get()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config: %w", err)
+	}
+
+	snapshots, err := r.SnapshotsMatching(ctx, req.Msg.Hosts, req.Msg.Paths, req.Msg.Tags)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+
+	// Only snapshots that aren't already managed by backrest are adopted, snapshots owned by other plans or instances are left alone.
+	var adopted []*v1.ResticSnapshot
+	var ids []string
+	originals := make(map[string]struct{})
+	for _, snapshot := range snapshots {
+		if repo.PlanFromTags(snapshot.Tags) != "" || repo.InstanceIDFromTags(snapshot.Tags) != "" {
+			continue
+		}
+		adopted = append(adopted, protoutil.SnapshotToProto(snapshot))
+		ids = append(ids, snapshot.Id)
+		if snapshot.Original != "" {
+			originals[snapshot.Original] = struct{}{}
+		} else {
+			originals[snapshot.Id] = struct{}{}
+		}
+	}
+
+	if req.Msg.DryRun || len(ids) == 0 {
+		return connect.NewResponse(&v1.AdoptSnapshotsResponse{Snapshots: adopted}), nil
+	}
+
+	planTags := []string{repo.TagForPlan(plan.Id), repo.TagForInstance(config.Instance)}
+	if err := r.AddTags(ctx, ids, planTags); err != nil {
+		return nil, fmt.Errorf("failed to tag snapshots for plan %q: %w", plan.Id, err)
+	}
+
+	// Tagging rewrites the snapshots under new IDs, list them again to return the adopted snapshots as they are now.
+	tagged, err := r.SnapshotsMatching(ctx, req.Msg.Hosts, req.Msg.Paths, append(slices.Clone(req.Msg.Tags), planTags...))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list adopted snapshots: %w", err)
+	}
+	adopted = nil
+	for _, snapshot := range tagged {
+		if _, ok := originals[snapshot.Original]; ok {
+			adopted = append(adopted, protoutil.SnapshotToProto(snapshot))
+		}
+	}
+
+	if err := s.orchestrator.ScheduleTask(tasks.NewOneoffIndexSnapshotsTask(repoCfg, time.Now()), tasks.TaskPriorityInteractive+tasks.TaskPriorityIndexSnapshots); err != nil {
+		return nil, fmt.Errorf("failed to schedule indexing task: %w", err)
+	}
+
+	return connect.NewResponse(&v1.AdoptSnapshotsResponse{Snapshots: adopted}), nil
+}
+
+func (s *BackrestHandler) HoldSnapshot(ctx context.Context, req *connect.Request[v1.HoldSnapshotRequest]) (*connect.Response[emptypb.Empty], error) {
+	if req.Msg.RepoId == "" || req.Msg.SnapshotId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("must specify repoId and snapshotId"))
+	}
+
+	var until time.Time
+	if req.Msg.ExpireUnixMs != 0 {
+		until = time.UnixMilli(req.Msg.ExpireUnixMs)
+		if until.Before(time.Now()) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("hold expiry must be in the future"))
+		}
+	}
+
+	repoCfg, err := s.orchestrator.GetRepo(req.Msg.RepoId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo %q: %w", req.Msg.RepoId, err)
+	}
+	r, err := s.orchestrator.GetRepoOrchestrator(req.Msg.RepoId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo %q: %w", req.Msg.RepoId, err)
+	}
+
+	snapshot, err := r.Snapshot(ctx, req.Msg.SnapshotId)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+	if _, ok := repo.HoldFromTags(snapshot.Tags); ok {
+		// restic assigns a new ID to a snapshot when its tags change, replacing a hold in place would leave the caller with a stale ID.
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("snapshot %q already has a hold, release it before placing a new one", req.Msg.SnapshotId))
+	}
+
+	holdTags := []string{repo.TagForHold(until)}
+	if req.Msg.Reason != "" {
+		holdTags = append(holdTags, repo.TagForHoldReason(req.Msg.Reason))
+	}
+	if err := r.AddTags(ctx, []string{snapshot.Id}, holdTags); err != nil {
+		return nil, fmt.Errorf("failed to add hold to snapshot %q: %w", snapshot.Id, err)
+	}
+
+	if err := s.orchestrator.ScheduleTask(tasks.NewOneoffIndexSnapshotsTask(repoCfg, time.Now()), tasks.TaskPriorityInteractive+tasks.TaskPriorityIndexSnapshots); err != nil {
+		return nil, fmt.Errorf("failed to schedule indexing task: %w", err)
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *BackrestHandler) ReleaseSnapshotHold(ctx context.Context, req *connect.Request[v1.ReleaseSnapshotHoldRequest]) (*connect.Response[emptypb.Empty], error) {
+	if req.Msg.RepoId == "" || req.Msg.SnapshotId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("must specify repoId and snapshotId"))
+	}
+
+	repoCfg, err := s.orchestrator.GetRepo(req.Msg.RepoId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo %q: %w", req.Msg.RepoId, err)
+	}
+	r, err := s.orchestrator.GetRepoOrchestrator(req.Msg.RepoId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo %q: %w", req.Msg.RepoId, err)
+	}
+
+	snapshot, err := r.Snapshot(ctx, req.Msg.SnapshotId)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+
+	var holdTags []string
+	for _, tag := range snapshot.Tags {
+		if repo.IsHoldTag(tag) {
+			holdTags = append(holdTags, tag)
+		}
+	}
+	if len(holdTags) == 0 {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+
+	if err := r.RemoveTags(ctx, []string{snapshot.Id}, holdTags); err != nil {
+		return nil, fmt.Errorf("failed to release hold on snapshot %q: %w", snapshot.Id, err)
+	}
+
+	if err := s.orchestrator.ScheduleTask(tasks.NewOneoffIndexSnapshotsTask(repoCfg, time.Now()), tasks.TaskPriorityInteractive+tasks.TaskPriorityIndexSnapshots); err != nil {
+		return nil, fmt.Errorf("failed to schedule indexing task: %w", err)
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
 func (s BackrestHandler) DoRepoTask(ctx context.Context, req *connect.Request[v1.DoRepoTaskRequest]) (*connect.Response[emptypb.Empty], error) {
 	var task tasks.Task
 
@@ -538,6 +792,12 @@ func (s BackrestHandler) DoRepoTask(ctx context.Context, req *connect.Request[v1
 	case v1.DoRepoTaskRequest_TASK_STATS:
 		task = tasks.NewStatsTask(repo, tasks.PlanForSystemTasks, true)
 		priority |= tasks.TaskPriorityStats
+	case v1.DoRepoTaskRequest_TASK_FORGET:
+		if repo.GetRetentionPolicy().GetRetention() == nil {
+			return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("repo %q has no retention policy", req.Msg.RepoId))
+		}
+		task = tasks.NewRepoForgetTask(repo, tasks.PlanForSystemTasks, true)
+		priority |= tasks.TaskPriorityForget
 	case v1.DoRepoTaskRequest_TASK_INDEX_SNAPSHOTS:
 		task = tasks.NewOneoffIndexSnapshotsTask(repo, time.Now())
 		priority |= tasks.TaskPriorityIndexSnapshots
@@ -794,6 +1054,300 @@ func (s *BackrestHandler) PathAutocomplete(ctx context.Context, path *connect.Re
 	return connect.NewResponse(&types.StringList{Values: paths}), nil
 }
 
+// testHookTimeout bounds hooks run by TestHook that do not set their own timeout.
+const testHookTimeout = 2 * time.Minute
+
+func (s *BackrestHandler) TestHook(ctx context.Context, req *connect.Request[v1.TestHookRequest]) (*connect.Response[v1.TestHookResponse], error) {
+	if req.Msg.Hook == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("hook is required"))
+	}
+	if req.Msg.Condition == v1.Hook_CONDITION_UNKNOWN {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("condition is required"))
+	}
+
+	cfg, err := s.config.Get()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config: %w", err)
+	}
+	repo := config.FindRepo(cfg, req.Msg.RepoId)
+	if repo == nil {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("repo %q not found", req.Msg.RepoId))
+	}
+	plan := &v1.Plan{Id: req.Msg.PlanId} // conveys only the ID when no plan is selected, as for hooks of system tasks.
+	if req.Msg.PlanId != "" {
+		if plan = config.FindPlan(cfg, req.Msg.PlanId); plan == nil {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("plan %q not found", req.Msg.PlanId))
+		}
+	}
+
+	// build the example variables from the latest finished operation of the kind that fires the condition,
+	// falling back to the latest finished operation of any kind.
+	var example, fallback *v1.Operation
+	q := oplog.Query{}.
+		SetInstanceID(cfg.Instance).
+		SetRepoGUID(repo.GetGuid()).
+		SetLimit(1000).
+		SetReversed(true)
+	if req.Msg.PlanId != "" {
+		q = q.SetPlanID(req.Msg.PlanId)
+	}
+	if err := s.oplog.Query(q, func(op *v1.Operation) error {
+		if op.Status == v1.OperationStatus_STATUS_PENDING || op.Status == v1.OperationStatus_STATUS_INPROGRESS {
+			return nil
+		}
+		if fallback == nil {
+			fallback = op
+		}
+		if tasks.OperationMatchesCondition(op, req.Msg.Condition) {
+			example = op
+			return oplog.ErrStopIteration
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to query operations: %w", err)
+	}
+	if example == nil {
+		example = fallback
+	}
+
+	var vars tasks.HookVars
+	if example != nil {
+		vars = tasks.HookVarsForOperation(example)
+	}
+	vars.Task = "test hook"
+	vars.Event = req.Msg.Condition
+	vars.Repo = repo
+	vars.Plan = plan
+	vars.CurTime = time.Now()
+	if vars.Error == "" && protoutil.IsErrorCondition(req.Msg.Condition) {
+		vars.Error = "example error, this hook is being tested"
+	}
+
+	resp := &v1.TestHookResponse{ExampleOperationId: example.GetId()}
+	payload, err := hooktypes.RenderPayload(req.Msg.Hook, vars)
+	if err != nil {
+		resp.Error = fmt.Sprintf("template rendering: %v", err)
+		return connect.NewResponse(resp), nil
+	}
+	resp.Payload = payload
+
+	var output bytes.Buffer
+	hookCtx := logging.ContextWithWriter(ctx, &ioutil.SynchronizedWriter{W: &output})
+	if req.Msg.Hook.GetTimeoutSeconds() <= 0 {
+		var cancel context.CancelFunc
+		hookCtx, cancel = context.WithTimeout(hookCtx, testHookTimeout)
+		defer cancel()
+	}
+	if err := s.orchestrator.ExecuteHookNow(hookCtx, repo, req.Msg.PlanId, req.Msg.Hook, vars); err != nil {
+		resp.Error = err.Error()
+	}
+	resp.Output = output.String()
+
+	return connect.NewResponse(resp), nil
+}
+
+// defaultSearchLimit is the number of results returned by SearchOperations when the request sets no limit.
+const defaultSearchLimit = 100
+
+func (s *BackrestHandler) SearchOperations(ctx context.Context, req *connect.Request[v1.SearchOperationsRequest]) (*connect.Response[v1.SearchOperationsResponse], error) {
+	if s.searchIndex == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, errors.New("search is not enabled"))
+	}
+
+	var q oplog.Query
+	if req.Msg.Selector != nil {
+		var err error
+		q, err = protoutil.OpSelectorToQuery(req.Msg.Selector)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+	}
+	limit := int(req.Msg.Limit)
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+
+	resp := &v1.SearchOperationsResponse{}
+	err := s.searchIndex.Search(req.Msg.Query, search.SearchOptions{
+		RawQuery:    req.Msg.RawQuery,
+		IncludeLogs: req.Msg.IncludeLogs,
+	}, func(r search.Result) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		op, err := s.oplog.Get(r.OpID)
+		if err != nil {
+			if errors.Is(err, oplog.ErrNotExist) {
+				return nil // deleted since it was indexed.
+			}
+			return fmt.Errorf("get operation %d: %w", r.OpID, err)
+		}
+		if !q.Match(op) {
+			return nil
+		}
+
+		result := &v1.SearchOperationsResult{
+			Operation: op,
+			Field:     r.Field,
+		}
+		for _, seg := range r.Snippet {
+			result.Snippet = append(result.Snippet, &v1.SearchSnippetSegment{Text: seg.Text, Highlight: seg.Highlight})
+		}
+		resp.Results = append(resp.Results, result)
+		if len(resp.Results) >= limit {
+			return oplog.ErrStopIteration
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, search.ErrInvalidQuery) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		return nil, fmt.Errorf("search operations: %w", err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// exportChunkBytes is the size of the chunks ExportOperations streams the export in.
+const exportChunkBytes = 64 * 1024
+
+func (s *BackrestHandler) ExportOperations(ctx context.Context, req *connect.Request[v1.ExportOperationsRequest], resp *connect.ServerStream[types.BytesValue]) error {
+	var q oplog.Query
+	if req.Msg.Selector != nil {
+		var err error
+		q, err = protoutil.OpSelectorToQuery(req.Msg.Selector)
+		if err != nil {
+			return connect.NewError(connect.CodeInvalidArgument, err)
+		}
+	}
+
+	w := bufio.NewWriterSize(streamWriter{resp}, exportChunkBytes)
+	var err error
+	switch req.Msg.Format {
+	case v1.ExportOperationsRequest_FORMAT_JSONL:
+		err = s.oplog.ExportJSONL(q, w)
+	case v1.ExportOperationsRequest_FORMAT_CSV:
+		err = s.oplog.ExportCSV(q, w)
+	default:
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("unknown export format %v", req.Msg.Format))
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	if err != nil {
+		return fmt.Errorf("export operations: %w", err)
+	}
+	return nil
+}
+
+// streamWriter sends each write to the stream as a message.
+type streamWriter struct {
+	stream *connect.ServerStream[types.BytesValue]
+}
+
+func (w streamWriter) Write(p []byte) (int, error) {
+	if err := w.stream.Send(&types.BytesValue{Value: bytes.Clone(p)}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (s *BackrestHandler) ImportOperations(ctx context.Context, req *connect.Request[v1.ImportOperationsRequest]) (*connect.Response[v1.ImportOperationsResponse], error) {
+	stats, err := s.oplog.ImportJSONL(bytes.NewReader(req.Msg.Data))
+	if err != nil {
+		// operations before the failing line are kept, importing again skips them.
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("import operations (%d imported before the error): %w", stats.Imported, err))
+	}
+	zap.S().Infof("imported %d operations, skipped %d", stats.Imported, stats.Skipped)
+	return connect.NewResponse(&v1.ImportOperationsResponse{
+		Imported: stats.Imported,
+		Skipped:  stats.Skipped,
+	}), nil
+}
+
+func (s *BackrestHandler) GetLogStoreStats(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[v1.LogStoreStats], error) {
+	stats, err := s.logStore.Stats()
+	if err != nil {
+		return nil, fmt.Errorf("get log store stats: %w", err)
+	}
+
+	// resolve the type of each log's owning operation, logs of removed operations are reported as unknown.
+	opTypes := make(map[int64]v1.OperationType, len(stats.Owners))
+	ownerIDs := make([]int64, 0, len(stats.Owners))
+	for _, owner := range stats.Owners {
+		if owner.OwnerOpID != 0 {
+			ownerIDs = append(ownerIDs, owner.OwnerOpID)
+		}
+	}
+	for _, batch := range ioutil.Batchify(ownerIDs, ioutil.DefaultBatchSize) {
+		if err := s.oplog.Query(oplog.Query{}.SetOpIDs(batch), func(op *v1.Operation) error {
+			opTypes[op.Id] = oplog.OperationTypeOf(op)
+			return nil
+		}); err != nil {
+			return nil, fmt.Errorf("query log owners: %w", err)
+		}
+	}
+
+	byType := make(map[v1.OperationType]*v1.LogStoreStats_OperationTypeUsage)
+	for _, owner := range stats.Owners {
+		opType := opTypes[owner.OwnerOpID]
+		usage, ok := byType[opType]
+		if !ok {
+			usage = &v1.LogStoreStats_OperationTypeUsage{OpType: opType}
+			byType[opType] = usage
+		}
+		usage.LogCount += owner.LogCount
+		usage.TotalBytes += owner.Bytes
+	}
+
+	resp := &v1.LogStoreStats{
+		LogCount:        stats.LogCount,
+		TotalBytes:      stats.TotalBytes,
+		InprogressCount: stats.InprogressCount,
+		InprogressBytes: stats.InprogressBytes,
+		QuotaBytes:      stats.QuotaBytes,
+	}
+	for _, usage := range byType {
+		resp.ByOperationType = append(resp.ByOperationType, usage)
+	}
+	slices.SortFunc(resp.ByOperationType, func(a, b *v1.LogStoreStats_OperationTypeUsage) int {
+		return cmp.Or(cmp.Compare(b.TotalBytes, a.TotalBytes), cmp.Compare(a.OpType, b.OpType))
+	})
+	return connect.NewResponse(resp), nil
+}
+
+func (s *BackrestHandler) GetBackupTimeline(ctx context.Context, req *connect.Request[v1.GetBackupTimelineRequest]) (*connect.Response[v1.GetBackupTimelineResponse], error) {
+	op, err := s.oplog.Get(req.Msg.OpId)
+	if err != nil {
+		if errors.Is(err, oplog.ErrNotExist) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("operation %d not found", req.Msg.OpId))
+		}
+		return nil, fmt.Errorf("get operation %d: %w", req.Msg.OpId, err)
+	}
+	backupOp := op.GetOperationBackup()
+	if backupOp == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("operation %d is not a backup", op.Id))
+	}
+	if backupOp.TimelineLogref == "" {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("no timeline was recorded for backup %d", op.Id))
+	}
+
+	// Tail doesn't block on a running backup's timeline, it returns the points recorded so far.
+	data, err := s.logStore.Tail(backupOp.TimelineLogref, tasks.MaxBackupTimelineBytes)
+	if err != nil {
+		if errors.Is(err, logstore.ErrLogNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("timeline of backup %d was removed", op.Id))
+		}
+		return nil, fmt.Errorf("read timeline of backup %d: %w", op.Id, err)
+	}
+	resp, err := tasks.ReadBackupTimeline(data, int(req.Msg.MaxPoints))
+	if err != nil {
+		return nil, fmt.Errorf("read timeline of backup %d: %w", op.Id, err)
+	}
+	resp.Complete = op.Status != v1.OperationStatus_STATUS_PENDING && op.Status != v1.OperationStatus_STATUS_INPROGRESS
+	return connect.NewResponse(resp), nil
+}
+
 func (s *BackrestHandler) GetSummaryDashboard(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[v1.SummaryDashboardResponse], error) {
 	config, err := s.config.Get()
 	if err != nil {
@@ -810,34 +1364,43 @@ func (s *BackrestHandler) GetSummaryDashboard(ctx context.Context, req *connect.
 		var nextBackupTime int64
 		backupChart := &v1.SummaryDashboardResponse_BackupChart{}
 
-		s.oplog.Query(q, func(op *v1.Operation) error {
-			t := time.UnixMilli(op.UnixTimeStartMs)
+		// only backups started in the last 30 days, pending backups are scheduled in the future and are included.
+		q = q.SetOpTypes(v1.OperationType_OPERATION_TYPE_BACKUP).
+			SetStartTimeGteMs(time.Now().Add(-30 * 24 * time.Hour).UnixMilli())
 
+		s.oplog.Query(q, func(op *v1.Operation) error {
 			if backupOp := op.GetOperationBackup(); backupOp != nil {
-				if time.Since(t) > 30*24*time.Hour {
-					return oplog.ErrStopIteration
-				} else if op.GetStatus() == v1.OperationStatus_STATUS_PENDING {
+				if op.GetStatus() == v1.OperationStatus_STATUS_PENDING {
 					nextBackupTime = op.UnixTimeStartMs
 					return nil
 				}
-				backupsExamined++
+
+				// a rollup counts as the backups compacted into it, with their totals.
+				count := int64(1)
+				duration := op.UnixTimeEndMs - op.UnixTimeStartMs
+				bytesScanned := backupOp.GetLastStatus().GetSummary().GetTotalBytesProcessed()
+				bytesAdded := backupOp.GetLastStatus().GetSummary().GetDataAdded()
+				if rollup := op.GetRollup(); rollup != nil {
+					count = max(rollup.Count, 1)
+					duration = rollup.TotalDurationMs
+					bytesScanned = rollup.TotalBytesProcessed
+					bytesAdded = rollup.TotalBytesAdded
+				}
+				backupsExamined += count
 
 				if op.Status == v1.OperationStatus_STATUS_SUCCESS {
-					backupsSuccess30++
+					backupsSuccess30 += count
 				} else if op.Status == v1.OperationStatus_STATUS_ERROR {
-					backupsFailed30++
+					backupsFailed30 += count
 				} else if op.Status == v1.OperationStatus_STATUS_WARNING {
-					backupsWarning30++
+					backupsWarning30 += count
 				}
 
-				if summary := backupOp.GetLastStatus().GetSummary(); summary != nil {
-					bytesScanned30 += summary.TotalBytesProcessed
-					bytesAdded30 += summary.DataAdded
-				}
+				bytesScanned30 += bytesScanned
+				bytesAdded30 += bytesAdded
 
 				// recent backups chart
 				if len(backupChart.TimestampMs) < 60 { // only include the latest 90 backups in the chart
-					duration := op.UnixTimeEndMs - op.UnixTimeStartMs
 					if duration <= 1000 {
 						duration = 1000
 					}
@@ -846,7 +1409,7 @@ func (s *BackrestHandler) GetSummaryDashboard(ctx context.Context, req *connect.
 					backupChart.TimestampMs = append(backupChart.TimestampMs, op.UnixTimeStartMs)
 					backupChart.DurationMs = append(backupChart.DurationMs, duration)
 					backupChart.Status = append(backupChart.Status, op.Status)
-					backupChart.BytesAdded = append(backupChart.BytesAdded, backupOp.GetLastStatus().GetSummary().GetDataAdded())
+					backupChart.BytesAdded = append(backupChart.BytesAdded, bytesAdded)
 				}
 			}
 
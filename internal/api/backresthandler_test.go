@@ -26,11 +26,14 @@ import (
 	"github.com/garethgeorge/backrest/internal/oplog"
 	"github.com/garethgeorge/backrest/internal/oplog/sqlitestore"
 	"github.com/garethgeorge/backrest/internal/orchestrator"
+	"github.com/garethgeorge/backrest/internal/orchestrator/repo"
 	"github.com/garethgeorge/backrest/internal/resticinstaller"
+	"github.com/garethgeorge/backrest/internal/search"
 	"github.com/garethgeorge/backrest/internal/testutil"
 	"github.com/hashicorp/go-multierror"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 func createConfigManager(cfg *v1.Config) *config.ConfigManager {
@@ -106,6 +109,79 @@ func TestUpdateConfig(t *testing.T) {
 	}
 }
 
+func TestSetConfigRetentionConfirmation(t *testing.T) {
+	t.Parallel()
+
+	cfg := &v1.Config{
+		Modno:    1234,
+		Instance: "test",
+		Repos: []*v1.Repo{
+			{
+				Id:       "local",
+				Guid:     cryptoutil.MustRandomID(cryptoutil.DefaultIDBits),
+				Uri:      t.TempDir(),
+				Password: "test",
+				Flags:    []string{"--no-cache"},
+			},
+		},
+		Plans: []*v1.Plan{
+			{
+				Id:    "test",
+				Repo:  "local",
+				Paths: []string{t.TempDir()},
+				Schedule: &v1.Schedule{
+					Schedule: &v1.Schedule_Disabled{Disabled: true},
+				},
+				Retention: &v1.RetentionPolicy{
+					Policy: &v1.RetentionPolicy_PolicyKeepAll{PolicyKeepAll: true},
+				},
+			},
+		},
+	}
+	sut := createSystemUnderTest(t, createConfigManager(proto.Clone(cfg).(*v1.Config)))
+	sut.handler.retentionConfirmThreshold = 1
+
+	ctx, cancel := testutil.WithDeadlineFromTest(t, context.Background())
+	defer cancel()
+
+	go func() {
+		sut.orch.Run(ctx)
+	}()
+
+	for i := 0; i < 3; i++ {
+		if _, err := sut.handler.Backup(ctx, connect.NewRequest(&types.StringValue{Value: "test"})); err != nil {
+			t.Fatalf("Backup() error = %v", err)
+		}
+	}
+
+	// keeping only the last snapshot removes 2 snapshots, more than the threshold.
+	cfg.Plans[0].Retention = &v1.RetentionPolicy{
+		Policy: &v1.RetentionPolicy_PolicyKeepLastN{PolicyKeepLastN: 1},
+	}
+
+	_, err := sut.handler.SetConfig(ctx, connect.NewRequest(cfg))
+	if connect.CodeOf(err) != connect.CodeFailedPrecondition {
+		t.Fatalf("SetConfig() error = %v, want a FailedPrecondition error", err)
+	}
+	if !strings.Contains(err.Error(), "would remove 2 snapshots") {
+		t.Errorf("SetConfig() error = %v, want the number of removed snapshots", err)
+	}
+
+	res, err := sut.handler.SetConfigWithOptions(ctx, connect.NewRequest(&v1.SetConfigRequest{
+		Config:           cfg,
+		ConfirmRetention: true,
+	}))
+	if err != nil {
+		t.Fatalf("SetConfigWithOptions() error = %v", err)
+	}
+	if res.Msg.Modno != cfg.Modno+1 {
+		t.Errorf("SetConfigWithOptions() modno = %d, want %d", res.Msg.Modno, cfg.Modno+1)
+	}
+	if got := res.Msg.Plans[0].Retention.GetPolicyKeepLastN(); got != 1 {
+		t.Errorf("SetConfigWithOptions() keep last = %d, want 1", got)
+	}
+}
+
 func TestRemoveRepo(t *testing.T) {
 	t.Parallel()
 
@@ -923,6 +999,104 @@ func TestRunCommand(t *testing.T) {
 	}
 }
 
+func TestTestHook(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a posix shell")
+	}
+	testutil.InstallZapLogger(t)
+	repoGUID := cryptoutil.MustRandomID(cryptoutil.DefaultIDBits)
+	sut := createSystemUnderTest(t, createConfigManager(&v1.Config{
+		Version:  4,
+		Modno:    1234,
+		Instance: "test",
+		Repos: []*v1.Repo{
+			{
+				Id:       "local",
+				Guid:     repoGUID,
+				Uri:      t.TempDir(),
+				Password: "test",
+				Flags:    []string{"--no-cache"},
+			},
+		},
+		Plans: []*v1.Plan{
+			{
+				Id:       "test",
+				Repo:     "local",
+				Paths:    []string{t.TempDir()},
+				Schedule: &v1.Schedule{Schedule: &v1.Schedule_Disabled{Disabled: true}},
+			},
+		},
+	}))
+
+	backupOp := &v1.Operation{
+		InstanceId:      "test",
+		RepoId:          "local",
+		RepoGuid:        repoGUID,
+		PlanId:          "test",
+		SnapshotId:      "abc123",
+		Status:          v1.OperationStatus_STATUS_SUCCESS,
+		UnixTimeStartMs: 1000,
+		UnixTimeEndMs:   2000,
+		Op: &v1.Operation_OperationBackup{OperationBackup: &v1.OperationBackup{
+			LastStatus: &v1.BackupProgressEntry{Entry: &v1.BackupProgressEntry_Summary{Summary: &v1.BackupProgressSummary{
+				SnapshotId: "abc123",
+				DataAdded:  1024,
+			}}},
+		}},
+	}
+	if err := sut.oplog.Add(backupOp); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	ctx, cancel := testutil.WithDeadlineFromTest(t, context.Background())
+	defer cancel()
+
+	res, err := sut.handler.TestHook(ctx, connect.NewRequest(&v1.TestHookRequest{
+		RepoId:    "local",
+		PlanId:    "test",
+		Condition: v1.Hook_CONDITION_SNAPSHOT_SUCCESS,
+		Hook: &v1.Hook{
+			Action: &v1.Hook_ActionCommand{ActionCommand: &v1.Hook_Command{
+				Command: "echo snapshot {{ .SnapshotId }} added {{ .SnapshotStats.DataAdded }}",
+			}},
+		},
+	}))
+	if err != nil {
+		t.Fatalf("TestHook() error = %v", err)
+	}
+	if res.Msg.Error != "" {
+		t.Errorf("got hook error %q", res.Msg.Error)
+	}
+	if res.Msg.ExampleOperationId != backupOp.Id {
+		t.Errorf("got example operation %d, want %d", res.Msg.ExampleOperationId, backupOp.Id)
+	}
+	if want := "echo snapshot abc123 added 1024"; res.Msg.Payload != want {
+		t.Errorf("got payload %q, want %q", res.Msg.Payload, want)
+	}
+	if !strings.Contains(res.Msg.Output, "[output] snapshot abc123 added 1024") {
+		t.Errorf("got output %q", res.Msg.Output)
+	}
+
+	// template errors are reported in the response rather than failing the request.
+	res, err = sut.handler.TestHook(ctx, connect.NewRequest(&v1.TestHookRequest{
+		RepoId:    "local",
+		Condition: v1.Hook_CONDITION_SNAPSHOT_SUCCESS,
+		Hook: &v1.Hook{
+			Action: &v1.Hook_ActionCommand{ActionCommand: &v1.Hook_Command{Command: "echo {{ .NoSuchField }"}},
+		},
+	}))
+	if err != nil {
+		t.Fatalf("TestHook() error = %v", err)
+	}
+	if !strings.Contains(res.Msg.Error, "template") {
+		t.Errorf("got error %q, want a template error", res.Msg.Error)
+	}
+
+	if len(getOperations(t, sut.oplog)) != 1 {
+		t.Errorf("expected testing a hook not to record operations")
+	}
+}
+
 func TestMultihostIndexSnapshots(t *testing.T) {
 	t.Parallel()
 	ctx, cancel := testutil.WithDeadlineFromTest(t, context.Background())
@@ -1048,6 +1222,252 @@ func TestMultihostIndexSnapshots(t *testing.T) {
 	}
 }
 
+func TestAdoptSnapshots(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := testutil.WithDeadlineFromTest(t, context.Background())
+	defer cancel()
+
+	sut := createSystemUnderTest(t, createConfigManager(&v1.Config{
+		Modno:    1234,
+		Instance: "test",
+		Repos: []*v1.Repo{
+			{
+				Id:       "local",
+				Guid:     cryptoutil.MustRandomID(cryptoutil.DefaultIDBits),
+				Uri:      t.TempDir(),
+				Password: "test",
+				Flags:    []string{"--no-cache"},
+			},
+		},
+		Plans: []*v1.Plan{
+			{
+				Id:    "test",
+				Repo:  "local",
+				Paths: []string{t.TempDir()},
+				Schedule: &v1.Schedule{
+					Schedule: &v1.Schedule_Disabled{Disabled: true},
+				},
+			},
+		},
+	}))
+
+	go func() {
+		sut.orch.Run(ctx)
+	}()
+
+	// create a snapshot outside of backrest e.g. as a cron job would.
+	r, err := sut.orch.GetRepoOrchestrator("local")
+	if err != nil {
+		t.Fatalf("GetRepoOrchestrator() error = %v", err)
+	}
+	if err := r.RunCommand(ctx, "backup --tag cron "+t.TempDir(), io.Discard); err != nil {
+		t.Fatalf("RunCommand() error = %v", err)
+	}
+
+	res, err := sut.handler.AdoptSnapshots(ctx, connect.NewRequest(&v1.AdoptSnapshotsRequest{
+		PlanId: "test",
+		Tags:   []string{"cron"},
+		DryRun: true,
+	}))
+	if err != nil {
+		t.Fatalf("AdoptSnapshots() dry run error = %v", err)
+	}
+	if len(res.Msg.Snapshots) != 1 {
+		t.Fatalf("expected 1 snapshot to be adopted, got %d", len(res.Msg.Snapshots))
+	}
+	dryRunID := res.Msg.Snapshots[0].Id
+
+	res, err = sut.handler.AdoptSnapshots(ctx, connect.NewRequest(&v1.AdoptSnapshotsRequest{
+		PlanId: "test",
+		Tags:   []string{"cron"},
+	}))
+	if err != nil {
+		t.Fatalf("AdoptSnapshots() error = %v", err)
+	}
+	if len(res.Msg.Snapshots) != 1 {
+		t.Fatalf("expected 1 snapshot to be adopted, got %d", len(res.Msg.Snapshots))
+	}
+	adopted := res.Msg.Snapshots[0]
+	if adopted.Id == dryRunID {
+		t.Errorf("expected the adopted snapshot's ID after tagging, got the ID before tagging %q", adopted.Id)
+	}
+	if !slices.Contains(adopted.Tags, repo.TagForPlan("test")) {
+		t.Errorf("expected the adopted snapshot to be tagged for plan test, got tags %v", adopted.Tags)
+	}
+
+	testutil.TryNonfatal(t, ctx, func() error {
+		for _, op := range getOperations(t, sut.oplog) {
+			if op.GetOperationIndexSnapshot() != nil && !op.GetOperationIndexSnapshot().Forgot && op.PlanId == "test" && op.InstanceId == "test" {
+				return nil
+			}
+		}
+		return errors.New("expected an indexed snapshot for plan test")
+	})
+}
+
+func TestSearchOperations(t *testing.T) {
+	sut := createSystemUnderTest(t, createConfigManager(&v1.Config{
+		Version:  4,
+		Modno:    1234,
+		Instance: "test",
+	}))
+
+	ops := []*v1.Operation{
+		{
+			InstanceId:      "test",
+			RepoId:          "local",
+			RepoGuid:        "local-guid",
+			PlanId:          "plan1",
+			Status:          v1.OperationStatus_STATUS_ERROR,
+			UnixTimeStartMs: 1000,
+			DisplayMessage:  "backup failed: permission denied",
+			Op:              &v1.Operation_OperationBackup{},
+		},
+		{
+			InstanceId:      "test",
+			RepoId:          "local",
+			RepoGuid:        "local-guid",
+			PlanId:          "plan2",
+			Status:          v1.OperationStatus_STATUS_WARNING,
+			UnixTimeStartMs: 2000,
+			DisplayMessage:  "backup completed with errors",
+			Op: &v1.Operation_OperationBackup{OperationBackup: &v1.OperationBackup{
+				Errors: []*v1.BackupProgressError{{Item: "/etc/shadow", Message: "permission denied"}},
+			}},
+		},
+	}
+	if err := sut.oplog.Add(ops...); err != nil {
+		t.Fatalf("Failed to add operations: %v", err)
+	}
+
+	resp, err := sut.handler.SearchOperations(context.Background(), connect.NewRequest(&v1.SearchOperationsRequest{
+		Query: "permission denied",
+	}))
+	if err != nil {
+		t.Fatalf("SearchOperations() error = %v", err)
+	}
+	if len(resp.Msg.Results) != 2 {
+		t.Fatalf("SearchOperations() got %d results, want 2", len(resp.Msg.Results))
+	}
+
+	resp, err = sut.handler.SearchOperations(context.Background(), connect.NewRequest(&v1.SearchOperationsRequest{
+		Query:    "permission denied",
+		Selector: &v1.OpSelector{PlanId: proto.String("plan2")},
+	}))
+	if err != nil {
+		t.Fatalf("SearchOperations() error = %v", err)
+	}
+	if len(resp.Msg.Results) != 1 || resp.Msg.Results[0].Operation.Id != ops[1].Id {
+		t.Fatalf("SearchOperations() with selector got %v, want operation %d", resp.Msg.Results, ops[1].Id)
+	}
+	if resp.Msg.Results[0].Field != search.FieldErrors {
+		t.Errorf("SearchOperations() got field %q, want %q", resp.Msg.Results[0].Field, search.FieldErrors)
+	}
+
+	resp, err = sut.handler.SearchOperations(context.Background(), connect.NewRequest(&v1.SearchOperationsRequest{
+		Query: "denied",
+		Limit: 1,
+	}))
+	if err != nil {
+		t.Fatalf("SearchOperations() error = %v", err)
+	}
+	if len(resp.Msg.Results) != 1 {
+		t.Errorf("SearchOperations() with limit got %d results, want 1", len(resp.Msg.Results))
+	}
+
+	_, err = sut.handler.SearchOperations(context.Background(), connect.NewRequest(&v1.SearchOperationsRequest{
+		Query:    `"unterminated`,
+		RawQuery: true,
+	}))
+	if connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Errorf("SearchOperations() with a malformed query got error %v, want invalid argument", err)
+	}
+}
+
+func TestGetSummaryDashboardRollup(t *testing.T) {
+	t.Parallel()
+
+	sut := createSystemUnderTest(t, createConfigManager(&v1.Config{
+		Version:  4,
+		Modno:    1234,
+		Instance: "test",
+		Repos: []*v1.Repo{
+			{
+				Id:       "local",
+				Guid:     cryptoutil.MustRandomID(cryptoutil.DefaultIDBits),
+				Uri:      t.TempDir(),
+				Password: "test",
+				Flags:    []string{"--no-cache"},
+			},
+		},
+		Plans: []*v1.Plan{
+			{
+				Id:    "test",
+				Repo:  "local",
+				Paths: []string{t.TempDir()},
+				Schedule: &v1.Schedule{
+					Schedule: &v1.Schedule_Disabled{Disabled: true},
+				},
+			},
+		},
+	}))
+
+	backup := func(age time.Duration, status v1.OperationStatus, bytesProcessed, bytesAdded int64) *v1.Operation {
+		start := time.Now().Add(-age).UnixMilli()
+		return &v1.Operation{
+			InstanceId:      "test",
+			RepoId:          "local",
+			RepoGuid:        sut.config.Repos[0].Guid,
+			PlanId:          "test",
+			Status:          status,
+			UnixTimeStartMs: start,
+			UnixTimeEndMs:   start + time.Minute.Milliseconds(),
+			Op: &v1.Operation_OperationBackup{OperationBackup: &v1.OperationBackup{
+				LastStatus: &v1.BackupProgressEntry{Entry: &v1.BackupProgressEntry_Summary{Summary: &v1.BackupProgressSummary{
+					TotalBytesProcessed: bytesProcessed,
+					DataAdded:           bytesAdded,
+				}}},
+			}},
+		}
+	}
+	rollup := backup(time.Hour, v1.OperationStatus_STATUS_SUCCESS, 100, 10)
+	rollup.Rollup = &v1.OperationRollup{
+		Count:               5,
+		FirstStartTimeMs:    rollup.UnixTimeStartMs - time.Hour.Milliseconds(),
+		TotalDurationMs:     5 * time.Minute.Milliseconds(),
+		TotalBytesProcessed: 500,
+		TotalBytesAdded:     50,
+	}
+	if err := sut.oplog.Add(
+		backup(3*time.Hour, v1.OperationStatus_STATUS_SUCCESS, 100, 10),
+		backup(2*time.Hour, v1.OperationStatus_STATUS_ERROR, 0, 0),
+		rollup,
+	); err != nil {
+		t.Fatalf("Failed to add operations: %v", err)
+	}
+
+	resp, err := sut.handler.GetSummaryDashboard(context.Background(), connect.NewRequest(&emptypb.Empty{}))
+	if err != nil {
+		t.Fatalf("GetSummaryDashboard() error = %v", err)
+	}
+	if len(resp.Msg.PlanSummaries) != 1 {
+		t.Fatalf("GetSummaryDashboard() got %d plan summaries, want 1", len(resp.Msg.PlanSummaries))
+	}
+	summary := resp.Msg.PlanSummaries[0]
+	if summary.BackupsSuccessLast_30Days != 6 || summary.BackupsFailed_30Days != 1 {
+		t.Errorf("got %d successful and %d failed backups, want the rollup counted as 5 backups", summary.BackupsSuccessLast_30Days, summary.BackupsFailed_30Days)
+	}
+	if summary.BytesScannedLast_30Days != 600 || summary.BytesAddedLast_30Days != 60 {
+		t.Errorf("got %d bytes scanned and %d bytes added, want the rollup's totals included", summary.BytesScannedLast_30Days, summary.BytesAddedLast_30Days)
+	}
+	if summary.BytesAddedAvg != 60/7 {
+		t.Errorf("got %d bytes added on average, want %d", summary.BytesAddedAvg, 60/7)
+	}
+	if chart := summary.RecentBackups; len(chart.BytesAdded) != 3 || chart.BytesAdded[0] != 50 || chart.DurationMs[0] != 5*time.Minute.Milliseconds() {
+		t.Errorf("got chart %v, want the rollup's totals as its latest entry", chart)
+	}
+}
+
 type systemUnderTest struct {
 	handler  *BackrestHandler
 	oplog    *oplog.OpLog
@@ -1083,8 +1503,13 @@ func createSystemUnderTest(t *testing.T, config *config.ConfigManager) systemUnd
 		t.Fatalf("Failed to create log store: %v", err)
 	}
 	t.Cleanup(func() { logStore.Close() })
+	searchIndex, err := search.NewIndex(filepath.Join(dir, "search.sqlite"), oplog, logStore)
+	if err != nil {
+		t.Fatalf("Failed to create search index: %v", err)
+	}
+	t.Cleanup(func() { searchIndex.Close() })
 	orch, err := orchestrator.NewOrchestrator(
-		resticBin, config, oplog, logStore,
+		resticBin, config, oplog, logStore, nil, nil, nil,
 	)
 	if err != nil {
 		t.Fatalf("Failed to create orchestrator: %v", err)
@@ -1101,7 +1526,7 @@ func createSystemUnderTest(t *testing.T, config *config.ConfigManager) systemUnd
 		}
 	}
 
-	h := NewBackrestHandler(config, peerStateManager, orch, oplog, logStore)
+	h := NewBackrestHandler(config, peerStateManager, orch, oplog, logStore, searchIndex)
 
 	return systemUnderTest{
 		handler:  h,
@@ -72,6 +72,18 @@ func (s *ScopeSet) ContainsRepo(repoID string) bool {
 	return false
 }
 
+// ContainsRepoOrPlan returns whether the scope set covers an operation of the plan in the repo, i.e. either is included
+// and neither is excluded. planID may be empty for operations that are not associated with a plan.
+func (s *ScopeSet) ContainsRepoOrPlan(repoID, planID string) bool {
+	if _, ok := s.excludedRepos[repoID]; ok {
+		return false
+	}
+	if _, ok := s.excludedPlans[planID]; ok && planID != "" {
+		return false
+	}
+	return s.ContainsRepo(repoID) || (planID != "" && s.ContainsPlan(planID))
+}
+
 func (s *ScopeSet) Merge(other *ScopeSet) {
 	if other.wildcard {
 		s.wildcard = true
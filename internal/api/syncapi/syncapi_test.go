@@ -763,7 +763,7 @@ func newPeerUnderTest(t *testing.T, initialConfig *v1.Config) *peerUnderTest {
 	}
 
 	var wg sync.WaitGroup
-	orchestrator, err := orchestrator.NewOrchestrator(resticbin, configMgr, oplog, logStore)
+	orchestrator, err := orchestrator.NewOrchestrator(resticbin, configMgr, oplog, logStore, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("failed to create orchestrator: %v", err)
 	}
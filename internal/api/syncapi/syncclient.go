@@ -18,6 +18,9 @@ import (
 	"github.com/garethgeorge/backrest/internal/api/syncapi/permissions"
 	"github.com/garethgeorge/backrest/internal/env"
 	"github.com/garethgeorge/backrest/internal/oplog"
+	"github.com/garethgeorge/backrest/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"golang.org/x/net/http2"
 	"google.golang.org/protobuf/proto"
@@ -297,13 +300,7 @@ func (c *syncSessionHandlerClient) OnConnectionEstablished(ctx context.Context,
 			return
 		}
 
-		stream.Send(&v1sync.SyncStreamItem{
-			Action: &v1sync.SyncStreamItem_ReceiveOperations{
-				ReceiveOperations: &v1sync.SyncStreamItem_SyncActionReceiveOperations{
-					Event: eventProto,
-				},
-			},
-		})
+		c.sendOperations(stream, eventProto, opsToForward)
 	}
 	c.oplog.Subscribe(oplog.Query{}, &oplogSubscription)
 	go func() {
@@ -313,6 +310,36 @@ func (c *syncSessionHandlerClient) OnConnectionEstablished(ctx context.Context,
 	return nil
 }
 
+// sendOperations forwards an operation event to the peer. If any of the operations belong to a flow that is running, the
+// event is sent in a span of the flow's trace and carries its trace context so that the peer's handling links back to it.
+func (c *syncSessionHandlerClient) sendOperations(stream *bidiSyncCommandStream, event *v1.OperationEvent, ops []*v1.Operation) {
+	action := &v1sync.SyncStreamItem_SyncActionReceiveOperations{Event: event}
+
+	var flowSpans []trace.SpanContext
+	for _, op := range ops {
+		if sc := tracing.FlowSpanContext(op.GetFlowId()); sc.IsValid() && !slices.ContainsFunc(flowSpans, sc.Equal) {
+			flowSpans = append(flowSpans, sc)
+		}
+	}
+	if len(flowSpans) > 0 {
+		ctx := trace.ContextWithSpanContext(context.Background(), flowSpans[0])
+		ctx, span := tracing.StartLinked(tracing.ContextWithLinks(ctx, flowSpans[1:]...), "sync.forward_operations",
+			trace.WithSpanKind(trace.SpanKindProducer),
+			trace.WithAttributes(
+				attribute.String("backrest.peer.instance_id", c.peer.InstanceId),
+				attribute.Int("backrest.operation.count", len(ops)),
+			))
+		defer span.End()
+		action.TraceContext = tracing.Inject(ctx)
+	}
+
+	stream.Send(&v1sync.SyncStreamItem{
+		Action: &v1sync.SyncStreamItem_ReceiveOperations{
+			ReceiveOperations: action,
+		},
+	})
+}
+
 func (c *syncSessionHandlerClient) HandleRequestResources(ctx context.Context, stream *bidiSyncCommandStream, item *v1sync.SyncStreamItem_SyncActionRequestResources) error {
 	return c.sendResourceList(ctx, stream)
 }
@@ -352,30 +379,18 @@ func (c *syncSessionHandlerClient) HandleRequestOperations(ctx context.Context,
 
 		// send new and updated operations
 		if len(newOps) > 0 {
-			stream.Send(&v1sync.SyncStreamItem{
-				Action: &v1sync.SyncStreamItem_ReceiveOperations{
-					ReceiveOperations: &v1sync.SyncStreamItem_SyncActionReceiveOperations{
-						Event: &v1.OperationEvent{
-							Event: &v1.OperationEvent_CreatedOperations{
-								CreatedOperations: &v1.OperationList{Operations: batch},
-							},
-						},
-					},
+			c.sendOperations(stream, &v1.OperationEvent{
+				Event: &v1.OperationEvent_CreatedOperations{
+					CreatedOperations: &v1.OperationList{Operations: batch},
 				},
-			})
+			}, batch)
 		}
 		if len(updatedOps) > 0 {
-			stream.Send(&v1sync.SyncStreamItem{
-				Action: &v1sync.SyncStreamItem_ReceiveOperations{
-					ReceiveOperations: &v1sync.SyncStreamItem_SyncActionReceiveOperations{
-						Event: &v1.OperationEvent{
-							Event: &v1.OperationEvent_UpdatedOperations{
-								UpdatedOperations: &v1.OperationList{Operations: updatedOps},
-							},
-						},
-					},
+			c.sendOperations(stream, &v1.OperationEvent{
+				Event: &v1.OperationEvent_UpdatedOperations{
+					UpdatedOperations: &v1.OperationList{Operations: updatedOps},
 				},
-			})
+			}, updatedOps)
 		}
 
 		batch = batch[:0]
@@ -14,6 +14,10 @@ import (
 	"github.com/garethgeorge/backrest/internal/api/syncapi/permissions"
 	"github.com/garethgeorge/backrest/internal/env"
 	"github.com/garethgeorge/backrest/internal/oplog"
+	"github.com/garethgeorge/backrest/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
@@ -195,7 +199,20 @@ func (h *syncSessionHandlerServer) HandleHeartbeat(ctx context.Context, stream *
 	return nil
 }
 
-func (h *syncSessionHandlerServer) HandleReceiveOperations(ctx context.Context, stream *bidiSyncCommandStream, item *v1sync.SyncStreamItem_SyncActionReceiveOperations) error {
+func (h *syncSessionHandlerServer) HandleReceiveOperations(ctx context.Context, stream *bidiSyncCommandStream, item *v1sync.SyncStreamItem_SyncActionReceiveOperations) (err error) {
+	if traceContext := item.GetTraceContext(); len(traceContext) > 0 {
+		// continue the trace of the flow on the peer that forwarded the operations.
+		_, span := tracing.Tracer().Start(tracing.Extract(ctx, traceContext), "sync.receive_operations",
+			trace.WithSpanKind(trace.SpanKindConsumer),
+			trace.WithAttributes(attribute.String("backrest.peer.instance_id", h.peer.InstanceId)))
+		defer func() {
+			if err != nil {
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+		}()
+	}
+
 	switch event := item.GetEvent().Event.(type) {
 	case *v1.OperationEvent_CreatedOperations:
 		h.l.Debug("received created operations", zap.Any("operations", event.CreatedOperations.GetOperations()))
@@ -138,6 +138,74 @@ func TestConfig(t *testing.T) {
 			wantErr:         true,
 			wantErrContains: "invalid max frequency days",
 		},
+		{
+			name: "global hook with bad scope",
+			config: &v1.Config{
+				Repos: []*v1.Repo{testRepo},
+				GlobalHooks: []*v1.GlobalHook{
+					{
+						Hook: &v1.Hook{
+							Conditions: []v1.Hook_Condition{v1.Hook_CONDITION_ANY_ERROR},
+							Action:     &v1.Hook_ActionCommand{ActionCommand: &v1.Hook_Command{Command: "true"}},
+						},
+						Scopes: []string{"*", "host:foo"},
+					},
+				},
+			},
+			store:           &ConfigManager{Store: &JsonFileStore{Path: dir + "/invalid-config4.json"}},
+			wantErr:         true,
+			wantErrContains: "invalid scope format",
+		},
+		{
+			name: "operation history rule with unknown type",
+			config: &v1.Config{
+				Repos: []*v1.Repo{testRepo},
+				Plans: []*v1.Plan{testPlan},
+				OperationHistory: &v1.OperationHistoryPolicy{
+					Rules: []*v1.OperationHistoryPolicy_Rule{
+						{OpType: "backup", PlanId: "test-plan", MaxAgeDays: 365},
+						{OpType: "backups", KeepMax: 10},
+					},
+				},
+			},
+			store:           &ConfigManager{Store: &JsonFileStore{Path: dir + "/invalid-config5.json"}},
+			wantErr:         true,
+			wantErrContains: `unknown operation type "backups"`,
+		},
+		{
+			name: "self backup to unknown repo",
+			config: &v1.Config{
+				Repos: []*v1.Repo{testRepo},
+				Plans: []*v1.Plan{testPlan},
+				SelfBackup: &v1.SelfBackup{
+					Repo: "missing-repo",
+				},
+			},
+			store:           &ConfigManager{Store: &JsonFileStore{Path: dir + "/invalid-config6.json"}},
+			wantErr:         true,
+			wantErrContains: `repo "missing-repo" not found`,
+		},
+		{
+			name: "repo retention selecting the snapshots of a plan",
+			config: &v1.Config{
+				Repos: []*v1.Repo{
+					{
+						Id:       "test-repo",
+						Guid:     testRepo.Guid,
+						Uri:      "/tmp/test",
+						Password: "test",
+						RetentionPolicy: &v1.RepoRetentionPolicy{
+							Retention: &v1.RetentionPolicy{Policy: &v1.RetentionPolicy_PolicyKeepLastN{PolicyKeepLastN: 10}},
+							Hosts:     []string{"laptop"},
+						},
+					},
+				},
+				Plans: []*v1.Plan{testPlan},
+			},
+			store:           &ConfigManager{Store: &JsonFileStore{Path: dir + "/invalid-config7.json"}},
+			wantErr:         true,
+			wantErrContains: "retention policy must only select unowned snapshots, plan test-plan backs up to the repo",
+		},
 	}
 
 	for _, tc := range tests {
@@ -10,6 +10,7 @@ import (
 	"github.com/garethgeorge/backrest/internal/api/syncapi/permissions"
 	"github.com/garethgeorge/backrest/internal/config/validationutil"
 	"github.com/garethgeorge/backrest/internal/cryptoutil"
+	"github.com/garethgeorge/backrest/internal/oplog"
 	"github.com/garethgeorge/backrest/internal/protoutil"
 	"github.com/hashicorp/go-multierror"
 	"go.uber.org/zap"
@@ -50,8 +51,8 @@ func ValidateConfig(c *v1.Config) error {
 		})
 	}
 
+	plans := make(map[string]*v1.Plan)
 	if c.Plans != nil {
-		plans := make(map[string]*v1.Plan)
 		for _, plan := range c.Plans {
 			if _, ok := plans[plan.Id]; ok {
 				err = multierror.Append(err, fmt.Errorf("plan %s: duplicate id", plan.GetId()))
@@ -69,10 +70,84 @@ func ValidateConfig(c *v1.Config) error {
 		})
 	}
 
+	// a repo wide policy that may select the snapshots of a plan would forget them by rules other than the plan's own.
+	for _, repo := range c.Repos {
+		policy := repo.GetRetentionPolicy()
+		if policy == nil || policy.OnlyUnowned {
+			continue
+		}
+		for _, plan := range c.Plans {
+			if plan.Repo == repo.Id {
+				err = multierror.Append(err, fmt.Errorf("repo %s: retention policy must only select unowned snapshots, plan %s backs up to the repo", repo.Id, plan.Id))
+				break
+			}
+		}
+	}
+
+	if e := validateGlobalHooks(c.GlobalHooks); e != nil {
+		err = multierror.Append(err, fmt.Errorf("global hooks: %w", e))
+	}
+
 	if e := validateMultihost(c); e != nil {
 		err = multierror.Append(err, fmt.Errorf("multihost: %w", e))
 	}
 
+	if e := validateOperationHistory(c.OperationHistory, plans); e != nil {
+		err = multierror.Append(err, fmt.Errorf("operation history: %w", e))
+	}
+
+	if c.SelfBackup != nil {
+		if e := validateSelfBackup(c.SelfBackup, repos); e != nil {
+			err = multierror.Append(err, fmt.Errorf("self backup: %w", e))
+		}
+	}
+
+	return err
+}
+
+func validateSelfBackup(selfBackup *v1.SelfBackup, repos map[string]*v1.Repo) error {
+	var err error
+	if _, ok := repos[selfBackup.Repo]; !ok {
+		err = multierror.Append(err, fmt.Errorf("repo %q not found", selfBackup.Repo))
+	}
+	if selfBackup.Schedule != nil {
+		if e := protoutil.ValidateSchedule(selfBackup.Schedule); e != nil {
+			err = multierror.Append(err, fmt.Errorf("schedule: %w", e))
+		}
+	}
+	if selfBackup.KeepLast < 0 {
+		err = multierror.Append(err, errors.New("keep_last must be non-negative"))
+	}
+	return err
+}
+
+func validateOperationHistory(policy *v1.OperationHistoryPolicy, plans map[string]*v1.Plan) error {
+	var err error
+	for idx, rule := range policy.GetRules() {
+		if rule.OpType != "" {
+			if _, e := oplog.ParseOperationTypeName(rule.OpType); e != nil {
+				err = multierror.Append(err, fmt.Errorf("rule[%d]: %w", idx, e))
+			}
+		}
+		if _, ok := plans[rule.PlanId]; rule.PlanId != "" && !ok {
+			err = multierror.Append(err, fmt.Errorf("rule[%d]: plan %q not found", idx, rule.PlanId))
+		}
+		if rule.MaxAgeDays < 0 || rule.KeepMin < 0 || rule.KeepMax < 0 {
+			err = multierror.Append(err, fmt.Errorf("rule[%d]: max age and keep counts must not be negative", idx))
+		} else if rule.KeepMax != 0 && rule.KeepMin > rule.KeepMax {
+			err = multierror.Append(err, fmt.Errorf("rule[%d]: keep min %d is greater than keep max %d", idx, rule.KeepMin, rule.KeepMax))
+		}
+	}
+
+	compaction := policy.GetCompaction()
+	if compaction.GetAfterDays() < 0 || compaction.GetRollupMaxAgeDays() < 0 {
+		err = multierror.Append(err, errors.New("compaction: ages must not be negative"))
+	}
+	for _, opType := range compaction.GetOpTypes() {
+		if _, e := oplog.ParseOperationTypeName(opType); e != nil {
+			err = multierror.Append(err, fmt.Errorf("compaction: %w", e))
+		}
+	}
 	return err
 }
 
@@ -111,6 +186,26 @@ func validateRepo(repo *v1.Repo) error {
 		}
 	}
 
+	if policy := repo.RetentionPolicy; policy != nil {
+		if policy.GetSchedule() != nil {
+			if e := protoutil.ValidateSchedule(policy.GetSchedule()); e != nil {
+				err = multierror.Append(err, fmt.Errorf("retention policy schedule: %w", e))
+			}
+		}
+		if policy.GetRetention().GetPolicy() == nil {
+			err = multierror.Append(err, errors.New("retention policy must specify a policy"))
+		} else if _, e := protoutil.RetentionPolicyFromProto(policy.GetRetention()); e != nil {
+			err = multierror.Append(err, fmt.Errorf("retention policy: %w", e))
+		}
+		if len(policy.Tags) == 0 && len(policy.Hosts) == 0 && !policy.OnlyUnowned {
+			err = multierror.Append(err, errors.New("retention policy must select snapshots by tags, hosts or only unowned snapshots"))
+		}
+	}
+
+	if e := validateHooks(repo.Hooks); e != nil {
+		err = multierror.Append(err, e)
+	}
+
 	for _, env := range repo.Env {
 		if !strings.Contains(env, "=") {
 			err = multierror.Append(err, fmt.Errorf("invalid env var %s, must take format KEY=VALUE", env))
@@ -122,6 +217,47 @@ func validateRepo(repo *v1.Repo) error {
 	return err
 }
 
+func validateHooks(hooks []*v1.Hook) error {
+	var err error
+	for idx, hook := range hooks {
+		if hook.TimeoutSeconds < 0 {
+			err = multierror.Append(err, fmt.Errorf("hook[%d]: timeout_seconds must be non-negative", idx))
+		}
+		if hook.GetActionCommand().GetMaxOutputBytes() < 0 {
+			err = multierror.Append(err, fmt.Errorf("hook[%d]: max_output_bytes must be non-negative", idx))
+		}
+		if hook.Digest == nil {
+			continue
+		}
+		if hook.Digest.GetSchedule() == nil {
+			err = multierror.Append(err, fmt.Errorf("hook[%d]: digest must specify a schedule", idx))
+		} else if e := protoutil.ValidateSchedule(hook.Digest.GetSchedule()); e != nil {
+			err = multierror.Append(err, fmt.Errorf("hook[%d]: digest schedule: %w", idx, e))
+		}
+	}
+	return err
+}
+
+func validateGlobalHooks(globalHooks []*v1.GlobalHook) error {
+	var err error
+	for idx, gh := range globalHooks {
+		if gh.GetHook() == nil {
+			err = multierror.Append(err, fmt.Errorf("global hook[%d]: hook is required", idx))
+			continue
+		}
+		if e := validateHooks([]*v1.Hook{gh.Hook}); e != nil {
+			err = multierror.Append(err, fmt.Errorf("global hook[%d]: %w", idx, e))
+		}
+		if gh.Hook.Digest != nil {
+			err = multierror.Append(err, fmt.Errorf("global hook[%d]: digest mode is not supported for global hooks", idx))
+		}
+		if _, e := permissions.NewScopeSet(gh.GetScopes()); e != nil {
+			err = multierror.Append(err, fmt.Errorf("global hook[%d]: scopes: %w", idx, e))
+		}
+	}
+	return err
+}
+
 func validatePlan(plan *v1.Plan, repos map[string]*v1.Repo) error {
 	var err error
 	if e := validationutil.ValidateID(plan.Id, 0); e != nil {
@@ -144,6 +280,14 @@ func validatePlan(plan *v1.Plan, repos map[string]*v1.Repo) error {
 		err = multierror.Append(err, fmt.Errorf("repo is required"))
 	}
 
+	if plan.StaleAfterHours < 0 {
+		err = multierror.Append(err, errors.New("stale_after_hours must be non-negative"))
+	}
+
+	if e := validateHooks(plan.Hooks); e != nil {
+		err = multierror.Append(err, e)
+	}
+
 	if _, ok := repos[plan.Repo]; !ok {
 		err = multierror.Append(err, fmt.Errorf("repo %q not found", plan.Repo))
 	}
@@ -151,10 +295,15 @@ func validatePlan(plan *v1.Plan, repos map[string]*v1.Repo) error {
 	if plan.Retention != nil && plan.Retention.Policy == nil {
 		err = multierror.Append(err, errors.New("retention policy must be nil or must specify a policy"))
 	} else if policyTimeBucketed, ok := plan.Retention.GetPolicy().(*v1.RetentionPolicy_PolicyTimeBucketed); ok {
-		if proto.Equal(policyTimeBucketed.PolicyTimeBucketed, &v1.RetentionPolicy_TimeBucketedCounts{}) {
+		buckets := plan.Retention.GetKeepWithinBuckets()
+		hasKeepWithin := plan.Retention.KeepWithin != "" || (buckets != nil && !proto.Equal(buckets, &v1.RetentionPolicy_TimeBucketedDurations{}))
+		if proto.Equal(policyTimeBucketed.PolicyTimeBucketed, &v1.RetentionPolicy_TimeBucketedCounts{}) && !hasKeepWithin {
 			err = multierror.Append(err, errors.New("time bucketed policy must specify a non-empty bucket"))
 		}
 	}
+	if _, e := protoutil.RetentionPolicyFromProto(plan.Retention); e != nil {
+		err = multierror.Append(err, fmt.Errorf("retention policy: %w", e))
+	}
 
 	slices.Sort(plan.Paths)
 
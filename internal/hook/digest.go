@@ -0,0 +1,73 @@
+package hook
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	cfg "github.com/garethgeorge/backrest/internal/config"
+	"github.com/garethgeorge/backrest/internal/hook/types"
+	"github.com/garethgeorge/backrest/internal/orchestrator/tasks"
+	"google.golang.org/protobuf/proto"
+)
+
+// DigestHook is a hook in digest mode that matched one of the events passed to DigestHooksTriggeredByEvent.
+type DigestHook struct {
+	Name  string
+	Key   string // identifies the hook's buffered events, see RepoDigestKey and PlanDigestKey.
+	Hook  *v1.Hook
+	Event v1.Hook_Condition
+}
+
+// RepoDigestKey returns the key the repo hook's digest is buffered under. Unlike RepoHookName it's derived from the
+// hook's config so that it doesn't change when other hooks are added, removed or reordered. Editing the hook changes
+// its key, tasks.DigestStore.Retain moves its buffered events to the new key.
+func RepoDigestKey(repoID string, hook *v1.Hook) string {
+	return fmt.Sprintf("repo/%v/digest/%v", repoID, hookHash(hook))
+}
+
+// PlanDigestKey returns the key the plan hook's digest is buffered under, see RepoDigestKey.
+func PlanDigestKey(planID string, hook *v1.Hook) string {
+	return fmt.Sprintf("plan/%v/digest/%v", planID, hookHash(hook))
+}
+
+func hookHash(hook *v1.Hook) string {
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(hook)
+	if err != nil {
+		panic(fmt.Sprintf("marshal hook: %v", err))
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:8])
+}
+
+// DigestHooksTriggeredByEvent returns the hooks in digest mode that should buffer one of the events, these are skipped by TasksTriggeredByEvent.
+func DigestHooksTriggeredByEvent(config *v1.Config, repoID string, planID string, events []v1.Hook_Condition) []DigestHook {
+	var hooks []DigestHook
+
+	repo := cfg.FindRepo(config, repoID)
+	for idx, hook := range repo.GetHooks() {
+		if event := firstMatchingCondition(hook, events); event != v1.Hook_CONDITION_UNKNOWN && hook.GetDigest() != nil {
+			hooks = append(hooks, DigestHook{Name: RepoHookName(repo.Id, idx), Key: RepoDigestKey(repo.Id, hook), Hook: hook, Event: event})
+		}
+	}
+
+	plan := cfg.FindPlan(config, planID)
+	for idx, hook := range plan.GetHooks() {
+		if event := firstMatchingCondition(hook, events); event != v1.Hook_CONDITION_UNKNOWN && hook.GetDigest() != nil {
+			hooks = append(hooks, DigestHook{Name: PlanHookName(plan.Id, idx), Key: PlanDigestKey(plan.Id, hook), Hook: hook, Event: event})
+		}
+	}
+
+	return hooks
+}
+
+// SendDigest delivers a flushed digest with the hook's handler, it is the tasks.DigestSender used by the orchestrator.
+func SendDigest(ctx context.Context, hook *v1.Hook, vars tasks.DigestVars, runner tasks.TaskRunner) error {
+	h, err := types.DefaultRegistry().GetHandler(hook)
+	if err != nil {
+		return err
+	}
+	return h.Execute(ctx, hook, vars, runner, v1.Hook_CONDITION_UNKNOWN)
+}
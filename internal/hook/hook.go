@@ -10,6 +10,7 @@ import (
 	"time"
 
 	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/api/syncapi/permissions"
 	cfg "github.com/garethgeorge/backrest/internal/config"
 	"github.com/garethgeorge/backrest/internal/hook/types"
 	"github.com/garethgeorge/backrest/internal/orchestrator/tasks"
@@ -26,11 +27,11 @@ func TasksTriggeredByEvent(config *v1.Config, repoID string, planID string, pare
 
 	for idx, hook := range repo.GetHooks() {
 		event := firstMatchingCondition(hook, events)
-		if event == v1.Hook_CONDITION_UNKNOWN {
+		if event == v1.Hook_CONDITION_UNKNOWN || hook.GetDigest() != nil {
 			continue
 		}
 
-		name := fmt.Sprintf("repo/%v/hook/%v", repo.Id, idx)
+		name := RepoHookName(repo.Id, idx)
 		task, err := newOneoffRunHookTask(name, config.Instance, repo, planID, parentOp, time.Now(), hook, event, vars)
 		if err != nil {
 			return nil, err
@@ -40,11 +41,31 @@ func TasksTriggeredByEvent(config *v1.Config, repoID string, planID string, pare
 
 	for idx, hook := range plan.GetHooks() {
 		event := firstMatchingCondition(hook, events)
-		if event == v1.Hook_CONDITION_UNKNOWN {
+		if event == v1.Hook_CONDITION_UNKNOWN || hook.GetDigest() != nil {
 			continue
 		}
 
-		name := fmt.Sprintf("plan/%v/hook/%v", plan.Id, idx)
+		name := PlanHookName(plan.Id, idx)
+		task, err := newOneoffRunHookTask(name, config.Instance, repo, planID, parentOp, time.Now(), hook, event, vars)
+		if err != nil {
+			return nil, err
+		}
+		taskSet = append(taskSet, task)
+	}
+
+	for idx, gh := range config.GetGlobalHooks() {
+		hook := gh.GetHook()
+		event := firstMatchingCondition(hook, events)
+		if event == v1.Hook_CONDITION_UNKNOWN || hook.GetDigest() != nil {
+			continue
+		}
+		if applies, err := globalHookApplies(gh, repoID, planID); err != nil {
+			return nil, fmt.Errorf("global hook %v: %w", idx, err)
+		} else if !applies {
+			continue
+		}
+
+		name := GlobalHookName(idx)
 		task, err := newOneoffRunHookTask(name, config.Instance, repo, planID, parentOp, time.Now(), hook, event, vars)
 		if err != nil {
 			return nil, err
@@ -55,6 +76,33 @@ func TasksTriggeredByEvent(config *v1.Config, repoID string, planID string, pare
 	return taskSet, nil
 }
 
+// globalHookApplies returns whether the global hook's scopes select the repo and plan, empty scopes select everything.
+func globalHookApplies(gh *v1.GlobalHook, repoID, planID string) (bool, error) {
+	if len(gh.GetScopes()) == 0 {
+		return true, nil
+	}
+	scopes, err := permissions.NewScopeSet(gh.GetScopes())
+	if err != nil {
+		return false, err
+	}
+	return scopes.ContainsRepoOrPlan(repoID, planID), nil
+}
+
+// RepoHookName returns the name identifying the idx'th hook of the repo.
+func RepoHookName(repoID string, idx int) string {
+	return fmt.Sprintf("repo/%v/hook/%v", repoID, idx)
+}
+
+// GlobalHookName returns the name identifying the idx'th global hook.
+func GlobalHookName(idx int) string {
+	return fmt.Sprintf("global/hook/%v", idx)
+}
+
+// PlanHookName returns the name identifying the idx'th hook of the plan.
+func PlanHookName(planID string, idx int) string {
+	return fmt.Sprintf("plan/%v/hook/%v", planID, idx)
+}
+
 func newOneoffRunHookTask(title, instanceID string, repo *v1.Repo, planID string, parentOp *v1.Operation, at time.Time, hook *v1.Hook, event v1.Hook_Condition, vars interface{}) (tasks.Task, error) {
 	h, err := types.DefaultRegistry().GetHandler(hook)
 	if err != nil {
@@ -63,45 +111,97 @@ func newOneoffRunHookTask(title, instanceID string, repo *v1.Repo, planID string
 
 	title = h.Name() + " hook " + title
 
-	return &tasks.GenericOneoffTask{
-		OneoffTask: tasks.OneoffTask{
-			BaseTask: tasks.BaseTask{
-				TaskType:   "hook",
-				TaskName:   fmt.Sprintf("run hook %v", title),
-				TaskRepo:   repo,
-				TaskPlanID: planID,
-			},
-			FlowID: parentOp.GetFlowId(),
-			RunAt:  at,
-			ProtoOp: &v1.Operation{
-				DisplayMessage: fmt.Sprintf("running %v triggered by %v", title, event.String()),
-				Op: &v1.Operation_OperationRunHook{
-					OperationRunHook: &v1.OperationRunHook{
-						Name:      title,
-						Condition: event,
-						ParentOp:  parentOp.GetId(),
+	return &runHookTask{
+		parallel: hook.GetParallel(),
+		GenericOneoffTask: &tasks.GenericOneoffTask{
+			OneoffTask: tasks.OneoffTask{
+				BaseTask: tasks.BaseTask{
+					TaskType:   "hook",
+					TaskName:   fmt.Sprintf("run hook %v", title),
+					TaskRepo:   repo,
+					TaskPlanID: planID,
+				},
+				FlowID: parentOp.GetFlowId(),
+				RunAt:  at,
+				ProtoOp: &v1.Operation{
+					DisplayMessage: fmt.Sprintf("running %v triggered by %v", title, event.String()),
+					Op: &v1.Operation_OperationRunHook{
+						OperationRunHook: &v1.OperationRunHook{
+							Name:      title,
+							Condition: event,
+							ParentOp:  parentOp.GetId(),
+						},
 					},
 				},
 			},
-		},
-		Do: func(ctx context.Context, st tasks.ScheduledTask, taskRunner tasks.TaskRunner) error {
-			// TODO: this is a hack to get around the fact that vars is an interface{} .
-			v := reflect.ValueOf(&vars).Elem()
-			clone := reflect.New(v.Elem().Type()).Elem()
-			clone.Set(v.Elem()) // copy vars to clone
-			if field := v.Elem().FieldByName("Event"); field.IsValid() {
-				clone.FieldByName("Event").Set(reflect.ValueOf(event))
-			}
-
-			if err := h.Execute(ctx, hook, clone, taskRunner, event); err != nil {
-				err = applyHookErrorPolicy(hook.OnError, err)
-				return err
-			}
-			return nil
+			Do: func(ctx context.Context, st tasks.ScheduledTask, taskRunner tasks.TaskRunner) error {
+				// TODO: this is a hack to get around the fact that vars is an interface{} .
+				v := reflect.ValueOf(&vars).Elem()
+				clone := reflect.New(v.Elem().Type()).Elem()
+				clone.Set(v.Elem()) // copy vars to clone
+				if field := v.Elem().FieldByName("Event"); field.IsValid() {
+					clone.FieldByName("Event").Set(reflect.ValueOf(event))
+				}
+
+				if hook.GetTimeoutSeconds() > 0 {
+					timeout := time.Duration(hook.GetTimeoutSeconds()) * time.Second
+					var cancel context.CancelFunc
+					ctx, cancel = context.WithTimeout(ctx, timeout)
+					defer cancel()
+				}
+
+				if err := h.Execute(ctx, hook, clone, taskRunner, event); err != nil {
+					if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+						err = fmt.Errorf("hook timed out after %v: %w", time.Duration(hook.GetTimeoutSeconds())*time.Second, err)
+					}
+					err = applyHookErrorPolicy(hook.OnError, err)
+					return err
+				}
+				return nil
+			},
 		},
 	}, nil
 }
 
+// runHookTask is a hook task, it records whether the hook may run concurrently with other hooks.
+type runHookTask struct {
+	*tasks.GenericOneoffTask
+	parallel bool
+}
+
+// ParallelGroups splits hook tasks into the groups they should run in, in order. Adjacent hooks that allow parallel
+// execution share a group and may run concurrently, every other hook runs alone in its own group.
+func ParallelGroups(taskSet []tasks.Task) [][]tasks.Task {
+	var groups [][]tasks.Task
+	lastParallel := false
+	for _, task := range taskSet {
+		ht, ok := task.(*runHookTask)
+		parallel := ok && ht.parallel
+		if parallel && lastParallel {
+			groups[len(groups)-1] = append(groups[len(groups)-1], task)
+		} else {
+			groups = append(groups, []tasks.Task{task})
+		}
+		lastParallel = parallel
+	}
+	return groups
+}
+
+// ExecuteNow runs the hook's handler for vars.Event immediately, without creating a task or operation and without
+// applying the hook's error policy. It is used to test hooks, the hook's timeout still applies.
+func ExecuteNow(ctx context.Context, hook *v1.Hook, vars tasks.HookVars, runner tasks.TaskRunner) error {
+	h, err := types.DefaultRegistry().GetHandler(hook)
+	if err != nil {
+		return err
+	}
+	if hook.GetTimeoutSeconds() > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(hook.GetTimeoutSeconds())*time.Second)
+		defer cancel()
+	}
+	return h.Execute(ctx, hook, vars, runner, vars.Event)
+}
+
 func firstMatchingCondition(hook *v1.Hook, events []v1.Hook_Condition) v1.Hook_Condition {
 	for _, event := range events {
 		if slices.Contains(hook.Conditions, event) {
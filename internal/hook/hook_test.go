@@ -1,10 +1,18 @@
 package hook
 
 import (
+	"context"
 	"errors"
+	"io"
+	"runtime"
+	"slices"
 	"testing"
+	"time"
 
 	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/kvstore"
+	"github.com/garethgeorge/backrest/internal/orchestrator/logging"
+	"github.com/garethgeorge/backrest/internal/orchestrator/tasks"
 )
 
 // TestApplyHookErrorPolicy tests that applyHookErrorPolicy is defined for all values of Hook_OnError.
@@ -14,3 +22,189 @@ func TestApplyHookErrorPolicy(t *testing.T) {
 		applyHookErrorPolicy(v1.Hook_OnError(values.Get(i).Number()), errors.New("an error"))
 	}
 }
+
+func TestDigestHooksAreBuffered(t *testing.T) {
+	digest := &v1.Hook{
+		Conditions: []v1.Hook_Condition{v1.Hook_CONDITION_SNAPSHOT_SUCCESS},
+		Digest:     &v1.Hook_Digest{Schedule: &v1.Schedule{Schedule: &v1.Schedule_Cron{Cron: "0 9 * * *"}}},
+		Action:     &v1.Hook_ActionCommand{ActionCommand: &v1.Hook_Command{Command: "true"}},
+	}
+	immediate := &v1.Hook{
+		Conditions: []v1.Hook_Condition{v1.Hook_CONDITION_SNAPSHOT_SUCCESS},
+		Action:     &v1.Hook_ActionCommand{ActionCommand: &v1.Hook_Command{Command: "true"}},
+	}
+	config := &v1.Config{
+		Instance: "test",
+		Repos:    []*v1.Repo{{Id: "repo", Hooks: []*v1.Hook{immediate, digest}}},
+		Plans:    []*v1.Plan{{Id: "plan", Repo: "repo", Hooks: []*v1.Hook{digest}}},
+	}
+	events := []v1.Hook_Condition{v1.Hook_CONDITION_SNAPSHOT_SUCCESS}
+
+	taskSet, err := TasksTriggeredByEvent(config, "repo", "plan", nil, events, struct{}{})
+	if err != nil {
+		t.Fatalf("TasksTriggeredByEvent() error = %v", err)
+	}
+	if len(taskSet) != 1 {
+		t.Errorf("got %d tasks, want 1 for the hook not in digest mode", len(taskSet))
+	}
+
+	digestHooks := DigestHooksTriggeredByEvent(config, "repo", "plan", events)
+	var names []string
+	for _, dh := range digestHooks {
+		names = append(names, dh.Name)
+	}
+	if want := []string{"repo/repo/hook/1", "plan/plan/hook/0"}; !slices.Equal(names, want) {
+		t.Errorf("got digest hooks %v, want %v", names, want)
+	}
+
+	if got := DigestHooksTriggeredByEvent(config, "repo", "plan", []v1.Hook_Condition{v1.Hook_CONDITION_SNAPSHOT_ERROR}); len(got) != 0 {
+		t.Errorf("got %d digest hooks for an unmatched condition, want 0", len(got))
+	}
+
+	// the digest keys don't depend on the hooks' positions.
+	if digestHooks[0].Key == digestHooks[1].Key {
+		t.Errorf("got the same digest key %q for the repo and plan hooks", digestHooks[0].Key)
+	}
+	config.Repos[0].Hooks = []*v1.Hook{digest, immediate}
+	if reordered := DigestHooksTriggeredByEvent(config, "repo", "plan", events); reordered[0].Key != digestHooks[0].Key {
+		t.Errorf("got digest key %q after reordering hooks, want %q", reordered[0].Key, digestHooks[0].Key)
+	}
+}
+
+func TestDigestEventsSurviveHookEdit(t *testing.T) {
+	store, err := tasks.NewDigestStore(kvstore.NewInMemorySqliteDbForKvStore(t))
+	if err != nil {
+		t.Fatalf("NewDigestStore() error = %v", err)
+	}
+	hook := &v1.Hook{
+		Conditions: []v1.Hook_Condition{v1.Hook_CONDITION_SNAPSHOT_ERROR},
+		Digest:     &v1.Hook_Digest{Schedule: &v1.Schedule{Schedule: &v1.Schedule_Cron{Cron: "0 9 * * *"}}},
+		Action:     &v1.Hook_ActionDiscord{ActionDiscord: &v1.Hook_Discord{WebhookUrl: "https://example.com", Template: "{{ .Sumary }}"}},
+	}
+	name := RepoHookName("repo", 0)
+	key := RepoDigestKey("repo", hook)
+	if err := store.Retain(map[string]string{key: name}); err != nil {
+		t.Fatalf("Retain() error = %v", err)
+	}
+	if err := store.Append(key, tasks.DigestEvent{Time: time.Now(), Event: v1.Hook_CONDITION_SNAPSHOT_ERROR}); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+
+	// fixing the template's typo changes the hook's key.
+	hook.GetActionDiscord().Template = "{{ .Summary }}"
+	editedKey := RepoDigestKey("repo", hook)
+	if editedKey == key {
+		t.Fatalf("got the same key %q for the edited hook", key)
+	}
+	if err := store.Retain(map[string]string{editedKey: name}); err != nil {
+		t.Fatalf("Retain() error = %v", err)
+	}
+	if events, err := store.Events(editedKey); err != nil || len(events) != 1 {
+		t.Errorf("got %d events (err %v) for the edited hook, want the event buffered before the edit", len(events), err)
+	}
+}
+
+func TestHookTimeoutAppliesErrorPolicy(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a posix shell")
+	}
+	hook := &v1.Hook{
+		Conditions:     []v1.Hook_Condition{v1.Hook_CONDITION_SNAPSHOT_START},
+		OnError:        v1.Hook_ON_ERROR_CANCEL,
+		TimeoutSeconds: 1,
+		Action:         &v1.Hook_ActionCommand{ActionCommand: &v1.Hook_Command{Command: "sleep 30"}},
+	}
+	task, err := newOneoffRunHookTask("test", "instance", &v1.Repo{Id: "repo"}, "plan", nil, time.Now(), hook, v1.Hook_CONDITION_SNAPSHOT_START, tasks.HookVars{})
+	if err != nil {
+		t.Fatalf("newOneoffRunHookTask() error = %v", err)
+	}
+
+	ctx := logging.ContextWithWriter(context.Background(), io.Discard)
+	start := time.Now()
+	err = task.Run(ctx, tasks.ScheduledTask{Task: task}, nil)
+	var cancelErr *HookErrorRequestCancel
+	if !errors.As(err, &cancelErr) {
+		t.Errorf("got error %v, want a cancel request from the on_error policy", err)
+	}
+	if d := time.Since(start); d > 10*time.Second {
+		t.Errorf("hook ran for %v, want it stopped by the timeout", d)
+	}
+}
+
+func TestParallelGroups(t *testing.T) {
+	newHook := func(parallel bool) *v1.Hook {
+		return &v1.Hook{
+			Conditions: []v1.Hook_Condition{v1.Hook_CONDITION_SNAPSHOT_START},
+			Parallel:   parallel,
+			Action:     &v1.Hook_ActionCommand{ActionCommand: &v1.Hook_Command{Command: "true"}},
+		}
+	}
+	config := &v1.Config{
+		Instance: "test",
+		Repos: []*v1.Repo{{Id: "repo", Hooks: []*v1.Hook{
+			newHook(true), newHook(true), newHook(false), newHook(true),
+		}}},
+		Plans: []*v1.Plan{{Id: "plan", Repo: "repo", Hooks: []*v1.Hook{newHook(true)}}},
+	}
+
+	taskSet, err := TasksTriggeredByEvent(config, "repo", "plan", nil, []v1.Hook_Condition{v1.Hook_CONDITION_SNAPSHOT_START}, struct{}{})
+	if err != nil {
+		t.Fatalf("TasksTriggeredByEvent() error = %v", err)
+	}
+
+	var sizes []int
+	for _, group := range ParallelGroups(taskSet) {
+		sizes = append(sizes, len(group))
+	}
+	if want := []int{2, 1, 2}; !slices.Equal(sizes, want) {
+		t.Errorf("got group sizes %v, want %v", sizes, want)
+	}
+}
+
+func TestGlobalHooksApplyByScope(t *testing.T) {
+	newGlobalHook := func(scopes ...string) *v1.GlobalHook {
+		return &v1.GlobalHook{
+			Hook: &v1.Hook{
+				Conditions: []v1.Hook_Condition{v1.Hook_CONDITION_ANY_ERROR},
+				Action:     &v1.Hook_ActionCommand{ActionCommand: &v1.Hook_Command{Command: "true"}},
+			},
+			Scopes: scopes,
+		}
+	}
+	config := &v1.Config{
+		Instance: "test",
+		Repos:    []*v1.Repo{{Id: "repo1"}, {Id: "repo2"}},
+		Plans:    []*v1.Plan{{Id: "plan1", Repo: "repo1"}, {Id: "plan2", Repo: "repo2"}},
+		GlobalHooks: []*v1.GlobalHook{
+			newGlobalHook(),                   // everything.
+			newGlobalHook("repo:repo1"),       // repo1 and its plans.
+			newGlobalHook("plan:plan2"),       // only plan2.
+			newGlobalHook("*", "!plan:plan1"), // everything except plan1.
+			newGlobalHook("*", "!repo:repo2"), // everything except repo2 and its plans.
+		},
+	}
+
+	tests := []struct {
+		repoID, planID string
+		want           []string
+	}{
+		{repoID: "repo1", planID: "plan1", want: []string{"0", "1", "4"}},
+		{repoID: "repo2", planID: "plan2", want: []string{"0", "2", "3"}},
+		{repoID: "repo1", planID: "", want: []string{"0", "1", "3", "4"}},
+	}
+
+	for _, tc := range tests {
+		taskSet, err := TasksTriggeredByEvent(config, tc.repoID, tc.planID, nil, []v1.Hook_Condition{v1.Hook_CONDITION_ANY_ERROR}, struct{}{})
+		if err != nil {
+			t.Fatalf("TasksTriggeredByEvent() error = %v", err)
+		}
+		var got []string
+		for _, task := range taskSet {
+			name := task.Name()
+			got = append(got, name[len(name)-1:])
+		}
+		if !slices.Equal(got, tc.want) {
+			t.Errorf("repo %q plan %q: got global hooks %v, want %v", tc.repoID, tc.planID, got, tc.want)
+		}
+	}
+}
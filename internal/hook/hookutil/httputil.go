@@ -1,11 +1,17 @@
 package hookutil
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 )
 
+// maxResponseBodyBytes limits how much of a response body is read, hook responses are only logged.
+const maxResponseBodyBytes = 64 * 1024
+
 func PostRequest(url string, contentType string, body io.Reader) (string, error) {
 	r, err := http.Post(url, contentType, body)
 	if err != nil {
@@ -23,3 +29,88 @@ func PostRequest(url string, contentType string, body io.Reader) (string, error)
 	}
 	return string(bodyBytes), nil
 }
+
+// Request describes an HTTP request sent by DoRequest.
+type Request struct {
+	Method     string
+	URL        string
+	Header     http.Header
+	Body       []byte
+	Client     *http.Client // defaults to http.DefaultClient.
+	MaxRetries int          // retries on connection errors and 5xx responses.
+	Backoff    time.Duration
+}
+
+// Response is the result of the last attempt made by DoRequest.
+type Response struct {
+	StatusCode int
+	Body       string // truncated to at most 64 KiB.
+	Attempts   int
+}
+
+// DoRequest sends the request, retrying with exponential backoff (starting at Backoff, default 1s) on connection errors and 5xx responses.
+// An error is returned for any non-2xx response, the response of the last attempt is returned alongside it if one was received.
+func DoRequest(ctx context.Context, req Request) (*Response, error) {
+	client := req.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	backoff := req.Backoff
+	if backoff == 0 {
+		backoff = time.Second
+	}
+
+	var resp *Response
+	var err error
+	for attempt := 0; attempt <= req.MaxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return resp, ctx.Err()
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+
+		var retryable bool
+		resp, retryable, err = doOnce(ctx, client, req)
+		if resp != nil {
+			resp.Attempts = attempt + 1
+		}
+		if err == nil || !retryable {
+			break
+		}
+	}
+	return resp, err
+}
+
+func doOnce(ctx context.Context, client *http.Client, req Request) (*Response, bool, error) {
+	r, err := http.NewRequestWithContext(ctx, req.Method, req.URL, bytes.NewReader(req.Body))
+	if err != nil {
+		return nil, false, fmt.Errorf("create request %v: %w", req.URL, err)
+	}
+	for name, values := range req.Header {
+		for _, v := range values {
+			r.Header.Add(name, v)
+		}
+	}
+
+	res, err := client.Do(r)
+	if err != nil {
+		return nil, ctx.Err() == nil, fmt.Errorf("send request %v: %w", req.URL, err)
+	}
+	defer res.Body.Close()
+
+	bodyBytes, err := io.ReadAll(io.LimitReader(res.Body, maxResponseBodyBytes))
+	if err != nil {
+		return nil, true, fmt.Errorf("read response: %w", err)
+	}
+	resp := &Response{
+		StatusCode: res.StatusCode,
+		Body:       string(bodyBytes),
+	}
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return resp, res.StatusCode >= 500, fmt.Errorf("unexpected status %v: %s", res.StatusCode, res.Status)
+	}
+	return resp, false, nil
+}
@@ -1,13 +1,17 @@
 package types
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"os"
 	"os/exec"
 	"reflect"
 	"runtime"
 	"strings"
+	"time"
 
 	v1 "github.com/garethgeorge/backrest/gen/go/v1"
 	"github.com/garethgeorge/backrest/internal/hook/hookutil"
@@ -18,6 +22,9 @@ import (
 	"github.com/google/shlex"
 )
 
+// commandWaitDelay bounds how long a cancelled command's output is drained, children of the shell may hold it open.
+const commandWaitDelay = 5 * time.Second
+
 type commandHandler struct{}
 
 func (commandHandler) Name() string {
@@ -52,22 +59,128 @@ func (commandHandler) Execute(ctx context.Context, h *v1.Hook, vars interface{},
 		command = command[nextLine+1:]
 	}
 
+	// Run the command in the specified shell, the context bounds its runtime e.g. when the hook has a timeout.
+	execCmd := exec.CommandContext(ctx, shell[0], shell[1:]...)
+	execCmd.WaitDelay = commandWaitDelay
+	platformutil.SetPlatformOptions(execCmd)
+	platformutil.KillProcessGroupOnCancel(execCmd)
+	var outputEnv []string
+	if hv, ok := hookVars(vars); ok {
+		outputEnv = hv.Output.EnvList()
+	}
+	if err := applyCommandOptions(execCmd, h.GetActionCommand(), outputEnv); err != nil {
+		return err
+	}
+	execCmd.Stdin = strings.NewReader(command)
+
 	scriptWriter := &ioutil.LinePrefixer{W: writer, Prefix: []byte("[script] ")}
 	fmt.Fprintf(scriptWriter, "%v\n%v\n", shell, command)
 	scriptWriter.Close()
 	outputWriter := &ioutil.LinePrefixer{W: writer, Prefix: []byte("[output] ")}
-	defer outputWriter.Close()
 
-	// Run the command in the specified shell
-	execCmd := exec.Command(shell[0], shell[1:]...)
-	platformutil.SetPlatformOptions(execCmd)
-	execCmd.Stdin = strings.NewReader(command)
-
-	stdout := &ioutil.SynchronizedWriter{W: outputWriter}
+	var output io.Writer = outputWriter
+	var limiter *ioutil.LimitWriter
+	if limit := h.GetActionCommand().GetMaxOutputBytes(); limit > 0 {
+		limiter = &ioutil.LimitWriter{W: outputWriter, N: int(limit)}
+		output = limiter
+	}
+	stdout := &ioutil.SynchronizedWriter{W: output}
+	scanner := &hookOutputScanner{}
 	execCmd.Stderr = stdout
-	execCmd.Stdout = stdout
+	execCmd.Stdout = io.MultiWriter(stdout, scanner)
+
+	err = execCmd.Run()
+	outputWriter.Close()
+	if limiter != nil && limiter.D > 0 {
+		fmt.Fprintf(writer, "\n[output truncated, dropped %d bytes]\n", limiter.D)
+	}
+	if err != nil {
+		return err
+	}
 
-	return execCmd.Run()
+	for _, payload := range scanner.Payloads() {
+		out, err := tasks.ParseHookOutput(payload)
+		if err != nil {
+			return err
+		}
+		tasks.AddHookOutput(ctx, out)
+	}
+	return nil
+}
+
+// applyCommandOptions sets the working directory, environment and user of the command.
+// outputEnv holds env emitted by earlier hooks, variables configured on the hook take precedence.
+func applyCommandOptions(cmd *exec.Cmd, opts *v1.Hook_Command, outputEnv []string) error {
+	cmd.Dir = opts.GetWorkingDir()
+
+	for _, kv := range opts.GetEnv() {
+		if k, _, ok := strings.Cut(kv, "="); !ok || k == "" {
+			return fmt.Errorf("invalid env var %q, expected KEY=VALUE", kv)
+		}
+	}
+	if len(outputEnv) > 0 || len(opts.GetEnv()) > 0 {
+		cmd.Env = append(append(os.Environ(), outputEnv...), opts.GetEnv()...)
+	}
+
+	if opts.GetUser() != "" {
+		if err := platformutil.SetUser(cmd, opts.GetUser()); err != nil {
+			return fmt.Errorf("run as user: %w", err)
+		}
+	}
+	return nil
+}
+
+// hookOutputMaxLineBytes bounds the memory used to buffer a line of stdout while looking for structured output.
+const hookOutputMaxLineBytes = 64 * 1024
+
+// hookOutputScanner collects the payloads of stdout lines prefixed with tasks.HookOutputMarker.
+type hookOutputScanner struct {
+	buf      []byte
+	skip     bool // whether the rest of the current line is dropped for exceeding hookOutputMaxLineBytes.
+	payloads [][]byte
+}
+
+func (s *hookOutputScanner) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		i := bytes.IndexByte(p, '\n')
+		if i < 0 {
+			s.append(p)
+			break
+		}
+		s.append(p[:i])
+		s.endLine()
+		p = p[i+1:]
+	}
+	return n, nil
+}
+
+func (s *hookOutputScanner) append(p []byte) {
+	if s.skip {
+		return
+	}
+	if len(s.buf)+len(p) > hookOutputMaxLineBytes {
+		s.buf = s.buf[:0]
+		s.skip = true
+		return
+	}
+	s.buf = append(s.buf, p...)
+}
+
+func (s *hookOutputScanner) endLine() {
+	if payload, ok := bytes.CutPrefix(bytes.TrimRight(s.buf, "\r"), []byte(tasks.HookOutputMarker)); ok && !s.skip {
+		s.payloads = append(s.payloads, bytes.Clone(payload))
+	}
+	s.buf = s.buf[:0]
+	s.skip = false
+}
+
+// Payloads returns the payloads of the marked lines in the order they were written, including an unterminated last line.
+func (s *hookOutputScanner) Payloads() [][]byte {
+	if len(s.buf) > 0 {
+		s.endLine()
+	}
+	return s.payloads
 }
 
 func (commandHandler) ActionType() reflect.Type {
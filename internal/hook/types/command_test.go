@@ -0,0 +1,118 @@
+package types
+
+import (
+	"bytes"
+	"context"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/orchestrator/logging"
+	"github.com/garethgeorge/backrest/internal/orchestrator/tasks"
+)
+
+func runCommandHook(t *testing.T, ctx context.Context, cmd *v1.Hook_Command) (string, error) {
+	t.Helper()
+	var buf bytes.Buffer
+	ctx = logging.ContextWithWriter(ctx, &buf)
+	hook := &v1.Hook{Action: &v1.Hook_ActionCommand{ActionCommand: cmd}}
+	err := commandHandler{}.Execute(ctx, hook, tasks.HookVars{}, nil, v1.Hook_CONDITION_SNAPSHOT_START)
+	return buf.String(), err
+}
+
+func TestCommandOptions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a posix shell")
+	}
+	dir := t.TempDir()
+
+	out, err := runCommandHook(t, context.Background(), &v1.Hook_Command{
+		Command:    "pwd; echo $BACKREST_TEST_VAR",
+		WorkingDir: dir,
+		Env:        []string{"BACKREST_TEST_VAR=hello"},
+	})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !strings.Contains(out, "[output] "+dir+"\n") || !strings.Contains(out, "[output] hello\n") {
+		t.Errorf("unexpected output %q", out)
+	}
+
+	if _, err := runCommandHook(t, context.Background(), &v1.Hook_Command{Command: "true", Env: []string{"NOEQUALS"}}); err == nil {
+		t.Errorf("expected an error for a malformed env var")
+	}
+}
+
+func TestCommandMaxOutputBytes(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a posix shell")
+	}
+
+	out, err := runCommandHook(t, context.Background(), &v1.Hook_Command{
+		Command:        "echo 0123456789",
+		MaxOutputBytes: 4,
+	})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !strings.Contains(out, "[output] 0123") || strings.Contains(out, "[output] 01234") {
+		t.Errorf("output not truncated: %q", out)
+	}
+	if !strings.Contains(out, "dropped 7 bytes") {
+		t.Errorf("missing truncation note: %q", out)
+	}
+}
+
+func TestCommandStopsWithContext(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a posix shell")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if _, err := runCommandHook(t, ctx, &v1.Hook_Command{Command: "sleep 30"}); err == nil {
+		t.Errorf("expected an error for a cancelled command")
+	}
+	if d := time.Since(start); d > 10*time.Second {
+		t.Errorf("command ran for %v after its context was done", d)
+	}
+}
+
+func TestCommandStructuredOutput(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a posix shell")
+	}
+	ctx := tasks.ContextWithHookOutput(context.Background())
+
+	_, err := runCommandHook(t, ctx, &v1.Hook_Command{
+		Command: `echo 'dumping'
+echo '::backrest-output::{"paths": ["/var/backups/db.sql"], "env": {"DUMP_ID": "42"}}'
+printf '::backrest-output::{"tags": ["db"]}'`,
+	})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	out := tasks.HookOutputFromContext(ctx)
+	if !slices.Equal(out.Paths, []string{"/var/backups/db.sql"}) || !slices.Equal(out.Tags, []string{"db"}) || out.Env["DUMP_ID"] != "42" {
+		t.Errorf("unexpected hook output %+v", out)
+	}
+
+	// env emitted by earlier hooks is visible to later command hooks.
+	var buf bytes.Buffer
+	hook := &v1.Hook{Action: &v1.Hook_ActionCommand{ActionCommand: &v1.Hook_Command{Command: "echo $DUMP_ID"}}}
+	if err := (commandHandler{}).Execute(logging.ContextWithWriter(ctx, &buf), hook, tasks.HookVars{Output: out}, nil, v1.Hook_CONDITION_SNAPSHOT_END); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "[output] 42\n") {
+		t.Errorf("unexpected output %q", buf.String())
+	}
+
+	if _, err := runCommandHook(t, ctx, &v1.Hook_Command{Command: "echo '::backrest-output::{broken'"}); err == nil {
+		t.Errorf("expected an error for malformed structured output")
+	}
+}
@@ -0,0 +1,273 @@
+package types
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/hook/hookutil"
+	"github.com/garethgeorge/backrest/internal/orchestrator/tasks"
+	"go.uber.org/zap"
+)
+
+const (
+	emailTimeout         = 30 * time.Second
+	emailDefaultSubject  = `Backrest: {{ .EventName .Event }} for {{ .Task }}`
+	emailAttachmentName  = "log.txt"
+	emailBase64LineWidth = 76
+)
+
+type emailHandler struct{}
+
+func (emailHandler) Name() string {
+	return "email"
+}
+
+func (emailHandler) Execute(ctx context.Context, h *v1.Hook, vars interface{}, runner tasks.TaskRunner, event v1.Hook_Condition) error {
+	e := h.GetActionEmail()
+
+	body, err := hookutil.RenderTemplateOrDefault(e.GetTemplate(), hookutil.DefaultTemplate, vars)
+	if err != nil {
+		return fmt.Errorf("template rendering: %w", err)
+	}
+
+	subject, err := hookutil.RenderTemplateOrDefault(e.GetSubjectTemplate(), emailDefaultSubject, vars)
+	if err != nil {
+		return fmt.Errorf("subject template rendering: %w", err)
+	}
+
+	l := runner.Logger(ctx)
+
+	var logExcerpt []byte
+	if e.GetAttachLogBytes() > 0 {
+		if hv, ok := hookVars(vars); ok && hv.Logref != "" {
+			logExcerpt, err = runner.LogTail(hv.Logref, int64(e.GetAttachLogBytes()))
+			if err != nil {
+				// the notification is more important than the excerpt, send it without the attachment.
+				l.Sugar().Warnf("failed to read log excerpt for %q: %v", hv.Logref, err)
+				logExcerpt = nil
+			}
+		}
+	}
+
+	msg, err := emailMessage(e, subject, body, logExcerpt, time.Now())
+	if err != nil {
+		return err
+	}
+
+	l.Sugar().Infof("Sending email to %s via %s", strings.Join(e.GetTo(), ", "), e.GetHost())
+	l.Debug("Sending email", zap.String("subject", subject), zap.String("body", body))
+
+	if err := sendEmail(ctx, e, msg); err != nil {
+		return fmt.Errorf("send email via %q: %w", e.GetHost(), err)
+	}
+	return nil
+}
+
+func (emailHandler) ActionType() reflect.Type {
+	return reflect.TypeOf(&v1.Hook_ActionEmail{})
+}
+
+// hookVars extracts the HookVars from the vars passed to a handler, these may be wrapped in a reflect.Value.
+func hookVars(vars interface{}) (tasks.HookVars, bool) {
+	if v, ok := vars.(reflect.Value); ok {
+		if !v.IsValid() || !v.CanInterface() {
+			return tasks.HookVars{}, false
+		}
+		vars = v.Interface()
+	}
+	hv, ok := vars.(tasks.HookVars)
+	return hv, ok
+}
+
+// emailMessage builds the RFC 5322 message, the log excerpt is attached as a text file if it is non-empty.
+func emailMessage(e *v1.Hook_Email, subject, body string, logExcerpt []byte, now time.Time) ([]byte, error) {
+	if e.GetFrom() == "" {
+		return nil, errors.New("email: no sender address")
+	}
+	if len(e.GetTo()) == 0 {
+		return nil, errors.New("email: no recipients")
+	}
+	for _, addr := range append([]string{e.GetFrom()}, e.GetTo()...) {
+		if strings.ContainsAny(addr, "\r\n") {
+			return nil, fmt.Errorf("email: invalid address %q", addr)
+		}
+	}
+
+	contentType := "text/plain; charset=utf-8"
+	if e.GetHtml() {
+		contentType = "text/html; charset=utf-8"
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", e.GetFrom())
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(e.GetTo(), ", "))
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", strings.TrimSpace(subject)))
+	fmt.Fprintf(&buf, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+
+	if len(logExcerpt) == 0 {
+		fmt.Fprintf(&buf, "Content-Type: %s\r\n", contentType)
+		buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+		if err := writeQuotedPrintable(&buf, body); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	mw := multipart.NewWriter(&buf)
+	fmt.Fprintf(&buf, "Content-Type: multipart/mixed; boundary=%q\r\n\r\n", mw.Boundary())
+
+	part, err := mw.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {contentType},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create body part: %w", err)
+	}
+	if err := writeQuotedPrintable(part, body); err != nil {
+		return nil, err
+	}
+
+	part, err = mw.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {fmt.Sprintf("text/plain; charset=utf-8; name=%q", emailAttachmentName)},
+		"Content-Disposition":       {fmt.Sprintf("attachment; filename=%q", emailAttachmentName)},
+		"Content-Transfer-Encoding": {"base64"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create attachment part: %w", err)
+	}
+	encoded := base64.StdEncoding.EncodeToString(logExcerpt)
+	for len(encoded) > emailBase64LineWidth {
+		fmt.Fprintf(part, "%s\r\n", encoded[:emailBase64LineWidth])
+		encoded = encoded[emailBase64LineWidth:]
+	}
+	fmt.Fprintf(part, "%s\r\n", encoded)
+
+	if err := mw.Close(); err != nil {
+		return nil, fmt.Errorf("close multipart writer: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func writeQuotedPrintable(w io.Writer, body string) error {
+	qp := quotedprintable.NewWriter(w)
+	if _, err := qp.Write([]byte(body)); err != nil {
+		return fmt.Errorf("encode body: %w", err)
+	}
+	return qp.Close()
+}
+
+// sendEmail delivers the message to every recipient using the server and security settings of the hook.
+func sendEmail(ctx context.Context, e *v1.Hook_Email, msg []byte) error {
+	if e.GetHost() == "" {
+		return errors.New("no SMTP host")
+	}
+
+	port := int(e.GetPort())
+	if port == 0 {
+		if e.GetSecurity() == v1.Hook_Email_SECURITY_TLS {
+			port = 465
+		} else {
+			port = 587
+		}
+	}
+	addr := net.JoinHostPort(e.GetHost(), strconv.Itoa(port))
+
+	tlsConfig := &tls.Config{
+		ServerName:         e.GetHost(),
+		InsecureSkipVerify: e.GetTlsSkipVerify(),
+	}
+
+	dialer := &net.Dialer{Timeout: emailTimeout}
+	var conn net.Conn
+	var err error
+	if e.GetSecurity() == v1.Hook_Email_SECURITY_TLS {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: tlsConfig}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return fmt.Errorf("dial %v: %w", addr, err)
+	}
+
+	deadline := time.Now().Add(emailTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	conn.SetDeadline(deadline)
+
+	c, err := smtp.NewClient(conn, e.GetHost())
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("smtp handshake: %w", err)
+	}
+	defer c.Close()
+
+	if e.GetSecurity() == v1.Hook_Email_SECURITY_STARTTLS {
+		if ok, _ := c.Extension("STARTTLS"); !ok {
+			return errors.New("server does not support STARTTLS")
+		}
+		if err := c.StartTLS(tlsConfig); err != nil {
+			return fmt.Errorf("starttls: %w", err)
+		}
+	}
+
+	if e.GetUsername() != "" {
+		if ok, _ := c.Extension("AUTH"); !ok {
+			return errors.New("server does not support authentication")
+		}
+		if err := c.Auth(smtp.PlainAuth("", e.GetUsername(), e.GetPassword(), e.GetHost())); err != nil {
+			return fmt.Errorf("auth: %w", err)
+		}
+	}
+
+	if err := c.Mail(envelopeAddress(e.GetFrom())); err != nil {
+		return fmt.Errorf("mail from %q: %w", e.GetFrom(), err)
+	}
+	for _, to := range e.GetTo() {
+		if err := c.Rcpt(envelopeAddress(to)); err != nil {
+			return fmt.Errorf("rcpt to %q: %w", to, err)
+		}
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return fmt.Errorf("data: %w", err)
+	}
+	if _, err := w.Write(msg); err != nil {
+		return fmt.Errorf("write message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("write message: %w", err)
+	}
+	return c.Quit()
+}
+
+// envelopeAddress strips the display name from addresses of the form "Name <user@example.com>".
+func envelopeAddress(addr string) string {
+	if a, err := mail.ParseAddress(addr); err == nil {
+		return a.Address
+	}
+	return addr
+}
+
+func init() {
+	DefaultRegistry().RegisterHandler(&emailHandler{})
+}
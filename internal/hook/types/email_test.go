@@ -0,0 +1,275 @@
+package types
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/http/httptest"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+)
+
+type receivedEmail struct {
+	from string
+	to   []string
+	auth string // decoded AUTH PLAIN credentials.
+	tls  bool
+	data string
+}
+
+// smtpStandIn is a minimal SMTP server that accepts a single message per connection.
+type smtpStandIn struct {
+	ln        net.Listener
+	tlsConfig *tls.Config // if set, STARTTLS is advertised.
+	implicit  bool        // connections are wrapped in TLS before the greeting.
+
+	mu       sync.Mutex
+	received []receivedEmail
+}
+
+func newSMTPStandIn(t *testing.T, starttls, implicit bool) *smtpStandIn {
+	t.Helper()
+
+	// borrow the self-signed certificate generated by httptest.
+	ts := httptest.NewTLSServer(nil)
+	tlsConfig := &tls.Config{Certificates: ts.TLS.Certificates}
+	ts.Close()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	s := &smtpStandIn{ln: ln, implicit: implicit}
+	if implicit {
+		s.ln = tls.NewListener(ln, tlsConfig)
+	} else if starttls {
+		s.tlsConfig = tlsConfig
+	}
+	t.Cleanup(func() { s.ln.Close() })
+
+	go func() {
+		for {
+			conn, err := s.ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *smtpStandIn) port() int32 {
+	return int32(s.ln.Addr().(*net.TCPAddr).Port)
+}
+
+func (s *smtpStandIn) messages() []receivedEmail {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]receivedEmail(nil), s.received...)
+}
+
+func (s *smtpStandIn) serve(conn net.Conn) {
+	defer func() { conn.Close() }()
+	conn.SetDeadline(time.Now().Add(10 * time.Second))
+
+	tp := textproto.NewConn(conn)
+	msg := receivedEmail{tls: s.implicit}
+	tp.PrintfLine("220 localhost ESMTP stand-in")
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			tp.PrintfLine("250-localhost")
+			if s.tlsConfig != nil && !msg.tls {
+				tp.PrintfLine("250-STARTTLS")
+			}
+			tp.PrintfLine("250 AUTH PLAIN")
+		case "STARTTLS":
+			tp.PrintfLine("220 ready to start TLS")
+			tlsConn := tls.Server(conn, s.tlsConfig)
+			if err := tlsConn.Handshake(); err != nil {
+				return
+			}
+			conn = tlsConn
+			tp = textproto.NewConn(conn)
+			msg.tls = true
+		case "AUTH":
+			_, creds, _ := strings.Cut(arg, " ")
+			decoded, _ := base64.StdEncoding.DecodeString(creds)
+			msg.auth = strings.ReplaceAll(string(decoded), "\x00", ":")
+			tp.PrintfLine("235 authenticated")
+		case "MAIL":
+			msg.from = strings.Trim(strings.TrimPrefix(arg, "FROM:"), "<>")
+			tp.PrintfLine("250 ok")
+		case "RCPT":
+			msg.to = append(msg.to, strings.Trim(strings.TrimPrefix(arg, "TO:"), "<>"))
+			tp.PrintfLine("250 ok")
+		case "DATA":
+			tp.PrintfLine("354 send data")
+			data, err := tp.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			msg.data = string(data)
+			s.mu.Lock()
+			s.received = append(s.received, msg)
+			s.mu.Unlock()
+			tp.PrintfLine("250 queued")
+		case "QUIT":
+			tp.PrintfLine("221 bye")
+			return
+		default:
+			tp.PrintfLine("502 not implemented")
+		}
+	}
+}
+
+func testEmailHook(port int32, security v1.Hook_Email_Security) *v1.Hook_Email {
+	return &v1.Hook_Email{
+		Host:          "127.0.0.1",
+		Port:          port,
+		Security:      security,
+		TlsSkipVerify: true,
+		Username:      "user",
+		Password:      "pass",
+		From:          "Backrest <backrest@example.com>",
+		To:            []string{"a@example.com", "b@example.com"},
+	}
+}
+
+func TestEmailSendStartTLS(t *testing.T) {
+	s := newSMTPStandIn(t, true, false)
+	e := testEmailHook(s.port(), v1.Hook_Email_SECURITY_STARTTLS)
+
+	msg, err := emailMessage(e, "backup done", "hello", nil, time.Now())
+	if err != nil {
+		t.Fatalf("emailMessage() error = %v", err)
+	}
+	if err := sendEmail(context.Background(), e, msg); err != nil {
+		t.Fatalf("sendEmail() error = %v", err)
+	}
+
+	received := s.messages()
+	if len(received) != 1 {
+		t.Fatalf("got %d messages, want 1", len(received))
+	}
+	got := received[0]
+	if !got.tls {
+		t.Errorf("message was not sent over TLS")
+	}
+	if got.auth != ":user:pass" {
+		t.Errorf("got auth %q, want %q", got.auth, ":user:pass")
+	}
+	if got.from != "backrest@example.com" {
+		t.Errorf("got envelope sender %q", got.from)
+	}
+	if strings.Join(got.to, ",") != "a@example.com,b@example.com" {
+		t.Errorf("got envelope recipients %v", got.to)
+	}
+	if !strings.Contains(got.data, "Subject: backup done") {
+		t.Errorf("message is missing the subject:\n%s", got.data)
+	}
+}
+
+func TestEmailSendImplicitTLS(t *testing.T) {
+	s := newSMTPStandIn(t, false, true)
+	e := testEmailHook(s.port(), v1.Hook_Email_SECURITY_TLS)
+
+	msg, err := emailMessage(e, "backup done", "hello", nil, time.Now())
+	if err != nil {
+		t.Fatalf("emailMessage() error = %v", err)
+	}
+	if err := sendEmail(context.Background(), e, msg); err != nil {
+		t.Fatalf("sendEmail() error = %v", err)
+	}
+	if received := s.messages(); len(received) != 1 || !received[0].tls {
+		t.Errorf("got %+v, want one message sent over TLS", received)
+	}
+}
+
+func TestEmailStartTLSRequired(t *testing.T) {
+	s := newSMTPStandIn(t, false, false)
+	e := testEmailHook(s.port(), v1.Hook_Email_SECURITY_STARTTLS)
+
+	if err := sendEmail(context.Background(), e, []byte("hello")); err == nil {
+		t.Errorf("expected an error when the server does not support STARTTLS")
+	}
+
+	e = testEmailHook(s.port(), v1.Hook_Email_SECURITY_NONE)
+	if err := sendEmail(context.Background(), e, []byte("hello")); err != nil {
+		t.Errorf("sendEmail() without TLS error = %v", err)
+	}
+}
+
+func TestEmailMessageAttachment(t *testing.T) {
+	e := testEmailHook(0, v1.Hook_Email_SECURITY_NONE)
+	e.Html = true
+
+	excerpt := strings.Repeat("log line\n", 50)
+	msg, err := emailMessage(e, "résumé\nof backup", "<b>hello</b>", []byte(excerpt), time.Now())
+	if err != nil {
+		t.Fatalf("emailMessage() error = %v", err)
+	}
+
+	m, err := mail.ReadMessage(strings.NewReader(string(msg)))
+	if err != nil {
+		t.Fatalf("parse message: %v", err)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(m.Header.Get("Subject"))
+	if err != nil || subject != "résumé\nof backup" {
+		t.Errorf("got subject %q (err %v)", subject, err)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(m.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/mixed" {
+		t.Fatalf("got content type %q (err %v), want multipart/mixed", mediaType, err)
+	}
+
+	mr := multipart.NewReader(m.Body, params["boundary"])
+	body, err := mr.NextPart() // quoted-printable is decoded by the multipart reader.
+	if err != nil {
+		t.Fatalf("read body part: %v", err)
+	}
+	if ct := body.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/html") {
+		t.Errorf("got body content type %q, want text/html", ct)
+	}
+	if b, _ := io.ReadAll(body); string(b) != "<b>hello</b>" {
+		t.Errorf("got body %q", b)
+	}
+
+	attachment, err := mr.NextPart()
+	if err != nil {
+		t.Fatalf("read attachment part: %v", err)
+	}
+	if attachment.FileName() != "log.txt" {
+		t.Errorf("got attachment name %q, want log.txt", attachment.FileName())
+	}
+	b, err := io.ReadAll(base64.NewDecoder(base64.StdEncoding, bufio.NewReader(attachment)))
+	if err != nil || string(b) != excerpt {
+		t.Errorf("got attachment %q (err %v)", b, err)
+	}
+}
+
+func TestEmailMessageRequiresRecipients(t *testing.T) {
+	e := testEmailHook(0, v1.Hook_Email_SECURITY_NONE)
+	e.To = nil
+	if _, err := emailMessage(e, "subject", "body", nil, time.Now()); err == nil {
+		t.Errorf("expected an error for a message without recipients")
+	}
+}
@@ -0,0 +1,99 @@
+package types
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/hook/hookutil"
+	"github.com/garethgeorge/backrest/internal/orchestrator/tasks"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type matrixHandler struct{}
+
+func (matrixHandler) Name() string {
+	return "matrix"
+}
+
+func (matrixHandler) Execute(ctx context.Context, h *v1.Hook, vars interface{}, runner tasks.TaskRunner, event v1.Hook_Condition) error {
+	m := h.GetActionMatrix()
+
+	payload, err := hookutil.RenderTemplateOrDefault(m.GetTemplate(), hookutil.DefaultTemplate, vars)
+	if err != nil {
+		return fmt.Errorf("template rendering: %w", err)
+	}
+
+	req, err := matrixRequest(m, uuid.New().String(), payload)
+	if err != nil {
+		return err
+	}
+
+	l := runner.Logger(ctx)
+	l.Sugar().Infof("Sending matrix message to room %s", m.GetRoomId())
+	l.Debug("Sending matrix message", zap.ByteString("message", req.Body))
+
+	resp, err := hookutil.DoRequest(ctx, req)
+	if err != nil {
+		if resp != nil {
+			return fmt.Errorf("send matrix message: %w: %s", err, resp.Body)
+		}
+		return fmt.Errorf("send matrix message: %w", err)
+	}
+
+	l.Sugar().Debugf("Matrix response: %s", resp.Body)
+
+	return nil
+}
+
+func (matrixHandler) ActionType() reflect.Type {
+	return reflect.TypeOf(&v1.Hook_ActionMatrix{})
+}
+
+// matrixRequest builds a client-server API request sending an m.text message to the room.
+// The transaction ID makes the request idempotent, retries with the same ID are only delivered once.
+func matrixRequest(m *v1.Hook_Matrix, txnID, payload string) (hookutil.Request, error) {
+	if m.GetHomeserverUrl() == "" || m.GetRoomId() == "" || m.GetAccessToken() == "" {
+		return hookutil.Request{}, errors.New("matrix: homeserver URL, room ID and access token are required")
+	}
+
+	message := struct {
+		MsgType string `json:"msgtype"`
+		Body    string `json:"body"`
+	}{
+		MsgType: "m.text",
+		Body:    payload,
+	}
+
+	b, err := json.Marshal(message)
+	if err != nil {
+		return hookutil.Request{}, fmt.Errorf("json marshal: %w", err)
+	}
+
+	putURL := fmt.Sprintf("%s/_matrix/client/v3/rooms/%s/send/m.room.message/%s",
+		strings.TrimRight(m.GetHomeserverUrl(), "/"),
+		url.PathEscape(m.GetRoomId()),
+		url.PathEscape(txnID))
+
+	return hookutil.Request{
+		Method: http.MethodPut,
+		URL:    putURL,
+		Header: http.Header{
+			"Content-Type":  {"application/json"},
+			"Authorization": {"Bearer " + m.GetAccessToken()},
+		},
+		Body:   b,
+		Client: notifyClient,
+	}, nil
+}
+
+func init() {
+	DefaultRegistry().RegisterHandler(&matrixHandler{})
+}
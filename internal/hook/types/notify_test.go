@@ -0,0 +1,156 @@
+package types
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"testing"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/hook/hookutil"
+)
+
+func TestNtfyRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			t.Errorf("got path %q, want /", r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer tk_secret" {
+			t.Errorf("got authorization %q", got)
+		}
+		var msg struct {
+			Topic    string   `json:"topic"`
+			Message  string   `json:"message"`
+			Title    string   `json:"title"`
+			Priority int      `json:"priority"`
+			Tags     []string `json:"tags"`
+			Click    string   `json:"click"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		if msg.Topic != "backups" || msg.Message != "hello" || msg.Title != "title" || msg.Priority != 4 ||
+			!slices.Equal(msg.Tags, []string{"warning", "floppy_disk"}) || msg.Click != "https://backrest.local" {
+			t.Errorf("unexpected message %+v", msg)
+		}
+		w.Write([]byte(`{"id":"abc"}`))
+	}))
+	defer server.Close()
+
+	req, err := ntfyRequest(&v1.Hook_Ntfy{
+		BaseUrl:  server.URL + "/",
+		Topic:    "backups",
+		Token:    "tk_secret",
+		Priority: 4,
+		Tags:     []string{"warning", "floppy_disk"},
+		ClickUrl: "https://backrest.local",
+	}, "title", "hello")
+	if err != nil {
+		t.Fatalf("ntfyRequest() error = %v", err)
+	}
+	if _, err := hookutil.DoRequest(context.Background(), req); err != nil {
+		t.Errorf("DoRequest() error = %v", err)
+	}
+
+	req, err = ntfyRequest(&v1.Hook_Ntfy{Topic: "backups"}, "", "hello")
+	if err != nil {
+		t.Fatalf("ntfyRequest() error = %v", err)
+	}
+	if req.URL != "https://ntfy.sh/" || req.Header.Get("Authorization") != "" {
+		t.Errorf("got url %q and authorization %q, want the public server without auth", req.URL, req.Header.Get("Authorization"))
+	}
+	if req.Client == nil || req.Client.Timeout == 0 {
+		t.Errorf("want a client with a timeout")
+	}
+
+	if _, err := ntfyRequest(&v1.Hook_Ntfy{Topic: "backups", Priority: 6}, "", "hello"); err == nil {
+		t.Errorf("expected an error for an out of range priority")
+	}
+
+	if _, err := ntfyRequest(&v1.Hook_Ntfy{}, "", "hello"); err == nil {
+		t.Errorf("expected an error for a missing topic")
+	}
+}
+
+func TestPushoverRequest(t *testing.T) {
+	req, err := pushoverRequest(&v1.Hook_Pushover{
+		Token:    "app",
+		UserKey:  "user",
+		Priority: 2,
+		Sound:    "siren",
+	}, "title", "hello")
+	if err != nil {
+		t.Fatalf("pushoverRequest() error = %v", err)
+	}
+	if req.URL != pushoverAPIURL {
+		t.Errorf("got url %q", req.URL)
+	}
+	if req.Client == nil || req.Client.Timeout == 0 {
+		t.Errorf("want a client with a timeout")
+	}
+
+	form, err := url.ParseQuery(string(req.Body))
+	if err != nil {
+		t.Fatalf("parse body: %v", err)
+	}
+	want := url.Values{
+		"token":    {"app"},
+		"user":     {"user"},
+		"title":    {"title"},
+		"message":  {"hello"},
+		"priority": {"2"},
+		"retry":    {"60"},
+		"expire":   {"3600"},
+		"sound":    {"siren"},
+	}
+	if form.Encode() != want.Encode() {
+		t.Errorf("got form %v, want %v", form, want)
+	}
+
+	if _, err := pushoverRequest(&v1.Hook_Pushover{Token: "app", UserKey: "user", Priority: 3}, "", ""); err == nil {
+		t.Errorf("expected an error for an out of range priority")
+	}
+}
+
+func TestMatrixRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("got method %q, want PUT", r.Method)
+		}
+		if want := "/_matrix/client/v3/rooms/!room:example.org/send/m.room.message/txn1"; r.URL.Path != want {
+			t.Errorf("got path %q, want %q", r.URL.Path, want)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer syt_token" {
+			t.Errorf("got authorization %q", got)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"msgtype":"m.text","body":"hello"}` {
+			t.Errorf("got body %s", body)
+		}
+		w.Write([]byte(`{"event_id":"$event"}`))
+	}))
+	defer server.Close()
+
+	req, err := matrixRequest(&v1.Hook_Matrix{
+		HomeserverUrl: server.URL,
+		RoomId:        "!room:example.org",
+		AccessToken:   "syt_token",
+	}, "txn1", "hello")
+	if err != nil {
+		t.Fatalf("matrixRequest() error = %v", err)
+	}
+	if req.Client == nil || req.Client.Timeout == 0 {
+		t.Errorf("want a client with a timeout")
+	}
+	if _, err := hookutil.DoRequest(context.Background(), req); err != nil {
+		t.Errorf("DoRequest() error = %v", err)
+	}
+
+	if _, err := matrixRequest(&v1.Hook_Matrix{HomeserverUrl: server.URL}, "txn1", "hello"); err == nil {
+		t.Errorf("expected an error for a missing room and token")
+	}
+}
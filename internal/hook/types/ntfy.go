@@ -0,0 +1,112 @@
+package types
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/hook/hookutil"
+	"github.com/garethgeorge/backrest/internal/orchestrator/tasks"
+	"go.uber.org/zap"
+)
+
+const ntfyDefaultBaseURL = "https://ntfy.sh"
+
+type ntfyHandler struct{}
+
+func (ntfyHandler) Name() string {
+	return "ntfy"
+}
+
+func (ntfyHandler) Execute(ctx context.Context, h *v1.Hook, vars interface{}, runner tasks.TaskRunner, event v1.Hook_Condition) error {
+	n := h.GetActionNtfy()
+
+	payload, err := hookutil.RenderTemplateOrDefault(n.GetTemplate(), hookutil.DefaultTemplate, vars)
+	if err != nil {
+		return fmt.Errorf("template rendering: %w", err)
+	}
+
+	title, err := hookutil.RenderTemplateOrDefault(n.GetTitleTemplate(), "Backrest Event", vars)
+	if err != nil {
+		return fmt.Errorf("title template rendering: %w", err)
+	}
+
+	req, err := ntfyRequest(n, title, payload)
+	if err != nil {
+		return err
+	}
+
+	l := runner.Logger(ctx)
+	l.Sugar().Infof("Sending ntfy message to topic %q on %s", n.GetTopic(), req.URL)
+	l.Debug("Sending ntfy message", zap.ByteString("message", req.Body))
+
+	resp, err := hookutil.DoRequest(ctx, req)
+	if err != nil {
+		return fmt.Errorf("send ntfy message: %w", err)
+	}
+
+	l.Sugar().Debugf("Ntfy response: %s", resp.Body)
+
+	return nil
+}
+
+func (ntfyHandler) ActionType() reflect.Type {
+	return reflect.TypeOf(&v1.Hook_ActionNtfy{})
+}
+
+// ntfyRequest builds a request publishing the message as JSON to the root of the ntfy server.
+func ntfyRequest(n *v1.Hook_Ntfy, title, payload string) (hookutil.Request, error) {
+	if n.GetTopic() == "" {
+		return hookutil.Request{}, errors.New("ntfy: no topic")
+	}
+	if n.GetPriority() < 0 || n.GetPriority() > 5 {
+		return hookutil.Request{}, fmt.Errorf("ntfy: priority %d out of range 0-5, 0 uses the server's default", n.GetPriority())
+	}
+
+	message := struct {
+		Topic    string   `json:"topic"`
+		Message  string   `json:"message"`
+		Title    string   `json:"title,omitempty"`
+		Priority int32    `json:"priority,omitempty"`
+		Tags     []string `json:"tags,omitempty"`
+		Click    string   `json:"click,omitempty"`
+	}{
+		Topic:    n.GetTopic(),
+		Message:  payload,
+		Title:    title,
+		Priority: n.GetPriority(),
+		Tags:     n.GetTags(),
+		Click:    n.GetClickUrl(),
+	}
+
+	b, err := json.Marshal(message)
+	if err != nil {
+		return hookutil.Request{}, fmt.Errorf("json marshal: %w", err)
+	}
+
+	baseURL := strings.TrimRight(n.GetBaseUrl(), "/")
+	if baseURL == "" {
+		baseURL = ntfyDefaultBaseURL
+	}
+
+	req := hookutil.Request{
+		Method: http.MethodPost,
+		URL:    baseURL + "/",
+		Header: http.Header{"Content-Type": {"application/json"}},
+		Body:   b,
+		Client: notifyClient,
+	}
+	if n.GetToken() != "" {
+		req.Header.Set("Authorization", "Bearer "+n.GetToken())
+	}
+	return req, nil
+}
+
+func init() {
+	DefaultRegistry().RegisterHandler(&ntfyHandler{})
+}
@@ -0,0 +1,109 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/hook/hookutil"
+	"github.com/garethgeorge/backrest/internal/orchestrator/tasks"
+)
+
+const (
+	pushoverAPIURL = "https://api.pushover.net/1/messages.json"
+
+	// emergency priority messages are repeated until acknowledged, pushover requires the interval and expiry.
+	pushoverEmergencyRetrySeconds  = 60
+	pushoverEmergencyExpireSeconds = 3600
+)
+
+type pushoverHandler struct{}
+
+func (pushoverHandler) Name() string {
+	return "pushover"
+}
+
+func (pushoverHandler) Execute(ctx context.Context, hook *v1.Hook, vars interface{}, runner tasks.TaskRunner, event v1.Hook_Condition) error {
+	p := hook.GetActionPushover()
+
+	payload, err := hookutil.RenderTemplateOrDefault(p.GetTemplate(), hookutil.DefaultTemplate, vars)
+	if err != nil {
+		return fmt.Errorf("template rendering: %w", err)
+	}
+
+	title, err := hookutil.RenderTemplateOrDefault(p.GetTitleTemplate(), "Backrest Event", vars)
+	if err != nil {
+		return fmt.Errorf("title template rendering: %w", err)
+	}
+
+	req, err := pushoverRequest(p, title, payload)
+	if err != nil {
+		return err
+	}
+
+	l := runner.Logger(ctx)
+	l.Sugar().Infof("Sending pushover message with priority %d", p.GetPriority())
+
+	resp, err := hookutil.DoRequest(ctx, req)
+	if err != nil {
+		if resp != nil {
+			// pushover explains rejected requests in the response body.
+			return fmt.Errorf("send pushover message: %w: %s", err, resp.Body)
+		}
+		return fmt.Errorf("send pushover message: %w", err)
+	}
+
+	l.Sugar().Debugf("Pushover response: %s", resp.Body)
+
+	return nil
+}
+
+func (pushoverHandler) ActionType() reflect.Type {
+	return reflect.TypeOf(&v1.Hook_ActionPushover{})
+}
+
+func pushoverRequest(p *v1.Hook_Pushover, title, payload string) (hookutil.Request, error) {
+	if p.GetToken() == "" || p.GetUserKey() == "" {
+		return hookutil.Request{}, errors.New("pushover: token and user key are required")
+	}
+	if p.GetPriority() < -2 || p.GetPriority() > 2 {
+		return hookutil.Request{}, fmt.Errorf("pushover: priority %d out of range -2 to 2", p.GetPriority())
+	}
+
+	form := url.Values{}
+	form.Set("token", p.GetToken())
+	form.Set("user", p.GetUserKey())
+	form.Set("title", title)
+	form.Set("message", payload)
+	form.Set("priority", strconv.Itoa(int(p.GetPriority())))
+	if p.GetPriority() == 2 {
+		form.Set("retry", strconv.Itoa(pushoverEmergencyRetrySeconds))
+		form.Set("expire", strconv.Itoa(pushoverEmergencyExpireSeconds))
+	}
+	if p.GetDevice() != "" {
+		form.Set("device", p.GetDevice())
+	}
+	if p.GetSound() != "" {
+		form.Set("sound", p.GetSound())
+	}
+	if p.GetUrl() != "" {
+		form.Set("url", p.GetUrl())
+	}
+
+	return hookutil.Request{
+		Method: http.MethodPost,
+		URL:    pushoverAPIURL,
+		Header: http.Header{"Content-Type": {"application/x-www-form-urlencoded"}},
+		Body:   []byte(form.Encode()),
+		Client: notifyClient,
+	}, nil
+}
+
+func init() {
+	DefaultRegistry().RegisterHandler(&pushoverHandler{})
+}
@@ -0,0 +1,26 @@
+package types
+
+import (
+	"fmt"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/hook/hookutil"
+)
+
+// RenderPayload renders the hook's main template as its handler would, the script for command hooks and the message
+// body for notification hooks. It lets a hook be previewed without sending anything.
+func RenderPayload(h *v1.Hook, vars interface{}) (string, error) {
+	if cmd, ok := h.GetAction().(*v1.Hook_ActionCommand); ok {
+		return hookutil.RenderTemplate(cmd.ActionCommand.GetCommand(), vars)
+	}
+
+	action := h.ProtoReflect().WhichOneof(h.ProtoReflect().Descriptor().Oneofs().ByName("action"))
+	if action == nil {
+		return "", fmt.Errorf("hook has no action: %w", ErrHandlerNotFound)
+	}
+	templated, ok := h.ProtoReflect().Get(action).Message().Interface().(interface{ GetTemplate() string })
+	if !ok {
+		return "", fmt.Errorf("hook action %v has no template", action.Name())
+	}
+	return hookutil.RenderTemplateOrDefault(templated.GetTemplate(), hookutil.DefaultTemplate, vars)
+}
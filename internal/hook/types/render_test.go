@@ -0,0 +1,52 @@
+package types
+
+import (
+	"testing"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/orchestrator/tasks"
+)
+
+func TestRenderPayload(t *testing.T) {
+	vars := tasks.HookVars{SnapshotId: "abc123"}
+
+	tests := []struct {
+		name    string
+		hook    *v1.Hook
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "command",
+			hook: &v1.Hook{Action: &v1.Hook_ActionCommand{ActionCommand: &v1.Hook_Command{Command: "echo {{ .SnapshotId }}"}}},
+			want: "echo abc123",
+		},
+		{
+			name: "notification template",
+			hook: &v1.Hook{Action: &v1.Hook_ActionDiscord{ActionDiscord: &v1.Hook_Discord{Template: "snapshot {{ .SnapshotId }}"}}},
+			want: "snapshot abc123",
+		},
+		{
+			name:    "template error",
+			hook:    &v1.Hook{Action: &v1.Hook_ActionGotify{ActionGotify: &v1.Hook_Gotify{Template: "{{ .SnapshotId"}}},
+			wantErr: true,
+		},
+		{
+			name:    "no action",
+			hook:    &v1.Hook{},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := RenderPayload(tc.hook, vars)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("RenderPayload() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if !tc.wantErr && got != tc.want {
+				t.Errorf("RenderPayload() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
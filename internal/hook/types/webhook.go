@@ -0,0 +1,161 @@
+package types
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/hook/hookutil"
+	"github.com/garethgeorge/backrest/internal/orchestrator/tasks"
+	"go.uber.org/zap"
+)
+
+const (
+	webhookDefaultTimeout = 30 * time.Second
+	webhookLogBodyLimit   = 512 // bytes of the response body included in the hook's log.
+	notifyTimeout         = 30 * time.Second
+)
+
+// notifyClient sends the requests of the notification services, which don't configure a timeout of their own.
+var notifyClient = &http.Client{Timeout: notifyTimeout}
+
+type webhookHandler struct {
+	backoff time.Duration // initial retry backoff, overridden in tests.
+}
+
+func (webhookHandler) Name() string {
+	return "webhook"
+}
+
+func (h webhookHandler) Execute(ctx context.Context, cmd *v1.Hook, vars interface{}, runner tasks.TaskRunner, event v1.Hook_Condition) error {
+	w := cmd.GetActionWebhook()
+
+	payload, err := hookutil.RenderTemplateOrDefault(w.GetTemplate(), hookutil.DefaultTemplate, vars)
+	if err != nil {
+		return fmt.Errorf("template rendering: %w", err)
+	}
+
+	req, err := webhookRequest(w, payload)
+	if err != nil {
+		return err
+	}
+	req.Backoff = h.backoff
+
+	client, err := webhookClient(w)
+	if err != nil {
+		return err
+	}
+	req.Client = client
+
+	l := runner.Logger(ctx)
+	l.Sugar().Infof("Sending webhook %s %s", req.Method, w.GetWebhookUrl())
+	l.Debug("Sending webhook", zap.String("payload", payload))
+
+	resp, err := hookutil.DoRequest(ctx, req)
+	if resp != nil {
+		l.Sugar().Infof("Webhook response (attempt %d): status %d, body: %s", resp.Attempts, resp.StatusCode, truncate(resp.Body, webhookLogBodyLimit))
+	}
+	if err != nil {
+		return fmt.Errorf("sending webhook to %q: %w", w.GetWebhookUrl(), err)
+	}
+	return nil
+}
+
+func (webhookHandler) ActionType() reflect.Type {
+	return reflect.TypeOf(&v1.Hook_ActionWebhook{})
+}
+
+// webhookRequest builds the request (without a client) for the webhook configuration.
+func webhookRequest(w *v1.Hook_Webhook, payload string) (hookutil.Request, error) {
+	req := hookutil.Request{
+		URL:        w.GetWebhookUrl(),
+		Header:     http.Header{},
+		MaxRetries: int(w.GetMaxRetries()),
+	}
+
+	switch w.GetMethod() {
+	case v1.Hook_Webhook_GET:
+		req.Method = http.MethodGet
+	case v1.Hook_Webhook_PUT:
+		req.Method = http.MethodPut
+	case v1.Hook_Webhook_PATCH:
+		req.Method = http.MethodPatch
+	default:
+		req.Method = http.MethodPost
+	}
+
+	if req.Method != http.MethodGet {
+		req.Body = []byte(payload)
+		contentType := w.GetContentType()
+		if contentType == "" {
+			contentType = "text/plain"
+		}
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	for _, header := range w.GetHeaders() {
+		name, value, ok := strings.Cut(header, ":")
+		if !ok || strings.TrimSpace(name) == "" {
+			return req, fmt.Errorf("invalid header %q, expected format \"Name: value\"", header)
+		}
+		req.Header.Add(strings.TrimSpace(name), strings.TrimSpace(value))
+	}
+
+	if w.GetBearerToken() != "" {
+		req.Header.Set("Authorization", "Bearer "+w.GetBearerToken())
+	} else if w.GetBasicAuthUsername() != "" {
+		r := &http.Request{Header: http.Header{}}
+		r.SetBasicAuth(w.GetBasicAuthUsername(), w.GetBasicAuthPassword())
+		req.Header.Set("Authorization", r.Header.Get("Authorization"))
+	}
+
+	return req, nil
+}
+
+// webhookClient returns an HTTP client configured with the webhook's timeout and TLS options.
+func webhookClient(w *v1.Hook_Webhook) (*http.Client, error) {
+	timeout := webhookDefaultTimeout
+	if w.GetTimeoutSeconds() > 0 {
+		timeout = time.Duration(w.GetTimeoutSeconds()) * time.Second
+	}
+
+	if w.GetTlsCaCert() == "" && !w.GetTlsSkipVerify() {
+		return &http.Client{Timeout: timeout}, nil
+	}
+
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: w.GetTlsSkipVerify(),
+	}
+	if w.GetTlsCaCert() != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM([]byte(w.GetTlsCaCert())) {
+			return nil, errors.New("tls ca cert: no valid PEM certificates found")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return &http.Client{Timeout: timeout, Transport: transport}, nil
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
+
+func init() {
+	DefaultRegistry().RegisterHandler(&webhookHandler{})
+}
@@ -0,0 +1,132 @@
+package types
+
+import (
+	"context"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/hook/hookutil"
+)
+
+func TestWebhookRequest(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		if r.Method != http.MethodPut {
+			t.Errorf("got method %q, want PUT", r.Method)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("got authorization %q, want %q", got, "Bearer secret")
+		}
+		if got := r.Header.Get("X-Custom"); got != "value: with colon" {
+			t.Errorf("got X-Custom %q, want %q", got, "value: with colon")
+		}
+		if got := r.Header.Get("Content-Type"); got != "application/json" {
+			t.Errorf("got content type %q, want application/json", got)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"text":"hello"}` {
+			t.Errorf("got body %q", body)
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	hook := &v1.Hook_Webhook{
+		WebhookUrl:  server.URL,
+		Method:      v1.Hook_Webhook_PUT,
+		Headers:     []string{"X-Custom: value: with colon"},
+		ContentType: "application/json",
+		BearerToken: "secret",
+		MaxRetries:  3,
+	}
+
+	req, err := webhookRequest(hook, `{"text":"hello"}`)
+	if err != nil {
+		t.Fatalf("webhookRequest() error = %v", err)
+	}
+	req.Backoff = time.Millisecond
+
+	resp, err := hookutil.DoRequest(context.Background(), req)
+	if err != nil {
+		t.Fatalf("DoRequest() error = %v", err)
+	}
+	if resp.Attempts != 3 || resp.Body != "ok" {
+		t.Errorf("got %d attempts with body %q, want 3 attempts with body %q", resp.Attempts, resp.Body, "ok")
+	}
+}
+
+func TestWebhookRequestNoRetryOn4xx(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		if user, pass, ok := r.BasicAuth(); !ok || user != "user" || pass != "pass" {
+			t.Errorf("got basic auth %q %q, want user pass", user, pass)
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte("denied"))
+	}))
+	defer server.Close()
+
+	req, err := webhookRequest(&v1.Hook_Webhook{
+		WebhookUrl:        server.URL,
+		BasicAuthUsername: "user",
+		BasicAuthPassword: "pass",
+		MaxRetries:        3,
+	}, "hello")
+	if err != nil {
+		t.Fatalf("webhookRequest() error = %v", err)
+	}
+	req.Backoff = time.Millisecond
+
+	resp, err := hookutil.DoRequest(context.Background(), req)
+	if err == nil {
+		t.Fatalf("expected an error for a 401 response")
+	}
+	if attempts.Load() != 1 {
+		t.Errorf("got %d attempts, want 1", attempts.Load())
+	}
+	if resp == nil || resp.StatusCode != http.StatusUnauthorized || resp.Body != "denied" {
+		t.Errorf("got response %+v, want the 401 response", resp)
+	}
+}
+
+func TestWebhookInvalidHeader(t *testing.T) {
+	if _, err := webhookRequest(&v1.Hook_Webhook{WebhookUrl: "http://localhost", Headers: []string{"no-colon"}}, ""); err == nil {
+		t.Errorf("expected an error for a malformed header")
+	}
+}
+
+func TestWebhookClientCustomCA(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	client, err := webhookClient(&v1.Hook_Webhook{TlsCaCert: string(caPEM)})
+	if err != nil {
+		t.Fatalf("webhookClient() error = %v", err)
+	}
+	if _, err := hookutil.DoRequest(context.Background(), hookutil.Request{Method: http.MethodGet, URL: server.URL, Client: client}); err != nil {
+		t.Errorf("request with custom CA failed: %v", err)
+	}
+
+	client, err = webhookClient(&v1.Hook_Webhook{})
+	if err != nil {
+		t.Fatalf("webhookClient() error = %v", err)
+	}
+	if _, err := hookutil.DoRequest(context.Background(), hookutil.Request{Method: http.MethodGet, URL: server.URL, Client: client}); err == nil {
+		t.Errorf("expected request without custom CA to fail verification")
+	}
+}
@@ -5,6 +5,8 @@ type KvStore interface {
 	Get(key string) ([]byte, error)
 	// Set sets the value for the given key.
 	Set(key string, value []byte) error
+	// Delete removes the given key, deleting a key that doesn't exist is not an error.
+	Delete(key string) error
 	// ForEach iterates over all key-value pairs with the given prefix.
 	ForEach(prefix string, onRow func(key string, value []byte) error) error
 }
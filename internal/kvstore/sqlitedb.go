@@ -42,6 +42,17 @@ func NewSqliteDbForKvStore(db string) (*sql.DB, error) {
 	return dbpool, nil
 }
 
+// SnapshotSqliteDb writes a transactionally consistent copy of the database to path, replacing any file at path.
+func SnapshotSqliteDb(ctx context.Context, dbpool *sql.DB, path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("remove previous snapshot: %v", err)
+	}
+	if _, err := dbpool.ExecContext(ctx, "VACUUM INTO ?", path); err != nil {
+		return fmt.Errorf("snapshot sqlite db: %v", err)
+	}
+	return nil
+}
+
 func NewInMemorySqliteDbForKvStore(t testing.TB) *sql.DB {
 	dbpool, err := sql.Open("sqlite3", memdb.TestDB(t))
 	if err != nil {
@@ -34,6 +34,7 @@ type sqliteKvStoreImpl struct {
 	createIndexSQL   string
 	getSQL           string
 	setSQL           string
+	deleteSQL        string
 	forEachAllSQL    string
 	forEachPrefixSQL string
 }
@@ -54,6 +55,7 @@ func NewSqliteKVStore(dbpool *sql.DB, basename string) (*sqliteKvStoreImpl, erro
 		createIndexSQL:   fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %s ON %s (key);`, basename+"_key_idx", basename),
 		getSQL:           fmt.Sprintf("SELECT value FROM %s WHERE key = ?", basename),
 		setSQL:           fmt.Sprintf("INSERT OR REPLACE INTO %s (key, value) VALUES (?, ?)", basename),
+		deleteSQL:        fmt.Sprintf("DELETE FROM %s WHERE key = ?", basename),
 		forEachAllSQL:    fmt.Sprintf("SELECT key, value FROM %s ORDER BY key", basename),
 		forEachPrefixSQL: fmt.Sprintf("SELECT key, value FROM %s WHERE key LIKE ? ESCAPE ? ORDER BY key", basename),
 	}
@@ -97,6 +99,14 @@ func (s *sqliteKvStoreImpl) Set(key string, value []byte) error {
 	return nil
 }
 
+func (s *sqliteKvStoreImpl) Delete(key string) error {
+	_, err := s.dbpool.ExecContext(context.Background(), s.deleteSQL, key)
+	if err != nil {
+		return fmt.Errorf("delete from kvstore: %v", err)
+	}
+	return nil
+}
+
 func (s *sqliteKvStoreImpl) ForEach(prefix string, onRow func(key string, value []byte) error) error {
 	var query string
 	var args []any
@@ -2,8 +2,10 @@ package kvstore
 
 import (
 	"bytes"
+	"context"
 	"database/sql"
 	"fmt"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -90,6 +92,22 @@ func TestSqliteKvStore(t *testing.T) {
 		}
 	})
 
+	t.Run("Delete", func(t *testing.T) {
+		key := "delete"
+		if err := store.Set(key, []byte("value")); err != nil {
+			t.Fatal(err)
+		}
+		if err := store.Delete(key); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := store.Get(key); err != ErrNotExist {
+			t.Errorf("expected ErrNotExist, got %v", err)
+		}
+		if err := store.Delete(key); err != nil {
+			t.Errorf("expected deleting a missing key to succeed, got %v", err)
+		}
+	})
+
 	t.Run("ForEach", func(t *testing.T) {
 		if err := store.Set("prefix1/key1", []byte("value1")); err != nil {
 			t.Fatal(err)
@@ -255,3 +273,40 @@ func BenchmarkSqliteKvStore_RangeScanWithFilter(b *testing.B) {
 		}
 	}
 }
+
+func TestSnapshotSqliteDb(t *testing.T) {
+	dir := t.TempDir()
+	dbpool, err := NewSqliteDbForKvStore(filepath.Join(dir, "kv.sqlite"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dbpool.Close()
+	store, err := NewSqliteKVStore(dbpool, "kv")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	snapshot := filepath.Join(dir, "snapshot.sqlite")
+	for _, value := range []string{"first", "second"} {
+		if err := store.Set("key", []byte(value)); err != nil {
+			t.Fatal(err)
+		}
+		// an existing snapshot is replaced.
+		if err := SnapshotSqliteDb(context.Background(), dbpool, snapshot); err != nil {
+			t.Fatalf("SnapshotSqliteDb() error = %v", err)
+		}
+	}
+
+	snapshotDb, err := NewSqliteDbForKvStore(snapshot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer snapshotDb.Close()
+	snapshotStore, err := NewSqliteKVStore(snapshotDb, "kv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := snapshotStore.Get("key"); err != nil || string(got) != "second" {
+		t.Errorf("want the latest value in the snapshot, got %q (err %v)", got, err)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"sync"
 	"time"
 
@@ -31,6 +32,9 @@ type LogMetadata struct {
 	OwnerOpID      int64     // ID of the operation that owns this log
 }
 
+// FinalizeSubscription is notified after a log is finalized i.e. its writer is closed and its full contents are stored.
+type FinalizeSubscription = func(meta LogMetadata)
+
 type LogStore struct {
 	dir           string
 	inprogressDir string
@@ -40,9 +44,20 @@ type LogStore struct {
 	trackingMu  sync.Mutex     // guards refcount and subscribers
 	refcount    map[string]int // id : refcount
 	subscribers map[string][]chan struct{}
+
+	finalizeSubsMu sync.Mutex
+	finalizeSubs   []*FinalizeSubscription
+
+	quotaMu          sync.Mutex // serializes quota enforcement
+	quotaBytes       int64      // limit on the compressed size of all logs, 0 if unlimited.
+	maxBytesPerOwner int64      // limit on the uncompressed size of each operation's logs, 0 if unlimited.
+	compressionLevel int
+
+	budgetsMu sync.Mutex
+	budgets   map[int64]*ownerBudget // owner op ID : budget shared by the owner's open logs
 }
 
-func NewLogStore(dir string) (*LogStore, error) {
+func NewLogStore(dir string, opts ...Option) (*LogStore, error) {
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return nil, fmt.Errorf("create dir: %v", err)
 	}
@@ -60,6 +75,15 @@ func NewLogStore(dir string) (*LogStore, error) {
 		dbpool:        dbpool,
 		subscribers:   make(map[string][]chan struct{}),
 		refcount:      make(map[string]int),
+		budgets:       make(map[int64]*ownerBudget),
+
+		compressionLevel: gzip.DefaultCompression,
+	}
+	for _, opt := range opts {
+		if err := opt(ls); err != nil {
+			dbpool.Close()
+			return nil, fmt.Errorf("log store option: %w", err)
+		}
 	}
 	if err := ls.init(); err != nil {
 		return nil, fmt.Errorf("init log store: %v", err)
@@ -100,6 +124,10 @@ func (ls *LogStore) init() error {
 		}
 	}
 
+	if err := ls.migrate(); err != nil {
+		return fmt.Errorf("migrate log store: %v", err)
+	}
+
 	// loop through all inprogress files and finalize them if they are in the database
 	files, err := os.ReadDir(ls.inprogressDir)
 	if err != nil {
@@ -135,6 +163,49 @@ func (ls *LogStore) init() error {
 		}
 	}
 
+	// the quota may have been lowered since the store was last opened.
+	return ls.enforceQuota()
+}
+
+// migrations are the schema changes applied after the initial schema, version_info records how many are applied.
+var migrations = [][]string{
+	{
+		// sizes and creation times for quota enforcement. Logs stored before sizes were tracked are the oldest and
+		// their uncompressed size is unknown.
+		`ALTER TABLE logs ADD COLUMN created_ts_unix INTEGER DEFAULT 0;`,
+		`ALTER TABLE logs ADD COLUMN size_bytes INTEGER DEFAULT 0;`,
+		`ALTER TABLE logs ADD COLUMN raw_size_bytes INTEGER DEFAULT 0;`,
+		`UPDATE logs SET size_bytes = length(data_gz) WHERE data_gz IS NOT NULL;`,
+		`CREATE INDEX IF NOT EXISTS logs_created_ts_unix_idx ON logs (created_ts_unix);`,
+		`CREATE INDEX IF NOT EXISTS logs_owner_opid_idx ON logs (owner_opid);`,
+	},
+}
+
+func (ls *LogStore) migrate() error {
+	var version int
+	if err := ls.dbpool.QueryRowContext(context.Background(), "SELECT version FROM version_info").Scan(&version); err != nil {
+		return fmt.Errorf("select version: %v", err)
+	}
+
+	for ; version < len(migrations); version++ {
+		tx, err := ls.dbpool.BeginTx(context.Background(), nil)
+		if err != nil {
+			return fmt.Errorf("begin tx: %v", err)
+		}
+		for _, stmt := range migrations[version] {
+			if _, err := tx.ExecContext(context.Background(), stmt); err != nil {
+				tx.Rollback()
+				return fmt.Errorf("migration %d: %v", version+1, err)
+			}
+		}
+		if _, err := tx.ExecContext(context.Background(), "UPDATE version_info SET version = ?", version+1); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migration %d: update version: %v", version+1, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("migration %d: commit: %v", version+1, err)
+		}
+	}
 	return nil
 }
 
@@ -167,7 +238,13 @@ func (ls *LogStore) Create(id string, parentOpID int64, ttl time.Duration) (io.W
 		expire_ts_unix = time.Now().Add(ttl).Unix()
 	}
 
-	if _, err := ls.dbpool.ExecContext(context.Background(), "INSERT INTO logs (id, expiration_ts_unix, owner_opid, data_fname) VALUES (?, ?, ?, ?)", id, expire_ts_unix, parentOpID, fname); err != nil {
+	budget, err := ls.acquireOwnerBudget(parentOpID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := ls.dbpool.ExecContext(context.Background(), "INSERT INTO logs (id, expiration_ts_unix, owner_opid, data_fname, created_ts_unix) VALUES (?, ?, ?, ?, ?)", id, expire_ts_unix, parentOpID, fname, time.Now().Unix()); err != nil {
+		ls.releaseOwnerBudget(parentOpID, budget)
 		return nil, fmt.Errorf("insert log: %v", err)
 	}
 
@@ -176,11 +253,22 @@ func (ls *LogStore) Create(id string, parentOpID int64, ttl time.Duration) (io.W
 	ls.refcount[id] = 1
 	ls.trackingMu.Unlock()
 
+	var expiration time.Time
+	if expire_ts_unix != 0 {
+		expiration = time.Unix(expire_ts_unix, 0)
+	}
+
 	return &writer{
-		ls:    ls,
-		f:     f,
-		fname: fname,
-		id:    id,
+		ls:     ls,
+		f:      f,
+		fname:  fname,
+		id:     id,
+		budget: budget,
+		meta: LogMetadata{
+			ID:             id,
+			ExpirationTime: expiration,
+			OwnerOpID:      parentOpID,
+		},
 	}, nil
 }
 
@@ -250,6 +338,55 @@ func (ls *LogStore) Open(id string) (io.ReadCloser, error) {
 	}
 }
 
+// Tail returns up to maxBytes from the end of the log. Unlike Open it never blocks, logs that are still in progress return the data written so far.
+func (ls *LogStore) Tail(id string, maxBytes int64) ([]byte, error) {
+	ls.mu.RLock(id)
+	defer ls.mu.RUnlock(id)
+
+	var fname sql.NullString
+	var dataGz []byte
+	err := ls.dbpool.QueryRowContext(context.Background(), "SELECT data_fname, data_gz FROM logs WHERE id = ?", id).Scan(&fname, &dataGz)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrLogNotFound
+		}
+		return nil, fmt.Errorf("select log: %v", err)
+	}
+
+	if fname.Valid {
+		f, err := os.Open(filepath.Join(ls.inprogressDir, fname.String))
+		if err != nil {
+			return nil, fmt.Errorf("open data file: %v", err)
+		}
+		defer f.Close()
+		info, err := f.Stat()
+		if err != nil {
+			return nil, fmt.Errorf("stat data file: %v", err)
+		}
+		if offset := info.Size() - maxBytes; offset > 0 {
+			if _, err := f.Seek(offset, io.SeekStart); err != nil {
+				return nil, fmt.Errorf("seek data file: %v", err)
+			}
+		}
+		return io.ReadAll(io.LimitReader(f, maxBytes))
+	} else if dataGz != nil {
+		gzr, err := gzip.NewReader(bytes.NewReader(dataGz))
+		if err != nil {
+			return nil, fmt.Errorf("create gzip reader: %v", err)
+		}
+		data, err := io.ReadAll(gzr)
+		if err != nil {
+			return nil, fmt.Errorf("decompress log: %v", err)
+		}
+		if int64(len(data)) > maxBytes {
+			data = data[int64(len(data))-maxBytes:]
+		}
+		return data, nil
+	} else {
+		return nil, errors.New("log has no associated data. This shouldn't be possible")
+	}
+}
+
 func (ls *LogStore) Delete(id string) error {
 	ls.mu.Lock(id)
 	defer ls.mu.Unlock(id)
@@ -320,6 +457,28 @@ func (ls *LogStore) FindLogsWithParent(parentOpID int64) ([]string, error) {
 	return logs, nil
 }
 
+// SubscribeFinalized registers f to be called after each log is finalized. f is called from the goroutine closing the log's writer.
+func (ls *LogStore) SubscribeFinalized(f *FinalizeSubscription) {
+	ls.finalizeSubsMu.Lock()
+	defer ls.finalizeSubsMu.Unlock()
+	ls.finalizeSubs = append(ls.finalizeSubs, f)
+}
+
+func (ls *LogStore) UnsubscribeFinalized(f *FinalizeSubscription) {
+	ls.finalizeSubsMu.Lock()
+	defer ls.finalizeSubsMu.Unlock()
+	ls.finalizeSubs = slices.DeleteFunc(ls.finalizeSubs, func(sub *FinalizeSubscription) bool { return sub == f })
+}
+
+func (ls *LogStore) notifyFinalized(meta LogMetadata) {
+	ls.finalizeSubsMu.Lock()
+	subs := slices.Clone(ls.finalizeSubs)
+	ls.finalizeSubsMu.Unlock()
+	for _, sub := range subs {
+		(*sub)(meta)
+	}
+}
+
 func (ls *LogStore) subscribe(id string) chan struct{} {
 	ls.trackingMu.Lock()
 	defer ls.trackingMu.Unlock()
@@ -355,15 +514,19 @@ func (ls *LogStore) finalizeLogFile(id string, fname string) error {
 	defer f.Close()
 
 	var dataGz bytes.Buffer
-	gzw := gzip.NewWriter(&dataGz)
-	if _, e := io.Copy(gzw, f); e != nil {
+	gzw, err := gzip.NewWriterLevel(&dataGz, ls.compressionLevel)
+	if err != nil {
+		return fmt.Errorf("create gzip writer: %v", err)
+	}
+	rawSize, e := io.Copy(gzw, f)
+	if e != nil {
 		return fmt.Errorf("compress log: %v", e)
 	}
 	if e := gzw.Close(); e != nil {
 		return fmt.Errorf("close gzip writer: %v", err)
 	}
 
-	res, err := ls.dbpool.ExecContext(context.Background(), "UPDATE logs SET data_fname = NULL, data_gz = ? WHERE id = ?", dataGz.Bytes(), id)
+	res, err := ls.dbpool.ExecContext(context.Background(), "UPDATE logs SET data_fname = NULL, data_gz = ?, size_bytes = ?, raw_size_bytes = ? WHERE id = ?", dataGz.Bytes(), dataGz.Len(), rawSize, id)
 	if err != nil {
 		return fmt.Errorf("update log: %v", err)
 	}
@@ -390,11 +553,14 @@ func (ls *LogStore) maybeReleaseTempFile(id, fname string) error {
 }
 
 type writer struct {
-	ls      *LogStore
-	id      string
-	fname   string
-	f       *os.File
-	onClose sync.Once
+	ls        *LogStore
+	id        string
+	fname     string
+	meta      LogMetadata
+	f         *os.File
+	onClose   sync.Once
+	budget    *ownerBudget // shared with the owner's other open logs, nil if unlimited.
+	truncated bool
 }
 
 var _ io.WriteCloser = (*writer)(nil)
@@ -402,6 +568,23 @@ var _ io.WriteCloser = (*writer)(nil)
 func (w *writer) Write(p []byte) (n int, err error) {
 	w.ls.mu.Lock(w.id)
 	defer w.ls.mu.Unlock(w.id)
+
+	if w.truncated {
+		return len(p), nil
+	}
+	if w.budget != nil {
+		if allowed := w.budget.take(int64(len(p))); allowed < int64(len(p)) {
+			// the owner's logs are over their cap, the rest of the output is dropped rather than failing the operation writing it.
+			w.truncated = true
+			data := append(p[:allowed:allowed], truncatedMarker...)
+			if _, err := w.f.Write(data); err != nil {
+				return 0, err
+			}
+			w.ls.notify(w.id)
+			return len(p), nil
+		}
+	}
+
 	n, err = w.f.Write(p)
 	if n != 0 {
 		w.ls.notify(w.id)
@@ -412,6 +595,7 @@ func (w *writer) Write(p []byte) (n int, err error) {
 func (w *writer) Close() error {
 	err := w.f.Close()
 
+	finalized := false
 	w.onClose.Do(func() {
 		w.ls.mu.Lock(w.id)
 		defer w.ls.mu.Unlock(w.id)
@@ -421,6 +605,7 @@ func (w *writer) Close() error {
 			err = multierror.Append(err, fmt.Errorf("finalize %v: %w", w.fname, e))
 		} else {
 			w.ls.refcount[w.id]--
+			finalized = true
 		}
 
 		// manually close all subscribers and delete the subscriber entry from the map; there are no more writes coming.
@@ -435,8 +620,19 @@ func (w *writer) Close() error {
 		delete(w.ls.subscribers, w.id)
 		w.ls.trackingMu.Unlock()
 		w.ls.maybeReleaseTempFile(w.id, w.fname)
+
+		// released once the log's size is stored, so that a budget recomputed for the owner's next log includes it.
+		w.ls.releaseOwnerBudget(w.meta.OwnerOpID, w.budget)
 	})
 
+	// notify outside of the log's lock so that subscribers may read the log.
+	if finalized {
+		w.ls.notifyFinalized(w.meta)
+		if e := w.ls.enforceQuota(); e != nil {
+			zap.S().Warnf("log store couldn't enforce its quota: %v", e)
+		}
+	}
+
 	return err
 }
 
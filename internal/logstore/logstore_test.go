@@ -2,10 +2,14 @@ package logstore
 
 import (
 	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"slices"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -397,3 +401,293 @@ func getInprogressEntries(t *testing.T, ls *LogStore) []os.DirEntry {
 	entries = slices.DeleteFunc(entries, func(e os.DirEntry) bool { return e.IsDir() })
 	return entries
 }
+
+func TestTail(t *testing.T) {
+	t.Parallel()
+
+	ls, err := NewLogStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("new log writer failed: %v", err)
+	}
+	defer ls.Close()
+
+	w, err := ls.Create("test", 0, 0)
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	if _, err := w.Write([]byte("hello, world")); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+
+	// tail of an in progress log must not block.
+	data, err := ls.Tail("test", 5)
+	if err != nil {
+		t.Fatalf("tail failed: %v", err)
+	}
+	if string(data) != "world" {
+		t.Errorf("unexpected in progress tail: %q", data)
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatalf("close writer failed: %v", err)
+	}
+
+	data, err = ls.Tail("test", 5)
+	if err != nil {
+		t.Fatalf("tail failed: %v", err)
+	}
+	if string(data) != "world" {
+		t.Errorf("unexpected finalized tail: %q", data)
+	}
+
+	data, err = ls.Tail("test", 100)
+	if err != nil {
+		t.Fatalf("tail failed: %v", err)
+	}
+	if string(data) != "hello, world" {
+		t.Errorf("unexpected full tail: %q", data)
+	}
+
+	if _, err := ls.Tail("missing", 5); err != ErrLogNotFound {
+		t.Errorf("got error %v, want ErrLogNotFound", err)
+	}
+}
+
+func TestSubscribeFinalized(t *testing.T) {
+	t.Parallel()
+
+	ls, err := NewLogStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("new log writer failed: %v", err)
+	}
+	defer ls.Close()
+
+	var finalized []LogMetadata
+	var contents []string
+	sub := FinalizeSubscription(func(meta LogMetadata) {
+		finalized = append(finalized, meta)
+		// subscribers must be able to read the log.
+		data, err := ls.Tail(meta.ID, 100)
+		if err != nil {
+			t.Errorf("tail failed: %v", err)
+		}
+		contents = append(contents, string(data))
+	})
+	ls.SubscribeFinalized(&sub)
+
+	w, err := ls.Create("test", 42, 0)
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	if _, err := w.Write([]byte("hello, world")); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+	if len(finalized) != 0 {
+		t.Fatalf("log notified before it was finalized")
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("close writer failed: %v", err)
+	}
+	w.Close() // closing again must not notify again.
+
+	if len(finalized) != 1 {
+		t.Fatalf("want 1 finalized notification, got %d", len(finalized))
+	}
+	if finalized[0].ID != "test" || finalized[0].OwnerOpID != 42 {
+		t.Errorf("unexpected finalized metadata: %+v", finalized[0])
+	}
+	if contents[0] != "hello, world" {
+		t.Errorf("unexpected finalized contents: %q", contents[0])
+	}
+
+	ls.UnsubscribeFinalized(&sub)
+	w, err = ls.Create("test2", 42, 0)
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("close writer failed: %v", err)
+	}
+	if len(finalized) != 1 {
+		t.Errorf("unsubscribed callback was notified")
+	}
+}
+
+func TestQuotaEvictsOldestLogs(t *testing.T) {
+	t.Parallel()
+
+	// random data doesn't compress, each log is stored at about its written size.
+	data := make([]byte, 1000)
+	ls, err := NewLogStore(t.TempDir(), WithQuota(2500), WithCompressionLevel(gzip.BestSpeed))
+	if err != nil {
+		t.Fatalf("new log store failed: %v", err)
+	}
+	defer ls.Close()
+
+	writeLog := func(id string, ownerOpID int64) {
+		t.Helper()
+		rand.Read(data)
+		w, err := ls.Create(id, ownerOpID, 0)
+		if err != nil {
+			t.Fatalf("create failed: %v", err)
+		}
+		if _, err := w.Write(data); err != nil {
+			t.Fatalf("write failed: %v", err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatalf("close failed: %v", err)
+		}
+	}
+
+	writeLog("log1", 1)
+	writeLog("log2", 2)
+
+	// a log being written isn't evicted, and doesn't count towards the quota until it's finalized.
+	inprogress, err := ls.Create("inprogress", 3, 0)
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	if _, err := inprogress.Write(data); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+
+	writeLog("log3", 4)
+	if _, err := ls.GetMetadata("log1"); !errors.Is(err, ErrLogNotFound) {
+		t.Errorf("want the oldest log evicted, got err %v", err)
+	}
+	for _, id := range []string{"log2", "log3", "inprogress"} {
+		if _, err := ls.GetMetadata(id); err != nil {
+			t.Errorf("want log %q kept, got err %v", id, err)
+		}
+	}
+
+	stats, err := ls.Stats()
+	if err != nil {
+		t.Fatalf("stats failed: %v", err)
+	}
+	if stats.LogCount != 2 || stats.TotalBytes > stats.QuotaBytes || stats.InprogressCount != 1 || stats.InprogressBytes != int64(len(data)) {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+	if len(stats.Owners) != 2 || stats.Owners[0].OwnerOpID != 2 || stats.Owners[1].OwnerOpID != 4 {
+		t.Errorf("unexpected owner usage %+v", stats.Owners)
+	}
+
+	if err := inprogress.Close(); err != nil {
+		t.Fatalf("close failed: %v", err)
+	}
+	if _, err := ls.GetMetadata("log2"); !errors.Is(err, ErrLogNotFound) {
+		t.Errorf("want log2 evicted once the in progress log was finalized, got err %v", err)
+	}
+}
+
+func TestMaxBytesPerOwner(t *testing.T) {
+	t.Parallel()
+
+	ls, err := NewLogStore(t.TempDir(), WithMaxBytesPerOwner(10))
+	if err != nil {
+		t.Fatalf("new log store failed: %v", err)
+	}
+	defer ls.Close()
+
+	w, err := ls.Create("log1", 1, 0)
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	for _, chunk := range []string{"hello ", "world, ", "dropped"} {
+		if n, err := w.Write([]byte(chunk)); err != nil || n != len(chunk) {
+			t.Fatalf("write(%q) = %d, %v, want the full write to succeed", chunk, n, err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("close failed: %v", err)
+	}
+
+	readLog := func(id string) string {
+		t.Helper()
+		r, err := ls.Open(id)
+		if err != nil {
+			t.Fatalf("open failed: %v", err)
+		}
+		defer r.Close()
+		data, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatalf("read failed: %v", err)
+		}
+		return string(data)
+	}
+	if got, want := readLog("log1"), "hello worl"+truncatedMarker; got != want {
+		t.Errorf("log1 = %q, want %q", got, want)
+	}
+
+	// the operation's logs are at the limit, its next log only gets the marker.
+	w, err = ls.Create("log2", 1, 0)
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	w.Write([]byte("more"))
+	w.Close()
+	if got := readLog("log2"); got != truncatedMarker {
+		t.Errorf("log2 = %q, want only the truncation marker", got)
+	}
+
+	if _, err := NewLogStore(t.TempDir(), WithCompressionLevel(10)); err == nil {
+		t.Errorf("want an error for an invalid compression level")
+	}
+}
+
+func TestMaxBytesPerOwnerSharedByOpenLogs(t *testing.T) {
+	t.Parallel()
+
+	ls, err := NewLogStore(t.TempDir(), WithMaxBytesPerOwner(100))
+	if err != nil {
+		t.Fatalf("new log store failed: %v", err)
+	}
+	defer ls.Close()
+
+	// the operation's logs are open at the same time, e.g. its task log and its timeline log.
+	ids := []string{"log1", "log2"}
+	writers := make([]io.WriteCloser, len(ids))
+	for i, id := range ids {
+		w, err := ls.Create(id, 1, 0)
+		if err != nil {
+			t.Fatalf("create failed: %v", err)
+		}
+		writers[i] = w
+	}
+
+	var wg sync.WaitGroup
+	for _, w := range writers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 10; i++ {
+				if n, err := w.Write([]byte("0123456789")); err != nil || n != 10 {
+					t.Errorf("write = %d, %v, want the full write to succeed", n, err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	for _, w := range writers {
+		if err := w.Close(); err != nil {
+			t.Fatalf("close failed: %v", err)
+		}
+	}
+
+	var written int
+	for _, id := range ids {
+		r, err := ls.Open(id)
+		if err != nil {
+			t.Fatalf("open failed: %v", err)
+		}
+		data, err := io.ReadAll(r)
+		r.Close()
+		if err != nil {
+			t.Fatalf("read failed: %v", err)
+		}
+		written += len(strings.ReplaceAll(string(data), truncatedMarker, ""))
+	}
+	if written != 100 {
+		t.Errorf("the operation's logs hold %d bytes of output, want the 100 bytes allowed per operation", written)
+	}
+}
@@ -0,0 +1,225 @@
+package logstore
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// truncatedMarker is appended to a log once it reaches its size cap, later writes are discarded.
+const truncatedMarker = "\n[backrest: log truncated, it exceeded the size limit for an operation's logs]\n"
+
+type Option func(ls *LogStore) error
+
+// WithQuota limits the total size of the stored logs to maxBytes. The oldest logs are removed to make room for new
+// ones, logs still being written or read are never removed.
+func WithQuota(maxBytes int64) Option {
+	return func(ls *LogStore) error {
+		if maxBytes < 0 {
+			return fmt.Errorf("quota must not be negative, got %d", maxBytes)
+		}
+		ls.quotaBytes = maxBytes
+		return nil
+	}
+}
+
+// WithMaxBytesPerOwner limits the uncompressed size of the logs owned by an operation to maxBytes, a log is truncated
+// once its operation's logs reach the limit.
+func WithMaxBytesPerOwner(maxBytes int64) Option {
+	return func(ls *LogStore) error {
+		if maxBytes < 0 {
+			return fmt.Errorf("max bytes per owner must not be negative, got %d", maxBytes)
+		}
+		ls.maxBytesPerOwner = maxBytes
+		return nil
+	}
+}
+
+// WithCompressionLevel sets the gzip level finalized logs are compressed with e.g. gzip.BestSpeed.
+func WithCompressionLevel(level int) Option {
+	return func(ls *LogStore) error {
+		if level < gzip.HuffmanOnly || level > gzip.BestCompression {
+			return fmt.Errorf("invalid gzip compression level %d", level)
+		}
+		ls.compressionLevel = level
+		return nil
+	}
+}
+
+// OwnerUsage is the space used by the stored logs of an operation.
+type OwnerUsage struct {
+	OwnerOpID int64
+	LogCount  int64
+	Bytes     int64
+}
+
+type Stats struct {
+	LogCount        int64 // number of stored logs.
+	TotalBytes      int64 // compressed size of the stored logs, the size the quota applies to.
+	InprogressCount int64 // number of logs still being written.
+	InprogressBytes int64 // uncompressed size of the logs still being written.
+	QuotaBytes      int64 // 0 if the store is unlimited.
+	Owners          []OwnerUsage
+}
+
+// Stats reports the space used by the logs in the store.
+func (ls *LogStore) Stats() (Stats, error) {
+	stats := Stats{QuotaBytes: ls.quotaBytes}
+
+	rows, err := ls.dbpool.QueryContext(context.Background(), "SELECT owner_opid, COUNT(*), COALESCE(SUM(size_bytes), 0) FROM logs WHERE data_gz IS NOT NULL GROUP BY owner_opid ORDER BY owner_opid")
+	if err != nil {
+		return Stats{}, fmt.Errorf("select log sizes: %v", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var usage OwnerUsage
+		if err := rows.Scan(&usage.OwnerOpID, &usage.LogCount, &usage.Bytes); err != nil {
+			return Stats{}, fmt.Errorf("scan log sizes: %v", err)
+		}
+		stats.LogCount += usage.LogCount
+		stats.TotalBytes += usage.Bytes
+		stats.Owners = append(stats.Owners, usage)
+	}
+	if err := rows.Err(); err != nil {
+		return Stats{}, fmt.Errorf("iterate log sizes: %v", err)
+	}
+
+	files, err := os.ReadDir(ls.inprogressDir)
+	if err != nil {
+		return Stats{}, fmt.Errorf("read inprogress dir: %v", err)
+	}
+	for _, file := range files {
+		info, err := file.Info()
+		if err != nil || file.IsDir() {
+			continue // the log was finalized while listing the directory.
+		}
+		stats.InprogressCount++
+		stats.InprogressBytes += info.Size()
+	}
+
+	return stats, nil
+}
+
+// enforceQuota removes the oldest stored logs until their total size is within the quota. Logs that are open are
+// skipped, the store may stay over quota until they're closed.
+func (ls *LogStore) enforceQuota() error {
+	if ls.quotaBytes == 0 {
+		return nil
+	}
+
+	// one eviction at a time, concurrent finalizations would otherwise remove logs for the same excess.
+	ls.quotaMu.Lock()
+	defer ls.quotaMu.Unlock()
+
+	var total int64
+	if err := ls.dbpool.QueryRowContext(context.Background(), "SELECT COALESCE(SUM(size_bytes), 0) FROM logs WHERE data_gz IS NOT NULL").Scan(&total); err != nil {
+		return fmt.Errorf("select total log size: %v", err)
+	}
+	if total <= ls.quotaBytes {
+		return nil
+	}
+
+	type candidate struct {
+		id   string
+		size int64
+	}
+	var candidates []candidate
+	rows, err := ls.dbpool.QueryContext(context.Background(), "SELECT id, size_bytes FROM logs WHERE data_gz IS NOT NULL ORDER BY created_ts_unix, rowid")
+	if err != nil {
+		return fmt.Errorf("select logs to evict: %v", err)
+	}
+	excess := total - ls.quotaBytes
+	for rows.Next() && excess > 0 {
+		var c candidate
+		if err := rows.Scan(&c.id, &c.size); err != nil {
+			rows.Close()
+			return fmt.Errorf("scan logs to evict: %v", err)
+		}
+		ls.trackingMu.Lock()
+		_, open := ls.refcount[c.id]
+		ls.trackingMu.Unlock()
+		if open {
+			continue
+		}
+		candidates = append(candidates, c)
+		excess -= c.size
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterate logs to evict: %v", err)
+	}
+
+	var evictedBytes int64
+	for _, c := range candidates {
+		if err := ls.Delete(c.id); err != nil && !errors.Is(err, ErrLogNotFound) {
+			return fmt.Errorf("evict log %q: %w", c.id, err)
+		}
+		evictedBytes += c.size
+	}
+	zap.L().Info("evicted logs over the log store quota",
+		zap.Int("logs_removed", len(candidates)),
+		zap.Int64("bytes_removed", evictedBytes),
+		zap.Int64("quota_bytes", ls.quotaBytes))
+	return nil
+}
+
+// ownerBudget is the number of bytes an operation's logs may still grow by, shared by all of its open logs.
+type ownerBudget struct {
+	mu        sync.Mutex
+	remaining int64
+	writers   int // open logs sharing the budget, guarded by LogStore.budgetsMu.
+}
+
+// take reserves up to n bytes of the budget and returns the number reserved.
+func (b *ownerBudget) take(n int64) int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	n = min(n, b.remaining)
+	b.remaining -= n
+	return n
+}
+
+// acquireOwnerBudget returns the budget of the owner's logs, nil if they're unlimited. Each call is paired with a call
+// to releaseOwnerBudget once the log is finalized.
+func (ls *LogStore) acquireOwnerBudget(ownerOpID int64) (*ownerBudget, error) {
+	if ls.maxBytesPerOwner == 0 {
+		return nil, nil
+	}
+	if ownerOpID == 0 {
+		return &ownerBudget{remaining: ls.maxBytesPerOwner}, nil // unowned logs are limited individually.
+	}
+
+	ls.budgetsMu.Lock()
+	defer ls.budgetsMu.Unlock()
+	if b, ok := ls.budgets[ownerOpID]; ok {
+		b.writers++
+		return b, nil
+	}
+
+	// the owner has no open logs, its finalized logs account for everything it wrote.
+	var used int64
+	if err := ls.dbpool.QueryRowContext(context.Background(), "SELECT COALESCE(SUM(raw_size_bytes), 0) FROM logs WHERE owner_opid = ?", ownerOpID).Scan(&used); err != nil {
+		return nil, fmt.Errorf("select owner log size: %v", err)
+	}
+	b := &ownerBudget{remaining: max(ls.maxBytesPerOwner-used, 0), writers: 1}
+	ls.budgets[ownerOpID] = b
+	return b, nil
+}
+
+// releaseOwnerBudget drops a log's share of its owner's budget, the budget is forgotten with the owner's last open log.
+func (ls *LogStore) releaseOwnerBudget(ownerOpID int64, b *ownerBudget) {
+	if b == nil || ownerOpID == 0 {
+		return
+	}
+	ls.budgetsMu.Lock()
+	defer ls.budgetsMu.Unlock()
+	b.writers--
+	if b.writers == 0 {
+		delete(ls.budgets, ownerOpID)
+	}
+}
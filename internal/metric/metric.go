@@ -42,6 +42,28 @@ func initRegistry() *Registry {
 			Name: "backrest_last_task_status",
 			Help: "The status of the last task",
 		}, append(slices.Clone(commonDims), "task_type", "status")),
+		backupLastSuccess: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "backrest_backup_last_success_timestamp_seconds",
+			Help: "The unix time the last successful backup finished, backups with warnings are successful",
+		}, commonDims),
+		snapshotCount: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "backrest_snapshots",
+			Help: "The number of snapshots in the repo that are not forgotten",
+		}, commonDims),
+		repoSize: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "backrest_repo_size_bytes",
+			Help: "The size of the data referenced by snapshots in the repo, from the last stats operation",
+		}, []string{"repo_id"}),
+		repoUncompressedSize: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "backrest_repo_uncompressed_size_bytes",
+			Help: "The uncompressed size of the data referenced by snapshots in the repo, from the last stats operation",
+		}, []string{"repo_id"}),
+		repoUnused: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "backrest_repo_unused_bytes",
+			Help: "The size of unused data left in the repo by the last prune",
+		}, []string{"repo_id"}),
+		tasks: newTaskCollector(),
+		ops:   newOpLogState(),
 	}
 
 	registry.reg.MustRegister(registry.backupBytesProcessed)
@@ -50,6 +72,12 @@ func initRegistry() *Registry {
 	registry.reg.MustRegister(registry.tasksDuration)
 	registry.reg.MustRegister(registry.tasksRun)
 	registry.reg.MustRegister(registry.lastTaskStatus)
+	registry.reg.MustRegister(registry.backupLastSuccess)
+	registry.reg.MustRegister(registry.snapshotCount)
+	registry.reg.MustRegister(registry.repoSize)
+	registry.reg.MustRegister(registry.repoUncompressedSize)
+	registry.reg.MustRegister(registry.repoUnused)
+	registry.reg.MustRegister(registry.tasks)
 
 	return registry
 }
@@ -66,6 +94,13 @@ type Registry struct {
 	tasksDuration        *prometheus.GaugeVec
 	tasksRun             *prometheus.CounterVec
 	lastTaskStatus       *prometheus.GaugeVec
+	backupLastSuccess    *prometheus.GaugeVec
+	snapshotCount        *prometheus.GaugeVec
+	repoSize             *prometheus.GaugeVec
+	repoUncompressedSize *prometheus.GaugeVec
+	repoUnused           *prometheus.GaugeVec
+	tasks                *taskCollector
+	ops                  *opLogState
 }
 
 func (r *Registry) Handler() http.Handler {
@@ -73,12 +108,12 @@ func (r *Registry) Handler() http.Handler {
 }
 
 func (r *Registry) RecordTaskRun(repoID, planID, taskType string, duration_secs float64, status string) {
-	if repoID == "" {
-		repoID = "_unassociated_"
-	}
-	if planID == "" {
-		planID = "_unassociated_"
-	}
+	repoID, planID = labelOrUnassociated(repoID), labelOrUnassociated(planID)
+	r.setLastTaskStatus(repoID, planID, taskType, duration_secs, status)
+	r.tasksRun.WithLabelValues(repoID, planID, taskType, status).Inc()
+}
+
+func (r *Registry) setLastTaskStatus(repoID, planID, taskType string, duration_secs float64, status string) {
 	r.lastTaskStatus.DeletePartialMatch(prometheus.Labels{"repo_id": repoID, "plan_id": planID, "task_type": taskType})
 	if status == "success" {
 		r.lastTaskStatus.WithLabelValues(repoID, planID, taskType, status).Set(0)
@@ -87,7 +122,6 @@ func (r *Registry) RecordTaskRun(repoID, planID, taskType string, duration_secs
 	} else {
 		r.lastTaskStatus.WithLabelValues(repoID, planID, taskType, status).Set(-1)
 	}
-	r.tasksRun.WithLabelValues(repoID, planID, taskType, status).Inc()
 	r.tasksDuration.WithLabelValues(repoID, planID, taskType).Set(duration_secs)
 }
 
@@ -96,3 +130,10 @@ func (r *Registry) RecordBackupSummary(repoID, planID string, bytesProcessed, by
 	r.backupBytesAdded.WithLabelValues(repoID, planID).Set(float64(bytesAdded))
 	r.backupFileWarnings.WithLabelValues(repoID, planID).Set(float64(fileWarnings))
 }
+
+func labelOrUnassociated(id string) string {
+	if id == "" {
+		return "_unassociated_"
+	}
+	return id
+}
@@ -0,0 +1,137 @@
+package metric
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/oplog"
+	"github.com/garethgeorge/backrest/internal/oplog/memstore"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestTrackOpLogRestoresMetrics(t *testing.T) {
+	log, err := oplog.NewOpLog(memstore.NewMemStore())
+	if err != nil {
+		t.Fatalf("error creating oplog: %v", err)
+	}
+
+	base := &v1.Operation{
+		InstanceId: "instance",
+		RepoId:     "repo",
+		RepoGuid:   "repo-guid",
+		PlanId:     "plan",
+	}
+	op := func(status v1.OperationStatus, startMs, endMs int64, o any) *v1.Operation {
+		op := proto.Clone(base).(*v1.Operation)
+		op.Status = status
+		op.UnixTimeStartMs = startMs
+		op.UnixTimeEndMs = endMs
+		switch o := o.(type) {
+		case *v1.OperationBackup:
+			op.Op = &v1.Operation_OperationBackup{OperationBackup: o}
+		case *v1.OperationIndexSnapshot:
+			op.Op = &v1.Operation_OperationIndexSnapshot{OperationIndexSnapshot: o}
+		case *v1.OperationStats:
+			op.Op = &v1.Operation_OperationStats{OperationStats: o}
+		case *v1.OperationPrune:
+			op.Op = &v1.Operation_OperationPrune{OperationPrune: o}
+		}
+		return op
+	}
+
+	unusedSize := int64(4096)
+	history := []*v1.Operation{
+		op(v1.OperationStatus_STATUS_SUCCESS, 1000, 2000, &v1.OperationBackup{
+			LastStatus: &v1.BackupProgressEntry{Entry: &v1.BackupProgressEntry_Summary{Summary: &v1.BackupProgressSummary{TotalBytesProcessed: 100, DataAdded: 10}}},
+		}),
+		op(v1.OperationStatus_STATUS_WARNING, 3000, 4000, &v1.OperationBackup{
+			LastStatus: &v1.BackupProgressEntry{Entry: &v1.BackupProgressEntry_Summary{Summary: &v1.BackupProgressSummary{TotalBytesProcessed: 200, DataAdded: 20}}},
+		}),
+		op(v1.OperationStatus_STATUS_ERROR, 5000, 6000, &v1.OperationBackup{}),
+		op(v1.OperationStatus_STATUS_SUCCESS, 2000, 2000, &v1.OperationIndexSnapshot{}),
+		op(v1.OperationStatus_STATUS_SUCCESS, 4000, 4000, &v1.OperationIndexSnapshot{}),
+		op(v1.OperationStatus_STATUS_SUCCESS, 500, 500, &v1.OperationIndexSnapshot{Forgot: true}),
+		op(v1.OperationStatus_STATUS_SUCCESS, 1000, 1500, &v1.OperationStats{Stats: &v1.RepoStats{TotalSize: 1, TotalUncompressedSize: 2}}),
+		op(v1.OperationStatus_STATUS_SUCCESS, 7000, 7500, &v1.OperationStats{Stats: &v1.RepoStats{TotalSize: 1000, TotalUncompressedSize: 2000}}),
+		op(v1.OperationStatus_STATUS_SUCCESS, 8000, 9000, &v1.OperationPrune{UnusedSize: &unusedSize}),
+	}
+	// operations synced from other instances are not reported.
+	synced := op(v1.OperationStatus_STATUS_SUCCESS, 10000, 11000, &v1.OperationBackup{})
+	synced.OriginalInstanceKeyid = "peer"
+	history = append(history, synced)
+	if err := log.Add(history...); err != nil {
+		t.Fatalf("error adding operations: %v", err)
+	}
+
+	r := initRegistry()
+	stop, err := r.TrackOpLog(log)
+	if err != nil {
+		t.Fatalf("TrackOpLog() error = %v", err)
+	}
+	defer stop()
+
+	assertGauge := func(name string, got, want float64) {
+		t.Helper()
+		if got != want {
+			t.Errorf("%s: got %v, want %v", name, got, want)
+		}
+	}
+	assertGauge("last success", testutil.ToFloat64(r.backupLastSuccess.WithLabelValues("repo", "plan")), 4)
+	assertGauge("snapshots", testutil.ToFloat64(r.snapshotCount.WithLabelValues("repo", "plan")), 2)
+	assertGauge("repo size", testutil.ToFloat64(r.repoSize.WithLabelValues("repo")), 1000)
+	assertGauge("repo uncompressed size", testutil.ToFloat64(r.repoUncompressedSize.WithLabelValues("repo")), 2000)
+	assertGauge("repo unused", testutil.ToFloat64(r.repoUnused.WithLabelValues("repo")), 4096)
+	assertGauge("last backup status", testutil.ToFloat64(r.lastTaskStatus.WithLabelValues("repo", "plan", "backup", "failed")), 1)
+	assertGauge("last stats status", testutil.ToFloat64(r.lastTaskStatus.WithLabelValues("repo", "plan", "stats", "success")), 0)
+	assertGauge("backup bytes processed", testutil.ToFloat64(r.backupBytesProcessed.WithLabelValues("repo", "plan")), 200)
+
+	// metrics follow changes to the log.
+	if err := log.Add(op(v1.OperationStatus_STATUS_SUCCESS, 12000, 13000, &v1.OperationBackup{})); err != nil {
+		t.Fatalf("error adding operation: %v", err)
+	}
+	assertGauge("last success after backup", testutil.ToFloat64(r.backupLastSuccess.WithLabelValues("repo", "plan")), 13)
+
+	forgotten := history[3]
+	forgotten.Op = &v1.Operation_OperationIndexSnapshot{OperationIndexSnapshot: &v1.OperationIndexSnapshot{Forgot: true}}
+	if err := log.Update(forgotten); err != nil {
+		t.Fatalf("error updating operation: %v", err)
+	}
+	assertGauge("snapshots after forget", testutil.ToFloat64(r.snapshotCount.WithLabelValues("repo", "plan")), 1)
+
+	if err := log.Delete(history[4].Id); err != nil {
+		t.Fatalf("error deleting operation: %v", err)
+	}
+	assertGauge("snapshots after delete", testutil.ToFloat64(r.snapshotCount.WithLabelValues("repo", "plan")), 0)
+}
+
+func TestTaskCollector(t *testing.T) {
+	r := initRegistry()
+	runAt := time.Unix(1700000000, 0)
+	r.SetTaskSource(func() []TaskInfo {
+		return []TaskInfo{
+			{RepoID: "repo", PlanID: "plan", TaskType: "backup", RunAt: runAt},
+			{RepoID: "repo", PlanID: "plan", TaskType: "backup", RunAt: runAt.Add(time.Hour)},
+			{RepoID: "repo", TaskType: "prune", RunAt: runAt.Add(2 * time.Hour)},
+			{RepoID: "repo", PlanID: "plan", TaskType: "check", RunAt: runAt, Running: true},
+		}
+	})
+
+	expected := `
+# HELP backrest_task_next_run_timestamp_seconds The unix time the task is next scheduled to run
+# TYPE backrest_task_next_run_timestamp_seconds gauge
+backrest_task_next_run_timestamp_seconds{plan_id="_unassociated_",repo_id="repo",task_type="prune"} 1.7000072e+09
+backrest_task_next_run_timestamp_seconds{plan_id="plan",repo_id="repo",task_type="backup"} 1.7e+09
+# HELP backrest_task_queue_depth The number of tasks waiting in the queue
+# TYPE backrest_task_queue_depth gauge
+backrest_task_queue_depth 3
+# HELP backrest_tasks_running The number of tasks currently running
+# TYPE backrest_tasks_running gauge
+backrest_tasks_running{plan_id="plan",repo_id="repo",task_type="check"} 1
+`
+	if err := testutil.CollectAndCompare(r.tasks, strings.NewReader(expected)); err != nil {
+		t.Errorf("unexpected task metrics: %v", err)
+	}
+}
@@ -0,0 +1,188 @@
+package metric
+
+import (
+	"fmt"
+	"sync"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/oplog"
+)
+
+type planKey struct {
+	repoID, planID string
+}
+
+// opLogState tracks the operations that metrics derived from the oplog are computed from.
+type opLogState struct {
+	mu             sync.Mutex
+	lastBackupMs   map[planKey]int64 // end time of the last successful backup.
+	snapshots      map[int64]planKey // index snapshot operation ID : plan of the snapshot.
+	snapshotCounts map[planKey]int
+	statsTimeMs    map[string]int64 // repo ID : start time of the stats operation the repo size is from.
+	pruneTimeMs    map[string]int64 // repo ID : start time of the prune operation the unused size is from.
+}
+
+func newOpLogState() *opLogState {
+	return &opLogState{
+		lastBackupMs:   make(map[planKey]int64),
+		snapshots:      make(map[int64]planKey),
+		snapshotCounts: make(map[planKey]int),
+		statsTimeMs:    make(map[string]int64),
+		pruneTimeMs:    make(map[string]int64),
+	}
+}
+
+// TrackOpLog restores metrics from the operations in the log so that they are available immediately after a restart,
+// and keeps them current as operations are added, updated and deleted. The returned function stops tracking the log.
+func (r *Registry) TrackOpLog(log *oplog.OpLog) (func(), error) {
+	sub := oplog.Subscription(func(ops []*v1.Operation, event oplog.OperationEvent) {
+		for _, op := range ops {
+			if event == oplog.OPERATION_DELETED {
+				r.removeOperation(op)
+			} else {
+				r.observeOperation(op)
+			}
+		}
+	})
+	log.Subscribe(oplog.Query{}, &sub)
+
+	// the last completed operation of each task, task statuses are otherwise only recorded when tasks run.
+	type taskKey struct {
+		planKey
+		taskType string
+	}
+	lastCompleted := make(map[taskKey]*v1.Operation)
+	lastSummary := make(map[planKey]*v1.Operation) // the last backup with a summary.
+	if err := log.Query(oplog.SelectAll, func(op *v1.Operation) error {
+		r.observeOperation(op)
+
+		taskType := taskTypeOf(op)
+		if taskType == "" || op.OriginalInstanceKeyid != "" || taskStatusOf(op) == "" {
+			return nil
+		}
+		key := taskKey{planKey{labelOrUnassociated(op.RepoId), labelOrUnassociated(op.PlanId)}, taskType}
+		if prev, ok := lastCompleted[key]; !ok || prev.UnixTimeStartMs <= op.UnixTimeStartMs {
+			lastCompleted[key] = op
+		}
+		if op.GetOperationBackup().GetLastStatus().GetSummary() != nil {
+			if prev, ok := lastSummary[key.planKey]; !ok || prev.UnixTimeStartMs <= op.UnixTimeStartMs {
+				lastSummary[key.planKey] = op
+			}
+		}
+		return nil
+	}); err != nil {
+		log.Unsubscribe(&sub)
+		return nil, fmt.Errorf("restore metrics from oplog: %w", err)
+	}
+
+	for key, op := range lastCompleted {
+		durationSecs := float64(op.UnixTimeEndMs-op.UnixTimeStartMs) / 1000
+		r.setLastTaskStatus(key.repoID, key.planID, key.taskType, max(durationSecs, 0), taskStatusOf(op))
+	}
+	for key, op := range lastSummary {
+		summary := op.GetOperationBackup().GetLastStatus().GetSummary()
+		// the stored errors are capped, the count is a lower bound of the warnings reported when the backup ran.
+		r.RecordBackupSummary(key.repoID, key.planID, summary.TotalBytesProcessed, summary.DataAdded, int64(len(op.GetOperationBackup().GetErrors())))
+	}
+
+	return func() { log.Unsubscribe(&sub) }, nil
+}
+
+func (r *Registry) observeOperation(op *v1.Operation) {
+	if op.OriginalInstanceKeyid != "" {
+		return // operations synced from other instances are reported by those instances.
+	}
+
+	s := r.ops
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := planKey{labelOrUnassociated(op.RepoId), labelOrUnassociated(op.PlanId)}
+	success := op.Status == v1.OperationStatus_STATUS_SUCCESS || op.Status == v1.OperationStatus_STATUS_WARNING
+
+	switch o := op.GetOp().(type) {
+	case *v1.Operation_OperationBackup:
+		endMs := op.UnixTimeEndMs
+		if endMs == 0 {
+			endMs = op.UnixTimeStartMs
+		}
+		if !success || endMs <= s.lastBackupMs[key] {
+			return
+		}
+		s.lastBackupMs[key] = endMs
+		r.backupLastSuccess.WithLabelValues(key.repoID, key.planID).Set(float64(endMs) / 1000)
+	case *v1.Operation_OperationIndexSnapshot:
+		_, counted := s.snapshots[op.Id]
+		if o.OperationIndexSnapshot.GetForgot() {
+			if counted {
+				s.removeSnapshot(r, op.Id)
+			}
+		} else if !counted {
+			s.snapshots[op.Id] = key
+			s.snapshotCounts[key]++
+			r.snapshotCount.WithLabelValues(key.repoID, key.planID).Set(float64(s.snapshotCounts[key]))
+		}
+	case *v1.Operation_OperationStats:
+		stats := o.OperationStats.GetStats()
+		if stats == nil || op.Status != v1.OperationStatus_STATUS_SUCCESS || op.UnixTimeStartMs < s.statsTimeMs[key.repoID] {
+			return
+		}
+		s.statsTimeMs[key.repoID] = op.UnixTimeStartMs
+		r.repoSize.WithLabelValues(key.repoID).Set(float64(stats.TotalSize))
+		r.repoUncompressedSize.WithLabelValues(key.repoID).Set(float64(stats.TotalUncompressedSize))
+	case *v1.Operation_OperationPrune:
+		if o.OperationPrune.UnusedSize == nil || op.Status != v1.OperationStatus_STATUS_SUCCESS || op.UnixTimeStartMs < s.pruneTimeMs[key.repoID] {
+			return
+		}
+		s.pruneTimeMs[key.repoID] = op.UnixTimeStartMs
+		r.repoUnused.WithLabelValues(key.repoID).Set(float64(o.OperationPrune.GetUnusedSize()))
+	}
+}
+
+func (r *Registry) removeOperation(op *v1.Operation) {
+	s := r.ops
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.snapshots[op.Id]; ok {
+		s.removeSnapshot(r, op.Id)
+	}
+}
+
+func (s *opLogState) removeSnapshot(r *Registry, opID int64) {
+	key := s.snapshots[opID]
+	delete(s.snapshots, opID)
+	s.snapshotCounts[key]--
+	r.snapshotCount.WithLabelValues(key.repoID, key.planID).Set(float64(s.snapshotCounts[key]))
+}
+
+// taskTypeOf returns the type of the task that runs an operation, matching the task types reported by RecordTaskRun.
+func taskTypeOf(op *v1.Operation) string {
+	switch op.GetOp().(type) {
+	case *v1.Operation_OperationBackup:
+		return "backup"
+	case *v1.Operation_OperationForget:
+		return "forget"
+	case *v1.Operation_OperationPrune:
+		return "prune"
+	case *v1.Operation_OperationCheck:
+		return "check"
+	case *v1.Operation_OperationStats:
+		return "stats"
+	case *v1.Operation_OperationRestore:
+		return "restore"
+	case *v1.Operation_OperationRunCommand:
+		return "run_command"
+	}
+	return ""
+}
+
+// taskStatusOf returns the status RecordTaskRun reports for a completed operation, empty if the operation is not complete.
+func taskStatusOf(op *v1.Operation) string {
+	switch op.Status {
+	case v1.OperationStatus_STATUS_SUCCESS, v1.OperationStatus_STATUS_WARNING:
+		return "success"
+	case v1.OperationStatus_STATUS_ERROR, v1.OperationStatus_STATUS_SYSTEM_CANCELLED, v1.OperationStatus_STATUS_USER_CANCELLED:
+		return "failed"
+	}
+	return ""
+}
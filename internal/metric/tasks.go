@@ -0,0 +1,86 @@
+package metric
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// TaskInfo describes a task that is queued or running.
+type TaskInfo struct {
+	RepoID   string
+	PlanID   string
+	TaskType string
+	RunAt    time.Time // the time the task is scheduled to run.
+	Running  bool
+}
+
+// taskCollector reports the state of the task queue when scraped.
+type taskCollector struct {
+	mu     sync.Mutex
+	source func() []TaskInfo
+
+	queueDepth *prometheus.Desc
+	running    *prometheus.Desc
+	nextRun    *prometheus.Desc
+}
+
+var _ prometheus.Collector = (*taskCollector)(nil)
+
+func newTaskCollector() *taskCollector {
+	dims := []string{"repo_id", "plan_id", "task_type"}
+	return &taskCollector{
+		queueDepth: prometheus.NewDesc("backrest_task_queue_depth", "The number of tasks waiting in the queue", nil, nil),
+		running:    prometheus.NewDesc("backrest_tasks_running", "The number of tasks currently running", dims, nil),
+		nextRun:    prometheus.NewDesc("backrest_task_next_run_timestamp_seconds", "The unix time the task is next scheduled to run", dims, nil),
+	}
+}
+
+// SetTaskSource sets the function used to list queued and running tasks when metrics are scraped.
+func (r *Registry) SetTaskSource(f func() []TaskInfo) {
+	r.tasks.mu.Lock()
+	defer r.tasks.mu.Unlock()
+	r.tasks.source = f
+}
+
+func (c *taskCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.queueDepth
+	ch <- c.running
+	ch <- c.nextRun
+}
+
+func (c *taskCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	source := c.source
+	c.mu.Unlock()
+	if source == nil {
+		return
+	}
+
+	type taskKey struct {
+		repoID, planID, taskType string
+	}
+	queued := 0
+	running := make(map[taskKey]int)
+	nextRun := make(map[taskKey]time.Time)
+	for _, t := range source() {
+		key := taskKey{labelOrUnassociated(t.RepoID), labelOrUnassociated(t.PlanID), t.TaskType}
+		if t.Running {
+			running[key]++
+			continue
+		}
+		queued++
+		if cur, ok := nextRun[key]; !ok || t.RunAt.Before(cur) {
+			nextRun[key] = t.RunAt
+		}
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.queueDepth, prometheus.GaugeValue, float64(queued))
+	for key, n := range running {
+		ch <- prometheus.MustNewConstMetric(c.running, prometheus.GaugeValue, float64(n), key.repoID, key.planID, key.taskType)
+	}
+	for key, runAt := range nextRun {
+		ch <- prometheus.MustNewConstMetric(c.nextRun, prometheus.GaugeValue, float64(runAt.Unix()), key.repoID, key.planID, key.taskType)
+	}
+}
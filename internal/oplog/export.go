@@ -0,0 +1,191 @@
+package oplog
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// maxImportLineBytes bounds the size of a single exported operation, large backups can record many errors.
+const maxImportLineBytes = 16 * 1024 * 1024
+
+var csvHeader = []string{
+	"id", "flow_id", "instance_id", "repo_id", "plan_id", "type", "status", "start_time", "end_time", "duration_seconds",
+	"snapshot_id", "files_processed", "bytes_processed", "bytes_added", "message",
+}
+
+// ExportJSONL writes the operations matching the query to w, one JSON encoded operation per line.
+func (o *OpLog) ExportJSONL(q Query, w io.Writer) error {
+	return o.Query(q, func(op *v1.Operation) error {
+		data, err := protojson.Marshal(op)
+		if err != nil {
+			return fmt.Errorf("marshal operation %d: %w", op.Id, err)
+		}
+		data = append(data, '\n')
+		_, err = w.Write(data)
+		return err
+	})
+}
+
+// ExportCSV writes a summary of each operation matching the query to w as CSV with a header row.
+func (o *OpLog) ExportCSV(q Query, w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	if err := o.Query(q, func(op *v1.Operation) error {
+		return cw.Write(csvRecord(op))
+	}); err != nil {
+		return err
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func csvRecord(op *v1.Operation) []string {
+	formatTime := func(ms int64) string {
+		if ms == 0 {
+			return ""
+		}
+		return time.UnixMilli(ms).UTC().Format(time.RFC3339)
+	}
+
+	var duration string
+	if op.UnixTimeEndMs != 0 {
+		duration = strconv.FormatFloat(float64(op.UnixTimeEndMs-op.UnixTimeStartMs)/1000, 'f', 3, 64)
+	}
+
+	var filesProcessed, bytesProcessed, bytesAdded string
+	if summary := op.GetOperationBackup().GetLastStatus().GetSummary(); summary != nil {
+		filesProcessed = strconv.FormatInt(summary.TotalFilesProcessed, 10)
+		bytesProcessed = strconv.FormatInt(summary.TotalBytesProcessed, 10)
+		bytesAdded = strconv.FormatInt(summary.DataAdded, 10)
+	}
+
+	return []string{
+		strconv.FormatInt(op.Id, 10),
+		strconv.FormatInt(op.FlowId, 10),
+		op.InstanceId,
+		op.RepoId,
+		op.PlanId,
+		OperationTypeName(OperationTypeOf(op)),
+		strings.ToLower(strings.TrimPrefix(op.Status.String(), "STATUS_")),
+		formatTime(op.UnixTimeStartMs),
+		formatTime(op.UnixTimeEndMs),
+		duration,
+		op.SnapshotId,
+		filesProcessed,
+		bytesProcessed,
+		bytesAdded,
+		op.DisplayMessage,
+	}
+}
+
+// ImportStats reports the outcome of an import.
+type ImportStats struct {
+	Imported int64 // operations added to the log.
+	Skipped  int64 // operations already in the log or incomplete when exported.
+}
+
+// ImportJSONL adds the operations written by ExportJSONL to the log. Operations are assigned new IDs, and operations
+// that shared a flow in the export share a flow in the log. Operations already in the log, matched by their instance,
+// repo, plan, type and start time (or original ID for operations synced from a peer), are skipped so that importing an
+// export twice doesn't duplicate history. Operations that were pending or in progress when exported are skipped.
+func (o *OpLog) ImportJSONL(r io.Reader) (ImportStats, error) {
+	imp := &importer{
+		log:      o,
+		flowIDs:  make(map[int64]int64),
+		imported: make(map[int64]struct{}),
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxImportLineBytes)
+	unmarshal := protojson.UnmarshalOptions{DiscardUnknown: true}
+	for line := 1; scanner.Scan(); line++ {
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+		op := &v1.Operation{}
+		if err := unmarshal.Unmarshal(scanner.Bytes(), op); err != nil {
+			return imp.stats, fmt.Errorf("line %d: unmarshal operation: %w", line, err)
+		}
+		if err := imp.importOperation(op); err != nil {
+			return imp.stats, fmt.Errorf("line %d: import operation %d: %w", line, op.Id, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return imp.stats, fmt.Errorf("read operations: %w", err)
+	}
+	return imp.stats, nil
+}
+
+type importer struct {
+	log      *OpLog
+	stats    ImportStats
+	flowIDs  map[int64]int64    // exported flow ID : flow ID in the log.
+	imported map[int64]struct{} // IDs of the operations added by this import.
+}
+
+func (imp *importer) importOperation(op *v1.Operation) error {
+	switch op.Status {
+	case v1.OperationStatus_STATUS_PENDING, v1.OperationStatus_STATUS_INPROGRESS, v1.OperationStatus_STATUS_UNKNOWN:
+		imp.stats.Skipped++
+		return nil
+	}
+
+	// operations added earlier in the import aren't duplicates, e.g. hooks of a flow may start in the same millisecond.
+	var existing *OpMetadata
+	if err := imp.log.QueryMetadata(importMatchQuery(op), func(meta OpMetadata) error {
+		if _, ok := imp.imported[meta.ID]; ok {
+			return nil
+		}
+		existing = &meta
+		return ErrStopIteration
+	}); err != nil && !errors.Is(err, ErrStopIteration) {
+		return fmt.Errorf("find existing operation: %w", err)
+	}
+	if existing != nil {
+		imp.flowIDs[op.FlowId] = existing.FlowID
+		imp.stats.Skipped++
+		return nil
+	}
+
+	exportedFlowID := op.FlowId
+	op.Id = 0
+	op.Modno = 0
+	op.FlowId = imp.flowIDs[exportedFlowID] // 0 if the flow is new, in which case the operation starts a flow.
+	op.Logref = ""                          // logs aren't exported.
+	if backup := op.GetOperationBackup(); backup != nil {
+		backup.TimelineLogref = ""
+	}
+	if err := imp.log.Add(op); err != nil {
+		return err
+	}
+	if exportedFlowID != 0 {
+		imp.flowIDs[exportedFlowID] = op.FlowId
+	}
+	imp.imported[op.Id] = struct{}{}
+	imp.stats.Imported++
+	return nil
+}
+
+// importMatchQuery selects the operation in the log that an imported operation is a copy of.
+func importMatchQuery(op *v1.Operation) Query {
+	q := Query{}.SetInstanceID(op.InstanceId).SetOriginalInstanceKeyid(op.OriginalInstanceKeyid)
+	if op.OriginalInstanceKeyid != "" {
+		return q.SetOriginalID(op.OriginalId)
+	}
+	return q.SetRepoGUID(op.RepoGuid).
+		SetPlanID(op.PlanId).
+		SetOpTypes(OperationTypeOf(op)).
+		SetStartTimeGteMs(op.UnixTimeStartMs).
+		SetStartTimeLtMs(op.UnixTimeStartMs + 1)
+}
@@ -0,0 +1,163 @@
+package oplog_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"slices"
+	"strings"
+	"testing"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/oplog"
+	"github.com/garethgeorge/backrest/internal/oplog/memstore"
+	"google.golang.org/protobuf/proto"
+)
+
+func newTestOpLog(t *testing.T) *oplog.OpLog {
+	log, err := oplog.NewOpLog(memstore.NewMemStore())
+	if err != nil {
+		t.Fatalf("error creating oplog: %v", err)
+	}
+	return log
+}
+
+var testSnapshotID = strings.Repeat("a", 64)
+
+func testBackup(startMs int64, status v1.OperationStatus) *v1.Operation {
+	return &v1.Operation{
+		InstanceId:      "instance",
+		RepoId:          "repo",
+		RepoGuid:        "repo-guid",
+		PlanId:          "plan",
+		Status:          status,
+		UnixTimeStartMs: startMs,
+		UnixTimeEndMs:   startMs + 1500,
+		SnapshotId:      testSnapshotID,
+		DisplayMessage:  "backup, done",
+		Logref:          "t-log",
+		Op: &v1.Operation_OperationBackup{OperationBackup: &v1.OperationBackup{
+			LastStatus: &v1.BackupProgressEntry{Entry: &v1.BackupProgressEntry_Summary{Summary: &v1.BackupProgressSummary{
+				TotalFilesProcessed: 10,
+				TotalBytesProcessed: 2048,
+				DataAdded:           512,
+			}}},
+		}},
+	}
+}
+
+func TestExportImportJSONL(t *testing.T) {
+	t.Parallel()
+
+	src := newTestOpLog(t)
+	backup := testBackup(1000, v1.OperationStatus_STATUS_SUCCESS)
+	if err := src.Add(backup); err != nil {
+		t.Fatalf("error adding operation: %v", err)
+	}
+	// hooks in the backup's flow, started in the same millisecond.
+	hook1 := &v1.Operation{InstanceId: "instance", RepoId: "repo", RepoGuid: "repo-guid", PlanId: "plan", FlowId: backup.FlowId, UnixTimeStartMs: 3000, Status: v1.OperationStatus_STATUS_SUCCESS, Op: &v1.Operation_OperationRunHook{OperationRunHook: &v1.OperationRunHook{Name: "hook1"}}}
+	hook2 := proto.Clone(hook1).(*v1.Operation)
+	hook2.GetOperationRunHook().Name = "hook2"
+	pending := testBackup(5000, v1.OperationStatus_STATUS_PENDING)
+	if err := src.Add(hook1, hook2, pending); err != nil {
+		t.Fatalf("error adding operations: %v", err)
+	}
+
+	var export bytes.Buffer
+	if err := src.ExportJSONL(oplog.SelectAll, &export); err != nil {
+		t.Fatalf("ExportJSONL() error = %v", err)
+	}
+
+	// the destination already has unrelated history, imported operations are assigned new IDs.
+	dst := newTestOpLog(t)
+	other := testBackup(100, v1.OperationStatus_STATUS_ERROR)
+	other.PlanId = "other-plan"
+	if err := dst.Add(other); err != nil {
+		t.Fatalf("error adding operation: %v", err)
+	}
+
+	stats, err := dst.ImportJSONL(bytes.NewReader(export.Bytes()))
+	if err != nil {
+		t.Fatalf("ImportJSONL() error = %v", err)
+	}
+	if stats != (oplog.ImportStats{Imported: 3, Skipped: 1}) {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+
+	var imported []*v1.Operation
+	if err := dst.Query(oplog.Query{}.SetPlanID("plan"), func(op *v1.Operation) error {
+		imported = append(imported, op)
+		return nil
+	}); err != nil {
+		t.Fatalf("error querying operations: %v", err)
+	}
+	if len(imported) != 3 {
+		t.Fatalf("want 3 imported operations, got %d", len(imported))
+	}
+	importedBackup := imported[0]
+	if importedBackup.Id == other.Id || importedBackup.Logref != "" {
+		t.Errorf("want a new ID and no log reference, got %+v", importedBackup)
+	}
+	for _, op := range imported {
+		if op.FlowId != importedBackup.Id {
+			t.Errorf("operation %d: want flow %d, got %d", op.Id, importedBackup.Id, op.FlowId)
+		}
+	}
+	want := proto.Clone(backup).(*v1.Operation)
+	want.Id, want.FlowId, want.Modno, want.Logref = importedBackup.Id, importedBackup.FlowId, importedBackup.Modno, ""
+	if !proto.Equal(importedBackup, want) {
+		t.Errorf("imported operation differs from the export:\nwant %v\ngot  %v", want, importedBackup)
+	}
+
+	// importing again doesn't duplicate history.
+	stats, err = dst.ImportJSONL(bytes.NewReader(export.Bytes()))
+	if err != nil {
+		t.Fatalf("ImportJSONL() error = %v", err)
+	}
+	if stats != (oplog.ImportStats{Skipped: 4}) {
+		t.Errorf("unexpected stats for the second import %+v", stats)
+	}
+
+	if _, err := dst.ImportJSONL(bytes.NewReader([]byte("{not json}\n"))); err == nil {
+		t.Errorf("want an error importing malformed data")
+	}
+}
+
+func TestExportCSV(t *testing.T) {
+	t.Parallel()
+
+	log := newTestOpLog(t)
+	op := testBackup(1700000000000, v1.OperationStatus_STATUS_SUCCESS)
+	if err := log.Add(op); err != nil {
+		t.Fatalf("error adding operation: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := log.ExportCSV(oplog.Query{}.SetOpTypes(v1.OperationType_OPERATION_TYPE_BACKUP), &out); err != nil {
+		t.Fatalf("ExportCSV() error = %v", err)
+	}
+	records, err := csv.NewReader(&out).ReadAll()
+	if err != nil {
+		t.Fatalf("error reading CSV: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("want a header and 1 row, got %d records", len(records))
+	}
+	header, row := records[0], records[1]
+	get := func(column string) string {
+		return row[slices.Index(header, column)]
+	}
+	checks := map[string]string{
+		"type":             "backup",
+		"status":           "success",
+		"start_time":       "2023-11-14T22:13:20Z",
+		"duration_seconds": "1.500",
+		"snapshot_id":      testSnapshotID,
+		"bytes_added":      "512",
+		"message":          "backup, done",
+	}
+	for column, want := range checks {
+		if got := get(column); got != want {
+			t.Errorf("column %q: want %q, got %q", column, want, got)
+		}
+	}
+}
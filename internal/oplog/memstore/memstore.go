@@ -44,6 +44,10 @@ func (m *MemStore) idsForQuery(q oplog.Query) []int64 {
 		return !q.Match(op)
 	})
 
+	if q.Reversed {
+		slices.Reverse(ids)
+	}
+
 	if q.Offset > 0 {
 		if int(q.Offset) >= len(ids) {
 			ids = nil
@@ -56,10 +60,6 @@ func (m *MemStore) idsForQuery(q oplog.Query) []int64 {
 		ids = ids[:q.Limit]
 	}
 
-	if q.Reversed {
-		slices.Reverse(ids)
-	}
-
 	return ids
 }
 
@@ -1,11 +1,16 @@
 package oplog
 
 import (
+	"context"
 	"errors"
 	"slices"
 	"sync"
 
 	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type OperationEvent int
@@ -139,7 +144,10 @@ func (o *OpLog) Add(ops ...*v1.Operation) error {
 			return errors.New("operation already has an ID or Modno, OpLog.Add is expected to set the ID/Modno")
 		}
 	}
-	if err := o.store.Add(ops...); err != nil {
+	span := startWriteSpan("oplog.add", ops)
+	err := o.store.Add(ops...)
+	endWriteSpan(span, ops, err)
+	if err != nil {
 		return err
 	}
 
@@ -154,7 +162,10 @@ func (o *OpLog) Update(ops ...*v1.Operation) error {
 		}
 	}
 
-	if err := o.store.Update(ops...); err != nil {
+	span := startWriteSpan("oplog.update", ops)
+	err := o.store.Update(ops...)
+	endWriteSpan(span, ops, err)
+	if err != nil {
 		return err
 	}
 
@@ -172,6 +183,35 @@ func (o *OpLog) Delete(opID ...int64) error {
 	return nil
 }
 
+// startWriteSpan starts a span for a write to the log in the trace of the task running the operations' flow. Writes made
+// outside of a running task aren't traced.
+func startWriteSpan(name string, ops []*v1.Operation) trace.Span {
+	for _, op := range ops {
+		sc := tracing.FlowSpanContext(op.FlowId)
+		if !sc.IsValid() {
+			continue
+		}
+		_, span := tracing.Tracer().Start(trace.ContextWithSpanContext(context.Background(), sc), name)
+		return span
+	}
+	return trace.SpanFromContext(context.Background())
+}
+
+func endWriteSpan(span trace.Span, ops []*v1.Operation, err error) {
+	if !span.IsRecording() {
+		return
+	}
+	ids := make([]int64, 0, len(ops))
+	for _, op := range ops {
+		ids = append(ids, op.Id)
+	}
+	span.SetAttributes(attribute.Int64Slice("backrest.operation.ids", ids))
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
 func (o *OpLog) Transform(q Query, f func(*v1.Operation) (*v1.Operation, error)) error {
 	return o.store.Transform(q, f)
 }
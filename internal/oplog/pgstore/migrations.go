@@ -0,0 +1,92 @@
+package pgstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+const (
+	pgSchemaVersion = 1
+
+	metadataKeySchemaVersion = "schema_version"
+)
+
+// pgSchema mirrors the sqlite schema so that the two stores answer queries the same way.
+var pgSchema = `
+CREATE TABLE IF NOT EXISTS oplog_metadata (
+	key TEXT PRIMARY KEY,
+	value BIGINT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS operation_groups (
+	ogid BIGSERIAL PRIMARY KEY,
+	instance_id TEXT NOT NULL,
+	original_instance_keyid TEXT NOT NULL,
+	repo_guid TEXT NOT NULL,
+	repo_id TEXT NOT NULL,
+	plan_id TEXT NOT NULL,
+	UNIQUE (instance_id, original_instance_keyid, repo_id, plan_id, repo_guid)
+);
+CREATE INDEX IF NOT EXISTS group_repo_instance ON operation_groups (repo_id, instance_id);
+CREATE INDEX IF NOT EXISTS group_repo_guid ON operation_groups (repo_guid);
+CREATE INDEX IF NOT EXISTS group_instance ON operation_groups (instance_id);
+
+CREATE SEQUENCE IF NOT EXISTS operation_ids;
+CREATE SEQUENCE IF NOT EXISTS operation_modnos;
+
+CREATE TABLE IF NOT EXISTS operations (
+	id BIGINT PRIMARY KEY,
+	ogid BIGINT NOT NULL REFERENCES operation_groups (ogid),
+	original_id BIGINT NOT NULL,
+	original_flow_id BIGINT NOT NULL,
+	modno BIGINT NOT NULL,
+	flow_id BIGINT NOT NULL,
+	start_time_ms BIGINT NOT NULL,
+	status INTEGER NOT NULL,
+	op_type INTEGER NOT NULL,
+	snapshot_id TEXT NOT NULL,
+	operation BYTEA NOT NULL
+);
+CREATE INDEX IF NOT EXISTS operation_ogid ON operations (ogid);
+CREATE INDEX IF NOT EXISTS operation_snapshot_id ON operations (snapshot_id);
+CREATE INDEX IF NOT EXISTS operation_flow_id ON operations (flow_id);
+CREATE INDEX IF NOT EXISTS operation_start_time_ms ON operations (start_time_ms);
+CREATE INDEX IF NOT EXISTS operation_original_id ON operations (ogid, original_id);
+CREATE INDEX IF NOT EXISTS operation_original_flow_id ON operations (ogid, original_flow_id);
+CREATE INDEX IF NOT EXISTS operation_modno ON operations (modno);
+CREATE INDEX IF NOT EXISTS operation_status ON operations (status, start_time_ms);
+CREATE INDEX IF NOT EXISTS operation_op_type ON operations (op_type, start_time_ms);
+`
+
+func applyMigrations(db *sql.DB) error {
+	tx, err := db.BeginTx(context.Background(), nil)
+	if err != nil {
+		return fmt.Errorf("migrate postgres schema: begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(context.Background(), pgSchema); err != nil {
+		return fmt.Errorf("migrate postgres schema: create tables: %w", err)
+	}
+
+	var version int64
+	err = tx.QueryRowContext(context.Background(), "SELECT value FROM oplog_metadata WHERE key = $1", metadataKeySchemaVersion).Scan(&version)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("getting database schema version: %w", err)
+	}
+	if version > pgSchemaVersion {
+		return fmt.Errorf("postgres oplog schema version %d is newer than the supported version %d", version, pgSchemaVersion)
+	} else if version == pgSchemaVersion {
+		return tx.Commit()
+	}
+
+	zap.S().Infof("applying oplog postgres schema migration from storage schema %d to %d", version, pgSchemaVersion)
+	if _, err := tx.ExecContext(context.Background(), "INSERT INTO oplog_metadata (key, value) VALUES ($1, $2) ON CONFLICT (key) DO UPDATE SET value = EXCLUDED.value", metadataKeySchemaVersion, pgSchemaVersion); err != nil {
+		return fmt.Errorf("setting database schema version: %w", err)
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,35 @@
+package pgstore
+
+import (
+	"strings"
+	"testing"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/oplog"
+)
+
+func TestBuildQueryWhereClause(t *testing.T) {
+	t.Parallel()
+
+	q := oplog.Query{}.
+		SetPlanID("plan").
+		SetStatuses(v1.OperationStatus_STATUS_SUCCESS, v1.OperationStatus_STATUS_ERROR).
+		SetOpIDs([]int64{1, 2}).
+		SetLimit(10).
+		SetOffset(5).
+		SetReversed(true)
+
+	where, args := buildQueryWhereClause(q, true)
+	want := "TRUE AND operation_groups.plan_id = $1 AND operations.status = ANY($2) AND operations.id = ANY($3) ORDER BY operations.start_time_ms DESC, operations.id DESC LIMIT $4 OFFSET $5"
+	if where != want {
+		t.Errorf("want where clause:\n%s\ngot:\n%s", want, where)
+	}
+	if len(args) != 5 {
+		t.Fatalf("want 5 args, got %d: %v", len(args), args)
+	}
+
+	where, args = buildQueryWhereClause(q, false)
+	if strings.Contains(where, "ORDER BY") || len(args) != 3 {
+		t.Errorf("want no select clauses, got %q with args %v", where, args)
+	}
+}
@@ -0,0 +1,48 @@
+// Package pgstoretest opens postgres oplog stores for tests.
+package pgstoretest
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/garethgeorge/backrest/internal/oplog/pgstore"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+)
+
+// EnvVarURL is the environment variable holding the URL of the database tests create stores in.
+const EnvVarURL = "BACKREST_TEST_POSTGRES_URL"
+
+// NewStore opens a store in a new schema of the database at dbURL, the schema is dropped when the test ends.
+func NewStore(t testing.TB, dbURL string) (*pgstore.PostgresStore, error) {
+	cfg, err := pgx.ParseConfig(dbURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse postgres url: %w", err)
+	}
+	u, err := url.Parse(dbURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse postgres url: %w", err)
+	}
+
+	schema := fmt.Sprintf("backrest_test_%d", time.Now().UnixNano())
+	admin := stdlib.OpenDB(*cfg)
+	defer admin.Close()
+	if _, err := admin.ExecContext(context.Background(), "CREATE SCHEMA "+schema); err != nil {
+		return nil, fmt.Errorf("create test schema: %w", err)
+	}
+	t.Cleanup(func() {
+		db := stdlib.OpenDB(*cfg)
+		defer db.Close()
+		if _, err := db.ExecContext(context.Background(), "DROP SCHEMA "+schema+" CASCADE"); err != nil {
+			t.Errorf("drop test schema: %v", err)
+		}
+	})
+
+	q := u.Query()
+	q.Set("search_path", schema)
+	u.RawQuery = q.Encode()
+	return pgstore.NewPostgresStore(u.String())
+}
@@ -1,6 +1,12 @@
 package oplog
 
-import v1 "github.com/garethgeorge/backrest/gen/go/v1"
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+)
 
 type Query struct {
 	// Filter by fields
@@ -15,6 +21,10 @@ type Query struct {
 	OriginalID            *int64
 	OriginalFlowID        *int64
 	ModnoGte              *int64
+	StartTimeGteMs        *int64               // operations that started at or after this unix time in ms.
+	StartTimeLtMs         *int64               // operations that started before this unix time in ms.
+	Statuses              []v1.OperationStatus // operations with any of these statuses.
+	OpTypes               []v1.OperationType   // operations of any of these types.
 
 	// Pagination
 	Limit    int
@@ -74,6 +84,26 @@ func (q Query) SetModnoGte(modnoGte int64) Query {
 	return q
 }
 
+func (q Query) SetStartTimeGteMs(startTimeMs int64) Query {
+	q.StartTimeGteMs = &startTimeMs
+	return q
+}
+
+func (q Query) SetStartTimeLtMs(startTimeMs int64) Query {
+	q.StartTimeLtMs = &startTimeMs
+	return q
+}
+
+func (q Query) SetStatuses(statuses ...v1.OperationStatus) Query {
+	q.Statuses = statuses
+	return q
+}
+
+func (q Query) SetOpTypes(opTypes ...v1.OperationType) Query {
+	q.OpTypes = opTypes
+	return q
+}
+
 func (q Query) SetLimit(limit int) Query {
 	q.Limit = limit
 	return q
@@ -140,5 +170,63 @@ func (q *Query) Match(op *v1.Operation) bool {
 		return false
 	}
 
+	if q.StartTimeGteMs != nil && op.UnixTimeStartMs < *q.StartTimeGteMs {
+		return false
+	}
+
+	if q.StartTimeLtMs != nil && op.UnixTimeStartMs >= *q.StartTimeLtMs {
+		return false
+	}
+
+	if len(q.Statuses) > 0 && !slices.Contains(q.Statuses, op.Status) {
+		return false
+	}
+
+	if len(q.OpTypes) > 0 && !slices.Contains(q.OpTypes, OperationTypeOf(op)) {
+		return false
+	}
+
 	return true
 }
+
+// OperationTypeOf returns the type of the operation, OPERATION_TYPE_UNKNOWN if its op is unset.
+func OperationTypeOf(op *v1.Operation) v1.OperationType {
+	switch op.GetOp().(type) {
+	case *v1.Operation_OperationBackup:
+		return v1.OperationType_OPERATION_TYPE_BACKUP
+	case *v1.Operation_OperationIndexSnapshot:
+		return v1.OperationType_OPERATION_TYPE_INDEX_SNAPSHOT
+	case *v1.Operation_OperationForget:
+		return v1.OperationType_OPERATION_TYPE_FORGET
+	case *v1.Operation_OperationPrune:
+		return v1.OperationType_OPERATION_TYPE_PRUNE
+	case *v1.Operation_OperationRestore:
+		return v1.OperationType_OPERATION_TYPE_RESTORE
+	case *v1.Operation_OperationStats:
+		return v1.OperationType_OPERATION_TYPE_STATS
+	case *v1.Operation_OperationRunHook:
+		return v1.OperationType_OPERATION_TYPE_RUN_HOOK
+	case *v1.Operation_OperationCheck:
+		return v1.OperationType_OPERATION_TYPE_CHECK
+	case *v1.Operation_OperationRunCommand:
+		return v1.OperationType_OPERATION_TYPE_RUN_COMMAND
+	default:
+		return v1.OperationType_OPERATION_TYPE_UNKNOWN
+	}
+}
+
+const operationTypePrefix = "OPERATION_TYPE_"
+
+// OperationTypeName returns the short name of the operation type used in exports and the config e.g. "backup".
+func OperationTypeName(opType v1.OperationType) string {
+	return strings.ToLower(strings.TrimPrefix(opType.String(), operationTypePrefix))
+}
+
+// ParseOperationTypeName returns the operation type with the short name returned by OperationTypeName.
+func ParseOperationTypeName(name string) (v1.OperationType, error) {
+	opType, ok := v1.OperationType_value[operationTypePrefix+strings.ToUpper(name)]
+	if !ok || opType == int32(v1.OperationType_OPERATION_TYPE_UNKNOWN) {
+		return v1.OperationType_OPERATION_TYPE_UNKNOWN, fmt.Errorf("unknown operation type %q", name)
+	}
+	return v1.OperationType(opType), nil
+}
@@ -15,7 +15,7 @@ import (
 	"google.golang.org/protobuf/proto"
 )
 
-const sqlSchemaVersion = 6
+const sqlSchemaVersion = 7
 
 var sqlSchema = fmt.Sprintf(`
 PRAGMA user_version = %d;
@@ -29,6 +29,7 @@ CREATE TABLE operations (
 	flow_id INTEGER NOT NULL,
 	start_time_ms INTEGER NOT NULL,
 	status INTEGER NOT NULL,
+	op_type INTEGER NOT NULL,
 	snapshot_id STRING NOT NULL,
 	operation BLOB NOT NULL,
 	FOREIGN KEY (ogid) REFERENCES operation_groups (ogid)
@@ -40,6 +41,8 @@ CREATE INDEX operation_start_time_ms ON operations (start_time_ms);
 CREATE INDEX operation_original_id ON operations (ogid, original_id);
 CREATE INDEX operation_original_flow_id ON operations (ogid, original_flow_id);
 CREATE INDEX operation_modno ON operations (modno);
+CREATE INDEX operation_status ON operations (status, start_time_ms);
+CREATE INDEX operation_op_type ON operations (op_type, start_time_ms);
 
 CREATE TABLE operation_groups (
 	ogid INTEGER PRIMARY KEY AUTOINCREMENT,
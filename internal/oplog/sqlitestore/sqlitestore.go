@@ -201,6 +201,11 @@ func (m *SqliteStore) backup(to string, keepCount int, force bool) error {
 	return nil
 }
 
+// Snapshot writes a consistent copy of the operation log to path e.g. for a self backup.
+func (m *SqliteStore) Snapshot(ctx context.Context, path string) error {
+	return kvstore.SnapshotSqliteDb(ctx, m.dbpool, path)
+}
+
 func (m *SqliteStore) GetHighestOpIDAndModno(q oplog.Query) (int64, int64, error) {
 	var highestID sql.NullInt64
 	var highestModno sql.NullInt64
@@ -276,6 +281,36 @@ func (m *SqliteStore) buildQueryWhereClause(q oplog.Query, includeSelectClauses
 		query = append(query, " AND operations.modno >= ?")
 		args = append(args, *q.ModnoGte)
 	}
+	if q.StartTimeGteMs != nil {
+		query = append(query, " AND operations.start_time_ms >= ?")
+		args = append(args, *q.StartTimeGteMs)
+	}
+	if q.StartTimeLtMs != nil {
+		query = append(query, " AND operations.start_time_ms < ?")
+		args = append(args, *q.StartTimeLtMs)
+	}
+	if len(q.Statuses) > 0 {
+		query = append(query, " AND operations.status IN (")
+		for i, status := range q.Statuses {
+			if i > 0 {
+				query = append(query, ",")
+			}
+			query = append(query, "?")
+			args = append(args, int64(status))
+		}
+		query = append(query, ")")
+	}
+	if len(q.OpTypes) > 0 {
+		query = append(query, " AND operations.op_type IN (")
+		for i, opType := range q.OpTypes {
+			if i > 0 {
+				query = append(query, ",")
+			}
+			query = append(query, "?")
+			args = append(args, int64(opType))
+		}
+		query = append(query, ")")
+	}
 	if q.OpIDs != nil {
 		query = append(query, " AND operations.id IN (")
 		for i, id := range q.OpIDs {
@@ -446,14 +481,14 @@ func (m *SqliteStore) addInternal(tx *sql.Tx, op ...*v1.Operation) error {
 		}
 
 		query := `INSERT INTO operations 
-			(id, ogid, original_id, original_flow_id, modno, flow_id, start_time_ms, status, snapshot_id, operation)
-			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+			(id, ogid, original_id, original_flow_id, modno, flow_id, start_time_ms, status, op_type, snapshot_id, operation)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 		bytes, err := proto.Marshal(o)
 		if err != nil {
 			return fmt.Errorf("marshal operation: %v", err)
 		}
 
-		_, err = tx.ExecContext(context.Background(), query, o.Id, ogid, o.OriginalId, o.OriginalFlowId, o.Modno, o.FlowId, o.UnixTimeStartMs, int64(o.Status), o.SnapshotId, bytes)
+		_, err = tx.ExecContext(context.Background(), query, o.Id, ogid, o.OriginalId, o.OriginalFlowId, o.Modno, o.FlowId, o.UnixTimeStartMs, int64(o.Status), int64(oplog.OperationTypeOf(o)), o.SnapshotId, bytes)
 		if err != nil {
 			// TODO: check for a more specific error
 			if strings.Contains(err.Error(), "UNIQUE constraint failed") {
@@ -520,7 +555,7 @@ func (m *SqliteStore) updateInternal(tx *sql.Tx, op ...*v1.Operation) error {
 			return fmt.Errorf("find ogid: %v", err)
 		}
 
-		res, err := tx.ExecContext(context.Background(), "UPDATE operations SET operation = ?, ogid = ?, start_time_ms = ?, flow_id = ?, snapshot_id = ?, modno = ?, original_id = ?, original_flow_id = ?, status = ? WHERE id = ?", bytes, ogid, o.UnixTimeStartMs, o.FlowId, o.SnapshotId, o.Modno, o.OriginalId, o.OriginalFlowId, int64(o.Status), o.Id)
+		res, err := tx.ExecContext(context.Background(), "UPDATE operations SET operation = ?, ogid = ?, start_time_ms = ?, flow_id = ?, snapshot_id = ?, modno = ?, original_id = ?, original_flow_id = ?, status = ?, op_type = ? WHERE id = ?", bytes, ogid, o.UnixTimeStartMs, o.FlowId, o.SnapshotId, o.Modno, o.OriginalId, o.OriginalFlowId, int64(o.Status), int64(oplog.OperationTypeOf(o)), o.Id)
 		if err != nil {
 			return fmt.Errorf("update operation: %v", err)
 		}
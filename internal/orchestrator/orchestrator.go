@@ -11,6 +11,7 @@ import (
 
 	v1 "github.com/garethgeorge/backrest/gen/go/v1"
 	"github.com/garethgeorge/backrest/internal/config"
+	"github.com/garethgeorge/backrest/internal/hook"
 	"github.com/garethgeorge/backrest/internal/logstore"
 	"github.com/garethgeorge/backrest/internal/metric"
 	"github.com/garethgeorge/backrest/internal/oplog"
@@ -18,7 +19,11 @@ import (
 	"github.com/garethgeorge/backrest/internal/orchestrator/repo"
 	"github.com/garethgeorge/backrest/internal/orchestrator/tasks"
 	"github.com/garethgeorge/backrest/internal/queue"
+	"github.com/garethgeorge/backrest/internal/tracing"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/proto"
@@ -44,11 +49,17 @@ type Orchestrator struct {
 	taskQueue          *queue.TimePriorityQueue[stContainer]
 	lastQueueResetTime time.Time
 	logStore           *logstore.LogStore
+	digestStore        *tasks.DigestStore        // buffers events for hooks in digest mode, may be nil.
+	selfBackup         *tasks.SelfBackupState    // state backed up by the self backup task, may be nil.
+	stalenessStore     *tasks.PlanStalenessStore // state of the plans' staleness checks, which don't run if it's nil.
 	resticBin          string
 
 	taskCancelMu sync.Mutex
 	taskCancel   map[int64]context.CancelFunc
 
+	runningMu sync.Mutex
+	running   map[*tasks.ScheduledTask]struct{} // tasks currently executing in RunTask.
+
 	// now for the purpose of testing; used by Run() to get the current time.
 	now func() time.Time
 }
@@ -60,6 +71,7 @@ type stContainer struct {
 	retryCount  int // number of times this task has been retried.
 	createdTime time.Time
 	callbacks   []func(error)
+	scheduledBy trace.SpanContext // span that scheduled the task, linked from the span that runs it.
 }
 
 func (st stContainer) Eq(other stContainer) bool {
@@ -70,15 +82,19 @@ func (st stContainer) Less(other stContainer) bool {
 	return st.ScheduledTask.Less(other.ScheduledTask)
 }
 
-func NewOrchestrator(resticBin string, cfgMgr *config.ConfigManager, log *oplog.OpLog, logStore *logstore.LogStore) (*Orchestrator, error) {
+func NewOrchestrator(resticBin string, cfgMgr *config.ConfigManager, log *oplog.OpLog, logStore *logstore.LogStore, digestStore *tasks.DigestStore, selfBackup *tasks.SelfBackupState, stalenessStore *tasks.PlanStalenessStore) (*Orchestrator, error) {
 	// create the orchestrator.
 	o := &Orchestrator{
-		OpLog:      log,
-		configMgr:  cfgMgr,
-		taskQueue:  queue.NewTimePriorityQueue[stContainer](),
-		logStore:   logStore,
-		taskCancel: make(map[int64]context.CancelFunc),
-		resticBin:  resticBin,
+		OpLog:          log,
+		configMgr:      cfgMgr,
+		taskQueue:      queue.NewTimePriorityQueue[stContainer](),
+		logStore:       logStore,
+		digestStore:    digestStore,
+		selfBackup:     selfBackup,
+		stalenessStore: stalenessStore,
+		taskCancel:     make(map[int64]context.CancelFunc),
+		running:        make(map[*tasks.ScheduledTask]struct{}),
+		resticBin:      resticBin,
 	}
 
 	// verify the operation log and mark any incomplete operations as failed.
@@ -286,11 +302,74 @@ func (o *Orchestrator) ScheduleDefaultTasks(config *v1.Config) error {
 		if err := o.ScheduleTask(t, tasks.TaskPriorityCheck); err != nil {
 			return fmt.Errorf("schedule check task for repo %q: %w", repo.GetId(), err)
 		}
+
+		// Schedule a forget task applying the repo's retention policy
+		t = tasks.NewRepoForgetTask(repo, tasks.PlanForSystemTasks, false)
+		if err := o.ScheduleTask(t, tasks.TaskPriorityForget); err != nil {
+			return fmt.Errorf("schedule forget task for repo %q: %w", repo.GetId(), err)
+		}
+	}
+
+	if err := o.scheduleDigestTasks(config, repoByID); err != nil {
+		return err
+	}
+
+	if selfBackup := config.GetSelfBackup(); selfBackup != nil && o.selfBackup != nil {
+		repo := repoByID[selfBackup.Repo]
+		if repo == nil {
+			return fmt.Errorf("repo %q not found for self backup", selfBackup.Repo)
+		}
+		if err := o.ScheduleTask(tasks.NewSelfBackupTask(repo, o.selfBackup), tasks.TaskPriorityDefault); err != nil {
+			return fmt.Errorf("schedule self backup task: %w", err)
+		}
 	}
 
 	return nil
 }
 
+// scheduleDigestTasks schedules a task flushing the buffered events of each hook in digest mode, the events of hooks
+// that are no longer in the config are discarded. The store is updated before the tasks are scheduled so that an
+// edited hook's flush is scheduled for the digest period it inherits.
+func (o *Orchestrator) scheduleDigestTasks(config *v1.Config, repoByID map[string]*v1.Repo) error {
+	if o.digestStore == nil {
+		return nil
+	}
+
+	hooks := make(map[string]string) // digest keys to hook names.
+	var digestTasks []tasks.Task
+	for _, repo := range config.Repos {
+		for idx, h := range repo.GetHooks() {
+			if h.GetDigest() == nil {
+				continue
+			}
+			key, name := hook.RepoDigestKey(repo.Id, h), hook.RepoHookName(repo.Id, idx)
+			hooks[key] = name
+			digestTasks = append(digestTasks, tasks.NewDigestFlushTask(repo, tasks.PlanForSystemTasks, name, key, h, o.digestStore, hook.SendDigest))
+		}
+	}
+
+	for _, plan := range config.Plans {
+		for idx, h := range plan.GetHooks() {
+			if h.GetDigest() == nil {
+				continue
+			}
+			key, name := hook.PlanDigestKey(plan.Id, h), hook.PlanHookName(plan.Id, idx)
+			hooks[key] = name
+			digestTasks = append(digestTasks, tasks.NewDigestFlushTask(repoByID[plan.Repo], plan.Id, name, key, h, o.digestStore, hook.SendDigest))
+		}
+	}
+
+	if err := o.digestStore.Retain(hooks); err != nil {
+		return fmt.Errorf("update digests of changed hooks: %w", err)
+	}
+	for _, t := range digestTasks {
+		if err := o.ScheduleTask(t, tasks.TaskPriorityDefault); err != nil {
+			return fmt.Errorf("schedule %v: %w", t.Name(), err)
+		}
+	}
+	return nil
+}
+
 func (o *Orchestrator) GetRepoOrchestrator(repoId string) (repo *repo.RepoOrchestrator, err error) {
 	o.mu.Lock()
 	defer o.mu.Unlock()
@@ -324,6 +403,22 @@ func (o *Orchestrator) GetPlan(planID string) (*v1.Plan, error) {
 	return plan, nil
 }
 
+// ExecuteHookNow runs the hook for the repo and plan immediately, outside of any scheduled task or operation. The hook's
+// log, including responses from notification services, is written to the context's log writer.
+func (o *Orchestrator) ExecuteHookNow(ctx context.Context, repo *v1.Repo, planID string, h *v1.Hook, vars tasks.HookVars) error {
+	task := &tasks.GenericOneoffTask{
+		OneoffTask: tasks.OneoffTask{
+			BaseTask: tasks.BaseTask{
+				TaskType:   "hook",
+				TaskName:   "test hook",
+				TaskRepo:   repo,
+				TaskPlanID: planID,
+			},
+		},
+	}
+	return hook.ExecuteNow(ctx, h, vars, newTaskRunnerImpl(o, task, nil))
+}
+
 func (o *Orchestrator) CancelOperation(operationId int64, status v1.OperationStatus) error {
 	allTasks := o.taskQueue.GetAll()
 	idx := slices.IndexFunc(allTasks, func(t stContainer) bool {
@@ -379,6 +474,13 @@ func (o *Orchestrator) Run(ctx context.Context) {
 	// Start the clock jump detector goroutine
 	go o.watchForClockJumps(ctx)
 
+	// Start the plan staleness checks, which run outside the task queue so that a wedged task doesn't delay them
+	if o.stalenessStore != nil && o.OpLog != nil {
+		succeeded, unsubscribe := o.subscribeBackupSuccesses()
+		defer unsubscribe()
+		go o.watchPlanStaleness(ctx, succeeded)
+	}
+
 	// Main task processing loop
 	for {
 		if ctx.Err() != nil {
@@ -396,7 +498,7 @@ func (o *Orchestrator) Run(ctx context.Context) {
 		o.prepareOperationForRetry(&t)
 
 		// Execute the task
-		err := o.RunTask(ctx, t.ScheduledTask)
+		err := o.RunTask(tracing.ContextWithLinks(ctx, t.scheduledBy), t.ScheduledTask)
 
 		// Handle task completion, including potential retry
 		if o.handleTaskCompletion(&t, err, originalOp) {
@@ -461,6 +563,77 @@ func (o *Orchestrator) watchForClockJumps(ctx context.Context) {
 	}
 }
 
+const (
+	stalenessStartupDelay  = 1 * time.Minute
+	stalenessCheckInterval = 15 * time.Minute
+)
+
+// subscribeBackupSuccesses sends the plan ID of each backup that succeeds to the returned channel until unsubscribe is
+// called. Sends don't block, a success is dropped if earlier ones haven't been received yet.
+func (o *Orchestrator) subscribeBackupSuccesses() (planIDs <-chan string, unsubscribe func()) {
+	succeeded := make(chan string, 16)
+	sub := oplog.Subscription(func(ops []*v1.Operation, event oplog.OperationEvent) {
+		if event != oplog.OPERATION_ADDED && event != oplog.OPERATION_UPDATED {
+			return
+		}
+		for _, op := range ops {
+			if _, ok := op.Op.(*v1.Operation_OperationBackup); !ok {
+				continue
+			}
+			if op.Status != v1.OperationStatus_STATUS_SUCCESS && op.Status != v1.OperationStatus_STATUS_WARNING {
+				continue
+			}
+			select {
+			case succeeded <- op.PlanId:
+			default:
+			}
+		}
+	})
+	o.OpLog.Subscribe(oplog.Query{}, &sub)
+	return succeeded, func() { o.OpLog.Unsubscribe(&sub) }
+}
+
+// watchPlanStaleness checks the age of the last successful backup of plans with a staleness threshold. All plans are
+// checked on a ticker and a plan is checked again as soon as one of its backups succeeds, so recovery is noticed
+// without waiting for the next tick. Checks run one at a time.
+func (o *Orchestrator) watchPlanStaleness(ctx context.Context, succeeded <-chan string) {
+	timer := time.NewTimer(stalenessStartupDelay)
+	defer timer.Stop()
+	ticker := time.NewTicker(stalenessCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+			o.checkPlanStaleness(ctx, "")
+		case <-ticker.C:
+			o.checkPlanStaleness(ctx, "")
+		case planID := <-succeeded:
+			o.checkPlanStaleness(ctx, planID)
+		}
+	}
+}
+
+// checkPlanStaleness runs the staleness check of the plan with the given ID, or of every plan if the ID is empty.
+func (o *Orchestrator) checkPlanStaleness(ctx context.Context, planID string) {
+	cfg := o.Config()
+	for _, plan := range cfg.Plans {
+		if plan.StaleAfterHours <= 0 || (planID != "" && plan.Id != planID) {
+			continue
+		}
+		repo := config.FindRepo(cfg, plan.Repo)
+		if repo == nil {
+			continue
+		}
+		t := tasks.NewPlanStalenessTask(repo, plan, o.stalenessStore)
+		if err := o.RunTask(ctx, tasks.ScheduledTask{Task: t, RunAt: o.curTime()}); err != nil {
+			zap.L().Error("staleness check failed", zap.String("plan", plan.Id), zap.Error(err))
+		}
+	}
+}
+
 // prepareOperationForRetry prepares a task's operation for a retry by updating its display message
 // and cleaning up previous hook executions if necessary
 func (o *Orchestrator) prepareOperationForRetry(t *stContainer) {
@@ -545,14 +718,36 @@ func (o *Orchestrator) retryTask(t *stContainer, retryErr *tasks.TaskRetryError,
 func (o *Orchestrator) RunTask(parentCtx context.Context, st tasks.ScheduledTask) error {
 	zap.L().Info("running task", zap.String("task", st.Task.Name()), zap.String("runAt", st.RunAt.Format(time.RFC3339)))
 
+	o.runningMu.Lock()
+	o.running[&st] = struct{}{}
+	o.runningMu.Unlock()
+	defer func() {
+		o.runningMu.Lock()
+		delete(o.running, &st)
+		o.runningMu.Unlock()
+	}()
+
+	ctx, span := tracing.StartLinked(parentCtx, "task "+st.Task.Type(), trace.WithAttributes(taskAttributes(st.Task)...))
+	defer span.End()
+
 	// Set up context, logging, and cancellation
-	ctx, cancel := context.WithCancel(parentCtx)
+	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
+	ctx = tasks.ContextWithHookOutput(ctx)
 	ctx, logWriter := o.setupTaskContext(ctx, st.Op, cancel)
 	defer o.cleanupTaskContext(ctx, st.Op, logWriter)
 
+	if st.Op != nil {
+		span.SetAttributes(attribute.Int64("backrest.operation.id", st.Op.Id), attribute.Int64("backrest.flow.id", st.Op.FlowId))
+		defer tracing.TrackFlow(ctx, st.Op.FlowId)()
+	}
+
 	// Run the task and record metrics
 	err := o.executeTask(ctx, st)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
 
 	// Update operation status based on execution result
 	if st.Op != nil {
@@ -562,6 +757,15 @@ func (o *Orchestrator) RunTask(parentCtx context.Context, st tasks.ScheduledTask
 	return err
 }
 
+func taskAttributes(t tasks.Task) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("backrest.task.type", t.Type()),
+		attribute.String("backrest.task.name", t.Name()),
+		attribute.String("backrest.repo.id", t.RepoID()),
+		attribute.String("backrest.plan.id", t.PlanID()),
+	}
+}
+
 // setupTaskContext prepares the context for task execution with appropriate logging and cancellation
 func (o *Orchestrator) setupTaskContext(ctx context.Context, op *v1.Operation, cancel context.CancelFunc) (context.Context, io.WriteCloser) {
 	var logWriter io.WriteCloser
@@ -686,18 +890,24 @@ func (o *Orchestrator) updateOperationStatus(ctx context.Context, op *v1.Operati
 }
 
 func (o *Orchestrator) ScheduleTask(t tasks.Task, priority int, callbacks ...func(error)) error {
+	_, span := tracing.Tracer().Start(context.Background(), "schedule "+t.Type(), trace.WithAttributes(taskAttributes(t)...))
+	defer span.End()
+
 	nextRun, err := o.CreateUnscheduledTask(t, priority, o.curTime())
 	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
 		return err
 	}
 	if nextRun.Eq(tasks.NeverScheduledTask) {
 		return nil
 	}
+	span.SetAttributes(attribute.String("backrest.task.run_at", nextRun.RunAt.Format(time.RFC3339)))
 
 	stc := stContainer{
 		ScheduledTask: nextRun,
 		callbacks:     callbacks,
 		createdTime:   o.curTime(),
+		scheduledBy:   span.SpanContext(),
 	}
 
 	o.taskQueue.Enqueue(nextRun.RunAt, priority, stc)
@@ -730,6 +940,32 @@ func (o *Orchestrator) CreateUnscheduledTask(t tasks.Task, priority int, curTime
 	return nextRun, nil
 }
 
+// TaskInfo lists the queued and running tasks for metrics.
+func (o *Orchestrator) TaskInfo() []metric.TaskInfo {
+	var infos []metric.TaskInfo
+	for _, t := range o.taskQueue.GetAll() {
+		infos = append(infos, metric.TaskInfo{
+			RepoID:   t.Task.RepoID(),
+			PlanID:   t.Task.PlanID(),
+			TaskType: t.Task.Type(),
+			RunAt:    t.RunAt,
+		})
+	}
+
+	o.runningMu.Lock()
+	defer o.runningMu.Unlock()
+	for st := range o.running {
+		infos = append(infos, metric.TaskInfo{
+			RepoID:   st.Task.RepoID(),
+			PlanID:   st.Task.PlanID(),
+			TaskType: st.Task.Type(),
+			RunAt:    st.RunAt,
+			Running:  true,
+		})
+	}
+	return infos
+}
+
 func (o *Orchestrator) Config() *v1.Config {
 	o.mu.Lock()
 	defer o.mu.Unlock()
@@ -1,10 +1,19 @@
 package orchestrator
 
 import (
+	"context"
+	"slices"
 	"testing"
+	"time"
 
 	v1 "github.com/garethgeorge/backrest/gen/go/v1"
 	"github.com/garethgeorge/backrest/internal/config"
+	"github.com/garethgeorge/backrest/internal/cryptoutil"
+	"github.com/garethgeorge/backrest/internal/kvstore"
+	"github.com/garethgeorge/backrest/internal/logstore"
+	"github.com/garethgeorge/backrest/internal/oplog"
+	"github.com/garethgeorge/backrest/internal/oplog/sqlitestore"
+	"github.com/garethgeorge/backrest/internal/orchestrator/tasks"
 	"github.com/garethgeorge/backrest/internal/resticinstaller"
 )
 
@@ -36,7 +45,7 @@ func TestAutoInitializeRepos(t *testing.T) {
 		t.Fatalf("failed to find or install restic binary: %v", err)
 	}
 
-	_, err = NewOrchestrator(resticBin, configMgr, nil, nil)
+	_, err = NewOrchestrator(resticBin, configMgr, nil, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("failed to create orchestrator: %v", err)
 	}
@@ -54,3 +63,100 @@ func TestAutoInitializeRepos(t *testing.T) {
 		t.Fatalf("expected repo auto-initialize to be false")
 	}
 }
+
+func TestPlanStalenessCheckedOnBackupSuccess(t *testing.T) {
+	t.Parallel()
+
+	repo := &v1.Repo{Id: "repo", Guid: cryptoutil.MustRandomID(cryptoutil.DefaultIDBits), Uri: t.TempDir()}
+	plan := &v1.Plan{
+		Id:              "plan",
+		Repo:            "repo",
+		StaleAfterHours: 1,
+		Hooks: []*v1.Hook{{
+			Conditions: []v1.Hook_Condition{v1.Hook_CONDITION_PLAN_STALE, v1.Hook_CONDITION_PLAN_RECOVERED},
+			Action:     &v1.Hook_ActionCommand{ActionCommand: &v1.Hook_Command{Command: "echo staleness"}},
+		}},
+	}
+	configMgr := &config.ConfigManager{
+		Store: &config.MemoryStore{
+			Config: &v1.Config{
+				Version:  4,
+				Instance: "test-instance",
+				Repos:    []*v1.Repo{repo},
+				Plans:    []*v1.Plan{plan},
+			},
+		},
+	}
+
+	opstore, err := sqlitestore.NewMemorySqliteStore(t)
+	if err != nil {
+		t.Fatalf("failed to create opstore: %v", err)
+	}
+	t.Cleanup(func() { opstore.Close() })
+	log, err := oplog.NewOpLog(opstore)
+	if err != nil {
+		t.Fatalf("failed to create oplog: %v", err)
+	}
+	logStore, err := logstore.NewLogStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create logstore: %v", err)
+	}
+	t.Cleanup(func() { logStore.Close() })
+	stalenessStore, err := tasks.NewPlanStalenessStore(kvstore.NewInMemorySqliteDbForKvStore(t))
+	if err != nil {
+		t.Fatalf("failed to create staleness store: %v", err)
+	}
+
+	orch, err := NewOrchestrator("", configMgr, log, logStore, nil, nil, stalenessStore)
+	if err != nil {
+		t.Fatalf("failed to create orchestrator: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	succeeded, unsubscribe := orch.subscribeBackupSuccesses()
+	defer unsubscribe()
+	go orch.watchPlanStaleness(ctx, succeeded)
+
+	// the checks are triggered by the backups, long before the first tick.
+	waitForHooks := func(want ...v1.Hook_Condition) {
+		t.Helper()
+		var got []v1.Hook_Condition
+		for deadline := time.Now().Add(10 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+			got = nil
+			if err := log.Query(oplog.Query{}.SetPlanID(plan.Id), func(op *v1.Operation) error {
+				if hookOp, ok := op.Op.(*v1.Operation_OperationRunHook); ok && op.Status != v1.OperationStatus_STATUS_INPROGRESS {
+					got = append(got, hookOp.OperationRunHook.Condition)
+				}
+				return nil
+			}); err != nil {
+				t.Fatalf("query oplog: %v", err)
+			}
+			if slices.Equal(got, want) {
+				return
+			}
+		}
+		t.Fatalf("got hooks %v, want %v", got, want)
+	}
+	addBackup := func(end time.Time) {
+		t.Helper()
+		if err := log.Add(&v1.Operation{
+			InstanceId:      "test-instance",
+			RepoId:          repo.Id,
+			RepoGuid:        repo.Guid,
+			PlanId:          plan.Id,
+			UnixTimeStartMs: end.UnixMilli(),
+			UnixTimeEndMs:   end.UnixMilli(),
+			Status:          v1.OperationStatus_STATUS_SUCCESS,
+			Op:              &v1.Operation_OperationBackup{},
+		}); err != nil {
+			t.Fatalf("failed to add backup op: %v", err)
+		}
+	}
+
+	addBackup(time.Now().Add(-2 * time.Hour))
+	waitForHooks(v1.Hook_CONDITION_PLAN_STALE)
+
+	addBackup(time.Now())
+	waitForHooks(v1.Hook_CONDITION_PLAN_STALE, v1.Hook_CONDITION_PLAN_RECOVERED)
+}
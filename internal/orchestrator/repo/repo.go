@@ -22,6 +22,9 @@ import (
 	"go.uber.org/zap"
 )
 
+// ErrSnapshotHeld is returned when an operation would remove a snapshot that has an active hold.
+var ErrSnapshotHeld = errors.New("snapshot is held")
+
 // RepoOrchestrator implements higher level repository operations on top of
 // the restic package. It can be thought of as a controller for a repo.
 type RepoOrchestrator struct {
@@ -126,7 +129,47 @@ func (r *RepoOrchestrator) SnapshotsForPlan(ctx context.Context, plan *v1.Plan)
 	return snapshots, nil
 }
 
-func (r *RepoOrchestrator) Backup(ctx context.Context, plan *v1.Plan, progressCallback func(event *restic.BackupProgressEntry)) (*restic.BackupProgressEntry, error) {
+// SnapshotsMatching returns the snapshots from any of the hosts that include all of the paths and tags. Empty filters match all snapshots.
+func (r *RepoOrchestrator) SnapshotsMatching(ctx context.Context, hosts, paths, tags []string) ([]*restic.Snapshot, error) {
+	ctx, flush := forwardResticLogs(ctx)
+	defer flush()
+
+	var opts []restic.GenericOption
+	for _, host := range hosts {
+		opts = append(opts, restic.WithFlags("--host", host))
+	}
+	for _, path := range paths {
+		opts = append(opts, restic.WithFlags("--path", path))
+	}
+	if len(tags) > 0 {
+		opts = append(opts, restic.WithFlags("--tag", strings.Join(tags, ",")))
+	}
+
+	snapshots, err := r.repo.Snapshots(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("get snapshots for repo %v: %w", r.repoConfig.Id, err)
+	}
+	sortSnapshotsByTime(snapshots)
+	return snapshots, nil
+}
+
+// Snapshot returns the snapshot with the given ID (or ID prefix).
+func (r *RepoOrchestrator) Snapshot(ctx context.Context, snapshotId string) (*restic.Snapshot, error) {
+	ctx, flush := forwardResticLogs(ctx)
+	defer flush()
+
+	snapshots, err := r.repo.Snapshots(ctx, restic.WithFlags(snapshotId))
+	if err != nil {
+		return nil, fmt.Errorf("get snapshot %q: %w", snapshotId, err)
+	}
+	if len(snapshots) != 1 {
+		return nil, fmt.Errorf("get snapshot %q: expected 1 snapshot, found %d", snapshotId, len(snapshots))
+	}
+	return snapshots[0], nil
+}
+
+// Backup creates a snapshot of the plan's paths, extraOpts are appended to the options derived from the plan.
+func (r *RepoOrchestrator) Backup(ctx context.Context, plan *v1.Plan, progressCallback func(event *restic.BackupProgressEntry), extraOpts ...restic.GenericOption) (*restic.BackupProgressEntry, error) {
 	l := r.logger(ctx)
 	l.Debug("repo orchestrator starting backup", zap.String("repo", r.repoConfig.Id))
 
@@ -171,6 +214,7 @@ func (r *RepoOrchestrator) Backup(ctx context.Context, plan *v1.Plan, progressCa
 		}
 		opts = append(opts, restic.WithFlags(args...))
 	}
+	opts = append(opts, extraOpts...)
 
 	ctx, flush := forwardResticLogs(ctx)
 	defer flush()
@@ -184,6 +228,48 @@ func (r *RepoOrchestrator) Backup(ctx context.Context, plan *v1.Plan, progressCa
 	return summary, nil
 }
 
+// SelfBackup creates a snapshot of backrest's own state staged in dir, the snapshot is tagged as a self backup of this instance.
+func (r *RepoOrchestrator) SelfBackup(ctx context.Context, dir string, progressCallback func(event *restic.BackupProgressEntry)) (*restic.BackupProgressEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ctx, flush := forwardResticLogs(ctx)
+	defer flush()
+
+	r.logger(ctx).Debug("starting self backup", zap.String("dir", dir))
+	summary, err := r.repo.Backup(ctx, []string{dir}, progressCallback, restic.WithFlags(
+		"--tag", TagSelfBackup,
+		"--tag", TagForInstance(r.config.Instance),
+	))
+	if err != nil {
+		return summary, fmt.Errorf("self backup to repo %v: %w", r.repoConfig.Id, err)
+	}
+	return summary, nil
+}
+
+// ForgetSelfBackups forgets all but the latest keepLast self backups of this instance and returns the forgotten snapshots.
+func (r *RepoOrchestrator) ForgetSelfBackups(ctx context.Context, keepLast int) ([]*v1.ResticSnapshot, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ctx, flush := forwardResticLogs(ctx)
+	defer flush()
+
+	policy := &restic.RetentionPolicy{KeepLastN: keepLast}
+	opts, _, err := r.forgetOpts(ctx, policy, []string{TagSelfBackup, TagForInstance(r.config.Instance)})
+	if err != nil {
+		return nil, err
+	}
+	result, err := r.repo.Forget(ctx, policy, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("forget self backups in repo %v: %w", r.repoConfig.Id, err)
+	}
+
+	var forgotten []*v1.ResticSnapshot
+	for _, snapshot := range result.Remove {
+		forgotten = append(forgotten, protoutil.SnapshotToProto(&snapshot))
+	}
+	return forgotten, nil
+}
+
 func (r *RepoOrchestrator) ListSnapshotFiles(ctx context.Context, snapshotId string, path string) ([]*v1.LsEntry, error) {
 	ctx, flush := forwardResticLogs(ctx)
 	defer flush()
@@ -212,11 +298,19 @@ func (r *RepoOrchestrator) Forget(ctx context.Context, plan *v1.Plan, tags []str
 		return nil, fmt.Errorf("plan %q has no retention policy", plan.Id)
 	}
 
-	result, err := r.repo.Forget(
-		ctx, protoutil.RetentionPolicyFromProto(plan.Retention),
-		restic.WithFlags("--tag", strings.Join(tags, ",")),
-		restic.WithFlags("--group-by", ""),
-	)
+	resticPolicy, err := protoutil.RetentionPolicyFromProto(policy)
+	if err != nil {
+		return nil, fmt.Errorf("plan %q retention policy: %w", plan.Id, err)
+	} else if resticPolicy == nil {
+		return nil, fmt.Errorf("plan %q retention policy keeps all snapshots", plan.Id)
+	}
+
+	opts, holdTags, err := r.forgetOpts(ctx, resticPolicy, tags)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.repo.Forget(ctx, resticPolicy, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("forget snapshots for repo %v: %w", r.repoConfig.Id, err)
 	}
@@ -230,17 +324,200 @@ func (r *RepoOrchestrator) Forget(ctx context.Context, plan *v1.Plan, tags []str
 		forgotten = append(forgotten, snapshotProto)
 	}
 
-	r.logger(ctx).Debug("forget snapshots", zap.String("plan", plan.Id), zap.Int("count", len(forgotten)), zap.Any("policy", policy))
+	r.logger(ctx).Debug("forget snapshots", zap.String("plan", plan.Id), zap.Int("count", len(forgotten)), zap.Int("held", len(holdTags)), zap.Any("policy", policy))
 
 	return forgotten, nil
 }
 
+// PreviewForget evaluates the retention policy against the snapshots matching tags using a dry run, nothing is removed.
+// It neither holds the repo's mutex nor locks the repo so that a preview doesn't wait for a running backup.
+func (r *RepoOrchestrator) PreviewForget(ctx context.Context, policy *v1.RetentionPolicy, tags []string) ([]*v1.RetentionDecision, error) {
+	ctx, flush := forwardResticLogs(ctx)
+	defer flush()
+
+	var decisions []*v1.RetentionDecision
+	resticPolicy, err := protoutil.RetentionPolicyFromProto(policy)
+	if err != nil {
+		return nil, fmt.Errorf("retention policy: %w", err)
+	} else if resticPolicy == nil {
+		// the policy keeps everything, there's nothing for restic to evaluate.
+		snapshots, err := r.repo.Snapshots(ctx, restic.WithFlags("--tag", strings.Join(tags, ",")))
+		if err != nil {
+			return nil, fmt.Errorf("get snapshots for repo %v: %w", r.repoConfig.Id, err)
+		}
+		for _, snapshot := range snapshots {
+			decisions = append(decisions, &v1.RetentionDecision{
+				Snapshot: protoutil.SnapshotToProto(snapshot),
+				Keep:     true,
+				Reasons:  []string{"keep all"},
+			})
+		}
+	} else {
+		opts, _, err := r.forgetOpts(ctx, resticPolicy, tags)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, restic.WithFlags("--dry-run", "--no-lock"))
+
+		result, err := r.repo.Forget(ctx, resticPolicy, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("preview forget for repo %v: %w", r.repoConfig.Id, err)
+		}
+
+		reasons := make(map[string][]string)
+		for _, reason := range result.Reasons {
+			reasons[reason.Snapshot.Id] = reason.Matches
+		}
+		for _, snapshot := range result.Keep {
+			decisions = append(decisions, &v1.RetentionDecision{
+				Snapshot: protoutil.SnapshotToProto(&snapshot),
+				Keep:     true,
+				Reasons:  reasons[snapshot.Id],
+			})
+		}
+		for _, snapshot := range result.Remove {
+			decisions = append(decisions, &v1.RetentionDecision{
+				Snapshot: protoutil.SnapshotToProto(&snapshot),
+			})
+		}
+	}
+
+	sort.SliceStable(decisions, func(i, j int) bool {
+		return decisions[i].Snapshot.UnixTimeMs > decisions[j].Snapshot.UnixTimeMs
+	})
+	return decisions, nil
+}
+
+// ApplyRepoRetention applies a repo level retention policy to the snapshots selected by its filters and returns the
+// forgotten snapshots. Snapshots are grouped by their tags when only unowned snapshots are selected so that the
+// snapshots of a plan never influence which unowned snapshots are kept. Ownership is checked against config, the
+// current config rather than the one the orchestrator was created with. Self backups are never selected.
+func (r *RepoOrchestrator) ApplyRepoRetention(ctx context.Context, config *v1.Config, policy *v1.RepoRetentionPolicy) ([]*v1.ResticSnapshot, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ctx, flush := forwardResticLogs(ctx)
+	defer flush()
+
+	resticPolicy, err := protoutil.RetentionPolicyFromProto(policy.GetRetention())
+	if err != nil {
+		return nil, fmt.Errorf("repo %q retention policy: %w", r.repoConfig.Id, err)
+	} else if resticPolicy == nil {
+		return nil, nil
+	}
+
+	var filters []restic.GenericOption
+	if len(policy.Tags) > 0 {
+		filters = append(filters, restic.WithFlags("--tag", strings.Join(policy.Tags, ",")))
+	}
+	for _, host := range policy.Hosts {
+		filters = append(filters, restic.WithFlags("--host", host))
+	}
+
+	snapshots, err := r.repo.Snapshots(ctx, filters...)
+	if err != nil {
+		return nil, fmt.Errorf("get snapshots for repo %v: %w", r.repoConfig.Id, err)
+	}
+
+	opts := append([]restic.GenericOption{restic.WithFlags("--dry-run")}, filters...)
+	for _, tag := range activeHoldTags(snapshots, time.Now()) {
+		opts = append(opts, restic.WithFlags("--keep-tag", tag))
+	}
+	if policy.OnlyUnowned {
+		p := *resticPolicy
+		if p.GroupBy == "" {
+			p.GroupBy = "tags"
+		} else if !strings.Contains(p.GroupBy, "tags") {
+			p.GroupBy += ",tags"
+		}
+		resticPolicy = &p
+	} else if resticPolicy.GroupBy == "" {
+		opts = append(opts, restic.WithFlags("--group-by", ""))
+	}
+
+	// The policy is evaluated with a dry run so that owned snapshots can be excluded before anything is removed.
+	result, err := r.repo.Forget(ctx, resticPolicy, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("evaluate retention for repo %v: %w", r.repoConfig.Id, err)
+	}
+
+	var forgotten []*v1.ResticSnapshot
+	var ids []string
+	for _, snapshot := range result.Remove {
+		if IsSelfBackup(snapshot.Tags) || (policy.OnlyUnowned && r.isOwned(config, snapshot.Tags)) {
+			continue
+		}
+		snapshotProto := protoutil.SnapshotToProto(&snapshot)
+		if err := protoutil.ValidateSnapshot(snapshotProto); err != nil {
+			return nil, fmt.Errorf("snapshot validation failed: %w", err)
+		}
+		forgotten = append(forgotten, snapshotProto)
+		ids = append(ids, snapshot.Id)
+	}
+
+	for idx, ids := range chunkBy(ids, 20) {
+		if err := r.repo.ForgetSnapshots(ctx, ids); err != nil {
+			return nil, fmt.Errorf("forget snapshots for repo %v batch %v: %w", r.repoConfig.Id, idx, err)
+		}
+	}
+
+	r.logger(ctx).Debug("applied repo retention policy", zap.Int("count", len(forgotten)), zap.Any("policy", policy))
+
+	return forgotten, nil
+}
+
+// isOwned returns true if the tags belong to a snapshot managed by a plan of this instance in config, a self backup, or
+// a snapshot created by another instance.
+func (r *RepoOrchestrator) isOwned(config *v1.Config, tags []string) bool {
+	if IsSelfBackup(tags) {
+		return true
+	}
+	if instanceID := InstanceIDFromTags(tags); instanceID != "" && instanceID != config.Instance {
+		return true
+	}
+	planID := PlanFromTags(tags)
+	return planID != "" && slices.ContainsFunc(config.Plans, func(p *v1.Plan) bool {
+		return p.Id == planID && p.Repo == r.repoConfig.Id
+	})
+}
+
+// forgetOpts returns the options for a forget operation scoped to the given tags. Held snapshots are exempt from
+// the retention policy, restic is told to keep them by their exact hold tags which are also returned.
+func (r *RepoOrchestrator) forgetOpts(ctx context.Context, policy *restic.RetentionPolicy, tags []string) ([]restic.GenericOption, []string, error) {
+	snapshots, err := r.repo.Snapshots(ctx, restic.WithFlags("--tag", strings.Join(tags, ",")))
+	if err != nil {
+		return nil, nil, fmt.Errorf("get snapshots for repo %v: %w", r.repoConfig.Id, err)
+	}
+	holdTags := activeHoldTags(snapshots, time.Now())
+
+	opts := []restic.GenericOption{
+		restic.WithFlags("--tag", strings.Join(tags, ",")),
+	}
+	if policy.GroupBy == "" {
+		// by default all snapshots matching the tags are evaluated as a single group.
+		opts = append(opts, restic.WithFlags("--group-by", ""))
+	}
+	for _, tag := range holdTags {
+		opts = append(opts, restic.WithFlags("--keep-tag", tag))
+	}
+	return opts, holdTags, nil
+}
+
 func (r *RepoOrchestrator) ForgetSnapshot(ctx context.Context, snapshotId string) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	ctx, flush := forwardResticLogs(ctx)
 	defer flush()
 
+	snapshots, err := r.repo.Snapshots(ctx, restic.WithFlags(snapshotId))
+	if err != nil {
+		return fmt.Errorf("get snapshot %q: %w", snapshotId, err)
+	}
+	for _, snapshot := range snapshots {
+		if IsHeld(snapshot.Tags, time.Now()) {
+			return fmt.Errorf("forget snapshot %q: %w", snapshotId, ErrSnapshotHeld)
+		}
+	}
+
 	r.logger(ctx).Debug("forget snapshot with ID", zap.String("snapshot", snapshotId), zap.String("repo", r.repoConfig.Id))
 	return r.repo.ForgetSnapshot(ctx, snapshotId)
 }
@@ -399,6 +676,23 @@ func (r *RepoOrchestrator) AddTags(ctx context.Context, snapshotIDs []string, ta
 	return nil
 }
 
+// RemoveTags removes the tags from the snapshots, restic rewrites each snapshot under a new ID.
+func (r *RepoOrchestrator) RemoveTags(ctx context.Context, snapshotIDs []string, tags []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ctx, flush := forwardResticLogs(ctx)
+	defer flush()
+
+	for idx, snapshotIDs := range chunkBy(snapshotIDs, 20) {
+		r.logger(ctx).Debug("removing tag from snapshots", zap.Strings("snapshots", snapshotIDs), zap.Strings("tags", tags))
+		if err := r.repo.RemoveTags(ctx, snapshotIDs, tags); err != nil {
+			return fmt.Errorf("batch %v: %w", idx, err)
+		}
+	}
+
+	return nil
+}
+
 // RunCommand runs a command in the repo's environment.
 // NOTE: this function does not lock the repo.
 func (r *RepoOrchestrator) RunCommand(ctx context.Context, command string, writer io.Writer) error {
@@ -429,6 +723,19 @@ func (r *RepoOrchestrator) RepoGUID() (string, error) {
 	return cryptoutil.TruncateID(cfg.Id, cryptoutil.DefaultIDBits), err
 }
 
+// activeHoldTags returns the distinct hold tags of snapshots that are held at the given time.
+func activeHoldTags(snapshots []*restic.Snapshot, now time.Time) []string {
+	var tags []string
+	for _, snapshot := range snapshots {
+		for _, tag := range snapshot.Tags {
+			if strings.HasPrefix(tag, holdTagPrefix) && IsHeld([]string{tag}, now) && !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	return tags
+}
+
 func sortSnapshotsByTime(snapshots []*restic.Snapshot) {
 	sort.SliceStable(snapshots, func(i, j int) bool {
 		return snapshots[i].UnixTimeMs() < snapshots[j].UnixTimeMs()
@@ -12,8 +12,10 @@ import (
 	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/pkg/restic"
 	"github.com/garethgeorge/backrest/test/helpers"
 	test "github.com/garethgeorge/backrest/test/helpers"
 	"golang.org/x/sync/errgroup"
@@ -302,6 +304,179 @@ func TestEnvVarPropagation(t *testing.T) {
 	}
 }
 
+func TestForgetRespectsHold(t *testing.T) {
+	t.Parallel()
+
+	testData := test.CreateTestData(t)
+
+	r := &v1.Repo{
+		Id:       "test",
+		Uri:      t.TempDir(),
+		Password: "test",
+		Flags:    []string{"--no-cache"},
+	}
+
+	plan := &v1.Plan{
+		Id:    "test",
+		Repo:  "test",
+		Paths: []string{testData},
+		Retention: &v1.RetentionPolicy{
+			Policy: &v1.RetentionPolicy_PolicyKeepLastN{PolicyKeepLastN: 1},
+		},
+	}
+
+	orchestrator := initRepoHelper(t, configForTest, r)
+
+	for i := 0; i < 3; i++ {
+		if _, err := orchestrator.Backup(context.Background(), plan, nil); err != nil {
+			t.Fatalf("backup error: %v", err)
+		}
+	}
+
+	snapshots, err := orchestrator.SnapshotsForPlan(context.Background(), plan)
+	if err != nil {
+		t.Fatalf("snapshots error: %v", err)
+	}
+	if len(snapshots) != 3 {
+		t.Fatalf("expected 3 snapshots, got %d", len(snapshots))
+	}
+
+	if err := orchestrator.AddTags(context.Background(), []string{snapshots[0].Id}, []string{TagForHold(time.Time{})}); err != nil {
+		t.Fatalf("add hold error: %v", err)
+	}
+
+	snapshots, err = orchestrator.SnapshotsForPlan(context.Background(), plan)
+	if err != nil {
+		t.Fatalf("snapshots error: %v", err)
+	}
+	heldIdx := slices.IndexFunc(snapshots, func(s *restic.Snapshot) bool { return IsHeld(s.Tags, time.Now()) })
+	if heldIdx == -1 {
+		t.Fatalf("expected a held snapshot")
+	}
+	held := snapshots[heldIdx]
+
+	if err := orchestrator.ForgetSnapshot(context.Background(), held.Id); !errors.Is(err, ErrSnapshotHeld) {
+		t.Errorf("expected ErrSnapshotHeld forgetting held snapshot, got: %v", err)
+	}
+
+	forgotten, err := orchestrator.Forget(context.Background(), plan, []string{TagForPlan(plan.Id), TagForInstance(configForTest.Instance)})
+	if err != nil {
+		t.Fatalf("forget error: %v", err)
+	}
+	if len(forgotten) != 1 {
+		t.Errorf("expected 1 snapshot forgotten, got %d", len(forgotten))
+	}
+	for _, f := range forgotten {
+		if f.Id == held.Id {
+			t.Errorf("held snapshot %v was forgotten", held.Id)
+		}
+	}
+}
+
+func TestApplyRepoRetention(t *testing.T) {
+	t.Parallel()
+
+	testData := test.CreateTestData(t)
+	keepLast1 := &v1.RetentionPolicy{Policy: &v1.RetentionPolicy_PolicyKeepLastN{PolicyKeepLastN: 1}}
+
+	tcs := []struct {
+		name   string
+		policy *v1.RepoRetentionPolicy
+		want   []string // names of the snapshots forgotten.
+	}{
+		{
+			name:   "only unowned",
+			policy: &v1.RepoRetentionPolicy{Retention: keepLast1, OnlyUnowned: true},
+			want:   []string{"manual2", "import1", "gone1"}, // one per group, held, plan, other instance and self backup snapshots are skipped.
+		},
+		{
+			name:   "tag filter respects holds",
+			policy: &v1.RepoRetentionPolicy{Retention: keepLast1, Tags: []string{"manual"}},
+			want:   []string{"manual2"},
+		},
+		{
+			name:   "host filter",
+			policy: &v1.RepoRetentionPolicy{Retention: keepLast1, Hosts: []string{"desktop"}},
+			want:   []string{"import1"},
+		},
+		{
+			name:   "self backups are never selected",
+			policy: &v1.RepoRetentionPolicy{Retention: keepLast1, Tags: []string{TagSelfBackup}},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := &v1.Repo{
+				Id:       "test",
+				Uri:      t.TempDir(),
+				Password: "test",
+				Flags:    []string{"--no-cache"},
+			}
+			// the orchestrator is created without plans, ownership must be checked against the config passed in.
+			orchestrator := initRepoHelper(t, configForTest, r)
+			config := &v1.Config{
+				Instance: configForTest.Instance,
+				Plans:    []*v1.Plan{{Id: "plan", Repo: r.Id, Paths: []string{testData}}},
+			}
+
+			ids := make(map[string]string)
+			for _, snapshot := range []struct {
+				name string
+				host string
+				tags []string
+			}{
+				{"manual1", "laptop", []string{"manual", TagForHold(time.Time{})}},
+				{"manual2", "laptop", []string{"manual"}},
+				{"manual3", "laptop", []string{"manual"}},
+				{"import1", "desktop", []string{"import"}},
+				{"import2", "desktop", []string{"import"}},
+				{"plan1", "laptop", []string{TagForPlan("plan"), TagForInstance("test")}},
+				{"plan2", "laptop", []string{TagForPlan("plan"), TagForInstance("test")}},
+				{"gone1", "laptop", []string{TagForPlan("gone"), TagForInstance("test")}},
+				{"gone2", "laptop", []string{TagForPlan("gone"), TagForInstance("test")}},
+				{"other1", "laptop", []string{TagForPlan("plan"), TagForInstance("other")}},
+				{"other2", "laptop", []string{TagForPlan("plan"), TagForInstance("other")}},
+				{"self1", "laptop", []string{TagSelfBackup, TagForInstance("test")}},
+				{"self2", "laptop", []string{TagSelfBackup, TagForInstance("test")}},
+			} {
+				summary, err := orchestrator.repo.Backup(context.Background(), []string{testData}, nil,
+					restic.WithFlags("--host", snapshot.host), restic.WithTags(snapshot.tags...))
+				if err != nil {
+					t.Fatalf("backup %s error: %v", snapshot.name, err)
+				}
+				ids[summary.SnapshotId] = snapshot.name
+			}
+
+			forgotten, err := orchestrator.ApplyRepoRetention(context.Background(), config, tc.policy)
+			if err != nil {
+				t.Fatalf("apply repo retention error: %v", err)
+			}
+			var got []string
+			for _, snapshot := range forgotten {
+				got = append(got, ids[snapshot.Id])
+			}
+			slices.Sort(got)
+			want := slices.Clone(tc.want)
+			slices.Sort(want)
+			if !slices.Equal(got, want) {
+				t.Errorf("forgot %v, want %v", got, want)
+			}
+
+			remaining, err := orchestrator.Snapshots(context.Background())
+			if err != nil {
+				t.Fatalf("snapshots error: %v", err)
+			}
+			if len(remaining) != len(ids)-len(want) {
+				t.Errorf("got %d snapshots left, want %d", len(remaining), len(ids)-len(want))
+			}
+		})
+	}
+}
+
 func TestCheck(t *testing.T) {
 	t.Parallel()
 
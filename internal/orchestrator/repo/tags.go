@@ -2,7 +2,18 @@ package repo
 
 import (
 	"fmt"
+	"slices"
 	"strings"
+	"time"
+)
+
+const (
+	holdTagPrefix       = "hold:"
+	holdReasonTagPrefix = "hold-reason:"
+	holdIndefinite      = "indefinite"
+
+	// TagSelfBackup is reserved for snapshots of backrest's own state.
+	TagSelfBackup = "backrest-self-backup"
 )
 
 // TagForPlan returns a tag for the plan.
@@ -34,3 +45,71 @@ func PlanFromTags(tags []string) string {
 	}
 	return ""
 }
+
+// IsSelfBackup returns true if the tags belong to a snapshot of backrest's own state.
+func IsSelfBackup(tags []string) bool {
+	return slices.Contains(tags, TagSelfBackup)
+}
+
+// TagForHold returns a tag that holds a snapshot until the given time, a zero time holds the snapshot indefinitely.
+func TagForHold(until time.Time) string {
+	if until.IsZero() {
+		return holdTagPrefix + holdIndefinite
+	}
+	return holdTagPrefix + until.UTC().Format(time.RFC3339)
+}
+
+// TagForHoldReason returns a tag recording the reason for a hold. Commas are not permitted in restic tags and are replaced.
+func TagForHoldReason(reason string) string {
+	return holdReasonTagPrefix + strings.ReplaceAll(reason, ",", ";")
+}
+
+// IsHoldTag returns true if the tag is a hold tag or a hold reason tag.
+func IsHoldTag(tag string) bool {
+	return strings.HasPrefix(tag, holdTagPrefix) || strings.HasPrefix(tag, holdReasonTagPrefix)
+}
+
+// HoldFromTags returns the expiry of the hold in the tags and whether a hold was found. A zero time indicates an indefinite hold.
+// If multiple holds are present the latest expiry wins.
+func HoldFromTags(tags []string) (time.Time, bool) {
+	var until time.Time
+	found := false
+	for _, tag := range tags {
+		if !strings.HasPrefix(tag, holdTagPrefix) {
+			continue
+		}
+		value := tag[len(holdTagPrefix):]
+		if value == holdIndefinite {
+			return time.Time{}, true
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			// an unparseable hold is treated as indefinite, it's safer to keep data than to drop it.
+			return time.Time{}, true
+		}
+		if !found || t.After(until) {
+			until = t
+		}
+		found = true
+	}
+	return until, found
+}
+
+// HoldReasonFromTags returns the reason for the hold, or an empty string if not found.
+func HoldReasonFromTags(tags []string) string {
+	for _, tag := range tags {
+		if strings.HasPrefix(tag, holdReasonTagPrefix) {
+			return tag[len(holdReasonTagPrefix):]
+		}
+	}
+	return ""
+}
+
+// IsHeld returns true if the tags contain a hold that is active at the given time.
+func IsHeld(tags []string, now time.Time) bool {
+	until, ok := HoldFromTags(tags)
+	if !ok {
+		return false
+	}
+	return until.IsZero() || now.Before(until)
+}
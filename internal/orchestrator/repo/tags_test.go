@@ -0,0 +1,62 @@
+package repo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIsHeld(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tcs := []struct {
+		name string
+		tags []string
+		want bool
+	}{
+		{
+			name: "no hold",
+			tags: []string{TagForPlan("plan1"), TagForInstance("instance1")},
+			want: false,
+		},
+		{
+			name: "indefinite hold",
+			tags: []string{TagForPlan("plan1"), TagForHold(time.Time{})},
+			want: true,
+		},
+		{
+			name: "hold in the future",
+			tags: []string{TagForHold(now.Add(24 * time.Hour)), TagForHoldReason("legal")},
+			want: true,
+		},
+		{
+			name: "expired hold",
+			tags: []string{TagForHold(now.Add(-24 * time.Hour))},
+			want: false,
+		},
+		{
+			name: "latest hold wins",
+			tags: []string{TagForHold(now.Add(-24 * time.Hour)), TagForHold(now.Add(24 * time.Hour))},
+			want: true,
+		},
+		{
+			name: "unparseable hold is indefinite",
+			tags: []string{"hold:garbage"},
+			want: true,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := IsHeld(tc.tags, now); got != tc.want {
+				t.Errorf("IsHeld(%v) = %v, want %v", tc.tags, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestHoldReasonFromTags(t *testing.T) {
+	tags := []string{TagForHold(time.Time{}), TagForHoldReason("month-end, legal")}
+	if got := HoldReasonFromTags(tags); got != "month-end; legal" {
+		t.Errorf("HoldReasonFromTags() = %q, want %q", got, "month-end; legal")
+	}
+}
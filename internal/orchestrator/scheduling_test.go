@@ -54,7 +54,7 @@ func newTestOrchestrator(t *testing.T) *Orchestrator {
 			Config: config.NewDefaultConfig(),
 		},
 	}
-	orch, err := NewOrchestrator("", cfgMgr, nil, nil)
+	orch, err := NewOrchestrator("", cfgMgr, nil, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("failed to create orchestrator: %v", err)
 	}
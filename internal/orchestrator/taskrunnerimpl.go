@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sync"
 	"time"
 
 	v1 "github.com/garethgeorge/backrest/gen/go/v1"
@@ -90,9 +91,11 @@ func (t *taskRunnerImpl) ExecuteHooks(ctx context.Context, events []v1.Hook_Cond
 	vars.Task = t.t.Name()
 	if t.op != nil {
 		vars.Duration = time.Since(time.UnixMilli(t.op.UnixTimeStartMs))
+		vars.Logref = t.op.Logref
 	}
 
 	vars.CurTime = time.Now()
+	vars.Output = tasks.HookOutputFromContext(ctx)
 
 	repoID := t.t.RepoID()
 	planID := t.t.PlanID()
@@ -110,33 +113,71 @@ func (t *taskRunnerImpl) ExecuteHooks(ctx context.Context, events []v1.Hook_Cond
 		}
 	}
 
+	for _, dh := range hook.DigestHooksTriggeredByEvent(t.Config(), repoID, planID, events) {
+		if t.orchestrator.digestStore == nil {
+			zap.L().Warn("no digest store available, dropping event for hook in digest mode", zap.String("hook", dh.Name))
+			continue
+		}
+		if err := t.orchestrator.digestStore.Append(dh.Key, tasks.NewDigestEvent(dh.Event, vars)); err != nil {
+			zap.L().Error("failed to buffer digest event", zap.String("hook", dh.Name), zap.Error(err))
+		}
+	}
+
 	hookTasks, err := hook.TasksTriggeredByEvent(t.Config(), repoID, planID, t.op, events, vars)
 	if err != nil {
 		return err
 	}
 
-	for _, task := range hookTasks {
-		st, err := t.orchestrator.CreateUnscheduledTask(task, tasks.TaskPriorityDefault, time.Now())
-		if err != nil {
-			return fmt.Errorf("creating task for hook: %w", err)
+	for _, group := range hook.ParallelGroups(hookTasks) {
+		if len(group) == 1 {
+			if err := t.runHookTask(ctx, group[0]); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// hooks in a group run concurrently, the first halting error in config order is reported once all finish.
+		errs := make([]error, len(group))
+		var wg sync.WaitGroup
+		for i, task := range group {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs[i] = t.runHookTask(ctx, task)
+			}()
 		}
-		if err := t.orchestrator.RunTask(ctx, st); hook.IsHaltingError(err) {
-			var cancelErr *hook.HookErrorRequestCancel
-			var retryErr *hook.HookErrorRetry
-			if errors.As(err, &cancelErr) {
-				return fmt.Errorf("%v: %w: %w", task.Name(), &tasks.TaskCancelledError{}, cancelErr.Err)
-			} else if errors.As(err, &retryErr) {
-				return fmt.Errorf("%v: %w", task.Name(), &tasks.TaskRetryError{
-					Err:     retryErr.Err,
-					Backoff: retryErr.Backoff,
-				})
+		wg.Wait()
+		for _, err := range errs {
+			if err != nil {
+				return err
 			}
-			return fmt.Errorf("%v: %w", task.Name(), err)
 		}
 	}
 	return nil
 }
 
+// runHookTask runs a hook task to completion and returns an error only if the hook's error policy halts the operation.
+func (t *taskRunnerImpl) runHookTask(ctx context.Context, task tasks.Task) error {
+	st, err := t.orchestrator.CreateUnscheduledTask(task, tasks.TaskPriorityDefault, time.Now())
+	if err != nil {
+		return fmt.Errorf("creating task for hook: %w", err)
+	}
+	if err := t.orchestrator.RunTask(ctx, st); hook.IsHaltingError(err) {
+		var cancelErr *hook.HookErrorRequestCancel
+		var retryErr *hook.HookErrorRetry
+		if errors.As(err, &cancelErr) {
+			return fmt.Errorf("%v: %w: %w", task.Name(), &tasks.TaskCancelledError{}, cancelErr.Err)
+		} else if errors.As(err, &retryErr) {
+			return fmt.Errorf("%v: %w", task.Name(), &tasks.TaskRetryError{
+				Err:     retryErr.Err,
+				Backoff: retryErr.Backoff,
+			})
+		}
+		return fmt.Errorf("%v: %w", task.Name(), err)
+	}
+	return nil
+}
+
 func (t *taskRunnerImpl) GetRepo(repoID string) (*v1.Repo, error) {
 	if repoID == t.t.RepoID() {
 		return t.t.Repo(), nil
@@ -176,3 +217,7 @@ func (t *taskRunnerImpl) LogrefWriter() (string, io.WriteCloser, error) {
 	writer, err := t.orchestrator.logStore.Create(logID, t.op.GetId(), time.Duration(0))
 	return logID, writer, err
 }
+
+func (t *taskRunnerImpl) LogTail(logref string, maxBytes int64) ([]byte, error) {
+	return t.orchestrator.logStore.Tail(logref, maxBytes)
+}
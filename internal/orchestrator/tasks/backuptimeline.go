@@ -0,0 +1,157 @@
+package tasks
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/pkg/restic"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	timelineInitialInterval   = 2 * time.Second
+	timelinePointsPerInterval = 500 // points recorded at each interval before it doubles.
+	timelineSlowestFiles      = 10
+
+	// MaxBackupTimelineBytes bounds the timeline read when it is served, a day long backup records ~3000 points.
+	MaxBackupTimelineBytes = 16 * 1024 * 1024
+)
+
+// backupTimeline records the progress of a backup as JSONL. The sampling interval doubles every
+// timelinePointsPerInterval points so that the size of the timeline grows with the log of the backup's duration.
+type backupTimeline struct {
+	w        io.Writer
+	interval time.Duration
+	points   int
+	last     time.Time
+}
+
+func newBackupTimeline(w io.Writer) *backupTimeline {
+	return &backupTimeline{
+		w:        w,
+		interval: timelineInitialInterval,
+	}
+}
+
+// record samples a status entry if the interval has passed since the last sample.
+func (tl *backupTimeline) record(now time.Time, entry *restic.BackupProgressEntry) error {
+	if !tl.last.IsZero() && now.Sub(tl.last) < tl.interval {
+		return nil
+	}
+	return tl.write(&v1.BackupTimelinePoint{
+		UnixTimeMs:   now.UnixMilli(),
+		FilesDone:    entry.FilesDone,
+		BytesDone:    entry.BytesDone,
+		TotalFiles:   entry.TotalFiles,
+		TotalBytes:   entry.TotalBytes,
+		CurrentFiles: entry.CurrentFiles,
+	}, now)
+}
+
+// finish records the final point of the timeline from the backup's summary.
+func (tl *backupTimeline) finish(now time.Time, summary *restic.BackupProgressEntry) error {
+	return tl.write(&v1.BackupTimelinePoint{
+		UnixTimeMs: now.UnixMilli(),
+		FilesDone:  summary.TotalFilesProcessed,
+		BytesDone:  summary.TotalBytesProcessed,
+		TotalFiles: summary.TotalFilesProcessed,
+		TotalBytes: summary.TotalBytesProcessed,
+	}, now)
+}
+
+func (tl *backupTimeline) write(point *v1.BackupTimelinePoint, now time.Time) error {
+	data, err := protojson.Marshal(point)
+	if err != nil {
+		return fmt.Errorf("marshal timeline point: %w", err)
+	}
+	if _, err := tl.w.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("write timeline point: %w", err)
+	}
+	tl.last = now
+	tl.points++
+	if tl.points%timelinePointsPerInterval == 0 {
+		tl.interval *= 2
+	}
+	return nil
+}
+
+// ReadBackupTimeline parses a timeline recorded by a backup, downsampling it to at most maxPoints points if maxPoints is
+// positive. Throughput is computed between the returned points, and the files read for longest from all recorded points.
+func ReadBackupTimeline(data []byte, maxPoints int) (*v1.GetBackupTimelineResponse, error) {
+	var points []*v1.BackupTimelinePoint
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), MaxBackupTimelineBytes)
+	for line := 1; scanner.Scan(); line++ {
+		// skips the last line of a timeline that is still being written, and the marker of a truncated log.
+		if !bytes.HasPrefix(scanner.Bytes(), []byte("{")) || !bytes.HasSuffix(scanner.Bytes(), []byte("}")) {
+			continue
+		}
+		point := &v1.BackupTimelinePoint{}
+		if err := protojson.Unmarshal(scanner.Bytes(), point); err != nil {
+			return nil, fmt.Errorf("line %d: unmarshal timeline point: %w", line, err)
+		}
+		points = append(points, point)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read timeline: %w", err)
+	}
+
+	resp := &v1.GetBackupTimelineResponse{
+		SlowestFiles: slowestFiles(points),
+		Points:       downsampleTimeline(points, maxPoints),
+	}
+	for i := 1; i < len(resp.Points); i++ {
+		prev, cur := resp.Points[i-1], resp.Points[i]
+		if secs := float64(cur.UnixTimeMs-prev.UnixTimeMs) / 1000; secs > 0 {
+			cur.FilesPerSecond = float64(cur.FilesDone-prev.FilesDone) / secs
+			cur.BytesPerSecond = float64(cur.BytesDone-prev.BytesDone) / secs
+		}
+	}
+	return resp, nil
+}
+
+// downsampleTimeline keeps every n-th point so that at most maxPoints remain, the last point is always kept.
+func downsampleTimeline(points []*v1.BackupTimelinePoint, maxPoints int) []*v1.BackupTimelinePoint {
+	if maxPoints <= 0 || len(points) <= maxPoints {
+		return points
+	}
+	if maxPoints == 1 {
+		return points[len(points)-1:]
+	}
+	// the last point takes one of the slots.
+	step := (len(points) - 1 + maxPoints - 2) / (maxPoints - 1)
+	sampled := make([]*v1.BackupTimelinePoint, 0, maxPoints)
+	for i := 0; i < len(points)-1; i += step {
+		sampled = append(sampled, points[i])
+	}
+	return append(sampled, points[len(points)-1])
+}
+
+// slowestFiles attributes the time between consecutive points to the files being read at the first of them.
+func slowestFiles(points []*v1.BackupTimelinePoint) []*v1.GetBackupTimelineResponse_FileTime {
+	durations := make(map[string]int64)
+	for i := 0; i+1 < len(points); i++ {
+		elapsed := points[i+1].UnixTimeMs - points[i].UnixTimeMs
+		for _, file := range points[i].CurrentFiles {
+			durations[file] += elapsed
+		}
+	}
+
+	files := make([]*v1.GetBackupTimelineResponse_FileTime, 0, len(durations))
+	for path, duration := range durations {
+		files = append(files, &v1.GetBackupTimelineResponse_FileTime{Path: path, DurationMs: duration})
+	}
+	slices.SortFunc(files, func(a, b *v1.GetBackupTimelineResponse_FileTime) int {
+		return cmp.Or(cmp.Compare(b.DurationMs, a.DurationMs), cmp.Compare(a.Path, b.Path))
+	})
+	if len(files) > timelineSlowestFiles {
+		files = files[:timelineSlowestFiles]
+	}
+	return files
+}
@@ -0,0 +1,72 @@
+package tasks
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/garethgeorge/backrest/pkg/restic"
+)
+
+func TestBackupTimeline(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	timeline := newBackupTimeline(&buf)
+	start := time.UnixMilli(1700000000000)
+
+	// restic reports status every second, the first points are sampled every other report.
+	var now time.Time
+	for i := 0; i < 2*timelinePointsPerInterval+10; i++ {
+		now = start.Add(time.Duration(i) * time.Second)
+		file := "/small"
+		if i < 20 {
+			file = "/large"
+		}
+		if err := timeline.record(now, &restic.BackupProgressEntry{
+			MessageType:  "status",
+			FilesDone:    int64(i),
+			BytesDone:    int64(i) * 1000,
+			CurrentFiles: []string{file},
+		}); err != nil {
+			t.Fatalf("record() error = %v", err)
+		}
+	}
+	if timeline.points != timelinePointsPerInterval+2 || timeline.interval != 2*timelineInitialInterval {
+		t.Errorf("want the interval doubled after %d points, got %d points at interval %v", timelinePointsPerInterval, timeline.points, timeline.interval)
+	}
+	if err := timeline.finish(now.Add(time.Second), &restic.BackupProgressEntry{TotalFilesProcessed: 2000, TotalBytesProcessed: 2000000}); err != nil {
+		t.Fatalf("finish() error = %v", err)
+	}
+
+	// a point being written and the marker of a truncated log are skipped.
+	buf.WriteString("\n[backrest: log truncated]\n{\"unixTimeMs\":")
+
+	resp, err := ReadBackupTimeline(buf.Bytes(), 0)
+	if err != nil {
+		t.Fatalf("ReadBackupTimeline() error = %v", err)
+	}
+	if len(resp.Points) != timeline.points {
+		t.Fatalf("want %d points, got %d", timeline.points, len(resp.Points))
+	}
+	if got := resp.Points[1].BytesPerSecond; got != 1000 {
+		t.Errorf("want 1000 bytes per second, got %v", got)
+	}
+	if last := resp.Points[len(resp.Points)-1]; last.FilesDone != 2000 {
+		t.Errorf("want the summary as the last point, got %v", last)
+	}
+	if len(resp.SlowestFiles) != 2 || resp.SlowestFiles[0].Path != "/small" || resp.SlowestFiles[1].Path != "/large" || resp.SlowestFiles[1].DurationMs != 20000 {
+		t.Errorf("unexpected slowest files %v", resp.SlowestFiles)
+	}
+
+	sampled, err := ReadBackupTimeline(buf.Bytes(), 50)
+	if err != nil {
+		t.Fatalf("ReadBackupTimeline() error = %v", err)
+	}
+	if len(sampled.Points) > 50 || sampled.Points[len(sampled.Points)-1].FilesDone != 2000 {
+		t.Errorf("want at most 50 points ending with the summary, got %d", len(sampled.Points))
+	}
+	if got := sampled.Points[1].UnixTimeMs - sampled.Points[0].UnixTimeMs; sampled.Points[1].BytesPerSecond != 1000 || got <= 2000 {
+		t.Errorf("want throughput computed between sampled points, got %v over %dms", sampled.Points[1].BytesPerSecond, got)
+	}
+}
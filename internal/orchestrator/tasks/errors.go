@@ -40,14 +40,19 @@ func (e TaskRetryError) Unwrap() error {
 // NotifyError triggers the ANY_ERROR hook and any specific error conditions provided.
 // It returns the original error for convenience in chaining.
 func NotifyError(ctx context.Context, runner TaskRunner, taskName string, err error, conditions ...v1.Hook_Condition) error {
+	return NotifyErrorWithVars(ctx, runner, taskName, err, HookVars{}, conditions...)
+}
+
+// NotifyErrorWithVars is NotifyError for tasks that populate vars for their hooks e.g. with the snapshot being restored,
+// the error hooks get the same vars with the error set.
+func NotifyErrorWithVars(ctx context.Context, runner TaskRunner, taskName string, err error, vars HookVars, conditions ...v1.Hook_Condition) error {
 	if err == nil {
 		return nil
 	}
 	allConditions := append([]v1.Hook_Condition{v1.Hook_CONDITION_ANY_ERROR}, conditions...)
+	vars.Task = taskName
+	vars.Error = err.Error()
 	// Log the error via hooks. We ignore the error from ExecuteHooks itself to avoid masking the original error.
-	_ = runner.ExecuteHooks(ctx, allConditions, HookVars{
-		Task:  taskName,
-		Error: err.Error(),
-	})
+	_ = runner.ExecuteHooks(ctx, allConditions, vars)
 	return err
 }
@@ -0,0 +1,117 @@
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"sync"
+)
+
+// HookOutputMarker prefixes a line of command hook stdout that carries structured output as a JSON encoded HookOutput.
+// e.g. ::backrest-output::{"paths": ["/var/backups/db.sql"], "tags": ["db"]}
+const HookOutputMarker = "::backrest-output::"
+
+// HookOutput is structured output emitted by hooks. Output of the hooks run by a task is merged and
+// available to the task (e.g. a backup reads extra paths, tags and env) and to the task's later hooks as .Output.
+type HookOutput struct {
+	Paths []string          `json:"paths,omitempty"` // extra paths included in the backup.
+	Tags  []string          `json:"tags,omitempty"`  // extra tags added to the snapshot.
+	Env   map[string]string `json:"env,omitempty"`   // extra environment variables for restic and later command hooks.
+	Vars  map[string]string `json:"vars,omitempty"`  // free-form values for later hooks e.g. {{ .Output.Vars.lvm_snapshot }}.
+}
+
+// ParseHookOutput parses the JSON payload of a line following HookOutputMarker.
+func ParseHookOutput(payload []byte) (HookOutput, error) {
+	var out HookOutput
+	if err := json.Unmarshal(payload, &out); err != nil {
+		return HookOutput{}, fmt.Errorf("parse hook output: %w", err)
+	}
+	return out, nil
+}
+
+// EnvList returns Env as sorted KEY=VALUE pairs.
+func (o HookOutput) EnvList() []string {
+	var env []string
+	for k, v := range o.Env {
+		env = append(env, k+"="+v)
+	}
+	sort.Strings(env)
+	return env
+}
+
+// merge adds other to o, paths and tags are de-duplicated and later env and vars values win.
+func (o *HookOutput) merge(other HookOutput) {
+	for _, p := range other.Paths {
+		if !slices.Contains(o.Paths, p) {
+			o.Paths = append(o.Paths, p)
+		}
+	}
+	for _, t := range other.Tags {
+		if !slices.Contains(o.Tags, t) {
+			o.Tags = append(o.Tags, t)
+		}
+	}
+	if len(other.Env) > 0 {
+		if o.Env == nil {
+			o.Env = make(map[string]string)
+		}
+		maps.Copy(o.Env, other.Env)
+	}
+	if len(other.Vars) > 0 {
+		if o.Vars == nil {
+			o.Vars = make(map[string]string)
+		}
+		maps.Copy(o.Vars, other.Vars)
+	}
+}
+
+func (o HookOutput) clone() HookOutput {
+	return HookOutput{
+		Paths: slices.Clone(o.Paths),
+		Tags:  slices.Clone(o.Tags),
+		Env:   maps.Clone(o.Env),
+		Vars:  maps.Clone(o.Vars),
+	}
+}
+
+// hookOutputCollector accumulates the output of the hooks run by a task, hooks may run concurrently.
+type hookOutputCollector struct {
+	mu     sync.Mutex
+	output HookOutput
+}
+
+type hookOutputKey struct{}
+
+// ContextWithHookOutput returns a context collecting the output of hooks run within it. A context that already
+// collects output is returned unchanged so hooks report to the task that triggered them.
+func ContextWithHookOutput(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(hookOutputKey{}).(*hookOutputCollector); ok {
+		return ctx
+	}
+	return context.WithValue(ctx, hookOutputKey{}, &hookOutputCollector{})
+}
+
+// AddHookOutput merges out into the output collected by the context, it is dropped if the context collects none.
+func AddHookOutput(ctx context.Context, out HookOutput) {
+	c, ok := ctx.Value(hookOutputKey{}).(*hookOutputCollector)
+	if !ok {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.output.merge(out)
+}
+
+// HookOutputFromContext returns a copy of the output collected by the context so far.
+func HookOutputFromContext(ctx context.Context) HookOutput {
+	c, ok := ctx.Value(hookOutputKey{}).(*hookOutputCollector)
+	if !ok {
+		return HookOutput{}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.output.clone()
+}
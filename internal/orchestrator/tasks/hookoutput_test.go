@@ -0,0 +1,51 @@
+package tasks
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+func TestHookOutputCollectsAcrossHooks(t *testing.T) {
+	ctx := ContextWithHookOutput(context.Background())
+	if got := ContextWithHookOutput(ctx); got != ctx {
+		t.Errorf("expected a context that already collects output to be returned unchanged")
+	}
+
+	first, err := ParseHookOutput([]byte(`{"paths": ["/dump/db.sql"], "tags": ["db"], "env": {"A": "1"}, "vars": {"snap": "lv0"}}`))
+	if err != nil {
+		t.Fatalf("ParseHookOutput() error = %v", err)
+	}
+	AddHookOutput(ctx, first)
+	AddHookOutput(ctx, HookOutput{Paths: []string{"/dump/db.sql", "/dump/extra"}, Env: map[string]string{"A": "2", "B": "3"}})
+
+	got := HookOutputFromContext(ctx)
+	if want := []string{"/dump/db.sql", "/dump/extra"}; !slices.Equal(got.Paths, want) {
+		t.Errorf("got paths %v, want %v", got.Paths, want)
+	}
+	if want := []string{"db"}; !slices.Equal(got.Tags, want) {
+		t.Errorf("got tags %v, want %v", got.Tags, want)
+	}
+	if want := []string{"A=2", "B=3"}; !slices.Equal(got.EnvList(), want) {
+		t.Errorf("got env %v, want %v", got.EnvList(), want)
+	}
+	if got.Vars["snap"] != "lv0" {
+		t.Errorf("got vars %v, want snap=lv0", got.Vars)
+	}
+
+	got.Paths[0] = "modified"
+	if HookOutputFromContext(ctx).Paths[0] != "/dump/db.sql" {
+		t.Errorf("expected HookOutputFromContext to return a copy")
+	}
+
+	if _, err := ParseHookOutput([]byte(`not json`)); err == nil {
+		t.Errorf("expected an error for malformed output")
+	}
+}
+
+func TestHookOutputWithoutCollector(t *testing.T) {
+	AddHookOutput(context.Background(), HookOutput{Paths: []string{"/dropped"}})
+	if got := HookOutputFromContext(context.Background()); len(got.Paths) != 0 {
+		t.Errorf("got paths %v, want none without a collector", got.Paths)
+	}
+}
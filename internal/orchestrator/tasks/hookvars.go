@@ -9,6 +9,7 @@ import (
 
 	"al.essio.dev/pkg/shellescape"
 	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/protoutil"
 	"github.com/garethgeorge/backrest/pkg/restic"
 )
 
@@ -25,6 +26,69 @@ type HookVars struct {
 	CurTime       time.Time                   // the current time as time.Time
 	Duration      time.Duration               // the duration of the operation that triggered the hook.
 	Error         string                      // the error that caused the hook to run as a string.
+	Logref        string                      // the log of the operation that triggered the hook, used to attach log excerpts.
+	LastSuccess   time.Time                   // the time of the plan's last successful backup, set for plan stale and recovered events.
+	RestoreTarget string                      // the path files are restored to, set for restore events.
+	RestoreStats  *v1.RestoreProgressEntry    // the summary of the restore operation.
+	RepoStats     *v1.RepoStats               // the repo stats computed by a stats operation.
+	Command       string                      // the command run by a run command operation.
+	Output        HookOutput                  // structured output emitted by earlier hooks of the task.
+}
+
+// HookVarsForOperation returns the variables a hook triggered by the completed operation would see,
+// e.g. to preview a hook with realistic values. Task, Repo, Plan, Event and CurTime are left for the caller.
+func HookVarsForOperation(op *v1.Operation) HookVars {
+	vars := HookVars{
+		SnapshotId: op.GetSnapshotId(),
+		Logref:     op.GetLogref(),
+	}
+	if op.GetUnixTimeEndMs() > op.GetUnixTimeStartMs() {
+		vars.Duration = time.Duration(op.GetUnixTimeEndMs()-op.GetUnixTimeStartMs()) * time.Millisecond
+	}
+	if op.GetStatus() == v1.OperationStatus_STATUS_ERROR || op.GetStatus() == v1.OperationStatus_STATUS_WARNING {
+		vars.Error = op.GetDisplayMessage()
+	}
+
+	switch o := op.GetOp().(type) {
+	case *v1.Operation_OperationBackup:
+		vars.SnapshotStats = protoutil.BackupProgressSummaryToEntry(o.OperationBackup.GetLastStatus().GetSummary())
+	case *v1.Operation_OperationRestore:
+		vars.RestoreTarget = o.OperationRestore.GetTarget()
+		vars.RestoreStats = o.OperationRestore.GetLastStatus()
+	case *v1.Operation_OperationStats:
+		vars.RepoStats = o.OperationStats.GetStats()
+	case *v1.Operation_OperationRunCommand:
+		vars.Command = o.OperationRunCommand.GetCommand()
+	}
+	return vars
+}
+
+// OperationMatchesCondition returns whether the operation is of the kind that fires hooks with the condition.
+func OperationMatchesCondition(op *v1.Operation, cond v1.Hook_Condition) bool {
+	var ok bool
+	switch cond {
+	case v1.Hook_CONDITION_ANY_ERROR:
+		ok = op.GetStatus() == v1.OperationStatus_STATUS_ERROR
+	case v1.Hook_CONDITION_SNAPSHOT_START, v1.Hook_CONDITION_SNAPSHOT_END, v1.Hook_CONDITION_SNAPSHOT_ERROR,
+		v1.Hook_CONDITION_SNAPSHOT_WARNING, v1.Hook_CONDITION_SNAPSHOT_SUCCESS, v1.Hook_CONDITION_SNAPSHOT_SKIPPED,
+		v1.Hook_CONDITION_PLAN_STALE, v1.Hook_CONDITION_PLAN_RECOVERED:
+		_, ok = op.GetOp().(*v1.Operation_OperationBackup)
+	case v1.Hook_CONDITION_PRUNE_START, v1.Hook_CONDITION_PRUNE_ERROR, v1.Hook_CONDITION_PRUNE_SUCCESS:
+		_, ok = op.GetOp().(*v1.Operation_OperationPrune)
+	case v1.Hook_CONDITION_CHECK_START, v1.Hook_CONDITION_CHECK_ERROR, v1.Hook_CONDITION_CHECK_SUCCESS:
+		_, ok = op.GetOp().(*v1.Operation_OperationCheck)
+	case v1.Hook_CONDITION_FORGET_START, v1.Hook_CONDITION_FORGET_ERROR, v1.Hook_CONDITION_FORGET_SUCCESS:
+		_, ok = op.GetOp().(*v1.Operation_OperationForget)
+	case v1.Hook_CONDITION_RESTORE_START, v1.Hook_CONDITION_RESTORE_ERROR, v1.Hook_CONDITION_RESTORE_SUCCESS:
+		_, ok = op.GetOp().(*v1.Operation_OperationRestore)
+	case v1.Hook_CONDITION_STATS_START, v1.Hook_CONDITION_STATS_ERROR, v1.Hook_CONDITION_STATS_SUCCESS:
+		_, ok = op.GetOp().(*v1.Operation_OperationStats)
+	case v1.Hook_CONDITION_INDEX_SNAPSHOTS_START, v1.Hook_CONDITION_INDEX_SNAPSHOTS_ERROR, v1.Hook_CONDITION_INDEX_SNAPSHOTS_SUCCESS:
+		_, ok = op.GetOp().(*v1.Operation_OperationIndexSnapshot)
+	case v1.Hook_CONDITION_RUN_COMMAND_START, v1.Hook_CONDITION_RUN_COMMAND_ERROR, v1.Hook_CONDITION_RUN_COMMAND_SUCCESS:
+		_, ok = op.GetOp().(*v1.Operation_OperationRunCommand)
+	}
+	return ok
 }
 
 func (v HookVars) EventName(cond v1.Hook_Condition) string {
@@ -61,6 +125,34 @@ func (v HookVars) EventName(cond v1.Hook_Condition) string {
 		return "forget error"
 	case v1.Hook_CONDITION_FORGET_SUCCESS:
 		return "forget success"
+	case v1.Hook_CONDITION_PLAN_STALE:
+		return "plan stale"
+	case v1.Hook_CONDITION_PLAN_RECOVERED:
+		return "plan recovered"
+	case v1.Hook_CONDITION_RESTORE_START:
+		return "restore start"
+	case v1.Hook_CONDITION_RESTORE_ERROR:
+		return "restore error"
+	case v1.Hook_CONDITION_RESTORE_SUCCESS:
+		return "restore success"
+	case v1.Hook_CONDITION_STATS_START:
+		return "stats start"
+	case v1.Hook_CONDITION_STATS_ERROR:
+		return "stats error"
+	case v1.Hook_CONDITION_STATS_SUCCESS:
+		return "stats success"
+	case v1.Hook_CONDITION_INDEX_SNAPSHOTS_START:
+		return "index snapshots start"
+	case v1.Hook_CONDITION_INDEX_SNAPSHOTS_ERROR:
+		return "index snapshots error"
+	case v1.Hook_CONDITION_INDEX_SNAPSHOTS_SUCCESS:
+		return "index snapshots success"
+	case v1.Hook_CONDITION_RUN_COMMAND_START:
+		return "run command start"
+	case v1.Hook_CONDITION_RUN_COMMAND_ERROR:
+		return "run command error"
+	case v1.Hook_CONDITION_RUN_COMMAND_SUCCESS:
+		return "run command success"
 	default:
 		return "unknown"
 	}
@@ -99,7 +191,9 @@ func (v HookVars) FormatSizeBytes(val any) string {
 }
 
 func (v HookVars) IsError(cond v1.Hook_Condition) bool {
-	return cond == v1.Hook_CONDITION_ANY_ERROR || cond == v1.Hook_CONDITION_SNAPSHOT_ERROR
+	// CONDITION_UNKNOWN is an error condition for protoutil but it's the condition of digests and manual hook tests,
+	// which render as non-errors.
+	return cond != v1.Hook_CONDITION_UNKNOWN && protoutil.IsErrorCondition(cond)
 }
 
 func (v HookVars) ShellEscape(s string) string {
@@ -120,19 +214,25 @@ func (v HookVars) Summary() (string, error) {
 		return v.renderTemplate(templateForSnapshotStart)
 	case v1.Hook_CONDITION_SNAPSHOT_END, v1.Hook_CONDITION_SNAPSHOT_WARNING, v1.Hook_CONDITION_SNAPSHOT_SUCCESS:
 		return v.renderTemplate(templateForSnapshotEnd)
+	case v1.Hook_CONDITION_RESTORE_SUCCESS:
+		return v.renderTemplate(templateForRestoreSuccess)
 	default:
 		return v.renderTemplate(templateDefault)
 	}
 }
 
 func (v HookVars) renderTemplate(templ string) (string, error) {
+	return renderTemplate(templ, v)
+}
+
+func renderTemplate(templ string, vars any) (string, error) {
 	t, err := template.New("t").Parse(templ)
 	if err != nil {
 		return "", err
 	}
 
 	var buf bytes.Buffer
-	err = t.Execute(&buf, v)
+	err = t.Execute(&buf, vars)
 	if err != nil {
 		return "", err
 	}
@@ -181,6 +281,17 @@ Backup Statistics:
 {{ end }}
 {{ end }}`
 
+var templateForRestoreSuccess = `
+Backrest Restore Notification
+Task: {{ .Task }} at {{ .FormatTime .CurTime }}
+Event: {{ .EventName .Event }}
+Snapshot: {{ .SnapshotId }}
+Target: {{ .RestoreTarget }}
+Duration: {{ .FormatDuration .Duration }}
+{{ if .RestoreStats -}}
+Restored: {{ .RestoreStats.FilesRestored }} files, {{ .FormatSizeBytes .RestoreStats.BytesRestored }}
+{{ end }}`
+
 var templateForSnapshotStart = `
 Backrest Notification for Snapshot Start
 Task: "{{ .Task }}" at {{ .FormatTime .CurTime }}
@@ -21,3 +21,80 @@ func TestHookVarsEventName(t *testing.T) {
 		}
 	}
 }
+
+func TestHookVarsIsError(t *testing.T) {
+	tests := []struct {
+		condition v1.Hook_Condition
+		want      bool
+	}{
+		{condition: v1.Hook_CONDITION_UNKNOWN, want: false},
+		{condition: v1.Hook_CONDITION_ANY_ERROR, want: true},
+		{condition: v1.Hook_CONDITION_SNAPSHOT_ERROR, want: true},
+		{condition: v1.Hook_CONDITION_CHECK_ERROR, want: true},
+		{condition: v1.Hook_CONDITION_PLAN_STALE, want: true},
+		{condition: v1.Hook_CONDITION_SNAPSHOT_SUCCESS, want: false},
+		{condition: v1.Hook_CONDITION_PLAN_RECOVERED, want: false},
+	}
+
+	vars := HookVars{}
+	for _, tc := range tests {
+		if got := vars.IsError(tc.condition); got != tc.want {
+			t.Errorf("IsError(%v) = %v, want %v", tc.condition, got, tc.want)
+		}
+	}
+}
+
+func TestOperationMatchesCondition(t *testing.T) {
+	// every condition fired by an operation should match an operation of some kind.
+	ops := []*v1.Operation{
+		{Op: &v1.Operation_OperationBackup{}},
+		{Op: &v1.Operation_OperationPrune{}},
+		{Op: &v1.Operation_OperationCheck{}},
+		{Op: &v1.Operation_OperationForget{}},
+		{Op: &v1.Operation_OperationRestore{}},
+		{Op: &v1.Operation_OperationStats{}},
+		{Op: &v1.Operation_OperationIndexSnapshot{}},
+		{Op: &v1.Operation_OperationRunCommand{}},
+		{Status: v1.OperationStatus_STATUS_ERROR},
+	}
+	values := v1.Hook_Condition(0).Descriptor().Values()
+	for i := 0; i < values.Len(); i++ {
+		condition := v1.Hook_Condition(values.Get(i).Number())
+		if condition == v1.Hook_CONDITION_UNKNOWN {
+			continue
+		}
+
+		matched := false
+		for _, op := range ops {
+			if OperationMatchesCondition(op, condition) {
+				matched = true
+			}
+		}
+		if !matched {
+			t.Errorf("no operation matches condition %v", condition)
+		}
+	}
+}
+
+func TestHookVarsForOperation(t *testing.T) {
+	vars := HookVarsForOperation(&v1.Operation{
+		SnapshotId:      "abc123",
+		Status:          v1.OperationStatus_STATUS_WARNING,
+		DisplayMessage:  "partial backup",
+		UnixTimeStartMs: 1000,
+		UnixTimeEndMs:   3500,
+		Op: &v1.Operation_OperationBackup{OperationBackup: &v1.OperationBackup{
+			LastStatus: &v1.BackupProgressEntry{Entry: &v1.BackupProgressEntry_Summary{Summary: &v1.BackupProgressSummary{
+				SnapshotId: "abc123",
+				DataAdded:  42,
+			}}},
+		}},
+	})
+
+	if vars.SnapshotId != "abc123" || vars.Error != "partial backup" || vars.Duration.Milliseconds() != 2500 {
+		t.Errorf("unexpected vars %+v", vars)
+	}
+	if vars.SnapshotStats == nil || vars.SnapshotStats.DataAdded != 42 {
+		t.Errorf("got snapshot stats %+v, want the backup summary", vars.SnapshotStats)
+	}
+}
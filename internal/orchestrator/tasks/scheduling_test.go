@@ -65,6 +65,18 @@ func TestScheduling(t *testing.T) {
 						Clock: v1.Schedule_CLOCK_LAST_RUN_TIME,
 					},
 				},
+				RetentionPolicy: &v1.RepoRetentionPolicy{
+					Schedule: &v1.Schedule{
+						Schedule: &v1.Schedule_MaxFrequencyHours{
+							MaxFrequencyHours: 24,
+						},
+						Clock: v1.Schedule_CLOCK_LAST_RUN_TIME,
+					},
+					Retention: &v1.RetentionPolicy{
+						Policy: &v1.RetentionPolicy_PolicyKeepLastN{PolicyKeepLastN: 10},
+					},
+					OnlyUnowned: true,
+				},
 			},
 		},
 		Plans: []*v1.Plan{
@@ -405,6 +417,34 @@ func TestScheduling(t *testing.T) {
 			},
 			wantTime: farFuture.Add(time.Hour),
 		},
+		{
+			name:     "repo forget schedule relative never run",
+			task:     NewRepoForgetTask(repoRelative, "_system_", false),
+			wantTime: now.Add(24 * time.Hour),
+		},
+		{
+			name: "repo forget schedule relative",
+			task: NewRepoForgetTask(repoRelative, "_system_", false),
+			ops: []*v1.Operation{
+				{
+					InstanceId: "instance1",
+					RepoId:     "repo-relative",
+					RepoGuid:   repoRelative.Guid,
+					PlanId:     "_system_",
+					Op: &v1.Operation_OperationForget{
+						OperationForget: &v1.OperationForget{},
+					},
+					UnixTimeStartMs: 1000,
+					UnixTimeEndMs:   farFuture.UnixMilli(),
+				},
+			},
+			wantTime: farFuture.Add(24 * time.Hour),
+		},
+		{
+			name:     "repo forget no policy",
+			task:     NewRepoForgetTask(repoAbsolute, "_system_", false),
+			wantTime: time.Time{},
+		},
 	}
 
 	for _, tc := range tests {
@@ -61,6 +61,8 @@ type TaskRunner interface {
 	Logger(ctx context.Context) *zap.Logger
 	// LogrefWriter returns a writer that can be used to track streaming operation output.
 	LogrefWriter() (id string, w io.WriteCloser, err error)
+	// LogTail returns up to maxBytes from the end of the log with the given logref, it does not block on in progress logs.
+	LogTail(logref string, maxBytes int64) ([]byte, error)
 }
 
 type TaskExecutor interface {
@@ -269,3 +271,7 @@ func (t *testTaskRunner) Logger(ctx context.Context) *zap.Logger {
 func (t *testTaskRunner) LogrefWriter() (id string, w io.WriteCloser, err error) {
 	panic("not implemented")
 }
+
+func (t *testTaskRunner) LogTail(logref string, maxBytes int64) ([]byte, error) {
+	panic("not implemented")
+}
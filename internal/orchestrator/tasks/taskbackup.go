@@ -14,6 +14,7 @@ import (
 	"github.com/garethgeorge/backrest/internal/protoutil"
 	"github.com/garethgeorge/backrest/pkg/restic"
 	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
 )
 
 var maxBackupErrorHistoryLength = 20 // arbitrary limit on the number of file read errors recorded in a backup operation to prevent it from growing too large.
@@ -145,6 +146,32 @@ func (t *BackupTask) Run(ctx context.Context, st ScheduledTask, runner TaskRunne
 		return notifyError(fmt.Errorf("snapshot start hook: %w", err))
 	}
 
+	// start hooks may emit extra paths, tags and env for the backup e.g. the location of a database dump.
+	var backupOpts []restic.GenericOption
+	if output := HookOutputFromContext(ctx); len(output.Paths)+len(output.Tags)+len(output.Env) > 0 {
+		l.Info("applying hook output to backup", zap.Strings("paths", output.Paths), zap.Strings("tags", output.Tags), zap.Int("env", len(output.Env)))
+		if len(output.Paths) > 0 {
+			plan = proto.Clone(plan).(*v1.Plan)
+			plan.Paths = append(plan.Paths, output.Paths...)
+		}
+		if len(output.Tags) > 0 {
+			backupOpts = append(backupOpts, restic.WithTags(output.Tags...))
+		}
+		if len(output.Env) > 0 {
+			backupOpts = append(backupOpts, restic.WithEnv(output.EnvList()...))
+		}
+	}
+
+	// the timeline is best effort, the backup runs without one if its log can't be written.
+	var timeline *backupTimeline
+	if timelineID, timelineWriter, err := runner.LogrefWriter(); err != nil {
+		l.Warn("failed to create backup timeline", zap.Error(err))
+	} else {
+		defer timelineWriter.Close()
+		backupOp.OperationBackup.TimelineLogref = timelineID
+		timeline = newBackupTimeline(timelineWriter)
+	}
+
 	var sendWg sync.WaitGroup
 	lastSent := time.Now() // debounce progress updates, these can endup being very frequent.
 	var lastFiles []string
@@ -160,6 +187,12 @@ func (t *BackupTask) Run(ctx context.Context, st ScheduledTask, runner TaskRunne
 			}
 
 			backupOp.OperationBackup.LastStatus = protoutil.BackupProgressEntryToProto(entry)
+			if timeline != nil {
+				if err := timeline.record(time.Now(), entry); err != nil {
+					l.Warn("failed to record backup timeline, no further progress will be recorded", zap.Error(err))
+					timeline = nil
+				}
+			}
 		} else if entry.MessageType == "error" {
 			l.Warn("error processing item", zap.String("item", entry.Item), zap.Any("error", entry.Error))
 			fileErrorCount++
@@ -191,7 +224,7 @@ func (t *BackupTask) Run(ctx context.Context, st ScheduledTask, runner TaskRunne
 			}
 			sendWg.Done()
 		}()
-	})
+	}, backupOpts...)
 	sendWg.Wait()
 
 	if summary == nil {
@@ -226,6 +259,11 @@ func (t *BackupTask) Run(ctx context.Context, st ScheduledTask, runner TaskRunne
 
 	op.SnapshotId = summary.SnapshotId
 	backupOp.OperationBackup.LastStatus = protoutil.BackupProgressEntryToProto(summary)
+	if timeline != nil {
+		if err := timeline.finish(time.Now(), summary); err != nil {
+			l.Warn("failed to record end of backup timeline", zap.Error(err))
+		}
+	}
 	if backupOp.OperationBackup.LastStatus == nil {
 		return fmt.Errorf("expected a final backup progress entry, got nil")
 	}
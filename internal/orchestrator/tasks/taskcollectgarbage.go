@@ -4,13 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"reflect"
+	"slices"
 	"time"
 
 	v1 "github.com/garethgeorge/backrest/gen/go/v1"
 	"github.com/garethgeorge/backrest/internal/logstore"
 	"github.com/garethgeorge/backrest/internal/oplog"
 	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
 )
 
 type gcSettingsForType struct {
@@ -24,26 +25,28 @@ type groupByKey struct {
 	RepoGUID   string
 	PlanID     string
 	InstanceID string
-	Type       reflect.Type
+	Type       v1.OperationType
 }
 
 const (
 	gcStartupDelay = 1 * time.Second
 	gcInterval     = 24 * time.Hour
+
+	defaultCompactAfter = 90 * 24 * time.Hour
 )
 
-var gcSettings = map[reflect.Type]gcSettingsForType{
-	reflect.TypeOf(&v1.Operation_OperationStats{}): {
+var gcSettings = map[v1.OperationType]gcSettingsForType{
+	v1.OperationType_OPERATION_TYPE_STATS: {
 		maxAge:  365 * 24 * time.Hour,
 		keepMin: 1,
 		keepMax: 100,
 	},
-	reflect.TypeOf(&v1.Operation_OperationCheck{}): {
+	v1.OperationType_OPERATION_TYPE_CHECK: {
 		maxAge:  365 * 24 * time.Hour,
 		keepMin: 1,
 		keepMax: 12,
 	},
-	reflect.TypeOf(&v1.Operation_OperationPrune{}): {
+	v1.OperationType_OPERATION_TYPE_PRUNE: {
 		maxAge:  365 * 24 * time.Hour,
 		keepMin: 1,
 		keepMax: 12,
@@ -56,6 +59,88 @@ var defaultGcSettings = gcSettingsForType{
 	keepMax: 100,
 }
 
+var defaultCompactOpTypes = []v1.OperationType{v1.OperationType_OPERATION_TYPE_BACKUP}
+
+// gcSettingsFor returns the retention of operations of the type in the plan. The most specific rule of the policy
+// matching the operations overrides the built in settings, fields the rule leaves unset keep their built in values.
+func gcSettingsFor(policy *v1.OperationHistoryPolicy, opType v1.OperationType, planID string) gcSettingsForType {
+	st, ok := gcSettings[opType]
+	if !ok {
+		st = defaultGcSettings
+	}
+
+	var match *v1.OperationHistoryPolicy_Rule
+	matchScore := -1
+	for _, rule := range policy.GetRules() {
+		score := 0
+		if rule.PlanId != "" {
+			if rule.PlanId != planID {
+				continue
+			}
+			score += 2 // a plan is more specific than a type.
+		}
+		if rule.OpType != "" {
+			if ruleType, err := oplog.ParseOperationTypeName(rule.OpType); err != nil || ruleType != opType {
+				continue
+			}
+			score++
+		}
+		if score > matchScore {
+			match, matchScore = rule, score
+		}
+	}
+
+	if match == nil {
+		return st
+	}
+	if match.MaxAgeDays > 0 {
+		st.maxAge = time.Duration(match.MaxAgeDays) * 24 * time.Hour
+	}
+	if match.KeepMin > 0 {
+		st.keepMin = int(match.KeepMin)
+	}
+	if match.KeepMax > 0 {
+		st.keepMax = int(match.KeepMax)
+	}
+	return st
+}
+
+type compactionSettings struct {
+	after        time.Duration
+	opTypes      []v1.OperationType
+	rollupMaxAge time.Duration // rollups are kept indefinitely if 0.
+}
+
+// compactionSettingsFor returns the compaction settings of the policy and whether compaction is enabled.
+func compactionSettingsFor(policy *v1.OperationHistoryPolicy) (compactionSettings, bool) {
+	compaction := policy.GetCompaction()
+	settings := compactionSettings{
+		after:        defaultCompactAfter,
+		opTypes:      defaultCompactOpTypes,
+		rollupMaxAge: time.Duration(compaction.GetRollupMaxAgeDays()) * 24 * time.Hour,
+	}
+	if compaction.GetAfterDays() > 0 {
+		settings.after = time.Duration(compaction.GetAfterDays()) * 24 * time.Hour
+	}
+	if len(compaction.GetOpTypes()) > 0 {
+		settings.opTypes = nil
+		for _, name := range compaction.GetOpTypes() {
+			if opType, err := oplog.ParseOperationTypeName(name); err == nil {
+				settings.opTypes = append(settings.opTypes, opType)
+			}
+		}
+	}
+	return settings, compaction.GetEnabled()
+}
+
+// compactable returns whether the operation is collapsed into a rollup, rather than removed, once it is old enough.
+func (s compactionSettings) compactable(op *v1.Operation) bool {
+	return op.Status == v1.OperationStatus_STATUS_SUCCESS &&
+		op.OriginalInstanceKeyid == "" && // synced operations are compacted by the peer that created them.
+		op.Rollup == nil &&
+		slices.Contains(s.opTypes, oplog.OperationTypeOf(op))
+}
+
 type CollectGarbageTask struct {
 	BaseTask
 	firstRun bool
@@ -92,6 +177,10 @@ func (t *CollectGarbageTask) Next(now time.Time, runner TaskRunner) (ScheduledTa
 }
 
 func (t *CollectGarbageTask) Run(ctx context.Context, st ScheduledTask, runner TaskRunner) error {
+	if err := t.compactOperations(runner); err != nil {
+		return fmt.Errorf("compacting operations: %w", err)
+	}
+
 	if err := t.gcOperations(runner); err != nil {
 		return fmt.Errorf("collecting garbage: %w", err)
 	}
@@ -99,18 +188,151 @@ func (t *CollectGarbageTask) Run(ctx context.Context, st ScheduledTask, runner T
 	return nil
 }
 
-func (t *CollectGarbageTask) gcOperations(runner TaskRunner) error {
-	// snapshotForgottenForFlow returns whether the snapshot associated with the flow is forgotten
+// snapshotForgottenByFlow returns whether the snapshot associated with each flow that indexed a snapshot is forgotten.
+func snapshotForgottenByFlow(runner TaskRunner) (map[int64]bool, error) {
 	snapshotForgottenForFlow := make(map[int64]bool)
-	if err := runner.QueryOperations(oplog.SelectAll, func(op *v1.Operation) error {
+	if err := runner.QueryOperations(oplog.Query{}.SetOpTypes(v1.OperationType_OPERATION_TYPE_INDEX_SNAPSHOT), func(op *v1.Operation) error {
 		if snapshotOp, ok := op.Op.(*v1.Operation_OperationIndexSnapshot); ok {
 			snapshotForgottenForFlow[op.FlowId] = snapshotOp.OperationIndexSnapshot.Forgot
 		}
 		return nil
 	}); err != nil {
-		return fmt.Errorf("identifying forgotten snapshots: %w", err)
+		return nil, fmt.Errorf("identifying forgotten snapshots: %w", err)
+	}
+	return snapshotForgottenForFlow, nil
+}
+
+type rollupKey struct {
+	groupByKey
+	Day string
+}
+
+// compactOperations collapses the compactable operations older than the compaction age into one rollup operation per
+// group and day. Operations whose snapshot is still in the repo are left alone, they stay browsable with their flow.
+func (t *CollectGarbageTask) compactOperations(runner TaskRunner) error {
+	settings, enabled := compactionSettingsFor(runner.Config().GetOperationHistory())
+	if !enabled || len(settings.opTypes) == 0 {
+		return nil
 	}
 
+	snapshotForgottenForFlow, err := snapshotForgottenByFlow(runner)
+	if err != nil {
+		return err
+	}
+
+	cutoff := curTimeMillis() - settings.after.Milliseconds()
+	groups := make(map[rollupKey][]*v1.Operation)
+	var keys []rollupKey
+	if err := runner.QueryOperations(oplog.Query{}.SetStartTimeLtMs(cutoff).SetOpTypes(settings.opTypes...), func(op *v1.Operation) error {
+		if op.Rollup == nil {
+			if !settings.compactable(op) {
+				return nil
+			}
+			if forgot, ok := snapshotForgottenForFlow[op.FlowId]; ok && !forgot {
+				return nil
+			}
+		} else if op.OriginalInstanceKeyid != "" {
+			return nil
+		}
+
+		// existing rollups are merged with the operations of their day that have aged since they were created.
+		key := rollupKey{
+			groupByKey: groupByKey{
+				RepoGUID:   op.RepoGuid,
+				RepoID:     op.RepoId,
+				PlanID:     op.PlanId,
+				InstanceID: op.InstanceId,
+				Type:       oplog.OperationTypeOf(op),
+			},
+			Day: time.UnixMilli(op.UnixTimeStartMs).Format(time.DateOnly),
+		}
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], op)
+		return nil
+	}); err != nil {
+		return fmt.Errorf("identifying compactable operations: %w", err)
+	}
+
+	var rollupsCreated, operationsCompacted int
+	for _, key := range keys {
+		ops := groups[key]
+		if len(ops) == 1 && ops[0].Rollup != nil {
+			continue
+		}
+
+		// the rollup is added before the operations are removed, an interrupted compaction leaves duplicates rather than losing history.
+		rollup := rollupOperations(ops)
+		if err := runner.CreateOperation(rollup); err != nil {
+			return fmt.Errorf("adding rollup for %v: %w", key, err)
+		}
+		ids := make([]int64, 0, len(ops))
+		for _, op := range ops {
+			ids = append(ids, op.Id)
+		}
+		if err := runner.DeleteOperation(ids...); err != nil {
+			return fmt.Errorf("removing operations compacted into rollup %d: %w", rollup.Id, err)
+		}
+		rollupsCreated++
+		operationsCompacted += len(ops)
+	}
+
+	zap.L().Info("compacting operations",
+		zap.Int("rollups_created", rollupsCreated),
+		zap.Int("operations_compacted", operationsCompacted))
+	return nil
+}
+
+// rollupOperations returns a new operation summarizing the operations, which are ordered by start time. The rollup
+// holds the details of the latest operation, it has a flow of its own and no snapshot or logs.
+func rollupOperations(ops []*v1.Operation) *v1.Operation {
+	summary := &v1.OperationRollup{FirstStartTimeMs: ops[0].UnixTimeStartMs}
+	for _, op := range ops {
+		if r := op.Rollup; r != nil {
+			summary.Count += r.Count
+			summary.FirstStartTimeMs = min(summary.FirstStartTimeMs, r.FirstStartTimeMs)
+			summary.TotalDurationMs += r.TotalDurationMs
+			summary.TotalFilesProcessed += r.TotalFilesProcessed
+			summary.TotalBytesProcessed += r.TotalBytesProcessed
+			summary.TotalBytesAdded += r.TotalBytesAdded
+			continue
+		}
+		summary.Count++
+		if op.UnixTimeEndMs > op.UnixTimeStartMs {
+			summary.TotalDurationMs += op.UnixTimeEndMs - op.UnixTimeStartMs
+		}
+		if backupSummary := op.GetOperationBackup().GetLastStatus().GetSummary(); backupSummary != nil {
+			summary.TotalFilesProcessed += backupSummary.TotalFilesProcessed
+			summary.TotalBytesProcessed += backupSummary.TotalBytesProcessed
+			summary.TotalBytesAdded += backupSummary.DataAdded
+		}
+	}
+
+	rollup := proto.Clone(ops[len(ops)-1]).(*v1.Operation)
+	rollup.Id = 0
+	rollup.Modno = 0
+	rollup.FlowId = 0
+	rollup.OriginalId = 0
+	rollup.OriginalFlowId = 0
+	rollup.SnapshotId = ""
+	rollup.Logref = ""
+	if backup := rollup.GetOperationBackup(); backup != nil {
+		backup.TimelineLogref = ""
+	}
+	rollup.Rollup = summary
+	return rollup
+}
+
+func (t *CollectGarbageTask) gcOperations(runner TaskRunner) error {
+	snapshotForgottenForFlow, err := snapshotForgottenByFlow(runner)
+	if err != nil {
+		return err
+	}
+
+	policy := runner.Config().GetOperationHistory()
+	compaction, compactionEnabled := compactionSettingsFor(policy)
+
 	// cache known peer key ids, operations from unknown peers will be purged from the history as they can always be resync'd if the peer is readded.
 	knownPeerKeyids := make(map[string]struct{})
 	for _, peer := range runner.Config().GetMultihost().GetAuthorizedClients() {
@@ -138,18 +360,31 @@ func (t *CollectGarbageTask) gcOperations(runner TaskRunner) error {
 			if !ok {
 				forgetIDs = append(forgetIDs, op.Id)
 				deletedByUnknownPeerKeyid++
-				deletedByType[reflect.TypeOf(op.Op).String()]++
+				deletedByType[oplog.OperationTypeOf(op).String()]++
 				return nil
 			}
 		}
 
+		if op.Rollup != nil {
+			if compaction.rollupMaxAge > 0 && curTime-op.UnixTimeStartMs > compaction.rollupMaxAge.Milliseconds() {
+				forgetIDs = append(forgetIDs, op.Id)
+				deletedByMaxAge++
+				deletedByType[oplog.OperationTypeOf(op).String()]++
+			}
+			return nil
+		}
+		if compactionEnabled && compaction.compactable(op) {
+			// the operation is collapsed into a rollup once it reaches the compaction age rather than removed.
+			return nil
+		}
+
 		forgot, ok := snapshotForgottenForFlow[op.FlowId]
 		if ok {
 			if forgot {
 				// snapshot is forgotten; this operation is eligible for gc
 				forgetIDs = append(forgetIDs, op.Id)
 				deletedByForgottenSnapshot++
-				deletedByType[reflect.TypeOf(op.Op).String()]++
+				deletedByType[oplog.OperationTypeOf(op).String()]++
 			}
 			return nil
 		}
@@ -159,17 +394,12 @@ func (t *CollectGarbageTask) gcOperations(runner TaskRunner) error {
 			RepoID:     op.RepoId,
 			PlanID:     op.PlanId,
 			InstanceID: op.InstanceId,
-			Type:       reflect.TypeOf(op.Op),
+			Type:       oplog.OperationTypeOf(op),
 		}
 
 		st, ok := stats[key]
 		if !ok {
-			gcSettings, ok := gcSettings[reflect.TypeOf(op.Op)]
-			if !ok {
-				st = defaultGcSettings
-			} else {
-				st = gcSettings
-			}
+			st = gcSettingsFor(policy, key.Type, op.PlanId)
 		}
 
 		st.keepMax--    // decrement the max retention, when this < 0 operation must be gc'd
@@ -0,0 +1,202 @@
+package tasks
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/logstore"
+	"github.com/garethgeorge/backrest/internal/oplog"
+	"github.com/garethgeorge/backrest/internal/oplog/sqlitestore"
+)
+
+func TestGcSettingsFor(t *testing.T) {
+	t.Parallel()
+
+	policy := &v1.OperationHistoryPolicy{
+		Rules: []*v1.OperationHistoryPolicy_Rule{
+			{OpType: "backup", MaxAgeDays: 60},
+			{PlanId: "plan1", KeepMax: 500},
+			{OpType: "backup", PlanId: "plan1", MaxAgeDays: 365},
+		},
+	}
+	backup := v1.OperationType_OPERATION_TYPE_BACKUP
+	check := v1.OperationType_OPERATION_TYPE_CHECK
+
+	tests := []struct {
+		name   string
+		policy *v1.OperationHistoryPolicy
+		opType v1.OperationType
+		planID string
+		want   gcSettingsForType
+	}{
+		{
+			name:   "no policy",
+			opType: check,
+			planID: "plan1",
+			want:   gcSettings[check],
+		},
+		{
+			name:   "type rule",
+			policy: policy,
+			opType: backup,
+			planID: "plan2",
+			want:   gcSettingsForType{maxAge: 60 * 24 * time.Hour, keepMin: 1, keepMax: 100},
+		},
+		{
+			name:   "plan rule",
+			policy: policy,
+			opType: check,
+			planID: "plan1",
+			want:   gcSettingsForType{maxAge: 365 * 24 * time.Hour, keepMin: 1, keepMax: 500},
+		},
+		{
+			name:   "plan and type rule",
+			policy: policy,
+			opType: backup,
+			planID: "plan1",
+			want:   gcSettingsForType{maxAge: 365 * 24 * time.Hour, keepMin: 1, keepMax: 100},
+		},
+		{
+			name:   "no matching rule",
+			policy: policy,
+			opType: check,
+			planID: "plan2",
+			want:   gcSettings[check],
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := gcSettingsFor(tc.policy, tc.opType, tc.planID); got != tc.want {
+				t.Errorf("gcSettingsFor() = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCompactOperations(t *testing.T) {
+	repo := &v1.Repo{Id: "repo", Guid: "repo-guid"}
+	plan := &v1.Plan{Id: "plan", Repo: "repo"}
+	cfg := &v1.Config{
+		Instance: "instance",
+		Repos:    []*v1.Repo{repo},
+		Plans:    []*v1.Plan{plan},
+		OperationHistory: &v1.OperationHistoryPolicy{
+			Compaction: &v1.OperationHistoryPolicy_Compaction{Enabled: true},
+		},
+	}
+
+	opstore, err := sqlitestore.NewMemorySqliteStore(t)
+	if err != nil {
+		t.Fatalf("failed to create opstore: %v", err)
+	}
+	t.Cleanup(func() { opstore.Close() })
+	log, err := oplog.NewOpLog(opstore)
+	if err != nil {
+		t.Fatalf("failed to create oplog: %v", err)
+	}
+	logs, err := logstore.NewLogStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create logstore: %v", err)
+	}
+	t.Cleanup(func() { logs.Close() })
+	runner := newTestTaskRunner(t, cfg, log)
+
+	addBackup := func(start time.Time, status v1.OperationStatus, snapshotID string) *v1.Operation {
+		t.Helper()
+		op := &v1.Operation{
+			RepoId:          repo.Id,
+			RepoGuid:        repo.Guid,
+			PlanId:          plan.Id,
+			UnixTimeStartMs: start.UnixMilli(),
+			UnixTimeEndMs:   start.Add(time.Minute).UnixMilli(),
+			Status:          status,
+			SnapshotId:      snapshotID,
+			Op: &v1.Operation_OperationBackup{OperationBackup: &v1.OperationBackup{
+				LastStatus: &v1.BackupProgressEntry{Entry: &v1.BackupProgressEntry_Summary{Summary: &v1.BackupProgressSummary{
+					TotalFilesProcessed: 10,
+					TotalBytesProcessed: 1000,
+					DataAdded:           100,
+				}}},
+			}},
+		}
+		if err := runner.CreateOperation(op); err != nil {
+			t.Fatalf("failed to add backup op: %v", err)
+		}
+		return op
+	}
+
+	old := time.Now().AddDate(0, 0, -100)
+	day1 := time.Date(old.Year(), old.Month(), old.Day(), 1, 0, 0, 0, time.Local)
+	day2 := day1.AddDate(0, 0, 1)
+	for i := 0; i < 10; i++ {
+		addBackup(day1.Add(time.Duration(i)*time.Hour), v1.OperationStatus_STATUS_SUCCESS, "")
+	}
+	for i := 0; i < 5; i++ {
+		addBackup(day2.Add(time.Duration(i)*time.Hour), v1.OperationStatus_STATUS_SUCCESS, "")
+	}
+	// a backup whose snapshot is still in the repo isn't compacted.
+	kept := addBackup(day2.Add(12*time.Hour), v1.OperationStatus_STATUS_SUCCESS, strings.Repeat("a", 64))
+	if err := runner.CreateOperation(&v1.Operation{
+		RepoId:          repo.Id,
+		RepoGuid:        repo.Guid,
+		PlanId:          plan.Id,
+		FlowId:          kept.FlowId,
+		UnixTimeStartMs: kept.UnixTimeStartMs,
+		Status:          v1.OperationStatus_STATUS_SUCCESS,
+		Op:              &v1.Operation_OperationIndexSnapshot{OperationIndexSnapshot: &v1.OperationIndexSnapshot{}},
+	}); err != nil {
+		t.Fatalf("failed to add index snapshot op: %v", err)
+	}
+	// successful backups newer than the compaction age are kept even though they're past the default max age.
+	recent := addBackup(time.Now().AddDate(0, 0, -45), v1.OperationStatus_STATUS_SUCCESS, "")
+
+	task := NewCollectGarbageTask(logs)
+	run := func() []*v1.Operation {
+		t.Helper()
+		if err := task.Run(context.Background(), ScheduledTask{Task: task}, runner); err != nil {
+			t.Fatalf("failed to run task: %v", err)
+		}
+		var ops []*v1.Operation
+		if err := runner.QueryOperations(oplog.Query{}.SetOpTypes(v1.OperationType_OPERATION_TYPE_BACKUP), func(op *v1.Operation) error {
+			ops = append(ops, op)
+			return nil
+		}); err != nil {
+			t.Fatalf("failed to query operations: %v", err)
+		}
+		return ops
+	}
+
+	ops := run()
+	if len(ops) != 4 {
+		t.Fatalf("want 2 rollups and 2 backups, got %d operations: %v", len(ops), ops)
+	}
+	for i, want := range []int64{10, 5} {
+		rollup := ops[i].GetRollup()
+		if rollup == nil {
+			t.Fatalf("operation %d: want a rollup, got %v", i, ops[i])
+		}
+		if rollup.Count != want || rollup.TotalBytesAdded != 100*want || rollup.TotalDurationMs != want*time.Minute.Milliseconds() {
+			t.Errorf("operation %d: unexpected rollup %v", i, rollup)
+		}
+		if ops[i].SnapshotId != "" || ops[i].FlowId != ops[i].Id {
+			t.Errorf("operation %d: want a rollup with its own flow and no snapshot, got %v", i, ops[i])
+		}
+	}
+	if ops[0].GetRollup().FirstStartTimeMs != day1.UnixMilli() || ops[0].UnixTimeStartMs != day1.Add(9*time.Hour).UnixMilli() {
+		t.Errorf("want the rollup to span the day's backups, got %v", ops[0])
+	}
+	if ops[2].Id != kept.Id || ops[3].Id != recent.Id {
+		t.Errorf("want the backup with a snapshot and the recent backup kept, got %v and %v", ops[2], ops[3])
+	}
+
+	// backups of a compacted day are merged into its rollup.
+	addBackup(day1.Add(12*time.Hour), v1.OperationStatus_STATUS_SUCCESS, "")
+	ops = run()
+	if len(ops) != 4 || ops[0].GetRollup().GetCount() != 11 || ops[1].GetRollup().GetCount() != 5 {
+		t.Errorf("want the backup merged into the first rollup, got %v", ops)
+	}
+}
@@ -0,0 +1,456 @@
+package tasks
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/kvstore"
+	"github.com/garethgeorge/backrest/internal/protoutil"
+)
+
+const (
+	digestEventsKeyPrefix  = "events/"
+	digestFlushedKeyPrefix = "flushed/"
+	digestHookKeyPrefix    = "hooks/" // name of the hook a key was last retained for, see Retain.
+)
+
+// DigestEvent is a hook event buffered until the hook's digest is flushed.
+type DigestEvent struct {
+	Time       time.Time         `json:"time"`
+	Event      v1.Hook_Condition `json:"event"`
+	Task       string            `json:"task"`
+	RepoID     string            `json:"repoId,omitempty"`
+	PlanID     string            `json:"planId,omitempty"`
+	SnapshotID string            `json:"snapshotId,omitempty"`
+	BytesAdded int64             `json:"bytesAdded,omitempty"`
+	Error      string            `json:"error,omitempty"`
+}
+
+// equal reports whether the events are the same, times are compared as instants since they are read back from JSON.
+func (e DigestEvent) equal(other DigestEvent) bool {
+	if !e.Time.Equal(other.Time) {
+		return false
+	}
+	e.Time, other.Time = time.Time{}, time.Time{}
+	return e == other
+}
+
+func NewDigestEvent(event v1.Hook_Condition, vars HookVars) DigestEvent {
+	ev := DigestEvent{
+		Time:       vars.CurTime,
+		Event:      event,
+		Task:       vars.Task,
+		RepoID:     vars.Repo.GetId(),
+		PlanID:     vars.Plan.GetId(),
+		SnapshotID: vars.SnapshotId,
+		Error:      vars.Error,
+	}
+	if vars.SnapshotStats != nil {
+		ev.BytesAdded = vars.SnapshotStats.DataAdded
+	}
+	return ev
+}
+
+// DigestVars is the set of variables available to a hook when its digest is flushed.
+// HookVars is embedded so that templates written for individual events still render.
+type DigestVars struct {
+	HookVars
+	Hook         string        // the name of the hook the digest is sent for.
+	Since        time.Time     // the start of the period covered by the digest.
+	Events       []DigestEvent // the buffered events, oldest first.
+	Successes    int           // the number of success events.
+	Warnings     int           // the number of warning events.
+	Errors       int           // the number of error events.
+	BytesAdded   int64         // the total data added by snapshots in the digest.
+	FailingPlans []string      // IDs of plans with at least one error event.
+}
+
+func NewDigestVars(hook string, since, now time.Time, events []DigestEvent) DigestVars {
+	v := DigestVars{
+		HookVars: HookVars{CurTime: now},
+		Hook:     hook,
+		Since:    since,
+		Events:   events,
+	}
+	for _, ev := range events {
+		if v.Since.IsZero() || ev.Time.Before(v.Since) {
+			v.Since = ev.Time
+		}
+		v.BytesAdded += ev.BytesAdded
+
+		switch {
+		case protoutil.IsSuccessCondition(ev.Event):
+			v.Successes++
+		case ev.Event == v1.Hook_CONDITION_SNAPSHOT_WARNING:
+			v.Warnings++
+		case protoutil.IsErrorCondition(ev.Event):
+			v.Errors++
+			if ev.PlanID != "" && !slices.Contains(v.FailingPlans, ev.PlanID) {
+				v.FailingPlans = append(v.FailingPlans, ev.PlanID)
+			}
+		}
+	}
+	sort.Strings(v.FailingPlans)
+	return v
+}
+
+func (v DigestVars) EventName(cond v1.Hook_Condition) string {
+	if cond == v1.Hook_CONDITION_UNKNOWN {
+		return "digest"
+	}
+	return v.HookVars.EventName(cond)
+}
+
+// IsError reports whether the digest includes error events when cond is the digest's own event.
+func (v DigestVars) IsError(cond v1.Hook_Condition) bool {
+	if cond == v1.Hook_CONDITION_UNKNOWN {
+		return v.Errors > 0
+	}
+	return v.HookVars.IsError(cond)
+}
+
+func (v DigestVars) Summary() (string, error) {
+	return renderTemplate(templateForDigest, v)
+}
+
+var templateForDigest = `
+Backrest Digest
+{{ .FormatTime .Since }} to {{ .FormatTime .CurTime }}: {{ len .Events }} events
+- Succeeded: {{ .Successes }}
+- Warnings: {{ .Warnings }}
+- Errors: {{ .Errors }}
+- Data added: {{ .FormatSizeBytes .BytesAdded }}
+{{ if .FailingPlans -}}
+Failing plans:
+{{ range .FailingPlans -}}
+- {{ . }}
+{{ end }}
+{{- end }}`
+
+// DigestStore persists the events buffered for hooks in digest mode so that they survive restarts.
+type DigestStore struct {
+	mu sync.Mutex
+	kv kvstore.KvStore
+}
+
+func NewDigestStore(dbpool *sql.DB) (*DigestStore, error) {
+	kv, err := kvstore.NewSqliteKVStore(dbpool, "hook_digests")
+	if err != nil {
+		return nil, fmt.Errorf("create kvstore: %w", err)
+	}
+	return &DigestStore{kv: kv}, nil
+}
+
+// Append buffers events for the hook identified by key.
+func (s *DigestStore) Append(key string, events ...DigestEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	buffered, err := s.getEvents(key)
+	if err != nil {
+		return err
+	}
+	buffered = append(buffered, events...)
+	sort.SliceStable(buffered, func(i, j int) bool {
+		return buffered[i].Time.Before(buffered[j].Time)
+	})
+	return s.setEvents(key, buffered)
+}
+
+// Events returns the events buffered for the hook identified by key, they stay buffered until removed by Remove.
+func (s *DigestStore) Events(key string) ([]DigestEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.getEvents(key)
+}
+
+// Remove removes the given events from the buffer of the hook identified by key e.g. once they were sent. Events
+// buffered since they were read are kept.
+func (s *DigestStore) Remove(key string, events []DigestEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	buffered, err := s.getEvents(key)
+	if err != nil {
+		return err
+	}
+	for _, ev := range events {
+		if idx := slices.IndexFunc(buffered, ev.equal); idx != -1 {
+			buffered = slices.Delete(buffered, idx, idx+1)
+		}
+	}
+	return s.setEvents(key, buffered)
+}
+
+// LastFlush returns the time the hook's digest was last flushed, or the zero time if it never was.
+func (s *DigestStore) LastFlush(key string) (time.Time, error) {
+	b, err := s.kv.Get(digestFlushedKeyPrefix + key)
+	if errors.Is(err, kvstore.ErrNotExist) || (err == nil && len(b) == 0) {
+		return time.Time{}, nil
+	} else if err != nil {
+		return time.Time{}, fmt.Errorf("get last flush for %q: %w", key, err)
+	}
+	ms, err := strconv.ParseInt(string(b), 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parse last flush for %q: %w", key, err)
+	}
+	return time.UnixMilli(ms), nil
+}
+
+func (s *DigestStore) SetLastFlush(key string, t time.Time) error {
+	if err := s.kv.Set(digestFlushedKeyPrefix+key, []byte(strconv.FormatInt(t.UnixMilli(), 10))); err != nil {
+		return fmt.Errorf("set last flush for %q: %w", key, err)
+	}
+	return nil
+}
+
+// Retain removes the buffered events and flush times of all hooks except those in hooks, which maps the keys of the
+// current hooks to their names e.g. when hooks are removed from the config. A key that changed because its hook was
+// edited is recognized by the hook's name, its events and digest period are moved to the new key rather than removed.
+func (s *DigestStore) Retain(hooks map[string]string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	keysByName := make(map[string]string, len(hooks))
+	for key, name := range hooks {
+		keysByName[name] = key
+	}
+
+	names := make(map[string]string)
+	stale := make(map[string]bool)
+	for _, prefix := range []string{digestEventsKeyPrefix, digestFlushedKeyPrefix, digestHookKeyPrefix} {
+		if err := s.kv.ForEach(prefix, func(key string, value []byte) error {
+			key = strings.TrimPrefix(key, prefix)
+			if prefix == digestHookKeyPrefix {
+				names[key] = string(value)
+			}
+			if _, ok := hooks[key]; !ok {
+				stale[key] = true
+			}
+			return nil
+		}); err != nil {
+			return fmt.Errorf("list digest keys: %w", err)
+		}
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(stale)) {
+		if newKey, ok := keysByName[names[key]]; ok && names[key] != "" {
+			if err := s.move(key, newKey); err != nil {
+				return err
+			}
+		}
+		for _, prefix := range []string{digestEventsKeyPrefix, digestFlushedKeyPrefix, digestHookKeyPrefix} {
+			if err := s.kv.Delete(prefix + key); err != nil {
+				return fmt.Errorf("delete stale digest key %q: %w", key, err)
+			}
+		}
+	}
+
+	for key, name := range hooks {
+		if names[key] == name {
+			continue
+		}
+		if err := s.kv.Set(digestHookKeyPrefix+key, []byte(name)); err != nil {
+			return fmt.Errorf("set digest hook for %q: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// move merges the events buffered under key into those of newKey, newKey's digest period starts at the earlier of the
+// two flush times.
+func (s *DigestStore) move(key, newKey string) error {
+	events, err := s.getEvents(key)
+	if err != nil {
+		return err
+	}
+	if len(events) > 0 {
+		buffered, err := s.getEvents(newKey)
+		if err != nil {
+			return err
+		}
+		buffered = append(buffered, events...)
+		sort.SliceStable(buffered, func(i, j int) bool {
+			return buffered[i].Time.Before(buffered[j].Time)
+		})
+		if err := s.setEvents(newKey, buffered); err != nil {
+			return err
+		}
+	}
+
+	lastFlush, err := s.LastFlush(key)
+	if err != nil {
+		return err
+	}
+	newLastFlush, err := s.LastFlush(newKey)
+	if err != nil {
+		return err
+	}
+	if !lastFlush.IsZero() && (newLastFlush.IsZero() || lastFlush.Before(newLastFlush)) {
+		return s.SetLastFlush(newKey, lastFlush)
+	}
+	return nil
+}
+
+func (s *DigestStore) getEvents(key string) ([]DigestEvent, error) {
+	b, err := s.kv.Get(digestEventsKeyPrefix + key)
+	if errors.Is(err, kvstore.ErrNotExist) || (err == nil && len(b) == 0) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("get digest events for %q: %w", key, err)
+	}
+	var events []DigestEvent
+	if err := json.Unmarshal(b, &events); err != nil {
+		return nil, fmt.Errorf("unmarshal digest events for %q: %w", key, err)
+	}
+	return events, nil
+}
+
+func (s *DigestStore) setEvents(key string, events []DigestEvent) error {
+	var b []byte
+	if len(events) > 0 {
+		var err error
+		if b, err = json.Marshal(events); err != nil {
+			return fmt.Errorf("marshal digest events for %q: %w", key, err)
+		}
+	}
+	if err := s.kv.Set(digestEventsKeyPrefix+key, b); err != nil {
+		return fmt.Errorf("set digest events for %q: %w", key, err)
+	}
+	return nil
+}
+
+// DigestSender delivers a digest using the hook's action.
+type DigestSender func(ctx context.Context, hook *v1.Hook, vars DigestVars, runner TaskRunner) error
+
+// DigestFlushTask sends the events buffered for a hook in digest mode as a single summary on the digest's schedule.
+// An operation is only recorded for flushes that send a digest.
+type DigestFlushTask struct {
+	BaseTask
+	hookName string
+	hookKey  string // identifies the hook's events in the store, see hook.RepoDigestKey.
+	hook     *v1.Hook
+	store    *DigestStore
+	send     DigestSender
+}
+
+func NewDigestFlushTask(repo *v1.Repo, planID string, hookName, hookKey string, hook *v1.Hook, store *DigestStore, send DigestSender) Task {
+	return &DigestFlushTask{
+		BaseTask: BaseTask{
+			TaskType:   "digest",
+			TaskName:   fmt.Sprintf("send digest for %v", hookName),
+			TaskRepo:   repo,
+			TaskPlanID: planID,
+		},
+		hookName: hookName,
+		hookKey:  hookKey,
+		hook:     hook,
+		store:    store,
+		send:     send,
+	}
+}
+
+func (t *DigestFlushTask) Next(now time.Time, runner TaskRunner) (ScheduledTask, error) {
+	lastFlush, err := t.store.LastFlush(t.hookKey)
+	if err != nil {
+		return NeverScheduledTask, err
+	} else if lastFlush.IsZero() {
+		// the digest period starts when the hook is first seen.
+		lastFlush = now
+		if err := t.store.SetLastFlush(t.hookKey, now); err != nil {
+			return NeverScheduledTask, err
+		}
+	}
+
+	runAt, err := protoutil.ResolveSchedule(t.hook.GetDigest().GetSchedule(), lastFlush, now)
+	if errors.Is(err, protoutil.ErrScheduleDisabled) {
+		return NeverScheduledTask, nil
+	} else if err != nil {
+		return NeverScheduledTask, fmt.Errorf("resolve schedule: %w", err)
+	}
+
+	return ScheduledTask{
+		Task:  t,
+		RunAt: runAt,
+	}, nil
+}
+
+// Run sends the buffered events, they are only removed from the store and the digest period only ends once the digest
+// is delivered so that a failed or interrupted flush includes them in the next digest.
+func (t *DigestFlushTask) Run(ctx context.Context, st ScheduledTask, runner TaskRunner) error {
+	since, err := t.store.LastFlush(t.hookKey)
+	if err != nil {
+		return err
+	}
+	events, err := t.store.Events(t.hookKey)
+	if err != nil {
+		return fmt.Errorf("get digest events: %w", err)
+	}
+	now := time.Now()
+	if len(events) == 0 {
+		return t.store.SetLastFlush(t.hookKey, now)
+	}
+
+	op := &v1.Operation{
+		PlanId:          t.PlanID(),
+		RepoId:          t.RepoID(),
+		RepoGuid:        t.Repo().GetGuid(),
+		UnixTimeStartMs: now.UnixMilli(),
+		Status:          v1.OperationStatus_STATUS_INPROGRESS,
+		DisplayMessage:  fmt.Sprintf("sending digest of %d events", len(events)),
+		Op: &v1.Operation_OperationRunHook{
+			OperationRunHook: &v1.OperationRunHook{
+				Name: t.hookName,
+			},
+		},
+	}
+	if err := runner.CreateOperation(op); err != nil {
+		return fmt.Errorf("create operation: %w", err)
+	}
+
+	err = t.sendDigest(ctx, since, now, events, runner)
+	if err == nil {
+		if e := t.store.Remove(t.hookKey, events); e != nil {
+			err = fmt.Errorf("remove sent digest events: %w", e)
+		} else if e := t.store.SetLastFlush(t.hookKey, now); e != nil {
+			err = e
+		}
+	}
+	op.UnixTimeEndMs = time.Now().UnixMilli()
+	op.Status = v1.OperationStatus_STATUS_SUCCESS
+	if err != nil {
+		op.Status = v1.OperationStatus_STATUS_ERROR
+		op.DisplayMessage = err.Error() + "\n\n" + op.DisplayMessage
+	}
+	if e := runner.UpdateOperation(op); e != nil {
+		runner.Logger(ctx).Sugar().Errorf("failed to update digest operation: %v", e)
+	}
+	return err
+}
+
+func (t *DigestFlushTask) sendDigest(ctx context.Context, since, now time.Time, events []DigestEvent, runner TaskRunner) error {
+	vars := NewDigestVars(t.hookName, since, now, events)
+	vars.Task = t.Name()
+	vars.Repo = t.Repo()
+	if plan, err := runner.GetPlan(t.PlanID()); err == nil {
+		vars.Plan = plan
+	} else {
+		vars.Plan = &v1.Plan{Id: t.PlanID()}
+	}
+
+	if err := t.send(ctx, t.hook, vars, runner); err != nil {
+		return fmt.Errorf("send digest: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/kvstore"
+	"github.com/garethgeorge/backrest/internal/oplog"
+	"github.com/garethgeorge/backrest/internal/oplog/sqlitestore"
+)
+
+func TestDigestStore(t *testing.T) {
+	store, err := NewDigestStore(kvstore.NewInMemorySqliteDbForKvStore(t))
+	if err != nil {
+		t.Fatalf("NewDigestStore() error = %v", err)
+	}
+
+	now := time.UnixMilli(time.Now().UnixMilli())
+	if err := store.Append("hook", DigestEvent{Time: now.Add(time.Minute), Task: "second"}); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	if err := store.Append("hook", DigestEvent{Time: now, Task: "first"}); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	if err := store.Append("other", DigestEvent{Time: now, Task: "other"}); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+
+	events, err := store.Events("hook")
+	if err != nil {
+		t.Fatalf("Events() error = %v", err)
+	}
+	if len(events) != 2 || events[0].Task != "first" || events[1].Task != "second" {
+		t.Errorf("got events %+v, want first and second in order", events)
+	}
+
+	if err := store.Append("hook", DigestEvent{Time: now.Add(2 * time.Minute), Task: "third"}); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	if err := store.Remove("hook", events); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if events, err := store.Events("hook"); err != nil || len(events) != 1 || events[0].Task != "third" {
+		t.Errorf("got events %+v (err %v) after remove, want only the event appended since they were read", events, err)
+	}
+	if events, err := store.Events("other"); err != nil || len(events) != 1 {
+		t.Errorf("got %d events (err %v) for other hook, want 1", len(events), err)
+	}
+
+	if last, err := store.LastFlush("hook"); err != nil || !last.IsZero() {
+		t.Errorf("got last flush %v (err %v), want zero time", last, err)
+	}
+	if err := store.SetLastFlush("hook", now); err != nil {
+		t.Fatalf("SetLastFlush() error = %v", err)
+	}
+	if last, err := store.LastFlush("hook"); err != nil || !last.Equal(now) {
+		t.Errorf("got last flush %v (err %v), want %v", last, err, now)
+	}
+
+	if err := store.Retain(map[string]string{"hook": "repo/repo/hook/0"}); err != nil {
+		t.Fatalf("Retain() error = %v", err)
+	}
+	if events, err := store.Events("other"); err != nil || len(events) != 0 {
+		t.Errorf("got %d events (err %v) for a hook that wasn't retained, want 0", len(events), err)
+	}
+	if last, err := store.LastFlush("hook"); err != nil || !last.Equal(now) {
+		t.Errorf("got last flush %v (err %v) for a retained hook, want %v", last, err, now)
+	}
+
+	// an edited hook has a new key, its events and digest period move to the new key.
+	if err := store.SetLastFlush("edited", now.Add(time.Hour)); err != nil {
+		t.Fatalf("SetLastFlush() error = %v", err)
+	}
+	if err := store.Retain(map[string]string{"edited": "repo/repo/hook/0"}); err != nil {
+		t.Fatalf("Retain() error = %v", err)
+	}
+	if events, err := store.Events("edited"); err != nil || len(events) != 1 || events[0].Task != "third" {
+		t.Errorf("got events %+v (err %v) for the edited hook, want the events buffered before the edit", events, err)
+	}
+	if last, err := store.LastFlush("edited"); err != nil || !last.Equal(now) {
+		t.Errorf("got last flush %v (err %v) for the edited hook, want %v", last, err, now)
+	}
+	if events, err := store.Events("hook"); err != nil || len(events) != 0 {
+		t.Errorf("got %d events (err %v) under the hook's old key, want 0", len(events), err)
+	}
+}
+
+func TestDigestFlushTask(t *testing.T) {
+	store, err := NewDigestStore(kvstore.NewInMemorySqliteDbForKvStore(t))
+	if err != nil {
+		t.Fatalf("NewDigestStore() error = %v", err)
+	}
+	opstore, err := sqlitestore.NewMemorySqliteStore(t)
+	if err != nil {
+		t.Fatalf("failed to create opstore: %v", err)
+	}
+	t.Cleanup(func() { opstore.Close() })
+	log, err := oplog.NewOpLog(opstore)
+	if err != nil {
+		t.Fatalf("failed to create oplog: %v", err)
+	}
+	repo := &v1.Repo{Id: "repo", Guid: "repo-guid"}
+	runner := newTestTaskRunner(t, &v1.Config{Instance: "instance", Repos: []*v1.Repo{repo}}, log)
+
+	var sent []DigestVars
+	task := NewDigestFlushTask(repo, PlanForSystemTasks, "repo/repo/hook/0", "key", &v1.Hook{}, store, func(ctx context.Context, hook *v1.Hook, vars DigestVars, runner TaskRunner) error {
+		sent = append(sent, vars)
+		return nil
+	})
+	getOps := func() []*v1.Operation {
+		var ops []*v1.Operation
+		if err := log.Query(oplog.SelectAll, func(op *v1.Operation) error {
+			ops = append(ops, op)
+			return nil
+		}); err != nil {
+			t.Fatalf("Query() error = %v", err)
+		}
+		return ops
+	}
+
+	if err := task.Run(context.Background(), ScheduledTask{Task: task}, runner); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if ops := getOps(); len(sent) != 0 || len(ops) != 0 {
+		t.Errorf("got %d digests sent and %d operations for a flush without events, want none", len(sent), len(ops))
+	}
+
+	if err := store.Append("key", DigestEvent{Time: time.Now(), Event: v1.Hook_CONDITION_SNAPSHOT_SUCCESS}); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	if err := task.Run(context.Background(), ScheduledTask{Task: task}, runner); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(sent) != 1 || len(sent[0].Events) != 1 {
+		t.Fatalf("got digests %v, want one digest of 1 event", sent)
+	}
+	if ops := getOps(); len(ops) != 1 || ops[0].Status != v1.OperationStatus_STATUS_SUCCESS || ops[0].GetOperationRunHook().GetName() != "repo/repo/hook/0" {
+		t.Errorf("got operations %v, want a successful run hook operation for the digest", ops)
+	}
+	if events, err := store.Events("key"); err != nil || len(events) != 0 {
+		t.Errorf("got %d events (err %v) after the digest was sent, want 0", len(events), err)
+	}
+
+	// events of a digest that isn't delivered stay buffered and the digest period doesn't end.
+	lastFlush, err := store.LastFlush("key")
+	if err != nil {
+		t.Fatalf("LastFlush() error = %v", err)
+	}
+	if err := store.Append("key", DigestEvent{Time: time.Now(), Event: v1.Hook_CONDITION_SNAPSHOT_ERROR}); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	failing := NewDigestFlushTask(repo, PlanForSystemTasks, "repo/repo/hook/0", "key", &v1.Hook{}, store, func(ctx context.Context, hook *v1.Hook, vars DigestVars, runner TaskRunner) error {
+		return errors.New("unreachable")
+	})
+	if err := failing.Run(context.Background(), ScheduledTask{Task: failing}, runner); err == nil {
+		t.Fatalf("Run() succeeded, want an error")
+	}
+	if events, err := store.Events("key"); err != nil || len(events) != 1 {
+		t.Errorf("got %d events (err %v) after a failed send, want 1", len(events), err)
+	}
+	if last, err := store.LastFlush("key"); err != nil || !last.Equal(lastFlush) {
+		t.Errorf("got last flush %v (err %v) after a failed send, want %v", last, err, lastFlush)
+	}
+	if ops := getOps(); len(ops) != 2 || ops[1].Status != v1.OperationStatus_STATUS_ERROR {
+		t.Errorf("got operations %v, want a failed run hook operation for the digest", ops)
+	}
+}
+
+func TestDigestVars(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	events := []DigestEvent{
+		{Time: start, Event: v1.Hook_CONDITION_SNAPSHOT_SUCCESS, PlanID: "a", BytesAdded: 1000},
+		{Time: start.Add(time.Hour), Event: v1.Hook_CONDITION_SNAPSHOT_WARNING, PlanID: "b", BytesAdded: 500},
+		{Time: start.Add(2 * time.Hour), Event: v1.Hook_CONDITION_SNAPSHOT_ERROR, PlanID: "c", Error: "failed"},
+		{Time: start.Add(3 * time.Hour), Event: v1.Hook_CONDITION_ANY_ERROR, PlanID: "c", Error: "failed"},
+	}
+
+	vars := NewDigestVars("hook", time.Time{}, start.Add(4*time.Hour), events)
+	if vars.Successes != 1 || vars.Warnings != 1 || vars.Errors != 2 || vars.BytesAdded != 1500 {
+		t.Errorf("got counts %d/%d/%d and %d bytes", vars.Successes, vars.Warnings, vars.Errors, vars.BytesAdded)
+	}
+	if len(vars.FailingPlans) != 1 || vars.FailingPlans[0] != "c" {
+		t.Errorf("got failing plans %v, want [c]", vars.FailingPlans)
+	}
+	if !vars.Since.Equal(start) {
+		t.Errorf("got since %v, want the first event time %v", vars.Since, start)
+	}
+
+	summary, err := vars.Summary()
+	if err != nil {
+		t.Fatalf("Summary() error = %v", err)
+	}
+	for _, want := range []string{"4 events", "Errors: 2", "Data added: 1.500 KB", "Failing plans:\n- c"} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("summary is missing %q:\n%s", want, summary)
+		}
+	}
+
+	if vars.EventName(vars.Event) != "digest" {
+		t.Errorf("got event name %q, want digest", vars.EventName(vars.Event))
+	}
+	if !vars.IsError(vars.Event) {
+		t.Errorf("want a digest with error events to be an error")
+	}
+	if NewDigestVars("hook", time.Time{}, start, events[:2]).IsError(v1.Hook_CONDITION_UNKNOWN) {
+		t.Errorf("want a digest without error events not to be an error")
+	}
+}
@@ -92,10 +92,28 @@ func forgetHelper(ctx context.Context, st ScheduledTask, taskRunner TaskRunner)
 	forgetOp.OperationForget.Forget = append(forgetOp.OperationForget.Forget, forgot...)
 	forgetOp.OperationForget.Policy = plan.Retention
 
+	if e := markSnapshotsForgotten(l, taskRunner, t.Repo().GetGuid(), forgot); e != nil {
+		err = multierror.Append(err, e)
+	}
+
+	if err != nil {
+		return notifyError(fmt.Errorf("forget: %w", err))
+	} else if e := taskRunner.ExecuteHooks(ctx, []v1.Hook_Condition{
+		v1.Hook_CONDITION_FORGET_SUCCESS,
+	}, HookVars{}); e != nil {
+		return fmt.Errorf("forget end hook: %w", e)
+	}
+
+	return err
+}
+
+// markSnapshotsForgotten marks the index snapshot operations of the forgotten snapshots as forgotten in the oplog.
+func markSnapshotsForgotten(l *zap.Logger, taskRunner TaskRunner, repoGUID string, forgot []*v1.ResticSnapshot) error {
+	var err error
 	var ops []*v1.Operation
 	for _, forgot := range forgot {
 		if e := taskRunner.QueryOperations(oplog.Query{}.
-			SetRepoGUID(t.Repo().GetGuid()).
+			SetRepoGUID(repoGUID).
 			SetSnapshotID(forgot.Id), func(op *v1.Operation) error {
 			ops = append(ops, op)
 			return nil
@@ -109,21 +127,12 @@ func forgetHelper(ctx context.Context, st ScheduledTask, taskRunner TaskRunner)
 	for _, op := range ops {
 		if indexOp, ok := op.Op.(*v1.Operation_OperationIndexSnapshot); ok {
 			indexOp.OperationIndexSnapshot.Forgot = true
-			if e := taskRunner.UpdateOperation(op); err != nil {
+			if e := taskRunner.UpdateOperation(op); e != nil {
 				err = multierror.Append(err, fmt.Errorf("mark index snapshot %v as forgotten: %w", op.Id, e))
 				continue
 			}
 		}
 	}
-
-	if err != nil {
-		return notifyError(fmt.Errorf("forget: %w", err))
-	} else if e := taskRunner.ExecuteHooks(ctx, []v1.Hook_Condition{
-		v1.Hook_CONDITION_FORGET_SUCCESS,
-	}, HookVars{}); e != nil {
-		return fmt.Errorf("forget end hook: %w", e)
-	}
-
 	return err
 }
 
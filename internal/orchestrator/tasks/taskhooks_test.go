@@ -0,0 +1,191 @@
+package tasks
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/cryptoutil"
+	"github.com/garethgeorge/backrest/internal/oplog"
+	"github.com/garethgeorge/backrest/internal/oplog/sqlitestore"
+	"github.com/garethgeorge/backrest/internal/orchestrator/repo"
+	"github.com/garethgeorge/backrest/test/helpers"
+)
+
+func newHookRecordingTaskRunner(t *testing.T, cfg *v1.Config) *hookRecordingTaskRunner {
+	opstore, err := sqlitestore.NewMemorySqliteStore(t)
+	if err != nil {
+		t.Fatalf("failed to create opstore: %v", err)
+	}
+	t.Cleanup(func() { opstore.Close() })
+	log, err := oplog.NewOpLog(opstore)
+	if err != nil {
+		t.Fatalf("failed to create oplog: %v", err)
+	}
+	return &hookRecordingTaskRunner{testTaskRunner: newTestTaskRunner(t, cfg, log)}
+}
+
+// runTaskOnce schedules the task and runs it, returning the error from Run.
+func runTaskOnce(t *testing.T, task Task, runner TaskRunner) error {
+	t.Helper()
+	st, err := task.Next(time.Now(), runner)
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	st.Task = task
+	return task.Run(context.Background(), st, runner)
+}
+
+func TestTaskHooksOnError(t *testing.T) {
+	r := &v1.Repo{Id: "repo", Guid: cryptoutil.MustRandomID(cryptoutil.DefaultIDBits)}
+	cfg := &v1.Config{Instance: "instance", Repos: []*v1.Repo{r}}
+
+	tests := []struct {
+		name       string
+		task       Task
+		start      v1.Hook_Condition
+		error      v1.Hook_Condition
+		checkVars  func(vars HookVars) bool
+		wantFields string
+	}{
+		{
+			name:       "restore",
+			task:       NewOneoffRestoreTask(r, "plan", 0, time.Now(), "snapshot", "/path", "/target"),
+			start:      v1.Hook_CONDITION_RESTORE_START,
+			error:      v1.Hook_CONDITION_RESTORE_ERROR,
+			checkVars:  func(vars HookVars) bool { return vars.SnapshotId == "snapshot" && vars.RestoreTarget == "/target" },
+			wantFields: "SnapshotId and RestoreTarget",
+		},
+		{
+			name:  "stats",
+			task:  NewStatsTask(r, PlanForSystemTasks, true),
+			start: v1.Hook_CONDITION_STATS_START,
+			error: v1.Hook_CONDITION_STATS_ERROR,
+		},
+		{
+			name:  "index snapshots",
+			task:  NewOneoffIndexSnapshotsTask(r, time.Now()),
+			start: v1.Hook_CONDITION_INDEX_SNAPSHOTS_START,
+			error: v1.Hook_CONDITION_INDEX_SNAPSHOTS_ERROR,
+		},
+		{
+			name:       "run command",
+			task:       NewOneoffRunCommandTask(r, "plan", 0, time.Now(), "snapshots"),
+			start:      v1.Hook_CONDITION_RUN_COMMAND_START,
+			error:      v1.Hook_CONDITION_RUN_COMMAND_ERROR,
+			checkVars:  func(vars HookVars) bool { return vars.Command == "snapshots" },
+			wantFields: "Command",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			runner := newHookRecordingTaskRunner(t, cfg) // without a repo orchestrator the task fails after its start hook.
+			if err := runTaskOnce(t, tc.task, runner); err == nil {
+				t.Fatalf("Run() succeeded, want an error")
+			}
+
+			if want := []v1.Hook_Condition{tc.start, v1.Hook_CONDITION_ANY_ERROR, tc.error}; !slices.Equal(runner.events, want) {
+				t.Errorf("got events %v, want %v", runner.events, want)
+			}
+			vars := runner.vars[tc.error]
+			if vars.Error == "" || vars.Task != tc.task.Name() {
+				t.Errorf("got error %q and task %q in the %v vars, want the error and task %q", vars.Error, vars.Task, tc.error, tc.task.Name())
+			}
+			if tc.checkVars != nil {
+				if !tc.checkVars(runner.vars[tc.start]) {
+					t.Errorf("got %v vars %+v, want %s set", tc.start, runner.vars[tc.start], tc.wantFields)
+				}
+				if !tc.checkVars(vars) {
+					t.Errorf("got %v vars %+v, want %s set", tc.error, vars, tc.wantFields)
+				}
+			}
+		})
+	}
+}
+
+func TestTaskHooksOnSuccess(t *testing.T) {
+	r := &v1.Repo{
+		Id:       "repo",
+		Guid:     cryptoutil.MustRandomID(cryptoutil.DefaultIDBits),
+		Uri:      t.TempDir(),
+		Password: "test",
+		Flags:    []string{"--no-cache"},
+	}
+	plan := &v1.Plan{Id: "plan", Repo: r.Id, Paths: []string{helpers.CreateTestData(t)}}
+	cfg := &v1.Config{Instance: "instance", Repos: []*v1.Repo{r}, Plans: []*v1.Plan{plan}}
+
+	orchestrator, err := repo.NewRepoOrchestrator(cfg, r, helpers.ResticBinary(t))
+	if err != nil {
+		t.Fatalf("failed to create repo orchestrator: %v", err)
+	}
+	if err := orchestrator.Init(context.Background()); err != nil {
+		t.Fatalf("failed to init repo: %v", err)
+	}
+	summary, err := orchestrator.Backup(context.Background(), plan, nil)
+	if err != nil {
+		t.Fatalf("failed to backup: %v", err)
+	}
+	target := filepath.Join(t.TempDir(), "restore")
+
+	tests := []struct {
+		name       string
+		task       Task
+		success    v1.Hook_Condition
+		checkVars  func(vars HookVars) bool
+		wantFields string
+	}{
+		{
+			name:    "restore",
+			task:    NewOneoffRestoreTask(r, plan.Id, 0, time.Now(), summary.SnapshotId, plan.Paths[0], target),
+			success: v1.Hook_CONDITION_RESTORE_SUCCESS,
+			checkVars: func(vars HookVars) bool {
+				return vars.SnapshotId == summary.SnapshotId && vars.RestoreTarget == target && vars.RestoreStats != nil
+			},
+			wantFields: "SnapshotId, RestoreTarget and RestoreStats",
+		},
+		{
+			name:       "stats",
+			task:       NewStatsTask(r, PlanForSystemTasks, true),
+			success:    v1.Hook_CONDITION_STATS_SUCCESS,
+			checkVars:  func(vars HookVars) bool { return vars.RepoStats != nil },
+			wantFields: "RepoStats",
+		},
+		{
+			name:    "index snapshots",
+			task:    NewOneoffIndexSnapshotsTask(r, time.Now()),
+			success: v1.Hook_CONDITION_INDEX_SNAPSHOTS_SUCCESS,
+		},
+		{
+			name:       "run command",
+			task:       NewOneoffRunCommandTask(r, plan.Id, 0, time.Now(), "snapshots"),
+			success:    v1.Hook_CONDITION_RUN_COMMAND_SUCCESS,
+			checkVars:  func(vars HookVars) bool { return vars.Command == "snapshots" },
+			wantFields: "Command",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			runner := newHookRecordingTaskRunner(t, cfg)
+			runner.repo = orchestrator
+			if err := runTaskOnce(t, tc.task, runner); err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+
+			vars, ok := runner.vars[tc.success]
+			if !ok {
+				t.Fatalf("got events %v, want %v", runner.events, tc.success)
+			}
+			if vars.Error != "" {
+				t.Errorf("got error %q in the %v vars, want none", vars.Error, tc.success)
+			}
+			if tc.checkVars != nil && !tc.checkVars(vars) {
+				t.Errorf("got %v vars %+v, want %s set", tc.success, vars, tc.wantFields)
+			}
+		})
+	}
+}
@@ -27,7 +27,27 @@ func NewOneoffIndexSnapshotsTask(repo *v1.Repo, at time.Time) Task {
 			ProtoOp: nil,
 		},
 		Do: func(ctx context.Context, st ScheduledTask, taskRunner TaskRunner) error {
-			return NotifyError(ctx, taskRunner, st.Task.Name(), indexSnapshotsHelper(ctx, st, taskRunner))
+			var vars HookVars
+			notifyError := func(err error) error {
+				return NotifyErrorWithVars(ctx, taskRunner, st.Task.Name(), err, vars, v1.Hook_CONDITION_INDEX_SNAPSHOTS_ERROR)
+			}
+
+			if err := taskRunner.ExecuteHooks(ctx, []v1.Hook_Condition{
+				v1.Hook_CONDITION_INDEX_SNAPSHOTS_START,
+			}, vars); err != nil {
+				return notifyError(fmt.Errorf("index snapshots start hook: %w", err))
+			}
+
+			if err := indexSnapshotsHelper(ctx, st, taskRunner); err != nil {
+				return notifyError(err)
+			}
+
+			if err := taskRunner.ExecuteHooks(ctx, []v1.Hook_Condition{
+				v1.Hook_CONDITION_INDEX_SNAPSHOTS_SUCCESS,
+			}, vars); err != nil {
+				return fmt.Errorf("index snapshots success hook: %w", err)
+			}
+			return nil
 		},
 	}
 }
@@ -155,6 +175,9 @@ func planForSnapshot(snapshot *v1.ResticSnapshot) string {
 	if p != "" {
 		return p
 	}
+	if repo.IsSelfBackup(snapshot.Tags) {
+		return PlanForSystemTasks
+	}
 	return PlanForUnassociatedOperations
 }
 
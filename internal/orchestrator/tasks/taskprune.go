@@ -1,14 +1,17 @@
 package tasks
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"time"
 
 	v1 "github.com/garethgeorge/backrest/gen/go/v1"
 	"github.com/garethgeorge/backrest/internal/oplog"
 	"github.com/garethgeorge/backrest/internal/protoutil"
+	"github.com/garethgeorge/backrest/pkg/restic"
 	"go.uber.org/zap"
 )
 
@@ -133,7 +136,8 @@ func (t *PruneTask) Run(ctx context.Context, st ScheduledTask, runner TaskRunner
 		return fmt.Errorf("update operation: %w", err)
 	}
 
-	err = repo.Prune(ctx, writer)
+	unusedSize := &pruneUnusedSizeScanner{}
+	err = repo.Prune(ctx, io.MultiWriter(writer, unusedSize))
 	if err != nil {
 		runner.ExecuteHooks(ctx, []v1.Hook_Condition{
 			v1.Hook_CONDITION_PRUNE_ERROR,
@@ -149,6 +153,8 @@ func (t *PruneTask) Run(ctx context.Context, st ScheduledTask, runner TaskRunner
 		return fmt.Errorf("close logref writer: %w", err)
 	}
 
+	opPrune.OperationPrune.UnusedSize = unusedSize.unusedSize
+
 	// Run a stats task after a successful prune
 	if err := runner.ScheduleTask(NewStatsTask(t.Repo(), PlanForSystemTasks, false), TaskPriorityStats); err != nil {
 		zap.L().Error("schedule stats task", zap.Error(err))
@@ -162,3 +168,41 @@ func (t *PruneTask) Run(ctx context.Context, st ScheduledTask, runner TaskRunner
 
 	return nil
 }
+
+// maxPruneLineBytes bounds the lines of prune output buffered by pruneUnusedSizeScanner, longer lines are skipped.
+const maxPruneLineBytes = 4096
+
+// pruneUnusedSizeScanner records the unused size reported by restic in the output of a prune.
+type pruneUnusedSizeScanner struct {
+	line       []byte
+	overflow   bool // the current line exceeds maxPruneLineBytes.
+	unusedSize *int64
+}
+
+func (s *pruneUnusedSizeScanner) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		idx := bytes.IndexByte(p, '\n')
+		chunk := p
+		if idx != -1 {
+			chunk = p[:idx]
+		}
+		if len(s.line)+len(chunk) > maxPruneLineBytes {
+			s.overflow = true
+		} else if !s.overflow {
+			s.line = append(s.line, chunk...)
+		}
+		if idx == -1 {
+			break
+		}
+		if !s.overflow {
+			if size, ok := restic.ParsePruneUnusedSize(string(s.line)); ok {
+				s.unusedSize = &size
+			}
+		}
+		s.line = s.line[:0]
+		s.overflow = false
+		p = p[idx+1:]
+	}
+	return n, nil
+}
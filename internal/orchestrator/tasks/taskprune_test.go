@@ -0,0 +1,31 @@
+package tasks
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPruneUnusedSizeScanner(t *testing.T) {
+	output := "loading indexes...\nto delete:         12 blobs / 9.8 MiB\nunused size after prune: 2.000 KiB (0.01% of remaining size)\ndone\n"
+
+	// write in small chunks to split lines across writes.
+	s := &pruneUnusedSizeScanner{}
+	for i := 0; i < len(output); i += 7 {
+		if _, err := s.Write([]byte(output[i:min(i+7, len(output))])); err != nil {
+			t.Fatalf("write failed: %v", err)
+		}
+	}
+	if s.unusedSize == nil || *s.unusedSize != 2048 {
+		t.Fatalf("want unused size 2048, got %v", s.unusedSize)
+	}
+
+	s = &pruneUnusedSizeScanner{}
+	s.Write([]byte("unused size after prune: 1.000 KiB " + strings.Repeat("x", maxPruneLineBytes) + "\n"))
+	if s.unusedSize != nil {
+		t.Errorf("want overlong lines skipped, got unused size %d", *s.unusedSize)
+	}
+	s.Write([]byte("no unused size reported\n"))
+	if s.unusedSize != nil {
+		t.Errorf("want no unused size, got %d", *s.unusedSize)
+	}
+}
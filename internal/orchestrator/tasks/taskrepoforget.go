@@ -0,0 +1,146 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/oplog"
+	"github.com/garethgeorge/backrest/internal/protoutil"
+)
+
+// RepoForgetTask applies a repo's retention policy to the snapshots selected by the policy's filters.
+type RepoForgetTask struct {
+	BaseTask
+	force  bool
+	didRun bool
+}
+
+func NewRepoForgetTask(repo *v1.Repo, planID string, force bool) Task {
+	return &RepoForgetTask{
+		BaseTask: BaseTask{
+			TaskType:   "forget",
+			TaskName:   fmt.Sprintf("apply retention policy for repo %q", repo.Id),
+			TaskRepo:   repo,
+			TaskPlanID: planID,
+		},
+		force: force,
+	}
+}
+
+func (t *RepoForgetTask) Next(now time.Time, runner TaskRunner) (ScheduledTask, error) {
+	if t.force {
+		if t.didRun {
+			return NeverScheduledTask, nil
+		}
+		t.didRun = true
+		return ScheduledTask{
+			Task:  t,
+			RunAt: now,
+			Op: &v1.Operation{
+				Op: &v1.Operation_OperationForget{},
+			},
+		}, nil
+	}
+
+	repo, err := runner.GetRepo(t.RepoID())
+	if err != nil {
+		return ScheduledTask{}, fmt.Errorf("get repo %v: %w", t.RepoID(), err)
+	}
+
+	if repo.RetentionPolicy.GetSchedule() == nil || repo.RetentionPolicy.GetRetention() == nil {
+		return NeverScheduledTask, nil
+	}
+
+	var lastRan time.Time
+	if err := runner.QueryOperations(oplog.Query{}.
+		SetInstanceID(runner.InstanceID()). // note: this means that forget tasks run by remote instances are ignored.
+		SetRepoGUID(repo.GetGuid()).
+		SetPlanID(PlanForSystemTasks).
+		SetReversed(true), func(op *v1.Operation) error {
+		if op.Status == v1.OperationStatus_STATUS_PENDING || op.Status == v1.OperationStatus_STATUS_SYSTEM_CANCELLED {
+			return nil
+		}
+		if _, ok := op.Op.(*v1.Operation_OperationForget); ok && op.UnixTimeEndMs != 0 {
+			lastRan = time.Unix(0, op.UnixTimeEndMs*int64(time.Millisecond))
+			return oplog.ErrStopIteration
+		}
+		return nil
+	}); err != nil {
+		return NeverScheduledTask, fmt.Errorf("finding last forget run time: %w", err)
+	} else if lastRan.IsZero() {
+		lastRan = now
+	}
+
+	runAt, err := protoutil.ResolveSchedule(repo.RetentionPolicy.GetSchedule(), lastRan, now)
+	if errors.Is(err, protoutil.ErrScheduleDisabled) {
+		return NeverScheduledTask, nil
+	} else if err != nil {
+		return NeverScheduledTask, fmt.Errorf("resolve schedule: %w", err)
+	}
+
+	return ScheduledTask{
+		Task:  t,
+		RunAt: runAt,
+		Op: &v1.Operation{
+			Op: &v1.Operation_OperationForget{},
+		},
+	}, nil
+}
+
+func (t *RepoForgetTask) Run(ctx context.Context, st ScheduledTask, runner TaskRunner) error {
+	l := runner.Logger(ctx)
+
+	notifyError := func(err error) error {
+		return NotifyError(ctx, runner, t.Name(), err, v1.Hook_CONDITION_FORGET_ERROR)
+	}
+
+	repoCfg, err := runner.GetRepo(t.RepoID())
+	if err != nil {
+		return notifyError(fmt.Errorf("get repo %q: %w", t.RepoID(), err))
+	}
+	policy := repoCfg.GetRetentionPolicy()
+	if policy.GetRetention() == nil {
+		return notifyError(fmt.Errorf("repo %q has no retention policy", t.RepoID()))
+	}
+
+	r, err := runner.GetRepoOrchestrator(t.RepoID())
+	if err != nil {
+		return notifyError(fmt.Errorf("get repo %q: %w", t.RepoID(), err))
+	}
+
+	if err := r.UnlockIfAutoEnabled(ctx); err != nil {
+		return notifyError(fmt.Errorf("auto unlock repo %q: %w", t.RepoID(), err))
+	}
+
+	if err := runner.ExecuteHooks(ctx, []v1.Hook_Condition{
+		v1.Hook_CONDITION_FORGET_START,
+	}, HookVars{}); err != nil {
+		return notifyError(fmt.Errorf("forget start hook: %w", err))
+	}
+
+	forgot, err := r.ApplyRepoRetention(ctx, runner.Config(), policy)
+
+	st.Op.Op = &v1.Operation_OperationForget{
+		OperationForget: &v1.OperationForget{
+			Forget: forgot,
+			Policy: policy.GetRetention(),
+		},
+	}
+
+	if err == nil {
+		err = markSnapshotsForgotten(l, runner, t.Repo().GetGuid(), forgot)
+	}
+
+	if err != nil {
+		return notifyError(fmt.Errorf("forget: %w", err))
+	} else if e := runner.ExecuteHooks(ctx, []v1.Hook_Condition{
+		v1.Hook_CONDITION_FORGET_SUCCESS,
+	}, HookVars{}); e != nil {
+		return fmt.Errorf("forget end hook: %w", e)
+	}
+
+	return nil
+}
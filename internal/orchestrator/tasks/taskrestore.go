@@ -33,7 +33,30 @@ func NewOneoffRestoreTask(repo *v1.Repo, planID string, flowID int64, at time.Ti
 			},
 		},
 		Do: func(ctx context.Context, st ScheduledTask, taskRunner TaskRunner) error {
-			return NotifyError(ctx, taskRunner, st.Task.Name(), restoreHelper(ctx, st, taskRunner, snapshotID, path, target))
+			vars := HookVars{
+				SnapshotId:    snapshotID,
+				RestoreTarget: target,
+			}
+			notifyError := func(err error) error {
+				return NotifyErrorWithVars(ctx, taskRunner, st.Task.Name(), err, vars, v1.Hook_CONDITION_RESTORE_ERROR)
+			}
+			if err := taskRunner.ExecuteHooks(ctx, []v1.Hook_Condition{
+				v1.Hook_CONDITION_RESTORE_START,
+			}, vars); err != nil {
+				return notifyError(fmt.Errorf("restore start hook: %w", err))
+			}
+
+			if err := restoreHelper(ctx, st, taskRunner, snapshotID, path, target); err != nil {
+				return notifyError(err)
+			}
+
+			vars.RestoreStats = st.Op.GetOperationRestore().GetLastStatus()
+			if err := taskRunner.ExecuteHooks(ctx, []v1.Hook_Condition{
+				v1.Hook_CONDITION_RESTORE_SUCCESS,
+			}, vars); err != nil {
+				return fmt.Errorf("restore success hook: %w", err)
+			}
+			return nil
 		},
 	}
 }
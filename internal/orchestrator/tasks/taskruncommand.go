@@ -37,7 +37,26 @@ func NewOneoffRunCommandTask(repo *v1.Repo, planID string, flowID int64, at time
 				panic("run command task with non-forget operation")
 			}
 
-			return NotifyError(ctx, taskRunner, st.Task.Name(), runCommandHelper(ctx, st, taskRunner, command))
+			vars := HookVars{Command: command}
+			notifyError := func(err error) error {
+				return NotifyErrorWithVars(ctx, taskRunner, st.Task.Name(), err, vars, v1.Hook_CONDITION_RUN_COMMAND_ERROR)
+			}
+			if err := taskRunner.ExecuteHooks(ctx, []v1.Hook_Condition{
+				v1.Hook_CONDITION_RUN_COMMAND_START,
+			}, vars); err != nil {
+				return notifyError(fmt.Errorf("run command start hook: %w", err))
+			}
+
+			if err := runCommandHelper(ctx, st, taskRunner, command); err != nil {
+				return notifyError(err)
+			}
+
+			if err := taskRunner.ExecuteHooks(ctx, []v1.Hook_Condition{
+				v1.Hook_CONDITION_RUN_COMMAND_SUCCESS,
+			}, vars); err != nil {
+				return fmt.Errorf("run command success hook: %w", err)
+			}
+			return nil
 		},
 	}
 }
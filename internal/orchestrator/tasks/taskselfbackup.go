@@ -0,0 +1,195 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/oplog"
+	"github.com/garethgeorge/backrest/internal/protoutil"
+	"go.uber.org/zap"
+)
+
+const defaultSelfBackupKeepLast = 30
+
+// SelfBackupSource is a piece of backrest's state included in self backups.
+type SelfBackupSource struct {
+	Name string                                       // name of the file in the snapshot e.g. "oplog.sqlite".
+	Copy func(ctx context.Context, path string) error // writes a consistent copy of the state to path.
+}
+
+// SelfBackupState is the state included in self backups and the directory it's staged in while it's backed up. The
+// directory is the same for every self backup so that restic finds the previous snapshot as a parent.
+type SelfBackupState struct {
+	StagingDir string
+	Sources    []SelfBackupSource
+}
+
+// SelfBackupFileSource copies the file at src e.g. the config, which is only ever replaced atomically.
+func SelfBackupFileSource(name, src string) SelfBackupSource {
+	return SelfBackupSource{
+		Name: name,
+		Copy: func(ctx context.Context, path string) error {
+			in, err := os.Open(src)
+			if err != nil {
+				return err
+			}
+			defer in.Close()
+			out, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+			if err != nil {
+				return err
+			}
+			if _, err := io.Copy(out, in); err != nil {
+				out.Close()
+				return err
+			}
+			return out.Close()
+		},
+	}
+}
+
+// stage copies the state into the staging directory and returns a func removing the copies.
+func (s *SelfBackupState) stage(ctx context.Context) (func(), error) {
+	if err := os.MkdirAll(s.StagingDir, 0700); err != nil {
+		return nil, fmt.Errorf("create staging dir: %w", err)
+	}
+	cleanup := func() {
+		for _, source := range s.Sources {
+			if err := os.Remove(filepath.Join(s.StagingDir, source.Name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+				zap.L().Warn("failed to remove staged self backup file", zap.String("file", source.Name), zap.Error(err))
+			}
+		}
+	}
+	for _, source := range s.Sources {
+		if err := source.Copy(ctx, filepath.Join(s.StagingDir, source.Name)); err != nil {
+			cleanup()
+			return nil, fmt.Errorf("copy %s: %w", source.Name, err)
+		}
+	}
+	return cleanup, nil
+}
+
+// SelfBackupTask backs up backrest's own state to the repo configured for self backups.
+type SelfBackupTask struct {
+	BaseTask
+	state *SelfBackupState
+}
+
+func NewSelfBackupTask(repo *v1.Repo, state *SelfBackupState) Task {
+	return &SelfBackupTask{
+		BaseTask: BaseTask{
+			TaskType:   "self_backup",
+			TaskName:   fmt.Sprintf("self backup to repo %q", repo.Id),
+			TaskRepo:   repo,
+			TaskPlanID: PlanForSystemTasks,
+		},
+		state: state,
+	}
+}
+
+func (t *SelfBackupTask) Next(now time.Time, runner TaskRunner) (ScheduledTask, error) {
+	selfBackup := runner.Config().GetSelfBackup()
+	if selfBackup.GetRepo() != t.RepoID() || selfBackup.GetSchedule() == nil {
+		return NeverScheduledTask, nil
+	}
+
+	var lastRan time.Time
+	if err := runner.QueryOperations(oplog.Query{}.
+		SetInstanceID(runner.InstanceID()).
+		SetRepoGUID(t.Repo().GetGuid()).
+		SetPlanID(PlanForSystemTasks).
+		SetOpTypes(v1.OperationType_OPERATION_TYPE_BACKUP).
+		SetReversed(true), func(op *v1.Operation) error {
+		if op.Status == v1.OperationStatus_STATUS_PENDING || op.Status == v1.OperationStatus_STATUS_SYSTEM_CANCELLED {
+			return nil
+		}
+		if op.UnixTimeEndMs != 0 {
+			lastRan = time.UnixMilli(op.UnixTimeEndMs)
+			return oplog.ErrStopIteration
+		}
+		return nil
+	}); err != nil {
+		return NeverScheduledTask, fmt.Errorf("finding last self backup run time: %w", err)
+	}
+
+	runAt, err := protoutil.ResolveSchedule(selfBackup.GetSchedule(), lastRan, now)
+	if errors.Is(err, protoutil.ErrScheduleDisabled) {
+		return NeverScheduledTask, nil
+	} else if err != nil {
+		return NeverScheduledTask, fmt.Errorf("resolve schedule: %w", err)
+	}
+
+	return ScheduledTask{
+		Task:  t,
+		RunAt: runAt,
+		Op: &v1.Operation{
+			Op: &v1.Operation_OperationBackup{},
+		},
+	}, nil
+}
+
+func (t *SelfBackupTask) Run(ctx context.Context, st ScheduledTask, runner TaskRunner) error {
+	if err := t.run(ctx, st, runner); err != nil {
+		runner.ExecuteHooks(ctx, []v1.Hook_Condition{
+			v1.Hook_CONDITION_ANY_ERROR,
+		}, HookVars{
+			Task:  t.Name(),
+			Error: err.Error(),
+		})
+		return err
+	}
+	return nil
+}
+
+func (t *SelfBackupTask) run(ctx context.Context, st ScheduledTask, runner TaskRunner) error {
+	l := runner.Logger(ctx)
+	op := st.Op
+	backupOp := &v1.Operation_OperationBackup{
+		OperationBackup: &v1.OperationBackup{},
+	}
+	op.Op = backupOp
+
+	repo, err := runner.GetRepoOrchestrator(t.RepoID())
+	if err != nil {
+		return fmt.Errorf("couldn't get repo %q: %w", t.RepoID(), err)
+	}
+	if err := repo.UnlockIfAutoEnabled(ctx); err != nil {
+		return fmt.Errorf("auto unlock repo %q: %w", t.RepoID(), err)
+	}
+
+	cleanup, err := t.state.stage(ctx)
+	if err != nil {
+		return fmt.Errorf("stage self backup: %w", err)
+	}
+	defer cleanup()
+
+	summary, err := repo.SelfBackup(ctx, t.state.StagingDir, nil)
+	if err != nil {
+		return err
+	}
+	op.SnapshotId = summary.SnapshotId
+	backupOp.OperationBackup.LastStatus = protoutil.BackupProgressEntryToProto(summary)
+	if err := runner.UpdateOperation(op); err != nil {
+		return fmt.Errorf("update operation: %w", err)
+	}
+
+	keepLast := int(runner.Config().GetSelfBackup().GetKeepLast())
+	if keepLast == 0 {
+		keepLast = defaultSelfBackupKeepLast
+	}
+	forgotten, err := repo.ForgetSelfBackups(ctx, keepLast)
+	if err != nil {
+		return fmt.Errorf("apply self backup retention: %w", err)
+	}
+	l.Info("self backup complete", zap.String("snapshot", summary.SnapshotId), zap.Int("forgotten", len(forgotten)))
+
+	if err := runner.ScheduleTask(NewOneoffIndexSnapshotsTask(t.Repo(), time.Now()), TaskPriorityIndexSnapshots); err != nil {
+		return fmt.Errorf("schedule index snapshots task: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package tasks
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/oplog"
+	"github.com/garethgeorge/backrest/internal/oplog/sqlitestore"
+)
+
+func TestSelfBackupStage(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.json")
+	if err := os.WriteFile(configPath, []byte(`{"instance":"test"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	state := &SelfBackupState{
+		StagingDir: filepath.Join(dir, "staging"),
+		Sources:    []SelfBackupSource{SelfBackupFileSource("config.json", configPath)},
+	}
+
+	cleanup, err := state.stage(context.Background())
+	if err != nil {
+		t.Fatalf("stage() error = %v", err)
+	}
+	staged := filepath.Join(state.StagingDir, "config.json")
+	if data, err := os.ReadFile(staged); err != nil || string(data) != `{"instance":"test"}` {
+		t.Errorf("want the config staged, got %q (err %v)", data, err)
+	}
+	cleanup()
+	if _, err := os.Stat(staged); !os.IsNotExist(err) {
+		t.Errorf("want the staged config removed, got err %v", err)
+	}
+
+	state.Sources = append(state.Sources, SelfBackupFileSource("missing.json", filepath.Join(dir, "missing.json")))
+	if _, err := state.stage(context.Background()); err == nil {
+		t.Errorf("want an error staging a missing file")
+	}
+	if _, err := os.Stat(staged); !os.IsNotExist(err) {
+		t.Errorf("want staged files removed after an error, got err %v", err)
+	}
+}
+
+func TestSelfBackupTaskNext(t *testing.T) {
+	t.Parallel()
+
+	repo := &v1.Repo{Id: "repo", Guid: "repo-guid"}
+	other := &v1.Repo{Id: "other", Guid: "other-guid"}
+	cfg := &v1.Config{
+		Instance: "instance",
+		Repos:    []*v1.Repo{repo, other},
+		SelfBackup: &v1.SelfBackup{
+			Repo:     repo.Id,
+			Schedule: &v1.Schedule{Schedule: &v1.Schedule_MaxFrequencyHours{MaxFrequencyHours: 24}, Clock: v1.Schedule_CLOCK_LAST_RUN_TIME},
+		},
+	}
+
+	opstore, err := sqlitestore.NewMemorySqliteStore(t)
+	if err != nil {
+		t.Fatalf("failed to create opstore: %v", err)
+	}
+	t.Cleanup(func() { opstore.Close() })
+	log, err := oplog.NewOpLog(opstore)
+	if err != nil {
+		t.Fatalf("failed to create oplog: %v", err)
+	}
+	runner := newTestTaskRunner(t, cfg, log)
+
+	lastRan := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	for _, op := range []*v1.Operation{
+		{PlanId: PlanForSystemTasks, UnixTimeEndMs: lastRan.UnixMilli()},
+		{PlanId: "plan", UnixTimeEndMs: lastRan.Add(time.Hour).UnixMilli()}, // backups of plans aren't self backups.
+	} {
+		op.RepoId = repo.Id
+		op.RepoGuid = repo.Guid
+		op.UnixTimeStartMs = op.UnixTimeEndMs
+		op.Status = v1.OperationStatus_STATUS_SUCCESS
+		op.Op = &v1.Operation_OperationBackup{OperationBackup: &v1.OperationBackup{}}
+		if err := runner.CreateOperation(op); err != nil {
+			t.Fatalf("failed to add operation: %v", err)
+		}
+	}
+
+	st, err := NewSelfBackupTask(repo, &SelfBackupState{}).Next(lastRan.Add(2*time.Hour), runner)
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	if want := lastRan.Add(24 * time.Hour); !st.RunAt.Equal(want) {
+		t.Errorf("want the self backup scheduled at %v, got %v", want, st.RunAt)
+	}
+
+	st, err = NewSelfBackupTask(other, &SelfBackupState{}).Next(lastRan, runner)
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	if st != NeverScheduledTask {
+		t.Errorf("want no self backup scheduled for a repo that isn't configured, got %v", st)
+	}
+}
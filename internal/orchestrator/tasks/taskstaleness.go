@@ -0,0 +1,168 @@
+package tasks
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/kvstore"
+	"github.com/garethgeorge/backrest/internal/oplog"
+)
+
+// PlanStalenessStore persists the state of plans' staleness checks so that it survives restarts and config changes.
+type PlanStalenessStore struct {
+	mu sync.Mutex
+	kv kvstore.KvStore
+}
+
+// planStaleness is the state of a plan's staleness check.
+type planStaleness struct {
+	FirstCheckMs int64 `json:"firstCheckMs"` // when the plan was first checked, a plan that never succeeded is measured from it.
+	Stale        bool  `json:"stale"`        // whether stale hooks fired and recovery hooks have not.
+}
+
+func NewPlanStalenessStore(dbpool *sql.DB) (*PlanStalenessStore, error) {
+	kv, err := kvstore.NewSqliteKVStore(dbpool, "plan_staleness")
+	if err != nil {
+		return nil, fmt.Errorf("create kvstore: %w", err)
+	}
+	return &PlanStalenessStore{kv: kv}, nil
+}
+
+func (s *PlanStalenessStore) get(planID string) (planStaleness, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var state planStaleness
+	b, err := s.kv.Get(planID)
+	if errors.Is(err, kvstore.ErrNotExist) || (err == nil && len(b) == 0) {
+		return state, nil
+	} else if err != nil {
+		return state, fmt.Errorf("get staleness of plan %q: %w", planID, err)
+	}
+	if err := json.Unmarshal(b, &state); err != nil {
+		return state, fmt.Errorf("unmarshal staleness of plan %q: %w", planID, err)
+	}
+	return state, nil
+}
+
+func (s *PlanStalenessStore) set(planID string, state planStaleness) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("marshal staleness of plan %q: %w", planID, err)
+	}
+	if err := s.kv.Set(planID, b); err != nil {
+		return fmt.Errorf("set staleness of plan %q: %w", planID, err)
+	}
+	return nil
+}
+
+// PlanStalenessTask checks the age of a plan's last successful backup. It fires CONDITION_PLAN_STALE hooks once when
+// the age exceeds the plan's threshold and CONDITION_PLAN_RECOVERED hooks once a backup succeeds again.
+// The task is never scheduled in the task queue, where a wedged backup or hook would delay it, the orchestrator runs
+// the checks of a plan one at a time on a ticker of its own and whenever a backup of the plan succeeds.
+// The task does not create operations, a check that fires no hooks leaves no trace in the oplog.
+type PlanStalenessTask struct {
+	BaseTask
+	store *PlanStalenessStore
+}
+
+func NewPlanStalenessTask(repo *v1.Repo, plan *v1.Plan, store *PlanStalenessStore) Task {
+	return &PlanStalenessTask{
+		BaseTask: BaseTask{
+			TaskType:   "staleness_check",
+			TaskName:   fmt.Sprintf("staleness check for plan %q", plan.Id),
+			TaskRepo:   repo,
+			TaskPlanID: plan.Id,
+		},
+		store: store,
+	}
+}
+
+func (t *PlanStalenessTask) Next(now time.Time, runner TaskRunner) (ScheduledTask, error) {
+	return NeverScheduledTask, nil
+}
+
+func (t *PlanStalenessTask) Run(ctx context.Context, st ScheduledTask, runner TaskRunner) error {
+	plan, err := runner.GetPlan(t.PlanID())
+	if err != nil {
+		return err
+	}
+	threshold := time.Duration(plan.StaleAfterHours) * time.Hour
+	if threshold <= 0 {
+		return nil
+	}
+
+	lastSuccess, err := t.lastSuccessfulBackup(runner)
+	if err != nil {
+		return fmt.Errorf("find last successful backup: %w", err)
+	}
+
+	state, err := t.store.get(t.PlanID())
+	if err != nil {
+		return err
+	}
+	if state.FirstCheckMs == 0 {
+		state.FirstCheckMs = time.Now().UnixMilli()
+		if err := t.store.set(t.PlanID(), state); err != nil {
+			return err
+		}
+	}
+
+	// a plan that never succeeded is measured from when it was first checked.
+	since := lastSuccess
+	if since.IsZero() {
+		since = time.UnixMilli(state.FirstCheckMs)
+	}
+	isStale := time.Since(since) > threshold
+
+	vars := HookVars{LastSuccess: lastSuccess}
+	switch {
+	case isStale && !state.Stale:
+		if lastSuccess.IsZero() {
+			vars.Error = fmt.Sprintf("no successful backup of plan %q in the last %v", t.PlanID(), threshold)
+		} else {
+			vars.Error = fmt.Sprintf("no successful backup of plan %q since %v", t.PlanID(), lastSuccess.Format(time.RFC3339))
+		}
+		if err := runner.ExecuteHooks(ctx, []v1.Hook_Condition{v1.Hook_CONDITION_PLAN_STALE}, vars); err != nil {
+			return fmt.Errorf("plan stale hook: %w", err)
+		}
+		state.Stale = true
+		return t.store.set(t.PlanID(), state)
+	case !isStale && state.Stale:
+		if err := runner.ExecuteHooks(ctx, []v1.Hook_Condition{v1.Hook_CONDITION_PLAN_RECOVERED}, vars); err != nil {
+			return fmt.Errorf("plan recovered hook: %w", err)
+		}
+		state.Stale = false
+		return t.store.set(t.PlanID(), state)
+	}
+	return nil
+}
+
+// lastSuccessfulBackup returns the end time of the plan's most recent successful backup, or the zero time if there is none.
+func (t *PlanStalenessTask) lastSuccessfulBackup(runner TaskRunner) (time.Time, error) {
+	var last time.Time
+	err := runner.QueryOperations(oplog.Query{}.
+		SetInstanceID(runner.InstanceID()).
+		SetRepoGUID(t.Repo().GetGuid()).
+		SetPlanID(t.PlanID()).
+		SetReversed(true), func(op *v1.Operation) error {
+		if _, ok := op.Op.(*v1.Operation_OperationBackup); !ok {
+			return nil
+		}
+		if op.Status == v1.OperationStatus_STATUS_SUCCESS || op.Status == v1.OperationStatus_STATUS_WARNING {
+			last = time.UnixMilli(op.UnixTimeEndMs)
+			return oplog.ErrStopIteration
+		}
+		return nil
+	})
+	return last, err
+}
@@ -0,0 +1,180 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"testing"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/cryptoutil"
+	"github.com/garethgeorge/backrest/internal/kvstore"
+	"github.com/garethgeorge/backrest/internal/oplog"
+	"github.com/garethgeorge/backrest/internal/oplog/sqlitestore"
+	"github.com/garethgeorge/backrest/internal/orchestrator/repo"
+)
+
+// hookRecordingTaskRunner records the events and vars passed to ExecuteHooks.
+type hookRecordingTaskRunner struct {
+	*testTaskRunner
+	events []v1.Hook_Condition
+	vars   map[v1.Hook_Condition]HookVars // the vars each event was last fired with.
+	repo   *repo.RepoOrchestrator         // returned by GetRepoOrchestrator, which fails if it's nil.
+}
+
+func (r *hookRecordingTaskRunner) ExecuteHooks(ctx context.Context, events []v1.Hook_Condition, vars HookVars) error {
+	r.events = append(r.events, events...)
+	if r.vars == nil {
+		r.vars = make(map[v1.Hook_Condition]HookVars)
+	}
+	for _, event := range events {
+		r.vars[event] = vars
+	}
+	return nil
+}
+
+func (r *hookRecordingTaskRunner) GetRepoOrchestrator(repoID string) (*repo.RepoOrchestrator, error) {
+	if r.repo == nil {
+		return nil, errors.New("no repo orchestrator")
+	}
+	return r.repo, nil
+}
+
+func (r *hookRecordingTaskRunner) LogrefWriter() (id string, w io.WriteCloser, err error) {
+	return "logref", nopWriteCloser{io.Discard}, nil
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
+func TestPlanStalenessTask(t *testing.T) {
+	repo := &v1.Repo{Id: "repo", Guid: cryptoutil.MustRandomID(cryptoutil.DefaultIDBits)}
+	plan := &v1.Plan{Id: "plan", Repo: "repo", StaleAfterHours: 24}
+	cfg := &v1.Config{Instance: "instance", Repos: []*v1.Repo{repo}, Plans: []*v1.Plan{plan}}
+
+	opstore, err := sqlitestore.NewMemorySqliteStore(t)
+	if err != nil {
+		t.Fatalf("failed to create opstore: %v", err)
+	}
+	t.Cleanup(func() { opstore.Close() })
+	log, err := oplog.NewOpLog(opstore)
+	if err != nil {
+		t.Fatalf("failed to create oplog: %v", err)
+	}
+	runner := &hookRecordingTaskRunner{testTaskRunner: newTestTaskRunner(t, cfg, log)}
+
+	addBackup := func(end time.Time, status v1.OperationStatus) {
+		t.Helper()
+		if err := runner.CreateOperation(&v1.Operation{
+			RepoId:          repo.Id,
+			RepoGuid:        repo.Guid,
+			PlanId:          plan.Id,
+			UnixTimeStartMs: end.UnixMilli(),
+			UnixTimeEndMs:   end.UnixMilli(),
+			Status:          status,
+			Op:              &v1.Operation_OperationBackup{},
+		}); err != nil {
+			t.Fatalf("failed to add backup op: %v", err)
+		}
+	}
+
+	store, err := NewPlanStalenessStore(kvstore.NewInMemorySqliteDbForKvStore(t))
+	if err != nil {
+		t.Fatalf("failed to create staleness store: %v", err)
+	}
+	task := NewPlanStalenessTask(repo, plan, store)
+	run := func() {
+		t.Helper()
+		if err := task.Run(context.Background(), ScheduledTask{Task: task}, runner); err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+	}
+
+	addBackup(time.Now().Add(-48*time.Hour), v1.OperationStatus_STATUS_SUCCESS)
+	addBackup(time.Now().Add(-time.Hour), v1.OperationStatus_STATUS_ERROR)
+
+	run()
+	run() // stale hooks fire only once per stale period.
+	if want := []v1.Hook_Condition{v1.Hook_CONDITION_PLAN_STALE}; !slices.Equal(runner.events, want) {
+		t.Fatalf("got events %v, want %v", runner.events, want)
+	}
+
+	addBackup(time.Now(), v1.OperationStatus_STATUS_WARNING)
+	run()
+	run()
+	if want := []v1.Hook_Condition{v1.Hook_CONDITION_PLAN_STALE, v1.Hook_CONDITION_PLAN_RECOVERED}; !slices.Equal(runner.events, want) {
+		t.Fatalf("got events %v, want %v", runner.events, want)
+	}
+}
+
+func TestPlanStalenessTaskPersistsState(t *testing.T) {
+	repo := &v1.Repo{Id: "repo", Guid: cryptoutil.MustRandomID(cryptoutil.DefaultIDBits)}
+	plan := &v1.Plan{Id: "plan", Repo: "repo", StaleAfterHours: 1}
+	cfg := &v1.Config{Instance: "instance", Repos: []*v1.Repo{repo}, Plans: []*v1.Plan{plan}}
+
+	opstore, err := sqlitestore.NewMemorySqliteStore(t)
+	if err != nil {
+		t.Fatalf("failed to create opstore: %v", err)
+	}
+	t.Cleanup(func() { opstore.Close() })
+	log, err := oplog.NewOpLog(opstore)
+	if err != nil {
+		t.Fatalf("failed to create oplog: %v", err)
+	}
+	store, err := NewPlanStalenessStore(kvstore.NewInMemorySqliteDbForKvStore(t))
+	if err != nil {
+		t.Fatalf("failed to create staleness store: %v", err)
+	}
+	runner := &hookRecordingTaskRunner{testTaskRunner: newTestTaskRunner(t, cfg, log)}
+
+	// each check uses a new task, as after a config change or a restart.
+	run := func() {
+		t.Helper()
+		task := NewPlanStalenessTask(repo, plan, store)
+		if err := task.Run(context.Background(), ScheduledTask{Task: task}, runner); err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+	}
+
+	// a plan that never succeeded is measured from its first check.
+	run()
+	if len(runner.events) != 0 {
+		t.Fatalf("got events %v on the first check, want none", runner.events)
+	}
+	state, err := store.get(plan.Id)
+	if err != nil {
+		t.Fatalf("get staleness: %v", err)
+	}
+	state.FirstCheckMs = time.Now().Add(-2 * time.Hour).UnixMilli()
+	if err := store.set(plan.Id, state); err != nil {
+		t.Fatalf("set staleness: %v", err)
+	}
+
+	run()
+	run()
+	if want := []v1.Hook_Condition{v1.Hook_CONDITION_PLAN_STALE}; !slices.Equal(runner.events, want) {
+		t.Fatalf("got events %v, want %v", runner.events, want)
+	}
+
+	if err := runner.CreateOperation(&v1.Operation{
+		RepoId:          repo.Id,
+		RepoGuid:        repo.Guid,
+		PlanId:          plan.Id,
+		UnixTimeStartMs: time.Now().UnixMilli(),
+		UnixTimeEndMs:   time.Now().UnixMilli(),
+		Status:          v1.OperationStatus_STATUS_SUCCESS,
+		Op:              &v1.Operation_OperationBackup{},
+	}); err != nil {
+		t.Fatalf("failed to add backup op: %v", err)
+	}
+	run()
+	run()
+	if want := []v1.Hook_Condition{v1.Hook_CONDITION_PLAN_STALE, v1.Hook_CONDITION_PLAN_RECOVERED}; !slices.Equal(runner.events, want) {
+		t.Errorf("got events %v, want %v", runner.events, want)
+	}
+}
@@ -74,8 +74,26 @@ func (t *StatsTask) Next(now time.Time, runner TaskRunner) (ScheduledTask, error
 }
 
 func (t *StatsTask) Run(ctx context.Context, st ScheduledTask, runner TaskRunner) error {
-	if err := NotifyError(ctx, runner, st.Task.Name(), statsHelper(ctx, st, runner)); err != nil {
-		return err
+	var vars HookVars
+	notifyError := func(err error) error {
+		return NotifyErrorWithVars(ctx, runner, st.Task.Name(), err, vars, v1.Hook_CONDITION_STATS_ERROR)
+	}
+
+	if err := runner.ExecuteHooks(ctx, []v1.Hook_Condition{
+		v1.Hook_CONDITION_STATS_START,
+	}, vars); err != nil {
+		return notifyError(fmt.Errorf("stats start hook: %w", err))
+	}
+
+	if err := statsHelper(ctx, st, runner); err != nil {
+		return notifyError(err)
+	}
+
+	vars.RepoStats = st.Op.GetOperationStats().GetStats()
+	if err := runner.ExecuteHooks(ctx, []v1.Hook_Condition{
+		v1.Hook_CONDITION_STATS_SUCCESS,
+	}, vars); err != nil {
+		return fmt.Errorf("stats success hook: %w", err)
 	}
 
 	return nil
@@ -0,0 +1,17 @@
+//go:build !unix
+// +build !unix
+
+package platformutil
+
+import (
+	"errors"
+	"os/exec"
+)
+
+// SetUser is not supported on this platform.
+func SetUser(cmd *exec.Cmd, username string) error {
+	return errors.New("running commands as another user is not supported on this platform")
+}
+
+// KillProcessGroupOnCancel keeps the default behavior of killing only the process itself on this platform.
+func KillProcessGroupOnCancel(cmd *exec.Cmd) {}
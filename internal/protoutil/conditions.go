@@ -9,6 +9,11 @@ var startConditionsMap = map[v1.Hook_Condition]bool{
 	v1.Hook_CONDITION_PRUNE_START:    true,
 	v1.Hook_CONDITION_SNAPSHOT_START: true,
 	v1.Hook_CONDITION_FORGET_START:   true,
+	v1.Hook_CONDITION_RESTORE_START:  true,
+	v1.Hook_CONDITION_STATS_START:    true,
+
+	v1.Hook_CONDITION_INDEX_SNAPSHOTS_START: true,
+	v1.Hook_CONDITION_RUN_COMMAND_START:     true,
 }
 
 var errorConditionsMap = map[v1.Hook_Condition]bool{
@@ -17,7 +22,13 @@ var errorConditionsMap = map[v1.Hook_Condition]bool{
 	v1.Hook_CONDITION_PRUNE_ERROR:    true,
 	v1.Hook_CONDITION_SNAPSHOT_ERROR: true,
 	v1.Hook_CONDITION_FORGET_ERROR:   true,
+	v1.Hook_CONDITION_RESTORE_ERROR:  true,
+	v1.Hook_CONDITION_STATS_ERROR:    true,
 	v1.Hook_CONDITION_UNKNOWN:        true,
+
+	v1.Hook_CONDITION_INDEX_SNAPSHOTS_ERROR: true,
+	v1.Hook_CONDITION_RUN_COMMAND_ERROR:     true,
+	v1.Hook_CONDITION_PLAN_STALE:            true, // a plan that hasn't backed up successfully within its threshold is treated as failing.
 }
 
 var logConditionsMap = map[v1.Hook_Condition]bool{
@@ -29,6 +40,11 @@ var successConditionsMap = map[v1.Hook_Condition]bool{
 	v1.Hook_CONDITION_PRUNE_SUCCESS:    true,
 	v1.Hook_CONDITION_SNAPSHOT_SUCCESS: true,
 	v1.Hook_CONDITION_FORGET_SUCCESS:   true,
+	v1.Hook_CONDITION_RESTORE_SUCCESS:  true,
+	v1.Hook_CONDITION_STATS_SUCCESS:    true,
+
+	v1.Hook_CONDITION_INDEX_SNAPSHOTS_SUCCESS: true,
+	v1.Hook_CONDITION_RUN_COMMAND_SUCCESS:     true,
 }
 
 // IsErrorCondition returns true if the event is an error condition.
@@ -35,13 +35,18 @@ func TestErrorConditionsMap(t *testing.T) {
 		t.Errorf("CONDITION_ANY_ERROR should be identified as an error condition")
 	}
 
+	// Special case for PLAN_STALE which should be identified as an error condition
+	if !IsErrorCondition(v1.Hook_CONDITION_PLAN_STALE) {
+		t.Errorf("CONDITION_PLAN_STALE should be identified as an error condition")
+	}
+
 	// Test that all conditions with "_ERROR" in their name are correctly identified by IsErrorCondition
 	for cond := range v1.Hook_Condition_name {
 		condEnum := v1.Hook_Condition(cond)
 		condName := condEnum.String()
 
 		// Skip the special cases we already checked
-		if condEnum == v1.Hook_CONDITION_UNKNOWN || condEnum == v1.Hook_CONDITION_ANY_ERROR {
+		if condEnum == v1.Hook_CONDITION_UNKNOWN || condEnum == v1.Hook_CONDITION_ANY_ERROR || condEnum == v1.Hook_CONDITION_PLAN_STALE {
 			continue
 		}
 
@@ -2,6 +2,10 @@ package protoutil
 
 import (
 	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
 
 	v1 "github.com/garethgeorge/backrest/gen/go/v1"
 	"github.com/garethgeorge/backrest/pkg/restic"
@@ -101,12 +105,20 @@ func BackupProgressEntryToBackupError(b *restic.BackupProgressEntry) (*v1.Backup
 	}, nil
 }
 
-func RetentionPolicyFromProto(p *v1.RetentionPolicy) *restic.RetentionPolicy {
+// resticDurationRegex matches restic's duration format e.g. "1y2m3d4h", at least one component is required.
+var resticDurationRegex = regexp.MustCompile(`^(\d+y)?(\d+m)?(\d+d)?(\d+h)?$`)
+
+// resticGroupByFields are the fields restic accepts for --group-by.
+var resticGroupByFields = []string{"host", "paths", "tags"}
+
+// RetentionPolicyFromProto converts and validates a retention policy. A nil policy is returned if the policy keeps all snapshots.
+func RetentionPolicyFromProto(p *v1.RetentionPolicy) (*restic.RetentionPolicy, error) {
+	var policy *restic.RetentionPolicy
 	switch p := p.GetPolicy().(type) {
 	case *v1.RetentionPolicy_PolicyKeepAll:
-		return nil
+		return nil, nil
 	case *v1.RetentionPolicy_PolicyTimeBucketed:
-		return &restic.RetentionPolicy{
+		policy = &restic.RetentionPolicy{
 			KeepDaily:   int(p.PolicyTimeBucketed.Daily),
 			KeepHourly:  int(p.PolicyTimeBucketed.Hourly),
 			KeepWeekly:  int(p.PolicyTimeBucketed.Weekly),
@@ -115,12 +127,52 @@ func RetentionPolicyFromProto(p *v1.RetentionPolicy) *restic.RetentionPolicy {
 			KeepLastN:   int(p.PolicyTimeBucketed.KeepLastN),
 		}
 	case *v1.RetentionPolicy_PolicyKeepLastN:
-		return &restic.RetentionPolicy{
+		policy = &restic.RetentionPolicy{
 			KeepLastN: int(p.PolicyKeepLastN),
 		}
 	default:
-		return nil
+		return nil, nil
+	}
+
+	durations := []struct {
+		name  string
+		value string
+		dest  *string
+	}{
+		{"keep within", p.GetKeepWithin(), &policy.KeepWithinDuration},
+		{"keep within hourly", p.GetKeepWithinBuckets().GetHourly(), &policy.KeepWithinHourly},
+		{"keep within daily", p.GetKeepWithinBuckets().GetDaily(), &policy.KeepWithinDaily},
+		{"keep within weekly", p.GetKeepWithinBuckets().GetWeekly(), &policy.KeepWithinWeekly},
+		{"keep within monthly", p.GetKeepWithinBuckets().GetMonthly(), &policy.KeepWithinMonthly},
+		{"keep within yearly", p.GetKeepWithinBuckets().GetYearly(), &policy.KeepWithinYearly},
 	}
+	for _, d := range durations {
+		if d.value == "" {
+			continue
+		}
+		if !resticDurationRegex.MatchString(d.value) {
+			return nil, fmt.Errorf("%s: invalid duration %q, expected a duration like \"1y2m3d4h\"", d.name, d.value)
+		}
+		*d.dest = d.value
+	}
+
+	for _, tags := range p.GetKeepTags() {
+		if strings.TrimSpace(tags) == "" {
+			return nil, errors.New("keep tags: entries must not be empty")
+		}
+		policy.KeepTags = append(policy.KeepTags, tags)
+	}
+
+	if groupBy := p.GetGroupBy(); groupBy != "" {
+		for _, field := range strings.Split(groupBy, ",") {
+			if !slices.Contains(resticGroupByFields, field) {
+				return nil, fmt.Errorf("group by: invalid field %q, expected any of %v", field, resticGroupByFields)
+			}
+		}
+		policy.GroupBy = groupBy
+	}
+
+	return policy, nil
 }
 
 func RestoreProgressEntryToProto(p *restic.RestoreProgressEntry) *v1.RestoreProgressEntry {
@@ -143,3 +195,26 @@ func RepoStatsToProto(s *restic.RepoStats) *v1.RepoStats {
 		SnapshotCount:         int64(s.SnapshotsCount),
 	}
 }
+
+// BackupProgressSummaryToEntry converts a stored backup summary back to the restic summary entry it was created from.
+func BackupProgressSummaryToEntry(s *v1.BackupProgressSummary) *restic.BackupProgressEntry {
+	if s == nil {
+		return nil
+	}
+	return &restic.BackupProgressEntry{
+		MessageType:         "summary",
+		FilesNew:            s.FilesNew,
+		FilesChanged:        s.FilesChanged,
+		FilesUnmodified:     s.FilesUnmodified,
+		DirsNew:             s.DirsNew,
+		DirsChanged:         s.DirsChanged,
+		DirsUnmodified:      s.DirsUnmodified,
+		DataBlobs:           s.DataBlobs,
+		TreeBlobs:           s.TreeBlobs,
+		DataAdded:           s.DataAdded,
+		TotalFilesProcessed: s.TotalFilesProcessed,
+		TotalBytesProcessed: s.TotalBytesProcessed,
+		TotalDuration:       s.TotalDuration,
+		SnapshotId:          s.SnapshotId,
+	}
+}
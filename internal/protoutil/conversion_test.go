@@ -1,6 +1,7 @@
 package protoutil
 
 import (
+	"reflect"
 	"testing"
 
 	v1 "github.com/garethgeorge/backrest/gen/go/v1"
@@ -187,3 +188,73 @@ func TestRepoStatsToProto(t *testing.T) {
 		})
 	}
 }
+
+func TestRetentionPolicyFromProto(t *testing.T) {
+	cases := []struct {
+		name    string
+		policy  *v1.RetentionPolicy
+		want    *restic.RetentionPolicy
+		wantErr bool
+	}{
+		{
+			name: "keep all",
+			policy: &v1.RetentionPolicy{
+				Policy: &v1.RetentionPolicy_PolicyKeepAll{PolicyKeepAll: true},
+			},
+			want: nil,
+		},
+		{
+			name: "keep last n",
+			policy: &v1.RetentionPolicy{
+				Policy: &v1.RetentionPolicy_PolicyKeepLastN{PolicyKeepLastN: 5},
+			},
+			want: &restic.RetentionPolicy{KeepLastN: 5},
+		},
+		{
+			name: "keep within plus monthlies",
+			policy: &v1.RetentionPolicy{
+				Policy:     &v1.RetentionPolicy_PolicyTimeBucketed{PolicyTimeBucketed: &v1.RetentionPolicy_TimeBucketedCounts{}},
+				KeepWithin: "14d",
+				KeepWithinBuckets: &v1.RetentionPolicy_TimeBucketedDurations{
+					Monthly: "2y",
+				},
+				KeepTags: []string{"important"},
+				GroupBy:  "host,paths",
+			},
+			want: &restic.RetentionPolicy{
+				KeepWithinDuration: "14d",
+				KeepWithinMonthly:  "2y",
+				KeepTags:           []string{"important"},
+				GroupBy:            "host,paths",
+			},
+		},
+		{
+			name: "invalid duration",
+			policy: &v1.RetentionPolicy{
+				Policy:     &v1.RetentionPolicy_PolicyKeepLastN{PolicyKeepLastN: 5},
+				KeepWithin: "14 days",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid group by",
+			policy: &v1.RetentionPolicy{
+				Policy:  &v1.RetentionPolicy_PolicyKeepLastN{PolicyKeepLastN: 5},
+				GroupBy: "host,user",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := RetentionPolicyFromProto(c.policy)
+			if (err != nil) != c.wantErr {
+				t.Fatalf("wanted error: %v, got: %v", c.wantErr, err)
+			}
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("wanted: %+v, got: %+v", c.want, got)
+			}
+		})
+	}
+}
@@ -21,6 +21,10 @@ func OpSelectorToQuery(sel *v1.OpSelector) (oplog.Query, error) {
 		InstanceID:            sel.InstanceId,
 		OriginalInstanceKeyid: sel.OriginalInstanceKeyid,
 		ModnoGte:              sel.ModnoGte,
+		StartTimeGteMs:        sel.StartTimeGteMs,
+		StartTimeLtMs:         sel.StartTimeLtMs,
+		Statuses:              sel.Statuses,
+		OpTypes:               sel.OpTypes,
 	}
 	if len(sel.Ids) > 0 && !reflect.DeepEqual(q, oplog.Query{}) {
 		return oplog.Query{}, errors.New("cannot specify both query and ids")
@@ -0,0 +1,468 @@
+package search
+
+import (
+	"context"
+	"database/sql"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/kvstore"
+	"github.com/garethgeorge/backrest/internal/logstore"
+	"github.com/garethgeorge/backrest/internal/oplog"
+	"github.com/ncruces/go-sqlite3"
+	"go.uber.org/zap"
+)
+
+// ErrInvalidQuery is returned by Search when the query can't be parsed.
+var ErrInvalidQuery = errors.New("invalid search query")
+
+const (
+	schemaVersion = 1
+
+	metadataKeySchemaVersion = "schema_version"
+	metadataKeyModno         = "modno"
+	metadataKeyIndexLogs     = "index_logs"
+
+	maxIndexedLogBytes = 256 * 1024 // only the tail of longer logs is indexed, errors are typically at the end.
+	snippetTokens      = 16
+	indexBatchSize     = 256
+
+	// control characters delimit highlights in snippets, they can't be confused with text in operations or logs.
+	highlightStart = "\x02"
+	highlightEnd   = "\x03"
+)
+
+// Fields of an operation that are indexed, in the order of the columns of the index.
+const (
+	FieldDisplayMessage = "display_message"
+	FieldErrors         = "errors"
+	FieldLogs           = "logs"
+)
+
+var fields = []string{FieldDisplayMessage, FieldErrors, FieldLogs}
+
+// Segment is a part of a snippet, highlighted segments match the query.
+type Segment struct {
+	Text      string
+	Highlight bool
+}
+
+// Result is an operation matching a search.
+type Result struct {
+	OpID    int64
+	Field   string    // the field the snippet is taken from.
+	Snippet []Segment // excerpt of the field around the match.
+}
+
+type SearchOptions struct {
+	RawQuery    bool // the query uses the SQLite FTS5 query syntax, otherwise each word of the query must match.
+	IncludeLogs bool // match operation logs, only available if logs are indexed.
+}
+
+// Index is a full-text index over the display messages, backup errors and optionally logs of operations.
+// It is kept in sync with the oplog and the logstore through their subscriptions.
+type Index struct {
+	dbpool   *sql.DB
+	kvstore  kvstore.KvStore
+	oplog    *oplog.OpLog
+	logstore *logstore.LogStore // nil if logs are not indexed.
+
+	mu           sync.Mutex // serializes writes to the index.
+	highestModno int64
+
+	opSub  oplog.Subscription
+	logSub logstore.FinalizeSubscription
+}
+
+// NewIndex opens the index at dbpath and indexes operations changed since it was last open. If logs is nil the
+// contents of logs are not indexed.
+func NewIndex(dbpath string, log *oplog.OpLog, logs *logstore.LogStore) (*Index, error) {
+	dbpool, err := kvstore.NewSqliteDbForKvStore(dbpath)
+	if err != nil {
+		return nil, fmt.Errorf("open search index: %w", err)
+	}
+	idx, err := newIndex(dbpool, log, logs)
+	if err != nil {
+		dbpool.Close()
+		return nil, err
+	}
+	return idx, nil
+}
+
+func newIndex(dbpool *sql.DB, log *oplog.OpLog, logs *logstore.LogStore) (*Index, error) {
+	kv, err := kvstore.NewSqliteKVStore(dbpool, "search_metadata")
+	if err != nil {
+		return nil, fmt.Errorf("create kvstore: %w", err)
+	}
+
+	idx := &Index{
+		dbpool:   dbpool,
+		kvstore:  kv,
+		oplog:    log,
+		logstore: logs,
+	}
+	if err := idx.init(); err != nil {
+		return nil, fmt.Errorf("init search index: %w", err)
+	}
+
+	// subscribe before catching up so that no changes are missed, operations may be indexed twice.
+	idx.opSub = func(ops []*v1.Operation, event oplog.OperationEvent) {
+		var err error
+		if event == oplog.OPERATION_DELETED {
+			err = idx.deleteOps(ops)
+		} else {
+			err = idx.indexOps(ops)
+		}
+		if err != nil {
+			zap.L().Warn("search index: failed to update operations", zap.Error(err))
+		}
+	}
+	log.Subscribe(oplog.Query{}, &idx.opSub)
+	if logs != nil {
+		idx.logSub = func(meta logstore.LogMetadata) {
+			if err := idx.indexLogs(meta.OwnerOpID); err != nil {
+				zap.L().Warn("search index: failed to update logs", zap.String("log_id", meta.ID), zap.Error(err))
+			}
+		}
+		logs.SubscribeFinalized(&idx.logSub)
+	}
+
+	if err := idx.catchUp(); err != nil {
+		idx.Close()
+		return nil, fmt.Errorf("catch up search index: %w", err)
+	}
+	return idx, nil
+}
+
+func (idx *Index) init() error {
+	version, err := idx.getInt(metadataKeySchemaVersion)
+	if err != nil {
+		return err
+	}
+	indexLogs, err := idx.getInt(metadataKeyIndexLogs)
+	if err != nil {
+		return err
+	}
+	wantIndexLogs := int64(0)
+	if idx.logstore != nil {
+		wantIndexLogs = 1
+	}
+
+	// the index is rebuilt from the oplog when its schema changes or logs are enabled or disabled.
+	if version != schemaVersion || indexLogs != wantIndexLogs {
+		zap.S().Infof("rebuilding search index (schema version %d to %d)", version, schemaVersion)
+		if _, err := idx.dbpool.ExecContext(context.Background(), "DROP TABLE IF EXISTS operations_fts"); err != nil {
+			return fmt.Errorf("drop index: %w", err)
+		}
+		if err := idx.setInt(metadataKeyModno, 0); err != nil {
+			return err
+		}
+	}
+
+	if _, err := idx.dbpool.ExecContext(context.Background(), `CREATE VIRTUAL TABLE IF NOT EXISTS operations_fts USING fts5(
+		display_message,
+		errors,
+		logs
+	)`); err != nil {
+		return fmt.Errorf("create index: %w", err)
+	}
+
+	if err := idx.setInt(metadataKeySchemaVersion, schemaVersion); err != nil {
+		return err
+	}
+	if err := idx.setInt(metadataKeyIndexLogs, wantIndexLogs); err != nil {
+		return err
+	}
+	idx.highestModno, err = idx.getInt(metadataKeyModno)
+	return err
+}
+
+// Close unsubscribes the index from the oplog and logstore and closes its database.
+func (idx *Index) Close() error {
+	idx.oplog.Unsubscribe(&idx.opSub)
+	if idx.logstore != nil {
+		idx.logstore.UnsubscribeFinalized(&idx.logSub)
+	}
+	return idx.dbpool.Close()
+}
+
+// catchUp indexes operations modified since the index was last updated.
+func (idx *Index) catchUp() error {
+	idx.mu.Lock()
+	modno := idx.highestModno
+	idx.mu.Unlock()
+
+	var batch []*v1.Operation
+	if err := idx.oplog.Query(oplog.Query{}.SetModnoGte(modno+1), func(op *v1.Operation) error {
+		batch = append(batch, op)
+		if len(batch) >= indexBatchSize {
+			if err := idx.indexOps(batch); err != nil {
+				return err
+			}
+			batch = nil
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	return idx.indexOps(batch)
+}
+
+func (idx *Index) indexOps(ops []*v1.Operation) error {
+	if len(ops) == 0 {
+		return nil
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	tx, err := idx.dbpool.BeginTx(context.Background(), nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	highestModno := idx.highestModno
+	for _, op := range ops {
+		res, err := tx.ExecContext(context.Background(), "UPDATE operations_fts SET display_message = ?, errors = ? WHERE rowid = ?", op.DisplayMessage, errorsText(op), op.Id)
+		if err != nil {
+			return fmt.Errorf("update operation %d: %w", op.Id, err)
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return fmt.Errorf("update operation %d: get rows affected: %w", op.Id, err)
+		} else if n == 0 {
+			logs, err := idx.logsText(op.Id)
+			if err != nil {
+				return err
+			}
+			if _, err := tx.ExecContext(context.Background(), "INSERT INTO operations_fts (rowid, display_message, errors, logs) VALUES (?, ?, ?, ?)", op.Id, op.DisplayMessage, errorsText(op), logs); err != nil {
+				return fmt.Errorf("insert operation %d: %w", op.Id, err)
+			}
+		}
+		highestModno = max(highestModno, op.Modno)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit: %w", err)
+	}
+	if highestModno != idx.highestModno {
+		if err := idx.setInt(metadataKeyModno, highestModno); err != nil {
+			return err
+		}
+		idx.highestModno = highestModno
+	}
+	return nil
+}
+
+func (idx *Index) deleteOps(ops []*v1.Operation) error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	tx, err := idx.dbpool.BeginTx(context.Background(), nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, op := range ops {
+		if _, err := tx.ExecContext(context.Background(), "DELETE FROM operations_fts WHERE rowid = ?", op.Id); err != nil {
+			return fmt.Errorf("delete operation %d: %w", op.Id, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// indexLogs updates the indexed logs of an operation e.g. after one of its logs is finalized.
+func (idx *Index) indexLogs(opID int64) error {
+	if opID == 0 {
+		return nil
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	logs, err := idx.logsText(opID)
+	if err != nil {
+		return err
+	}
+	res, err := idx.dbpool.ExecContext(context.Background(), "UPDATE operations_fts SET logs = ? WHERE rowid = ?", logs, opID)
+	if err != nil {
+		return fmt.Errorf("update logs of operation %d: %w", opID, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("update logs of operation %d: get rows affected: %w", opID, err)
+	} else if n != 0 {
+		return nil
+	}
+
+	// the operation isn't indexed yet, index it along with its logs.
+	op, err := idx.oplog.Get(opID)
+	if err != nil {
+		if errors.Is(err, oplog.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("get operation %d: %w", opID, err)
+	}
+	if _, err := idx.dbpool.ExecContext(context.Background(), "INSERT INTO operations_fts (rowid, display_message, errors, logs) VALUES (?, ?, ?, ?)", op.Id, op.DisplayMessage, errorsText(op), logs); err != nil {
+		return fmt.Errorf("insert operation %d: %w", op.Id, err)
+	}
+	return nil
+}
+
+// logsText returns the contents of the logs owned by an operation, empty if logs are not indexed.
+func (idx *Index) logsText(opID int64) (string, error) {
+	if idx.logstore == nil {
+		return "", nil
+	}
+	ids, err := idx.logstore.FindLogsWithParent(opID)
+	if err != nil {
+		return "", fmt.Errorf("find logs of operation %d: %w", opID, err)
+	}
+	var sb strings.Builder
+	for _, id := range ids {
+		data, err := idx.logstore.Tail(id, maxIndexedLogBytes)
+		if err != nil {
+			if errors.Is(err, logstore.ErrLogNotFound) {
+				continue
+			}
+			return "", fmt.Errorf("read log %q: %w", id, err)
+		}
+		sb.Write(data)
+		sb.WriteString("\n")
+	}
+	return sb.String(), nil
+}
+
+// errorsText returns the indexed text of the errors reported by a backup.
+func errorsText(op *v1.Operation) string {
+	var sb strings.Builder
+	for _, e := range op.GetOperationBackup().GetErrors() {
+		sb.WriteString(e.Item)
+		sb.WriteString(": ")
+		sb.WriteString(e.Message)
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// Search calls f for each operation matching query, best matches first. f may return oplog.ErrStopIteration to stop early.
+// Results may reference operations that were deleted since they were indexed.
+func (idx *Index) Search(query string, opts SearchOptions, f func(Result) error) error {
+	expr := query
+	if !opts.RawQuery {
+		expr = termsQuery(query)
+	}
+	if strings.TrimSpace(expr) == "" {
+		return fmt.Errorf("%w: empty query", ErrInvalidQuery)
+	}
+	searchFields := fields
+	if !opts.IncludeLogs || idx.logstore == nil {
+		searchFields = fields[:2]
+		expr = "{" + strings.Join(searchFields, " ") + "} : (" + expr + ")"
+	}
+
+	var cols []string
+	for i := range searchFields {
+		cols = append(cols, fmt.Sprintf("snippet(operations_fts, %d, '%s', '%s', '...', %d)", i, highlightStart, highlightEnd, snippetTokens))
+	}
+	rows, err := idx.dbpool.QueryContext(context.Background(),
+		"SELECT rowid, "+strings.Join(cols, ", ")+" FROM operations_fts WHERE operations_fts MATCH ? ORDER BY bm25(operations_fts, 10.0, 5.0, 1.0)", expr)
+	if err != nil {
+		return queryError(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var opID int64
+		snippets := make([]string, len(searchFields))
+		dest := []any{&opID}
+		for i := range snippets {
+			dest = append(dest, &snippets[i])
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return fmt.Errorf("scan result: %w", err)
+		}
+
+		res := Result{OpID: opID, Field: searchFields[0], Snippet: parseSnippet(snippets[0])}
+		for i, snippet := range snippets {
+			if strings.Contains(snippet, highlightStart) {
+				res = Result{OpID: opID, Field: searchFields[i], Snippet: parseSnippet(snippet)}
+				break
+			}
+		}
+		if err := f(res); err != nil {
+			if errors.Is(err, oplog.ErrStopIteration) {
+				return nil
+			}
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return queryError(err)
+	}
+	return nil
+}
+
+// queryError classifies generic sqlite errors, raised for malformed FTS5 expressions, as invalid queries.
+func queryError(err error) error {
+	if errors.Is(err, sqlite3.ERROR) {
+		return fmt.Errorf("%w: %v", ErrInvalidQuery, err)
+	}
+	return fmt.Errorf("search: %w", err)
+}
+
+// termsQuery quotes each word of a plain text query so that it matches operations containing all of the words.
+func termsQuery(query string) string {
+	var terms []string
+	for _, word := range strings.Fields(query) {
+		terms = append(terms, `"`+strings.ReplaceAll(word, `"`, `""`)+`"`)
+	}
+	return strings.Join(terms, " ")
+}
+
+func parseSnippet(snippet string) []Segment {
+	var segments []Segment
+	for snippet != "" {
+		start := strings.Index(snippet, highlightStart)
+		if start == -1 {
+			segments = append(segments, Segment{Text: snippet})
+			break
+		}
+		if start > 0 {
+			segments = append(segments, Segment{Text: snippet[:start]})
+		}
+		snippet = snippet[start+len(highlightStart):]
+		end := strings.Index(snippet, highlightEnd)
+		if end == -1 {
+			end = len(snippet)
+		}
+		segments = append(segments, Segment{Text: snippet[:end], Highlight: true})
+		snippet = strings.TrimPrefix(snippet[end:], highlightEnd)
+	}
+	return segments
+}
+
+func (idx *Index) getInt(key string) (int64, error) {
+	b, err := idx.kvstore.Get(key)
+	if err != nil {
+		if errors.Is(err, kvstore.ErrNotExist) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("get %s: %w", key, err)
+	}
+	if len(b) != 8 {
+		return 0, fmt.Errorf("get %s: bytes length is not 8: %d", key, len(b))
+	}
+	return int64(binary.LittleEndian.Uint64(b)), nil
+}
+
+func (idx *Index) setInt(key string, value int64) error {
+	if err := idx.kvstore.Set(key, binary.LittleEndian.AppendUint64(nil, uint64(value))); err != nil {
+		return fmt.Errorf("set %s: %w", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,282 @@
+package search
+
+import (
+	"errors"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/logstore"
+	"github.com/garethgeorge/backrest/internal/oplog"
+	"github.com/garethgeorge/backrest/internal/oplog/memstore"
+)
+
+func newTestOpLog(t *testing.T) *oplog.OpLog {
+	log, err := oplog.NewOpLog(memstore.NewMemStore())
+	if err != nil {
+		t.Fatalf("error creating oplog: %v", err)
+	}
+	return log
+}
+
+func newTestLogStore(t *testing.T) *logstore.LogStore {
+	ls, err := logstore.NewLogStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("error creating logstore: %v", err)
+	}
+	t.Cleanup(func() { ls.Close() })
+	return ls
+}
+
+func newTestIndex(t *testing.T, dbpath string, log *oplog.OpLog, logs *logstore.LogStore) *Index {
+	idx, err := NewIndex(dbpath, log, logs)
+	if err != nil {
+		t.Fatalf("error creating index: %v", err)
+	}
+	t.Cleanup(func() { idx.Close() })
+	return idx
+}
+
+func testOp(msg string) *v1.Operation {
+	return &v1.Operation{
+		InstanceId:      "instance",
+		RepoId:          "repo",
+		RepoGuid:        "repo-guid",
+		PlanId:          "plan",
+		UnixTimeStartMs: 1234,
+		DisplayMessage:  msg,
+		Op:              &v1.Operation_OperationBackup{},
+	}
+}
+
+func search(t *testing.T, idx *Index, query string, opts SearchOptions) []Result {
+	t.Helper()
+	var results []Result
+	if err := idx.Search(query, opts, func(r Result) error {
+		results = append(results, r)
+		return nil
+	}); err != nil {
+		t.Fatalf("search %q failed: %v", query, err)
+	}
+	return results
+}
+
+func resultIDs(results []Result) []int64 {
+	var ids []int64
+	for _, r := range results {
+		ids = append(ids, r.OpID)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+func highlighted(r Result) []string {
+	var words []string
+	for _, s := range r.Snippet {
+		if s.Highlight {
+			words = append(words, s.Text)
+		}
+	}
+	return words
+}
+
+func TestSearchDisplayMessageAndErrors(t *testing.T) {
+	t.Parallel()
+
+	log := newTestOpLog(t)
+	idx := newTestIndex(t, filepath.Join(t.TempDir(), "search.sqlite"), log, nil)
+
+	op1 := testOp("backup failed: permission denied")
+	op2 := testOp("backup succeeded")
+	op2.Op = &v1.Operation_OperationBackup{
+		OperationBackup: &v1.OperationBackup{
+			Errors: []*v1.BackupProgressError{
+				{Item: "/var/lib/secret.db", Message: "open failed: permission denied"},
+			},
+		},
+	}
+	op3 := testOp("forget completed")
+	if err := log.Add(op1, op2, op3); err != nil {
+		t.Fatalf("error adding operations: %v", err)
+	}
+
+	results := search(t, idx, "permission denied", SearchOptions{})
+	if got, want := resultIDs(results), []int64{op1.Id, op2.Id}; !slices.Equal(got, want) {
+		t.Fatalf("want results %v, got %v", want, got)
+	}
+	for _, r := range results {
+		want := FieldDisplayMessage
+		if r.OpID == op2.Id {
+			want = FieldErrors
+		}
+		if r.Field != want {
+			t.Errorf("result for op %d: want field %q, got %q", r.OpID, want, r.Field)
+		}
+		if got := highlighted(r); !slices.Equal(got, []string{"permission", "denied"}) {
+			t.Errorf("result for op %d: unexpected highlights %v", r.OpID, got)
+		}
+	}
+
+	// paths are matched as a phrase of their words.
+	if got := resultIDs(search(t, idx, "/var/lib/secret.db", SearchOptions{})); !slices.Equal(got, []int64{op2.Id}) {
+		t.Errorf("want path match %v, got %v", []int64{op2.Id}, got)
+	}
+
+	// raw queries use the FTS5 syntax.
+	if got := resultIDs(search(t, idx, "denied OR forget", SearchOptions{RawQuery: true})); !slices.Equal(got, []int64{op1.Id, op2.Id, op3.Id}) {
+		t.Errorf("unexpected raw query results: %v", got)
+	}
+
+	if err := idx.Search(`"unterminated`, SearchOptions{RawQuery: true}, func(Result) error { return nil }); !errors.Is(err, ErrInvalidQuery) {
+		t.Errorf("want ErrInvalidQuery for a malformed query, got %v", err)
+	}
+	if err := idx.Search("  ", SearchOptions{}, func(Result) error { return nil }); !errors.Is(err, ErrInvalidQuery) {
+		t.Errorf("want ErrInvalidQuery for an empty query, got %v", err)
+	}
+}
+
+func TestSearchFollowsOpLog(t *testing.T) {
+	t.Parallel()
+
+	log := newTestOpLog(t)
+	idx := newTestIndex(t, filepath.Join(t.TempDir(), "search.sqlite"), log, nil)
+
+	op := testOp("backup running")
+	if err := log.Add(op); err != nil {
+		t.Fatalf("error adding operation: %v", err)
+	}
+	if got := search(t, idx, "running", SearchOptions{}); len(got) != 1 {
+		t.Fatalf("want 1 result, got %d", len(got))
+	}
+
+	op.DisplayMessage = "backup timed out"
+	if err := log.Update(op); err != nil {
+		t.Fatalf("error updating operation: %v", err)
+	}
+	if got := search(t, idx, "running", SearchOptions{}); len(got) != 0 {
+		t.Errorf("want no results for the old message, got %d", len(got))
+	}
+	if got := search(t, idx, "timed out", SearchOptions{}); len(got) != 1 {
+		t.Errorf("want 1 result for the new message, got %d", len(got))
+	}
+
+	if err := log.Delete(op.Id); err != nil {
+		t.Fatalf("error deleting operation: %v", err)
+	}
+	if got := search(t, idx, "timed out", SearchOptions{}); len(got) != 0 {
+		t.Errorf("want no results after delete, got %d", len(got))
+	}
+}
+
+func TestSearchLogs(t *testing.T) {
+	t.Parallel()
+
+	log := newTestOpLog(t)
+	logs := newTestLogStore(t)
+	idx := newTestIndex(t, filepath.Join(t.TempDir(), "search.sqlite"), log, logs)
+
+	op := testOp("prune")
+	if err := log.Add(op); err != nil {
+		t.Fatalf("error adding operation: %v", err)
+	}
+
+	w, err := logs.Create("prune-log", op.Id, 0)
+	if err != nil {
+		t.Fatalf("error creating log: %v", err)
+	}
+	if _, err := w.Write([]byte("repository contains 42 packs\nFatal: unable to lock repository\n")); err != nil {
+		t.Fatalf("error writing log: %v", err)
+	}
+	if got := search(t, idx, "unable to lock", SearchOptions{IncludeLogs: true}); len(got) != 0 {
+		t.Fatalf("want logs indexed only once finalized, got %d results", len(got))
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("error closing log: %v", err)
+	}
+
+	results := search(t, idx, "unable to lock", SearchOptions{IncludeLogs: true})
+	if len(results) != 1 || results[0].OpID != op.Id || results[0].Field != FieldLogs {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+	if got := highlighted(results[0]); !slices.Equal(got, []string{"unable", "to", "lock"}) {
+		t.Errorf("unexpected highlights %v", got)
+	}
+
+	// logs are only matched when requested.
+	if got := search(t, idx, "unable to lock", SearchOptions{}); len(got) != 0 {
+		t.Errorf("want no results without logs, got %d", len(got))
+	}
+
+	// updates to the operation keep its indexed logs.
+	op.DisplayMessage = "prune failed"
+	if err := log.Update(op); err != nil {
+		t.Fatalf("error updating operation: %v", err)
+	}
+	if got := search(t, idx, "unable to lock", SearchOptions{IncludeLogs: true}); len(got) != 1 {
+		t.Errorf("want logs to remain indexed after update, got %d results", len(got))
+	}
+}
+
+func TestSearchCatchesUpOnOpen(t *testing.T) {
+	t.Parallel()
+
+	log := newTestOpLog(t)
+	dbpath := filepath.Join(t.TempDir(), "search.sqlite")
+
+	idx, err := NewIndex(dbpath, log, nil)
+	if err != nil {
+		t.Fatalf("error creating index: %v", err)
+	}
+	op1 := testOp("first backup")
+	if err := log.Add(op1); err != nil {
+		t.Fatalf("error adding operation: %v", err)
+	}
+	if err := idx.Close(); err != nil {
+		t.Fatalf("error closing index: %v", err)
+	}
+
+	// changes while the index is closed are indexed when it's reopened.
+	op2 := testOp("second backup")
+	if err := log.Add(op2); err != nil {
+		t.Fatalf("error adding operation: %v", err)
+	}
+	op1.DisplayMessage = "first backup renamed"
+	if err := log.Update(op1); err != nil {
+		t.Fatalf("error updating operation: %v", err)
+	}
+
+	idx = newTestIndex(t, dbpath, log, nil)
+	if got := resultIDs(search(t, idx, "backup", SearchOptions{})); !slices.Equal(got, []int64{op1.Id, op2.Id}) {
+		t.Errorf("want results %v, got %v", []int64{op1.Id, op2.Id}, got)
+	}
+	if got := resultIDs(search(t, idx, "renamed", SearchOptions{})); !slices.Equal(got, []int64{op1.Id}) {
+		t.Errorf("want results %v, got %v", []int64{op1.Id}, got)
+	}
+}
+
+func TestParseSnippet(t *testing.T) {
+	t.Parallel()
+
+	snippet := "..." + highlightStart + "permission" + highlightEnd + " " + highlightStart + "denied" + highlightEnd + " on /foo"
+	got := parseSnippet(snippet)
+	want := []Segment{
+		{Text: "..."},
+		{Text: "permission", Highlight: true},
+		{Text: " "},
+		{Text: "denied", Highlight: true},
+		{Text: " on /foo"},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("want segments %+v, got %+v", want, got)
+	}
+
+	var sb strings.Builder
+	for _, s := range parseSnippet("no highlights") {
+		sb.WriteString(s.Text)
+	}
+	if sb.String() != "no highlights" {
+		t.Errorf("unexpected text %q", sb.String())
+	}
+}
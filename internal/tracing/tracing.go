@@ -0,0 +1,146 @@
+// Package tracing exports OpenTelemetry traces of the work backrest does, from scheduling a task through the restic
+// commands it runs, the oplog writes it makes and the operations forwarded to multihost peers.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/garethgeorge/backrest"
+
+// defaultURLPath is the OTLP/HTTP path traces are sent to if the endpoint doesn't specify one.
+const defaultURLPath = "/v1/traces"
+
+// Setup installs a global tracer provider that exports spans to the OTLP/HTTP collector at endpoint e.g.
+// http://localhost:4318. Tracing is disabled if endpoint is empty. The returned function flushes buffered spans and
+// stops the exporter.
+func Setup(ctx context.Context, endpoint string, version string) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	if endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid OTLP endpoint %q: must be a URL e.g. http://localhost:4318", endpoint)
+	}
+	if strings.Trim(u.Path, "/") == "" {
+		u.Path = defaultURLPath
+	}
+
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(u.String()))
+	if err != nil {
+		return nil, fmt.Errorf("create OTLP exporter: %w", err)
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName("backrest"),
+		semconv.ServiceVersion(version),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("create trace resource: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(res))
+	otel.SetTracerProvider(tp)
+	return tp.Shutdown, nil
+}
+
+// Tracer returns the tracer backrest's spans are created with.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Inject returns the trace context of the span in ctx encoded for sending to another process, nil if there is none.
+func Inject(ctx context.Context) map[string]string {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return nil
+	}
+	return carrier
+}
+
+// Extract returns a context with the remote span encoded by Inject.
+func Extract(ctx context.Context, traceContext map[string]string) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(traceContext))
+}
+
+type linksKey struct{}
+
+// ContextWithLinks returns a context that links the next span started by StartLinked to the given span contexts.
+func ContextWithLinks(ctx context.Context, links ...trace.SpanContext) context.Context {
+	return context.WithValue(ctx, linksKey{}, links)
+}
+
+// StartLinked starts a span that links to the span contexts attached by ContextWithLinks. The links are not inherited by
+// children of the span.
+func StartLinked(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	if links, ok := ctx.Value(linksKey{}).([]trace.SpanContext); ok {
+		for _, sc := range links {
+			if sc.IsValid() {
+				opts = append(opts, trace.WithLinks(trace.Link{SpanContext: sc}))
+			}
+		}
+		ctx = context.WithValue(ctx, linksKey{}, nil)
+	}
+	return Tracer().Start(ctx, name, opts...)
+}
+
+// flows tracks the span of the task currently running each flow. Work that isn't passed the task's context e.g. oplog
+// writes and operations forwarded to peers uses it to attach to the task's trace.
+var flows = struct {
+	mu    sync.Mutex
+	spans map[int64][]trace.SpanContext // stack of spans, nested tasks e.g. hooks run in their parent's flow.
+}{spans: make(map[int64][]trace.SpanContext)}
+
+// TrackFlow records the span in ctx as the span of the flow until the returned function is called.
+func TrackFlow(ctx context.Context, flowID int64) func() {
+	sc := trace.SpanContextFromContext(ctx)
+	if flowID == 0 || !sc.IsValid() {
+		return func() {}
+	}
+
+	flows.mu.Lock()
+	flows.spans[flowID] = append(flows.spans[flowID], sc)
+	flows.mu.Unlock()
+
+	return func() {
+		flows.mu.Lock()
+		defer flows.mu.Unlock()
+		stack := flows.spans[flowID]
+		for i := len(stack) - 1; i >= 0; i-- {
+			if stack[i].Equal(sc) {
+				stack = append(stack[:i], stack[i+1:]...)
+				break
+			}
+		}
+		if len(stack) == 0 {
+			delete(flows.spans, flowID)
+		} else {
+			flows.spans[flowID] = stack
+		}
+	}
+}
+
+// FlowSpanContext returns the span of the task running the flow, the span context is invalid if no task is running it.
+func FlowSpanContext(flowID int64) trace.SpanContext {
+	flows.mu.Lock()
+	defer flows.mu.Unlock()
+	stack := flows.spans[flowID]
+	if len(stack) == 0 {
+		return trace.SpanContext{}
+	}
+	return stack[len(stack)-1]
+}
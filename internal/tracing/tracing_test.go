@@ -0,0 +1,137 @@
+package tracing
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// fakeCollector is a stand-in for an OTLP/HTTP collector that records the spans exported to it.
+type fakeCollector struct {
+	mu    sync.Mutex
+	paths []string
+	spans []*tracepb.Span
+}
+
+func (c *fakeCollector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var req coltracepb.ExportTraceServiceRequest
+	if err := proto.Unmarshal(body, &req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	c.mu.Lock()
+	c.paths = append(c.paths, r.URL.Path)
+	for _, rs := range req.GetResourceSpans() {
+		for _, ss := range rs.GetScopeSpans() {
+			c.spans = append(c.spans, ss.GetSpans()...)
+		}
+	}
+	c.mu.Unlock()
+
+	resp, _ := proto.Marshal(&coltracepb.ExportTraceServiceResponse{})
+	w.Header().Set("Content-Type", "application/x-protobuf")
+	w.Write(resp)
+}
+
+func (c *fakeCollector) span(t *testing.T, name string) *tracepb.Span {
+	t.Helper()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, s := range c.spans {
+		if s.GetName() == name {
+			return s
+		}
+	}
+	t.Fatalf("span %q not exported", name)
+	return nil
+}
+
+func TestExportToCollector(t *testing.T) {
+	collector := &fakeCollector{}
+	srv := httptest.NewServer(collector)
+	defer srv.Close()
+
+	prev := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	ctx := context.Background()
+	shutdown, err := Setup(ctx, srv.URL, "test")
+	if err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+
+	_, scheduleSpan := Tracer().Start(ctx, "schedule backup")
+	scheduleSpan.End()
+
+	// the task span links to the span that scheduled it, the links aren't inherited by its children.
+	taskCtx, taskSpan := StartLinked(ContextWithLinks(ctx, scheduleSpan.SpanContext()), "task backup")
+	untrack := TrackFlow(taskCtx, 42)
+	if got := FlowSpanContext(42); !got.Equal(taskSpan.SpanContext()) {
+		t.Errorf("want flow span %v, got %v", taskSpan.SpanContext(), got)
+	}
+	_, childSpan := StartLinked(taskCtx, "task hook")
+	childSpan.End()
+
+	// a peer continues the trace from the propagated context.
+	traceContext := Inject(trace.ContextWithSpanContext(ctx, FlowSpanContext(42)))
+	_, peerSpan := Tracer().Start(Extract(ctx, traceContext), "sync.receive_operations")
+	peerSpan.End()
+
+	untrack()
+	if FlowSpanContext(42).IsValid() {
+		t.Errorf("want no flow span after the task finished")
+	}
+	taskSpan.End()
+
+	if err := shutdown(ctx); err != nil {
+		t.Fatalf("shutdown error = %v", err)
+	}
+
+	for _, p := range collector.paths {
+		if p != defaultURLPath {
+			t.Errorf("want spans exported to %q, got %q", defaultURLPath, p)
+		}
+	}
+
+	task := collector.span(t, "task backup")
+	schedule := collector.span(t, "schedule backup")
+	if len(task.GetLinks()) != 1 || string(task.GetLinks()[0].GetSpanId()) != string(schedule.GetSpanId()) {
+		t.Errorf("want task span linked to the schedule span, got links %v", task.GetLinks())
+	}
+	hook := collector.span(t, "task hook")
+	if string(hook.GetParentSpanId()) != string(task.GetSpanId()) || len(hook.GetLinks()) != 0 {
+		t.Errorf("want hook span to be an unlinked child of the task span")
+	}
+	peer := collector.span(t, "sync.receive_operations")
+	if string(peer.GetTraceId()) != string(task.GetTraceId()) || string(peer.GetParentSpanId()) != string(task.GetSpanId()) {
+		t.Errorf("want peer span to continue the task's trace")
+	}
+}
+
+func TestSetupDisabled(t *testing.T) {
+	shutdown, err := Setup(context.Background(), "", "test")
+	if err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Errorf("shutdown error = %v", err)
+	}
+	if _, err := Setup(context.Background(), "localhost:4318", "test"); err == nil {
+		t.Errorf("want an error for an endpoint that isn't a URL")
+	}
+}
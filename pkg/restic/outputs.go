@@ -6,6 +6,8 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strconv"
+	"strings"
 	"time"
 
 	v1 "github.com/garethgeorge/backrest/gen/go/v1"
@@ -20,6 +22,7 @@ type Snapshot struct {
 	Username        string          `json:"username"`
 	Tags            []string        `json:"tags"`
 	Parent          string          `json:"parent"`
+	Original        string          `json:"original"` // ID of the snapshot before restic first rewrote it e.g. to change its tags.
 	SnapshotSummary SnapshotSummary `json:"summary"`
 	unixTimeMs      int64           `json:"-"`
 }
@@ -311,8 +314,15 @@ func readLs(output io.Reader) (*Snapshot, []*LsEntry, error) {
 }
 
 type ForgetResult struct {
-	Keep   []Snapshot `json:"keep"`
-	Remove []Snapshot `json:"remove"`
+	Keep    []Snapshot     `json:"keep"`
+	Remove  []Snapshot     `json:"remove"`
+	Reasons []ForgetReason `json:"reasons"`
+}
+
+// ForgetReason describes the policy rules that caused a snapshot to be kept.
+type ForgetReason struct {
+	Snapshot Snapshot `json:"snapshot"`
+	Matches  []string `json:"matches"`
 }
 
 func (r *ForgetResult) Validate() error {
@@ -346,6 +356,46 @@ type RepoStats struct {
 	SnapshotsCount         int64   `json:"snapshots_count"`
 }
 
+const pruneUnusedSizePrefix = "unused size after prune:"
+
+// ParsePruneUnusedSize parses the unused size reported by a line of prune output
+// e.g. "unused size after prune: 6.127 MiB (0.09% of remaining size)".
+func ParsePruneUnusedSize(line string) (int64, bool) {
+	rest, ok := strings.CutPrefix(strings.TrimSpace(line), pruneUnusedSizePrefix)
+	if !ok {
+		return 0, false
+	}
+	fields := strings.Fields(rest)
+	if len(fields) < 2 {
+		return 0, false
+	}
+	return parseBytes(fields[0], fields[1])
+}
+
+// parseBytes parses a size formatted by restic e.g. "512 B" or "1.500 GiB".
+func parseBytes(value, unit string) (int64, bool) {
+	var multiplier float64
+	switch unit {
+	case "B":
+		multiplier = 1
+	case "KiB":
+		multiplier = 1 << 10
+	case "MiB":
+		multiplier = 1 << 20
+	case "GiB":
+		multiplier = 1 << 30
+	case "TiB":
+		multiplier = 1 << 40
+	default:
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil || f < 0 {
+		return 0, false
+	}
+	return int64(f * multiplier), true
+}
+
 type RepoConfig struct {
 	Version           int    `json:"version"`
 	Id                string `json:"id"`
@@ -89,3 +89,27 @@ func TestReadLs(t *testing.T) {
 		t.Errorf("wanted 3 entries, got: %d", len(entries))
 	}
 }
+
+func TestParsePruneUnusedSize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		line   string
+		want   int64
+		wantOk bool
+	}{
+		{line: "unused size after prune: 6.000 MiB (0.09% of remaining size)", want: 6 << 20, wantOk: true},
+		{line: "unused size after prune: 512 B (0.00% of remaining size)", want: 512, wantOk: true},
+		{line: "  unused size after prune: 1.500 GiB (4.12% of remaining size)\n", want: 3 << 29, wantOk: true},
+		{line: "unused size after prune: 12 parsecs", wantOk: false},
+		{line: "total prune:       12 blobs / 9.8 MiB", wantOk: false},
+		{line: "", wantOk: false},
+	}
+
+	for _, tc := range tests {
+		got, ok := ParsePruneUnusedSize(tc.line)
+		if ok != tc.wantOk || got != tc.want {
+			t.Errorf("ParsePruneUnusedSize(%q) = %d, %v, want %d, %v", tc.line, got, ok, tc.want, tc.wantOk)
+		}
+	}
+}
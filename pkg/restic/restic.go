@@ -154,7 +154,7 @@ func (r *Repo) executeWithJSONOutput(ctx context.Context, args []string, result
 	// Run the command
 	cmd := r.commandWithContext(ctx, args, opts...)
 	r.handleOutput(cmd, withAllTo(&errorCollector), withStdOutTo(stdoutOutput), withLogWriterFromContext(ctx))
-	if err := cmd.Run(); err != nil {
+	if err := r.runCmd(ctx, cmd); err != nil {
 		return errorCollector.AddCmdOutputToError(cmd, err)
 	}
 
@@ -203,7 +203,7 @@ func (r *Repo) Exists(ctx context.Context, opts ...GenericOption) error {
 		errorCollector := errorMessageCollector{}
 		cmd := r.commandWithContext(ctx, []string{"cat", "config"}, opts...)
 		r.handleOutput(cmd, withAllTo(&errorCollector), withStdOutTo(output), withLogWriterFromContext(ctx))
-		if err := cmd.Run(); err != nil {
+		if err := r.runCmd(ctx, cmd); err != nil {
 			var exitErr *exec.ExitError
 			if errors.As(err, &exitErr) && exitErr.ExitCode() == 10 {
 				err = ErrRepoNotFound
@@ -230,7 +230,7 @@ func (r *Repo) init(ctx context.Context, opts ...GenericOption) error {
 		errorCollector := errorMessageCollector{}
 		r.handleOutput(cmd, withAllTo(&errorCollector), withStdOutTo(output), withLogWriterFromContext(ctx))
 
-		if err := cmd.Run(); err != nil {
+		if err := r.runCmd(ctx, cmd); err != nil {
 			if strings.Contains(output.String(), "config file already exists") || strings.Contains(output.String(), "already initialized") {
 				r.initialized = errAlreadyInitialized
 			} else {
@@ -301,7 +301,7 @@ func runCommandWithProgress[T ProgressEntryValidator](ctx context.Context, r *Re
 		}
 	}()
 
-	cmdErr := cmd.Run()
+	cmdErr := r.runCmd(ctx, cmd)
 	writer.Close()
 	wg.Wait()
 
@@ -360,23 +360,35 @@ func (r *Repo) Forget(ctx context.Context, policy *RetentionPolicy, opts ...Gene
 		return nil, err
 	}
 
-	if len(results) != 1 {
-		return nil, fmt.Errorf("expected 1 output from forget, got %v", len(results))
+	if len(results) == 0 {
+		return nil, errors.New("expected at least 1 output from forget, got 0")
 	}
 
-	if err := results[0].Validate(); err != nil {
-		return nil, fmt.Errorf("invalid forget result: %w", err)
+	// restic reports one result per snapshot group, merge them into a single result.
+	merged := &ForgetResult{}
+	for _, result := range results {
+		if err := result.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid forget result: %w", err)
+		}
+		merged.Keep = append(merged.Keep, result.Keep...)
+		merged.Remove = append(merged.Remove, result.Remove...)
+		merged.Reasons = append(merged.Reasons, result.Reasons...)
 	}
 
-	return &results[0], nil
+	return merged, nil
 }
 
 func (r *Repo) ForgetSnapshot(ctx context.Context, snapshotId string, opts ...GenericOption) error {
-	args := []string{"forget", "--json", snapshotId}
+	return r.ForgetSnapshots(ctx, []string{snapshotId}, opts...)
+}
+
+func (r *Repo) ForgetSnapshots(ctx context.Context, snapshotIDs []string, opts ...GenericOption) error {
+	args := []string{"forget", "--json"}
+	args = append(args, snapshotIDs...)
 	cmd := r.commandWithContext(ctx, args, opts...)
 	errorCollector := errorMessageCollector{}
 	r.handleOutput(cmd, withAllTo(&errorCollector), withLogWriterFromContext(ctx))
-	if err := cmd.Run(); err != nil {
+	if err := r.runCmd(ctx, cmd); err != nil {
 		return errorCollector.AddCmdOutputToError(cmd, err)
 	}
 	return nil
@@ -398,7 +410,7 @@ func (r *Repo) Dump(ctx context.Context, snapshotID string, file string, dumpOut
 
 	// Dump writes binary output to stdout, we should only ever capture and print stderr
 	r.handleOutput(cmd, withStdOutTo(dumpOutput), withStdErrTo(logWriter), withStdErrTo(&errorCollector))
-	if err := cmd.Run(); err != nil {
+	if err := r.runCmd(ctx, cmd); err != nil {
 		return errorCollector.AddCmdOutputToError(cmd, err)
 	}
 
@@ -420,7 +432,7 @@ func (r *Repo) runSimpleCommand(ctx context.Context, args []string, outputWriter
 	if outputWriter != nil {
 		r.handleOutput(cmd, withStdOutTo(outputWriter), withAllTo(&errorCollector), withLogWriterFromContext(ctx))
 	}
-	if err := cmd.Run(); err != nil {
+	if err := r.runCmd(ctx, cmd); err != nil {
 		return errorCollector.AddCmdOutputToError(cmd, err)
 	}
 	return nil
@@ -436,7 +448,7 @@ func (r *Repo) ListDirectory(ctx context.Context, snapshot string, path string,
 	errorCollector := errorMessageCollector{}
 	output := bytes.NewBuffer(nil)
 	r.handleOutput(cmd, withStdOutTo(output), withAllTo(&errorCollector), withLogWriterFromContext(ctx))
-	if err := cmd.Run(); err != nil {
+	if err := r.runCmd(ctx, cmd); err != nil {
 		return nil, nil, errorCollector.AddCmdOutputToError(cmd, fmt.Errorf("error running command: %w", err))
 	}
 
@@ -451,7 +463,7 @@ func (r *Repo) Unlock(ctx context.Context, opts ...GenericOption) error {
 	errorCollector := errorMessageCollector{}
 	cmd := r.commandWithContext(ctx, []string{"unlock"}, opts...)
 	r.handleOutput(cmd, withAllTo(&errorCollector), withLogWriterFromContext(ctx))
-	if err := cmd.Run(); err != nil {
+	if err := r.runCmd(ctx, cmd); err != nil {
 		return errorCollector.AddCmdOutputToError(cmd, err)
 	}
 	return nil
@@ -475,7 +487,21 @@ func (r *Repo) AddTags(ctx context.Context, snapshotIDs []string, tags []string,
 	errorCollector := errorMessageCollector{}
 	cmd := r.commandWithContext(ctx, args, opts...)
 	r.handleOutput(cmd, withAllTo(&errorCollector), withLogWriterFromContext(ctx))
-	if err := cmd.Run(); err != nil {
+	if err := r.runCmd(ctx, cmd); err != nil {
+		return errorCollector.AddCmdOutputToError(cmd, err)
+	}
+	return nil
+}
+
+func (r *Repo) RemoveTags(ctx context.Context, snapshotIDs []string, tags []string, opts ...GenericOption) error {
+	args := []string{"tag"}
+	args = append(args, "--remove", strings.Join(tags, ","))
+	args = append(args, snapshotIDs...)
+
+	errorCollector := errorMessageCollector{}
+	cmd := r.commandWithContext(ctx, args, opts...)
+	r.handleOutput(cmd, withAllTo(&errorCollector), withLogWriterFromContext(ctx))
+	if err := r.runCmd(ctx, cmd); err != nil {
 		return errorCollector.AddCmdOutputToError(cmd, err)
 	}
 	return nil
@@ -484,20 +510,27 @@ func (r *Repo) AddTags(ctx context.Context, snapshotIDs []string, tags []string,
 func (r *Repo) GenericCommand(ctx context.Context, args []string, opts ...GenericOption) error {
 	cmd := r.commandWithContext(ctx, args, opts...)
 	r.handleOutput(cmd, withLogWriterFromContext(ctx))
-	if err := cmd.Run(); err != nil {
+	if err := r.runCmd(ctx, cmd); err != nil {
 		return err
 	}
 	return nil
 }
 
 type RetentionPolicy struct {
-	KeepLastN          int    // keep the last n snapshots.
-	KeepHourly         int    // keep the last n hourly snapshots.
-	KeepDaily          int    // keep the last n daily snapshots.
-	KeepWeekly         int    // keep the last n weekly snapshots.
-	KeepMonthly        int    // keep the last n monthly snapshots.
-	KeepYearly         int    // keep the last n yearly snapshots.
-	KeepWithinDuration string // keep snapshots within a duration e.g. 1y2m3d4h5m6s
+	KeepLastN          int      // keep the last n snapshots.
+	KeepHourly         int      // keep the last n hourly snapshots.
+	KeepDaily          int      // keep the last n daily snapshots.
+	KeepWeekly         int      // keep the last n weekly snapshots.
+	KeepMonthly        int      // keep the last n monthly snapshots.
+	KeepYearly         int      // keep the last n yearly snapshots.
+	KeepWithinDuration string   // keep snapshots within a duration e.g. 1y2m3d4h5m6s
+	KeepWithinHourly   string   // keep hourly snapshots within a duration.
+	KeepWithinDaily    string   // keep daily snapshots within a duration.
+	KeepWithinWeekly   string   // keep weekly snapshots within a duration.
+	KeepWithinMonthly  string   // keep monthly snapshots within a duration.
+	KeepWithinYearly   string   // keep yearly snapshots within a duration.
+	KeepTags           []string // keep snapshots with all the tags of any entry, each entry is a comma separated tag list.
+	GroupBy            string   // group snapshots by e.g. "host,paths", empty uses restic's default grouping.
 }
 
 func (r *RetentionPolicy) toForgetFlags() []string {
@@ -523,6 +556,27 @@ func (r *RetentionPolicy) toForgetFlags() []string {
 	if r.KeepWithinDuration != "" {
 		flags = append(flags, "--keep-within", r.KeepWithinDuration)
 	}
+	if r.KeepWithinHourly != "" {
+		flags = append(flags, "--keep-within-hourly", r.KeepWithinHourly)
+	}
+	if r.KeepWithinDaily != "" {
+		flags = append(flags, "--keep-within-daily", r.KeepWithinDaily)
+	}
+	if r.KeepWithinWeekly != "" {
+		flags = append(flags, "--keep-within-weekly", r.KeepWithinWeekly)
+	}
+	if r.KeepWithinMonthly != "" {
+		flags = append(flags, "--keep-within-monthly", r.KeepWithinMonthly)
+	}
+	if r.KeepWithinYearly != "" {
+		flags = append(flags, "--keep-within-yearly", r.KeepWithinYearly)
+	}
+	for _, tags := range r.KeepTags {
+		flags = append(flags, "--keep-tag", tags)
+	}
+	if r.GroupBy != "" {
+		flags = append(flags, "--group-by", r.GroupBy)
+	}
 	return flags
 }
 
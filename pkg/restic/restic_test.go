@@ -436,6 +436,45 @@ func TestResticForget(t *testing.T) {
 	}
 }
 
+func TestResticForgetDryRun(t *testing.T) {
+	t.Parallel()
+
+	repo := t.TempDir()
+	r := NewRepo(helpers.ResticBinary(t), repo, WithFlags("--no-cache"), WithEnv("RESTIC_PASSWORD=test"))
+	if err := r.Init(context.Background()); err != nil {
+		t.Fatalf("failed to init repo: %v", err)
+	}
+
+	testData := helpers.CreateTestData(t)
+
+	for i := 0; i < 3; i++ {
+		if _, err := r.Backup(context.Background(), []string{testData}, nil); err != nil {
+			t.Fatalf("failed to backup and create new snapshot: %v", err)
+		}
+	}
+
+	res, err := r.Forget(context.Background(), &RetentionPolicy{KeepLastN: 1}, WithFlags("--dry-run"))
+	if err != nil {
+		t.Fatalf("failed to preview forget: %v", err)
+	}
+
+	if len(res.Keep) != 1 || len(res.Remove) != 2 {
+		t.Errorf("wanted 1 kept and 2 removed, got: %d kept and %d removed", len(res.Keep), len(res.Remove))
+	}
+
+	if len(res.Reasons) != 1 || len(res.Reasons[0].Matches) == 0 {
+		t.Errorf("wanted a reason for the kept snapshot, got: %+v", res.Reasons)
+	}
+
+	snapshots, err := r.Snapshots(context.Background())
+	if err != nil {
+		t.Fatalf("failed to list snapshots: %v", err)
+	}
+	if len(snapshots) != 3 {
+		t.Errorf("wanted dry run to keep all 3 snapshots, got: %d", len(snapshots))
+	}
+}
+
 func TestForgetSnapshotId(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,81 @@
+package restic
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"slices"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const redacted = "[redacted]"
+
+var tracer = otel.Tracer("github.com/garethgeorge/backrest/pkg/restic")
+
+// runCmd runs the restic command in a span recording its redacted arguments, exit code and duration.
+func (r *Repo) runCmd(ctx context.Context, cmd *exec.Cmd) error {
+	args := r.resticArgs(cmd)
+	name := "restic"
+	if len(args) > 0 && args[0] != redacted && !strings.HasPrefix(args[0], "-") {
+		name += " " + args[0]
+	}
+	_, span := tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		attribute.StringSlice("restic.args", args),
+	))
+	defer span.End()
+
+	start := time.Now()
+	err := cmd.Run()
+	span.SetAttributes(attribute.Int64("restic.duration_ms", time.Since(start).Milliseconds()))
+
+	if cmd.ProcessState != nil {
+		span.SetAttributes(attribute.Int("process.exit.code", cmd.ProcessState.ExitCode()))
+	}
+	var exitErr *exec.ExitError
+	if err != nil {
+		// exit errors are reported by the exit code, their messages may contain output from restic.
+		if errors.As(err, &exitErr) {
+			span.SetStatus(codes.Error, exitErr.ProcessState.String())
+		} else {
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}
+	return err
+}
+
+// resticArgs returns the redacted arguments passed to restic by the command, skipping any prefix command.
+func (r *Repo) resticArgs(cmd *exec.Cmd) []string {
+	args := cmd.Args[1:]
+	if r.cmd != "" {
+		if idx := slices.Index(cmd.Args, r.cmd); idx != -1 {
+			args = cmd.Args[idx+1:]
+		}
+	}
+	return redactArgs(args)
+}
+
+// redactArgs keeps the subcommand and flag names of restic's arguments and redacts the values, which may contain paths,
+// tags, hostnames or credentials.
+func redactArgs(args []string) []string {
+	redactedArgs := make([]string, 0, len(args))
+	for i, arg := range args {
+		switch {
+		case i == 0 && !strings.HasPrefix(arg, "-"):
+			redactedArgs = append(redactedArgs, arg)
+		case strings.HasPrefix(arg, "-"):
+			if name, _, ok := strings.Cut(arg, "="); ok {
+				arg = name + "=" + redacted
+			}
+			redactedArgs = append(redactedArgs, arg)
+		default:
+			redactedArgs = append(redactedArgs, redacted)
+		}
+	}
+	return redactedArgs
+}
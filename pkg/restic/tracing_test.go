@@ -0,0 +1,86 @@
+package restic
+
+import (
+	"context"
+	"runtime"
+	"slices"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestRedactArgs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		args []string
+		want []string
+	}{
+		{
+			args: []string{"backup", "--json", "--tag", "plan:secret", "/home/user", "--exclude=/home/user/.cache", "-o", "s3.region=eu"},
+			want: []string{"backup", "--json", "--tag", redacted, redacted, "--exclude=" + redacted, "-o", redacted},
+		},
+		{
+			args: []string{"--no-lock", "snapshots"},
+			want: []string{"--no-lock", redacted},
+		},
+		{
+			args: nil,
+			want: []string{},
+		},
+	}
+
+	for _, tc := range tests {
+		if got := redactArgs(tc.args); !slices.Equal(got, tc.want) {
+			t.Errorf("redactArgs(%q) = %q, want %q", tc.args, got, tc.want)
+		}
+	}
+}
+
+func TestRunCmdSpan(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test uses a shell script in place of restic")
+	}
+
+	recorder := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	r := NewRepo("sh", "/tmp/repo")
+	ctx := context.Background()
+	cmd := r.commandWithContext(ctx, []string{"-c", "exit 3"})
+	if err := r.runCmd(ctx, cmd); err == nil {
+		t.Fatalf("want an error from the failing command")
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("want 1 span, got %d", len(spans))
+	}
+	span := spans[0]
+	if span.Name() != "restic" {
+		t.Errorf("want span name %q, got %q", "restic", span.Name())
+	}
+	if span.Status().Code != codes.Error {
+		t.Errorf("want error status, got %v", span.Status())
+	}
+
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	if got := attrs["process.exit.code"].AsInt64(); got != 3 {
+		t.Errorf("want exit code 3, got %d", got)
+	}
+	if got := attrs["restic.args"].AsStringSlice(); !slices.Equal(got, []string{"-c", redacted}) {
+		t.Errorf("want redacted args, got %q", got)
+	}
+	if _, ok := attrs["restic.duration_ms"]; !ok {
+		t.Errorf("want duration attribute")
+	}
+}
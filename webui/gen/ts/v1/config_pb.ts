@@ -13,7 +13,7 @@ import type { Message } from "@bufbuild/protobuf";
  * Describes the file v1/config.proto.
  */
 export const file_v1_config: GenFile = /*@__PURE__*/
-  fileDesc("Cg92MS9jb25maWcucHJvdG8SAnYxIqwBCgZDb25maWcSDQoFbW9kbm8YASABKAUSDwoHdmVyc2lvbhgGIAEoBRIQCghpbnN0YW5jZRgCIAEoCRIXCgVyZXBvcxgDIAMoCzIILnYxLlJlcG8SFwoFcGxhbnMYBCADKAsyCC52MS5QbGFuEhYKBGF1dGgYBSABKAsyCC52MS5BdXRoEiYKCW11bHRpaG9zdBgHIAEoCzINLnYxLk11bHRpaG9zdFIEc3luYyLwAwoJTXVsdGlob3N0EiAKCGlkZW50aXR5GAEgASgLMg4udjEuUHJpdmF0ZUtleRInCgtrbm93bl9ob3N0cxgCIAMoCzISLnYxLk11bHRpaG9zdC5QZWVyEi4KEmF1dGhvcml6ZWRfY2xpZW50cxgDIAMoCzISLnYxLk11bHRpaG9zdC5QZWVyGp0BCgRQZWVyEhMKC2luc3RhbmNlX2lkGAEgASgJEhQKBWtleWlkGAIgASgJUgVrZXlJZBIlCg5rZXlpZF92ZXJpZmllZBgDIAEoCFINa2V5SWRWZXJpZmllZBItCgtwZXJtaXNzaW9ucxgFIAMoCzIYLnYxLk11bHRpaG9zdC5QZXJtaXNzaW9uEhQKDGluc3RhbmNlX3VybBgEIAEoCRrHAQoKUGVybWlzc2lvbhIrCgR0eXBlGAEgASgOMh0udjEuTXVsdGlob3N0LlBlcm1pc3Npb24uVHlwZRIOCgZzY29wZXMYAiADKAkifAoEVHlwZRIWChJQRVJNSVNTSU9OX1VOS05PV04QABIeChpQRVJNSVNTSU9OX1JFQURfT1BFUkFUSU9OUxABEhoKFlBFUk1JU1NJT05fUkVBRF9DT05GSUcQAhIgChxQRVJNSVNTSU9OX1JFQURfV1JJVEVfQ09ORklHEAMimwIKBFJlcG8SCgoCaWQYASABKAkSCwoDdXJpGAIgASgJEgwKBGd1aWQYCyABKAkSEAoIcGFzc3dvcmQYAyABKAkSCwoDZW52GAQgAygJEg0KBWZsYWdzGAUgAygJEiUKDHBydW5lX3BvbGljeRgGIAEoCzIPLnYxLlBydW5lUG9saWN5EiUKDGNoZWNrX3BvbGljeRgJIAEoCzIPLnYxLkNoZWNrUG9saWN5EhcKBWhvb2tzGAcgAygLMggudjEuSG9vaxITCgthdXRvX3VubG9jaxgIIAEoCBIXCg9hdXRvX2luaXRpYWxpemUYDCABKAgSKQoOY29tbWFuZF9wcmVmaXgYCiABKAsyES52MS5Db21tYW5kUHJlZml4IoYCCgRQbGFuEgoKAmlkGAEgASgJEgwKBHJlcG8YAiABKAkSDQoFcGF0aHMYBCADKAkSEAoIZXhjbHVkZXMYBSADKAkSEQoJaWV4Y2x1ZGVzGAkgAygJEh4KCHNjaGVkdWxlGAwgASgLMgwudjEuU2NoZWR1bGUSJgoJcmV0ZW50aW9uGAcgASgLMhMudjEuUmV0ZW50aW9uUG9saWN5EhcKBWhvb2tzGAggAygLMggudjEuSG9vaxIiCgxiYWNrdXBfZmxhZ3MYCiADKAlSDGJhY2t1cF9mbGFncxIZChFza2lwX2lmX3VuY2hhbmdlZBgNIAEoCEoECAMQBEoECAYQB0oECAsQDCKKAgoNQ29tbWFuZFByZWZpeBIuCgdpb19uaWNlGAEgASgOMh0udjEuQ29tbWFuZFByZWZpeC5JT05pY2VMZXZlbBIwCghjcHVfbmljZRgCIAEoDjIeLnYxLkNvbW1hbmRQcmVmaXguQ1BVTmljZUxldmVsIlsKC0lPTmljZUxldmVsEg4KCklPX0RFRkFVTFQQABIWChJJT19CRVNUX0VGRk9SVF9MT1cQARIXChNJT19CRVNUX0VGRk9SVF9ISUdIEAISCwoHSU9fSURMRRADIjoKDENQVU5pY2VMZXZlbBIPCgtDUFVfREVGQVVMVBAAEgwKCENQVV9ISUdIEAESCwoHQ1BVX0xPVxACIpcCCg9SZXRlbnRpb25Qb2xpY3kSHAoScG9saWN5X2tlZXBfbGFzdF9uGAogASgFSAASRgoUcG9saWN5X3RpbWVfYnVja2V0ZWQYCyABKAsyJi52MS5SZXRlbnRpb25Qb2xpY3kuVGltZUJ1Y2tldGVkQ291bnRzSAASGQoPcG9saWN5X2tlZXBfYWxsGAwgASgISAAaeQoSVGltZUJ1Y2tldGVkQ291bnRzEg4KBmhvdXJseRgBIAEoBRINCgVkYWlseRgCIAEoBRIOCgZ3ZWVrbHkYAyABKAUSDwoHbW9udGhseRgEIAEoBRIOCgZ5ZWFybHkYBSABKAUSEwoLa2VlcF9sYXN0X24YBiABKAVCCAoGcG9saWN5ImMKC1BydW5lUG9saWN5Eh4KCHNjaGVkdWxlGAIgASgLMgwudjEuU2NoZWR1bGUSGAoQbWF4X3VudXNlZF9ieXRlcxgDIAEoAxIaChJtYXhfdW51c2VkX3BlcmNlbnQYBCABKAEicwoLQ2hlY2tQb2xpY3kSHgoIc2NoZWR1bGUYASABKAsyDC52MS5TY2hlZHVsZRIYCg5zdHJ1Y3R1cmVfb25seRhkIAEoCEgAEiIKGHJlYWRfZGF0YV9zdWJzZXRfcGVyY2VudBhlIAEoAUgAQgYKBG1vZGUi6wEKCFNjaGVkdWxlEhIKCGRpc2FibGVkGAEgASgISAASDgoEY3JvbhgCIAEoCUgAEhoKEG1heEZyZXF1ZW5jeURheXMYAyABKAVIABIbChFtYXhGcmVxdWVuY3lIb3VycxgEIAEoBUgAEiEKBWNsb2NrGAUgASgOMhIudjEuU2NoZWR1bGUuQ2xvY2siUwoFQ2xvY2sSEQoNQ0xPQ0tfREVGQVVMVBAAEg8KC0NMT0NLX0xPQ0FMEAESDQoJQ0xPQ0tfVVRDEAISFwoTQ0xPQ0tfTEFTVF9SVU5fVElNRRADQgoKCHNjaGVkdWxlIoANCgRIb29rEiYKCmNvbmRpdGlvbnMYASADKA4yEi52MS5Ib29rLkNvbmRpdGlvbhIiCghvbl9lcnJvchgCIAEoDjIQLnYxLkhvb2suT25FcnJvchIqCg5hY3Rpb25fY29tbWFuZBhkIAEoCzIQLnYxLkhvb2suQ29tbWFuZEgAEioKDmFjdGlvbl93ZWJob29rGGUgASgLMhAudjEuSG9vay5XZWJob29rSAASKgoOYWN0aW9uX2Rpc2NvcmQYZiABKAsyEC52MS5Ib29rLkRpc2NvcmRIABIoCg1hY3Rpb25fZ290aWZ5GGcgASgLMg8udjEuSG9vay5Hb3RpZnlIABImCgxhY3Rpb25fc2xhY2sYaCABKAsyDi52MS5Ib29rLlNsYWNrSAASLAoPYWN0aW9uX3Nob3V0cnJyGGkgASgLMhEudjEuSG9vay5TaG91dHJyckgAEjQKE2FjdGlvbl9oZWFsdGhjaGVja3MYaiABKAsyFS52MS5Ib29rLkhlYWx0aGNoZWNrc0gAEiwKD2FjdGlvbl90ZWxlZ3JhbRhrIAEoCzIRLnYxLkhvb2suVGVsZWdyYW1IABoaCgdDb21tYW5kEg8KB2NvbW1hbmQYASABKAkagwEKB1dlYmhvb2sSEwoLd2ViaG9va191cmwYASABKAkSJwoGbWV0aG9kGAIgASgOMhcudjEuSG9vay5XZWJob29rLk1ldGhvZBIQCgh0ZW1wbGF0ZRhkIAEoCSIoCgZNZXRob2QSCwoHVU5LTk9XThAAEgcKA0dFVBABEggKBFBPU1QQAhowCgdEaXNjb3JkEhMKC3dlYmhvb2tfdXJsGAEgASgJEhAKCHRlbXBsYXRlGAIgASgJGmUKBkdvdGlmeRIQCghiYXNlX3VybBgBIAEoCRINCgV0b2tlbhgDIAEoCRIQCgh0ZW1wbGF0ZRhkIAEoCRIWCg50aXRsZV90ZW1wbGF0ZRhlIAEoCRIQCghwcmlvcml0eRhmIAEoBRouCgVTbGFjaxITCgt3ZWJob29rX3VybBgBIAEoCRIQCgh0ZW1wbGF0ZRgCIAEoCRoyCghTaG91dHJychIUCgxzaG91dHJycl91cmwYASABKAkSEAoIdGVtcGxhdGUYAiABKAkaNQoMSGVhbHRoY2hlY2tzEhMKC3dlYmhvb2tfdXJsGAEgASgJEhAKCHRlbXBsYXRlGAIgASgJGkAKCFRlbGVncmFtEhEKCWJvdF90b2tlbhgBIAEoCRIPCgdjaGF0X2lkGAIgASgJEhAKCHRlbXBsYXRlGAMgASgJIvUDCglDb25kaXRpb24SFQoRQ09ORElUSU9OX1VOS05PV04QABIXChNDT05ESVRJT05fQU5ZX0VSUk9SEAESHAoYQ09ORElUSU9OX1NOQVBTSE9UX1NUQVJUEAISGgoWQ09ORElUSU9OX1NOQVBTSE9UX0VORBADEhwKGENPTkRJVElPTl9TTkFQU0hPVF9FUlJPUhAEEh4KGkNPTkRJVElPTl9TTkFQU0hPVF9XQVJOSU5HEAUSHgoaQ09ORElUSU9OX1NOQVBTSE9UX1NVQ0NFU1MQBhIeChpDT05ESVRJT05fU05BUFNIT1RfU0tJUFBFRBAHEhkKFUNPTkRJVElPTl9QUlVORV9TVEFSVBBkEhkKFUNPTkRJVElPTl9QUlVORV9FUlJPUhBlEhsKF0NPTkRJVElPTl9QUlVORV9TVUNDRVNTEGYSGgoVQ09ORElUSU9OX0NIRUNLX1NUQVJUEMgBEhoKFUNPTkRJVElPTl9DSEVDS19FUlJPUhDJARIcChdDT05ESVRJT05fQ0hFQ0tfU1VDQ0VTUxDKARIbChZDT05ESVRJT05fRk9SR0VUX1NUQVJUEKwCEhsKFkNPTkRJVElPTl9GT1JHRVRfRVJST1IQrQISHQoYQ09ORElUSU9OX0ZPUkdFVF9TVUNDRVNTEK4CIqkBCgdPbkVycm9yEhMKD09OX0VSUk9SX0lHTk9SRRAAEhMKD09OX0VSUk9SX0NBTkNFTBABEhIKDk9OX0VSUk9SX0ZBVEFMEAISGgoWT05fRVJST1JfUkVUUllfMU1JTlVURRBkEhwKGE9OX0VSUk9SX1JFVFJZXzEwTUlOVVRFUxBlEiYKIk9OX0VSUk9SX1JFVFJZX0VYUE9ORU5USUFMX0JBQ0tPRkYQZ0IICgZhY3Rpb24iMQoEQXV0aBIQCghkaXNhYmxlZBgBIAEoCBIXCgV1c2VycxgCIAMoCzIILnYxLlVzZXIiOwoEVXNlchIMCgRuYW1lGAEgASgJEhkKD3Bhc3N3b3JkX2JjcnlwdBgCIAEoCUgAQgoKCHBhc3N3b3JkQixaKmdpdGh1Yi5jb20vZ2FyZXRoZ2VvcmdlL2JhY2tyZXN0L2dlbi9nby92MWIGcHJvdG8z", [file_google_protobuf_empty, file_v1_crypto]);
+  fileDesc("Cg92MS9jb25maWcucHJvdG8SAnYxIq4CCgZDb25maWcSDQoFbW9kbm8YASABKAUSDwoHdmVyc2lvbhgGIAEoBRIQCghpbnN0YW5jZRgCIAEoCRIXCgVyZXBvcxgDIAMoCzIILnYxLlJlcG8SFwoFcGxhbnMYBCADKAsyCC52MS5QbGFuEhYKBGF1dGgYBSABKAsyCC52MS5BdXRoEiYKCW11bHRpaG9zdBgHIAEoCzINLnYxLk11bHRpaG9zdFIEc3luYxIkCgxnbG9iYWxfaG9va3MYCCADKAsyDi52MS5HbG9iYWxIb29rEjUKEW9wZXJhdGlvbl9oaXN0b3J5GAkgASgLMhoudjEuT3BlcmF0aW9uSGlzdG9yeVBvbGljeRIjCgtzZWxmX2JhY2t1cBgKIAEoCzIOLnYxLlNlbGZCYWNrdXAiTQoKU2VsZkJhY2t1cBIMCgRyZXBvGAEgASgJEh4KCHNjaGVkdWxlGAIgASgLMgwudjEuU2NoZWR1bGUSEQoJa2VlcF9sYXN0GAMgASgFIskCChZPcGVyYXRpb25IaXN0b3J5UG9saWN5Ei4KBXJ1bGVzGAEgAygLMh8udjEuT3BlcmF0aW9uSGlzdG9yeVBvbGljeS5SdWxlEjkKCmNvbXBhY3Rpb24YAiABKAsyJS52MS5PcGVyYXRpb25IaXN0b3J5UG9saWN5LkNvbXBhY3Rpb24aYgoEUnVsZRIPCgdvcF90eXBlGAEgASgJEg8KB3BsYW5faWQYAiABKAkSFAoMbWF4X2FnZV9kYXlzGAMgASgFEhAKCGtlZXBfbWluGAQgASgFEhAKCGtlZXBfbWF4GAUgASgFGmAKCkNvbXBhY3Rpb24SDwoHZW5hYmxlZBgBIAEoCBISCgphZnRlcl9kYXlzGAIgASgFEhAKCG9wX3R5cGVzGAMgAygJEhsKE3JvbGx1cF9tYXhfYWdlX2RheXMYBCABKAUiNAoKR2xvYmFsSG9vaxIWCgRob29rGAEgASgLMggudjEuSG9vaxIOCgZzY29wZXMYAiADKAki8AMKCU11bHRpaG9zdBIgCghpZGVudGl0eRgBIAEoCzIOLnYxLlByaXZhdGVLZXkSJwoLa25vd25faG9zdHMYAiADKAsyEi52MS5NdWx0aWhvc3QuUGVlchIuChJhdXRob3JpemVkX2NsaWVudHMYAyADKAsyEi52MS5NdWx0aWhvc3QuUGVlchqdAQoEUGVlchITCgtpbnN0YW5jZV9pZBgBIAEoCRIUCgVrZXlpZBgCIAEoCVIFa2V5SWQSJQoOa2V5aWRfdmVyaWZpZWQYAyABKAhSDWtleUlkVmVyaWZpZWQSLQoLcGVybWlzc2lvbnMYBSADKAsyGC52MS5NdWx0aWhvc3QuUGVybWlzc2lvbhIUCgxpbnN0YW5jZV91cmwYBCABKAkaxwEKClBlcm1pc3Npb24SKwoEdHlwZRgBIAEoDjIdLnYxLk11bHRpaG9zdC5QZXJtaXNzaW9uLlR5cGUSDgoGc2NvcGVzGAIgAygJInwKBFR5cGUSFgoSUEVSTUlTU0lPTl9VTktOT1dOEAASHgoaUEVSTUlTU0lPTl9SRUFEX09QRVJBVElPTlMQARIaChZQRVJNSVNTSU9OX1JFQURfQ09ORklHEAISIAocUEVSTUlTU0lPTl9SRUFEX1dSSVRFX0NPTkZJRxADIs4CCgRSZXBvEgoKAmlkGAEgASgJEgsKA3VyaRgCIAEoCRIMCgRndWlkGAsgASgJEhAKCHBhc3N3b3JkGAMgASgJEgsKA2VudhgEIAMoCRINCgVmbGFncxgFIAMoCRIlCgxwcnVuZV9wb2xpY3kYBiABKAsyDy52MS5QcnVuZVBvbGljeRIlCgxjaGVja19wb2xpY3kYCSABKAsyDy52MS5DaGVja1BvbGljeRIXCgVob29rcxgHIAMoCzIILnYxLkhvb2sSEwoLYXV0b191bmxvY2sYCCABKAgSFwoPYXV0b19pbml0aWFsaXplGAwgASgIEikKDmNvbW1hbmRfcHJlZml4GAogASgLMhEudjEuQ29tbWFuZFByZWZpeBIxChByZXRlbnRpb25fcG9saWN5GA0gASgLMhcudjEuUmVwb1JldGVudGlvblBvbGljeSKhAgoEUGxhbhIKCgJpZBgBIAEoCRIMCgRyZXBvGAIgASgJEg0KBXBhdGhzGAQgAygJEhAKCGV4Y2x1ZGVzGAUgAygJEhEKCWlleGNsdWRlcxgJIAMoCRIeCghzY2hlZHVsZRgMIAEoCzIMLnYxLlNjaGVkdWxlEiYKCXJldGVudGlvbhgHIAEoCzITLnYxLlJldGVudGlvblBvbGljeRIXCgVob29rcxgIIAMoCzIILnYxLkhvb2sSIgoMYmFja3VwX2ZsYWdzGAogAygJUgxiYWNrdXBfZmxhZ3MSGQoRc2tpcF9pZl91bmNoYW5nZWQYDSABKAgSGQoRc3RhbGVfYWZ0ZXJfaG91cnMYDiABKAVKBAgDEARKBAgGEAdKBAgLEAwiigIKDUNvbW1hbmRQcmVmaXgSLgoHaW9fbmljZRgBIAEoDjIdLnYxLkNvbW1hbmRQcmVmaXguSU9OaWNlTGV2ZWwSMAoIY3B1X25pY2UYAiABKA4yHi52MS5Db21tYW5kUHJlZml4LkNQVU5pY2VMZXZlbCJbCgtJT05pY2VMZXZlbBIOCgpJT19ERUZBVUxUEAASFgoSSU9fQkVTVF9FRkZPUlRfTE9XEAESFwoTSU9fQkVTVF9FRkZPUlRfSElHSBACEgsKB0lPX0lETEUQAyI6CgxDUFVOaWNlTGV2ZWwSDwoLQ1BVX0RFRkFVTFQQABIMCghDUFVfSElHSBABEgsKB0NQVV9MT1cQAiKCBAoPUmV0ZW50aW9uUG9saWN5EhwKEnBvbGljeV9rZWVwX2xhc3RfbhgKIAEoBUgAEkYKFHBvbGljeV90aW1lX2J1Y2tldGVkGAsgASgLMiYudjEuUmV0ZW50aW9uUG9saWN5LlRpbWVCdWNrZXRlZENvdW50c0gAEhkKD3BvbGljeV9rZWVwX2FsbBgMIAEoCEgAEhMKC2tlZXBfd2l0aGluGA0gASgJEkYKE2tlZXBfd2l0aGluX2J1Y2tldHMYDiABKAsyKS52MS5SZXRlbnRpb25Qb2xpY3kuVGltZUJ1Y2tldGVkRHVyYXRpb25zEhEKCWtlZXBfdGFncxgPIAMoCRIQCghncm91cF9ieRgQIAEoCRp5ChJUaW1lQnVja2V0ZWRDb3VudHMSDgoGaG91cmx5GAEgASgFEg0KBWRhaWx5GAIgASgFEg4KBndlZWtseRgDIAEoBRIPCgdtb250aGx5GAQgASgFEg4KBnllYXJseRgFIAEoBRITCgtrZWVwX2xhc3RfbhgGIAEoBRpnChVUaW1lQnVja2V0ZWREdXJhdGlvbnMSDgoGaG91cmx5GAEgASgJEg0KBWRhaWx5GAIgASgJEg4KBndlZWtseRgDIAEoCRIPCgdtb250aGx5GAQgASgJEg4KBnllYXJseRgFIAEoCUIICgZwb2xpY3kikAEKE1JlcG9SZXRlbnRpb25Qb2xpY3kSHgoIc2NoZWR1bGUYASABKAsyDC52MS5TY2hlZHVsZRImCglyZXRlbnRpb24YAiABKAsyEy52MS5SZXRlbnRpb25Qb2xpY3kSDAoEdGFncxgDIAMoCRINCgVob3N0cxgEIAMoCRIUCgxvbmx5X3Vub3duZWQYBSABKAgiYwoLUHJ1bmVQb2xpY3kSHgoIc2NoZWR1bGUYAiABKAsyDC52MS5TY2hlZHVsZRIYChBtYXhfdW51c2VkX2J5dGVzGAMgASgDEhoKEm1heF91bnVzZWRfcGVyY2VudBgEIAEoASJzCgtDaGVja1BvbGljeRIeCghzY2hlZHVsZRgBIAEoCzIMLnYxLlNjaGVkdWxlEhgKDnN0cnVjdHVyZV9vbmx5GGQgASgISAASIgoYcmVhZF9kYXRhX3N1YnNldF9wZXJjZW50GGUgASgBSABCBgoEbW9kZSLrAQoIU2NoZWR1bGUSEgoIZGlzYWJsZWQYASABKAhIABIOCgRjcm9uGAIgASgJSAASGgoQbWF4RnJlcXVlbmN5RGF5cxgDIAEoBUgAEhsKEW1heEZyZXF1ZW5jeUhvdXJzGAQgASgFSAASIQoFY2xvY2sYBSABKA4yEi52MS5TY2hlZHVsZS5DbG9jayJTCgVDbG9jaxIRCg1DTE9DS19ERUZBVUxUEAASDwoLQ0xPQ0tfTE9DQUwQARINCglDTE9DS19VVEMQAhIXChNDTE9DS19MQVNUX1JVTl9USU1FEANCCgoIc2NoZWR1bGUi4BoKBEhvb2sSJgoKY29uZGl0aW9ucxgBIAMoDjISLnYxLkhvb2suQ29uZGl0aW9uEiIKCG9uX2Vycm9yGAIgASgOMhAudjEuSG9vay5PbkVycm9yEh8KBmRpZ2VzdBgDIAEoCzIPLnYxLkhvb2suRGlnZXN0EhcKD3RpbWVvdXRfc2Vjb25kcxgEIAEoBRIQCghwYXJhbGxlbBgFIAEoCBIqCg5hY3Rpb25fY29tbWFuZBhkIAEoCzIQLnYxLkhvb2suQ29tbWFuZEgAEioKDmFjdGlvbl93ZWJob29rGGUgASgLMhAudjEuSG9vay5XZWJob29rSAASKgoOYWN0aW9uX2Rpc2NvcmQYZiABKAsyEC52MS5Ib29rLkRpc2NvcmRIABIoCg1hY3Rpb25fZ290aWZ5GGcgASgLMg8udjEuSG9vay5Hb3RpZnlIABImCgxhY3Rpb25fc2xhY2sYaCABKAsyDi52MS5Ib29rLlNsYWNrSAASLAoPYWN0aW9uX3Nob3V0cnJyGGkgASgLMhEudjEuSG9vay5TaG91dHJyckgAEjQKE2FjdGlvbl9oZWFsdGhjaGVja3MYaiABKAsyFS52MS5Ib29rLkhlYWx0aGNoZWNrc0gAEiwKD2FjdGlvbl90ZWxlZ3JhbRhrIAEoCzIRLnYxLkhvb2suVGVsZWdyYW1IABImCgxhY3Rpb25fZW1haWwYbCABKAsyDi52MS5Ib29rLkVtYWlsSAASJAoLYWN0aW9uX250ZnkYbSABKAsyDS52MS5Ib29rLk50ZnlIABIsCg9hY3Rpb25fcHVzaG92ZXIYbiABKAsyES52MS5Ib29rLlB1c2hvdmVySAASKAoNYWN0aW9uX21hdHJpeBhvIAEoCzIPLnYxLkhvb2suTWF0cml4SAAaKAoGRGlnZXN0Eh4KCHNjaGVkdWxlGAEgASgLMgwudjEuU2NoZWR1bGUaZAoHQ29tbWFuZBIPCgdjb21tYW5kGAEgASgJEhMKC3dvcmtpbmdfZGlyGAIgASgJEgsKA2VudhgDIAMoCRIMCgR1c2VyGAQgASgJEhgKEG1heF9vdXRwdXRfYnl0ZXMYBSABKAMa6gIKB1dlYmhvb2sSEwoLd2ViaG9va191cmwYASABKAkSJwoGbWV0aG9kGAIgASgOMhcudjEuSG9vay5XZWJob29rLk1ldGhvZBIPCgdoZWFkZXJzGAMgAygJEhQKDGNvbnRlbnRfdHlwZRgEIAEoCRIUCgxiZWFyZXJfdG9rZW4YBSABKAkSGwoTYmFzaWNfYXV0aF91c2VybmFtZRgGIAEoCRIbChNiYXNpY19hdXRoX3Bhc3N3b3JkGAcgASgJEhMKC3Rsc19jYV9jZXJ0GAggASgJEhcKD3Rsc19za2lwX3ZlcmlmeRgJIAEoCBIXCg90aW1lb3V0X3NlY29uZHMYCiABKAUSEwoLbWF4X3JldHJpZXMYCyABKAUSEAoIdGVtcGxhdGUYZCABKAkiPAoGTWV0aG9kEgsKB1VOS05PV04QABIHCgNHRVQQARIICgRQT1NUEAISBwoDUFVUEAMSCQoFUEFUQ0gQBBowCgdEaXNjb3JkEhMKC3dlYmhvb2tfdXJsGAEgASgJEhAKCHRlbXBsYXRlGAIgASgJGmUKBkdvdGlmeRIQCghiYXNlX3VybBgBIAEoCRINCgV0b2tlbhgDIAEoCRIQCgh0ZW1wbGF0ZRhkIAEoCRIWCg50aXRsZV90ZW1wbGF0ZRhlIAEoCRIQCghwcmlvcml0eRhmIAEoBRouCgVTbGFjaxITCgt3ZWJob29rX3VybBgBIAEoCRIQCgh0ZW1wbGF0ZRgCIAEoCRoyCghTaG91dHJychIUCgxzaG91dHJycl91cmwYASABKAkSEAoIdGVtcGxhdGUYAiABKAkaNQoMSGVhbHRoY2hlY2tzEhMKC3dlYmhvb2tfdXJsGAEgASgJEhAKCHRlbXBsYXRlGAIgASgJGkAKCFRlbGVncmFtEhEKCWJvdF90b2tlbhgBIAEoCRIPCgdjaGF0X2lkGAIgASgJEhAKCHRlbXBsYXRlGAMgASgJGsECCgVFbWFpbBIMCgRob3N0GAEgASgJEgwKBHBvcnQYAiABKAUSKQoIc2VjdXJpdHkYAyABKA4yFy52MS5Ib29rLkVtYWlsLlNlY3VyaXR5EhcKD3Rsc19za2lwX3ZlcmlmeRgEIAEoCBIQCgh1c2VybmFtZRgFIAEoCRIQCghwYXNzd29yZBgGIAEoCRIMCgRmcm9tGAcgASgJEgoKAnRvGAggAygJEgwKBGh0bWwYCSABKAgSGAoQYXR0YWNoX2xvZ19ieXRlcxgKIAEoBRIQCgh0ZW1wbGF0ZRhkIAEoCRIYChBzdWJqZWN0X3RlbXBsYXRlGGUgASgJIkYKCFNlY3VyaXR5EhUKEVNFQ1VSSVRZX1NUQVJUVExTEAASEAoMU0VDVVJJVFlfVExTEAESEQoNU0VDVVJJVFlfTk9ORRACGpMBCgROdGZ5EhAKCGJhc2VfdXJsGAEgASgJEg0KBXRvcGljGAIgASgJEg0KBXRva2VuGAMgASgJEhAKCHByaW9yaXR5GAQgASgFEgwKBHRhZ3MYBSADKAkSEQoJY2xpY2tfdXJsGAYgASgJEhAKCHRlbXBsYXRlGGQgASgJEhYKDnRpdGxlX3RlbXBsYXRlGGUgASgJGpMBCghQdXNob3ZlchINCgV0b2tlbhgBIAEoCRIQCgh1c2VyX2tleRgCIAEoCRIOCgZkZXZpY2UYAyABKAkSEAoIcHJpb3JpdHkYBCABKAUSDQoFc291bmQYBSABKAkSCwoDdXJsGAYgASgJEhAKCHRlbXBsYXRlGGQgASgJEhYKDnRpdGxlX3RlbXBsYXRlGGUgASgJGlkKBk1hdHJpeBIWCg5ob21lc2VydmVyX3VybBgBIAEoCRIPCgdyb29tX2lkGAIgASgJEhQKDGFjY2Vzc190b2tlbhgDIAEoCRIQCgh0ZW1wbGF0ZRhkIAEoCSK9BwoJQ29uZGl0aW9uEhUKEUNPTkRJVElPTl9VTktOT1dOEAASFwoTQ09ORElUSU9OX0FOWV9FUlJPUhABEhwKGENPTkRJVElPTl9TTkFQU0hPVF9TVEFSVBACEhoKFkNPTkRJVElPTl9TTkFQU0hPVF9FTkQQAxIcChhDT05ESVRJT05fU05BUFNIT1RfRVJST1IQBBIeChpDT05ESVRJT05fU05BUFNIT1RfV0FSTklORxAFEh4KGkNPTkRJVElPTl9TTkFQU0hPVF9TVUNDRVNTEAYSHgoaQ09ORElUSU9OX1NOQVBTSE9UX1NLSVBQRUQQBxIZChVDT05ESVRJT05fUFJVTkVfU1RBUlQQZBIZChVDT05ESVRJT05fUFJVTkVfRVJST1IQZRIbChdDT05ESVRJT05fUFJVTkVfU1VDQ0VTUxBmEhoKFUNPTkRJVElPTl9DSEVDS19TVEFSVBDIARIaChVDT05ESVRJT05fQ0hFQ0tfRVJST1IQyQESHAoXQ09ORElUSU9OX0NIRUNLX1NVQ0NFU1MQygESGwoWQ09ORElUSU9OX0ZPUkdFVF9TVEFSVBCsAhIbChZDT05ESVRJT05fRk9SR0VUX0VSUk9SEK0CEh0KGENPTkRJVElPTl9GT1JHRVRfU1VDQ0VTUxCuAhIZChRDT05ESVRJT05fUExBTl9TVEFMRRCQAxIdChhDT05ESVRJT05fUExBTl9SRUNPVkVSRUQQkQMSHAoXQ09ORElUSU9OX1JFU1RPUkVfU1RBUlQQ9AMSHAoXQ09ORElUSU9OX1JFU1RPUkVfRVJST1IQ9QMSHgoZQ09ORElUSU9OX1JFU1RPUkVfU1VDQ0VTUxD2AxIaChVDT05ESVRJT05fU1RBVFNfU1RBUlQQ2AQSGgoVQ09ORElUSU9OX1NUQVRTX0VSUk9SENkEEhwKF0NPTkRJVElPTl9TVEFUU19TVUNDRVNTENoEEiQKH0NPTkRJVElPTl9JTkRFWF9TTkFQU0hPVFNfU1RBUlQQvAUSJAofQ09ORElUSU9OX0lOREVYX1NOQVBTSE9UU19FUlJPUhC9BRImCiFDT05ESVRJT05fSU5ERVhfU05BUFNIT1RTX1NVQ0NFU1MQvgUSIAobQ09ORElUSU9OX1JVTl9DT01NQU5EX1NUQVJUEKAGEiAKG0NPTkRJVElPTl9SVU5fQ09NTUFORF9FUlJPUhChBhIiCh1DT05ESVRJT05fUlVOX0NPTU1BTkRfU1VDQ0VTUxCiBiKpAQoHT25FcnJvchITCg9PTl9FUlJPUl9JR05PUkUQABITCg9PTl9FUlJPUl9DQU5DRUwQARISCg5PTl9FUlJPUl9GQVRBTBACEhoKFk9OX0VSUk9SX1JFVFJZXzFNSU5VVEUQZBIcChhPTl9FUlJPUl9SRVRSWV8xME1JTlVURVMQZRImCiJPTl9FUlJPUl9SRVRSWV9FWFBPTkVOVElBTF9CQUNLT0ZGEGdCCAoGYWN0aW9uIjEKBEF1dGgSEAoIZGlzYWJsZWQYASABKAgSFwoFdXNlcnMYAiADKAsyCC52MS5Vc2VyIjsKBFVzZXISDAoEbmFtZRgBIAEoCRIZCg9wYXNzd29yZF9iY3J5cHQYAiABKAlIAEIKCghwYXNzd29yZEIsWipnaXRodWIuY29tL2dhcmV0aGdlb3JnZS9iYWNrcmVzdC9nZW4vZ28vdjFiBnByb3RvMw", [file_google_protobuf_empty, file_v1_crypto]);
 
 /**
  * Config is the top level config object for restic UI.
@@ -62,6 +62,27 @@ export type Config = Message<"v1.Config"> & {
    * @generated from field: v1.Multihost multihost = 7 [json_name = "sync"];
    */
   multihost?: Multihost;
+
+  /**
+   * hooks run for events of every repo and plan selected by their scopes.
+   *
+   * @generated from field: repeated v1.GlobalHook global_hooks = 8;
+   */
+  globalHooks: GlobalHook[];
+
+  /**
+   * how long operations are kept in the operation history, built in defaults apply if unset.
+   *
+   * @generated from field: v1.OperationHistoryPolicy operation_history = 9;
+   */
+  operationHistory?: OperationHistoryPolicy;
+
+  /**
+   * backs up backrest's own state to a repo, disabled if unset.
+   *
+   * @generated from field: v1.SelfBackup self_backup = 10;
+   */
+  selfBackup?: SelfBackup;
 };
 
 /**
@@ -71,6 +92,180 @@ export type Config = Message<"v1.Config"> & {
 export const ConfigSchema: GenMessage<Config> = /*@__PURE__*/
   messageDesc(file_v1_config, 0);
 
+/**
+ * SelfBackup periodically snapshots backrest's config, operation log and key value store into a repo under a reserved tag.
+ * A new install is bootstrapped from the latest snapshot with the -restore-self-backup flag. The config includes repo passwords.
+ *
+ * @generated from message v1.SelfBackup
+ */
+export type SelfBackup = Message<"v1.SelfBackup"> & {
+  /**
+   * ID of the repo the state is backed up to.
+   *
+   * @generated from field: string repo = 1;
+   */
+  repo: string;
+
+  /**
+   * schedule for the self backup, never runs if unset.
+   *
+   * @generated from field: v1.Schedule schedule = 2;
+   */
+  schedule?: Schedule;
+
+  /**
+   * number of self backup snapshots of this instance kept in the repo, defaults to 30 if 0.
+   *
+   * @generated from field: int32 keep_last = 3;
+   */
+  keepLast: number;
+};
+
+/**
+ * Describes the message v1.SelfBackup.
+ * Use `create(SelfBackupSchema)` to create a new message.
+ */
+export const SelfBackupSchema: GenMessage<SelfBackup> = /*@__PURE__*/
+  messageDesc(file_v1_config, 1);
+
+/**
+ * OperationHistoryPolicy controls the garbage collection of the operation history. Snapshots in repos are unaffected.
+ *
+ * @generated from message v1.OperationHistoryPolicy
+ */
+export type OperationHistoryPolicy = Message<"v1.OperationHistoryPolicy"> & {
+  /**
+   * rules overriding the built in retention of operations, the most specific rule matching an operation applies (plan and type, then plan, then type).
+   *
+   * @generated from field: repeated v1.OperationHistoryPolicy.Rule rules = 1;
+   */
+  rules: OperationHistoryPolicy_Rule[];
+
+  /**
+   * @generated from field: v1.OperationHistoryPolicy.Compaction compaction = 2;
+   */
+  compaction?: OperationHistoryPolicy_Compaction;
+};
+
+/**
+ * Describes the message v1.OperationHistoryPolicy.
+ * Use `create(OperationHistoryPolicySchema)` to create a new message.
+ */
+export const OperationHistoryPolicySchema: GenMessage<OperationHistoryPolicy> = /*@__PURE__*/
+  messageDesc(file_v1_config, 2);
+
+/**
+ * @generated from message v1.OperationHistoryPolicy.Rule
+ */
+export type OperationHistoryPolicy_Rule = Message<"v1.OperationHistoryPolicy.Rule"> & {
+  /**
+   * type of operation the rule applies to e.g. "backup", "check", "prune". Applies to all types if empty.
+   *
+   * @generated from field: string op_type = 1;
+   */
+  opType: string;
+
+  /**
+   * plan the rule applies to. Applies to all plans if empty.
+   *
+   * @generated from field: string plan_id = 2;
+   */
+  planId: string;
+
+  /**
+   * operations older than this are removed once more than keep_min are kept. The built in setting for the type applies if 0.
+   *
+   * @generated from field: int32 max_age_days = 3;
+   */
+  maxAgeDays: number;
+
+  /**
+   * number of operations kept regardless of age. The built in setting for the type applies if 0.
+   *
+   * @generated from field: int32 keep_min = 4;
+   */
+  keepMin: number;
+
+  /**
+   * number of operations kept regardless of age, older operations are removed. The built in setting for the type applies if 0.
+   *
+   * @generated from field: int32 keep_max = 5;
+   */
+  keepMax: number;
+};
+
+/**
+ * Describes the message v1.OperationHistoryPolicy.Rule.
+ * Use `create(OperationHistoryPolicy_RuleSchema)` to create a new message.
+ */
+export const OperationHistoryPolicy_RuleSchema: GenMessage<OperationHistoryPolicy_Rule> = /*@__PURE__*/
+  messageDesc(file_v1_config, 2, 0);
+
+/**
+ * Compaction collapses old successful operations into one rollup operation per plan and day instead of removing them, preserving long term history.
+ * Successful operations of the compacted types are exempt from the rules above, operations whose snapshot is still in the repo aren't compacted.
+ *
+ * @generated from message v1.OperationHistoryPolicy.Compaction
+ */
+export type OperationHistoryPolicy_Compaction = Message<"v1.OperationHistoryPolicy.Compaction"> & {
+  /**
+   * @generated from field: bool enabled = 1;
+   */
+  enabled: boolean;
+
+  /**
+   * age at which operations are compacted, defaults to 90 days if 0.
+   *
+   * @generated from field: int32 after_days = 2;
+   */
+  afterDays: number;
+
+  /**
+   * types of operations to compact, defaults to ["backup"] if empty.
+   *
+   * @generated from field: repeated string op_types = 3;
+   */
+  opTypes: string[];
+
+  /**
+   * rollups older than this are removed, rollups are kept indefinitely if 0.
+   *
+   * @generated from field: int32 rollup_max_age_days = 4;
+   */
+  rollupMaxAgeDays: number;
+};
+
+/**
+ * Describes the message v1.OperationHistoryPolicy.Compaction.
+ * Use `create(OperationHistoryPolicy_CompactionSchema)` to create a new message.
+ */
+export const OperationHistoryPolicy_CompactionSchema: GenMessage<OperationHistoryPolicy_Compaction> = /*@__PURE__*/
+  messageDesc(file_v1_config, 2, 1);
+
+/**
+ * @generated from message v1.GlobalHook
+ */
+export type GlobalHook = Message<"v1.GlobalHook"> & {
+  /**
+   * @generated from field: v1.Hook hook = 1;
+   */
+  hook?: Hook;
+
+  /**
+   * repos and plans the hook applies to in scope set syntax e.g. "*", "repo:<id>", "plan:<id>", "!plan:<id>". Applies to all if empty.
+   *
+   * @generated from field: repeated string scopes = 2;
+   */
+  scopes: string[];
+};
+
+/**
+ * Describes the message v1.GlobalHook.
+ * Use `create(GlobalHookSchema)` to create a new message.
+ */
+export const GlobalHookSchema: GenMessage<GlobalHook> = /*@__PURE__*/
+  messageDesc(file_v1_config, 3);
+
 /**
  * @generated from message v1.Multihost
  */
@@ -96,7 +291,7 @@ export type Multihost = Message<"v1.Multihost"> & {
  * Use `create(MultihostSchema)` to create a new message.
  */
 export const MultihostSchema: GenMessage<Multihost> = /*@__PURE__*/
-  messageDesc(file_v1_config, 1);
+  messageDesc(file_v1_config, 4);
 
 /**
  * @generated from message v1.Multihost.Peer
@@ -145,7 +340,7 @@ export type Multihost_Peer = Message<"v1.Multihost.Peer"> & {
  * Use `create(Multihost_PeerSchema)` to create a new message.
  */
 export const Multihost_PeerSchema: GenMessage<Multihost_Peer> = /*@__PURE__*/
-  messageDesc(file_v1_config, 1, 0);
+  messageDesc(file_v1_config, 4, 0);
 
 /**
  * @generated from message v1.Multihost.Permission
@@ -171,7 +366,7 @@ export type Multihost_Permission = Message<"v1.Multihost.Permission"> & {
  * Use `create(Multihost_PermissionSchema)` to create a new message.
  */
 export const Multihost_PermissionSchema: GenMessage<Multihost_Permission> = /*@__PURE__*/
-  messageDesc(file_v1_config, 1, 1);
+  messageDesc(file_v1_config, 4, 1);
 
 /**
  * @generated from enum v1.Multihost.Permission.Type
@@ -214,7 +409,7 @@ export enum Multihost_Permission_Type {
  * Describes the enum v1.Multihost.Permission.Type.
  */
 export const Multihost_Permission_TypeSchema: GenEnum<Multihost_Permission_Type> = /*@__PURE__*/
-  enumDesc(file_v1_config, 1, 1, 0);
+  enumDesc(file_v1_config, 4, 1, 0);
 
 /**
  * @generated from message v1.Repo
@@ -303,6 +498,13 @@ export type Repo = Message<"v1.Repo"> & {
    * @generated from field: v1.CommandPrefix command_prefix = 10;
    */
   commandPrefix?: CommandPrefix;
+
+  /**
+   * optional, retention applied to snapshots across the repo e.g. those not owned by any plan.
+   *
+   * @generated from field: v1.RepoRetentionPolicy retention_policy = 13;
+   */
+  retentionPolicy?: RepoRetentionPolicy;
 };
 
 /**
@@ -310,7 +512,7 @@ export type Repo = Message<"v1.Repo"> & {
  * Use `create(RepoSchema)` to create a new message.
  */
 export const RepoSchema: GenMessage<Repo> = /*@__PURE__*/
-  messageDesc(file_v1_config, 2);
+  messageDesc(file_v1_config, 5);
 
 /**
  * @generated from message v1.Plan
@@ -385,6 +587,13 @@ export type Plan = Message<"v1.Plan"> & {
    * @generated from field: bool skip_if_unchanged = 13;
    */
   skipIfUnchanged: boolean;
+
+  /**
+   * fire CONDITION_PLAN_STALE hooks if no backup succeeds for this many hours, 0 disables.
+   *
+   * @generated from field: int32 stale_after_hours = 14;
+   */
+  staleAfterHours: number;
 };
 
 /**
@@ -392,7 +601,7 @@ export type Plan = Message<"v1.Plan"> & {
  * Use `create(PlanSchema)` to create a new message.
  */
 export const PlanSchema: GenMessage<Plan> = /*@__PURE__*/
-  messageDesc(file_v1_config, 3);
+  messageDesc(file_v1_config, 6);
 
 /**
  * @generated from message v1.CommandPrefix
@@ -418,7 +627,7 @@ export type CommandPrefix = Message<"v1.CommandPrefix"> & {
  * Use `create(CommandPrefixSchema)` to create a new message.
  */
 export const CommandPrefixSchema: GenMessage<CommandPrefix> = /*@__PURE__*/
-  messageDesc(file_v1_config, 4);
+  messageDesc(file_v1_config, 7);
 
 /**
  * @generated from enum v1.CommandPrefix.IONiceLevel
@@ -449,7 +658,7 @@ export enum CommandPrefix_IONiceLevel {
  * Describes the enum v1.CommandPrefix.IONiceLevel.
  */
 export const CommandPrefix_IONiceLevelSchema: GenEnum<CommandPrefix_IONiceLevel> = /*@__PURE__*/
-  enumDesc(file_v1_config, 4, 0);
+  enumDesc(file_v1_config, 7, 0);
 
 /**
  * @generated from enum v1.CommandPrefix.CPUNiceLevel
@@ -475,7 +684,7 @@ export enum CommandPrefix_CPUNiceLevel {
  * Describes the enum v1.CommandPrefix.CPUNiceLevel.
  */
 export const CommandPrefix_CPUNiceLevelSchema: GenEnum<CommandPrefix_CPUNiceLevel> = /*@__PURE__*/
-  enumDesc(file_v1_config, 4, 1);
+  enumDesc(file_v1_config, 7, 1);
 
 /**
  * @generated from message v1.RetentionPolicy
@@ -503,6 +712,36 @@ export type RetentionPolicy = Message<"v1.RetentionPolicy"> & {
     value: boolean;
     case: "policyKeepAll";
   } | { case: undefined; value?: undefined };
+
+  /**
+   * Rules below are applied in addition to the policy above, a snapshot matched by any rule is kept. They have no effect with policy_keep_all.
+   *
+   * keep all snapshots within this duration of the newest snapshot e.g. "14d" or "1y6m". Durations use restic's format of years (y), months (m), days (d) and hours (h).
+   *
+   * @generated from field: string keep_within = 13;
+   */
+  keepWithin: string;
+
+  /**
+   * keep the newest snapshot of each bucket within the given durations.
+   *
+   * @generated from field: v1.RetentionPolicy.TimeBucketedDurations keep_within_buckets = 14;
+   */
+  keepWithinBuckets?: RetentionPolicy_TimeBucketedDurations;
+
+  /**
+   * keep snapshots carrying all the tags of any entry, each entry is a comma separated tag list.
+   *
+   * @generated from field: repeated string keep_tags = 15;
+   */
+  keepTags: string[];
+
+  /**
+   * group snapshots by any of "host", "paths" and "tags" (comma separated) before applying the policy. If empty all snapshots of the plan form a single group.
+   *
+   * @generated from field: string group_by = 16;
+   */
+  groupBy: string;
 };
 
 /**
@@ -510,7 +749,7 @@ export type RetentionPolicy = Message<"v1.RetentionPolicy"> & {
  * Use `create(RetentionPolicySchema)` to create a new message.
  */
 export const RetentionPolicySchema: GenMessage<RetentionPolicy> = /*@__PURE__*/
-  messageDesc(file_v1_config, 5);
+  messageDesc(file_v1_config, 8);
 
 /**
  * @generated from message v1.RetentionPolicy.TimeBucketedCounts
@@ -564,7 +803,104 @@ export type RetentionPolicy_TimeBucketedCounts = Message<"v1.RetentionPolicy.Tim
  * Use `create(RetentionPolicy_TimeBucketedCountsSchema)` to create a new message.
  */
 export const RetentionPolicy_TimeBucketedCountsSchema: GenMessage<RetentionPolicy_TimeBucketedCounts> = /*@__PURE__*/
-  messageDesc(file_v1_config, 5, 0);
+  messageDesc(file_v1_config, 8, 0);
+
+/**
+ * @generated from message v1.RetentionPolicy.TimeBucketedDurations
+ */
+export type RetentionPolicy_TimeBucketedDurations = Message<"v1.RetentionPolicy.TimeBucketedDurations"> & {
+  /**
+   * keep hourly snapshots within this duration.
+   *
+   * @generated from field: string hourly = 1;
+   */
+  hourly: string;
+
+  /**
+   * keep daily snapshots within this duration.
+   *
+   * @generated from field: string daily = 2;
+   */
+  daily: string;
+
+  /**
+   * keep weekly snapshots within this duration.
+   *
+   * @generated from field: string weekly = 3;
+   */
+  weekly: string;
+
+  /**
+   * keep monthly snapshots within this duration.
+   *
+   * @generated from field: string monthly = 4;
+   */
+  monthly: string;
+
+  /**
+   * keep yearly snapshots within this duration.
+   *
+   * @generated from field: string yearly = 5;
+   */
+  yearly: string;
+};
+
+/**
+ * Describes the message v1.RetentionPolicy.TimeBucketedDurations.
+ * Use `create(RetentionPolicy_TimeBucketedDurationsSchema)` to create a new message.
+ */
+export const RetentionPolicy_TimeBucketedDurationsSchema: GenMessage<RetentionPolicy_TimeBucketedDurations> = /*@__PURE__*/
+  messageDesc(file_v1_config, 8, 1);
+
+/**
+ * RepoRetentionPolicy applies retention to snapshots of the repo that no plan manages e.g. snapshots of plans that were
+ * removed or of tools other than backrest. Held snapshots and self backups are never forgotten.
+ *
+ * @generated from message v1.RepoRetentionPolicy
+ */
+export type RepoRetentionPolicy = Message<"v1.RepoRetentionPolicy"> & {
+  /**
+   * schedule for applying the policy.
+   *
+   * @generated from field: v1.Schedule schedule = 1;
+   */
+  schedule?: Schedule;
+
+  /**
+   * retention policy applied to the selected snapshots.
+   *
+   * @generated from field: v1.RetentionPolicy retention = 2;
+   */
+  retention?: RetentionPolicy;
+
+  /**
+   * optional, only snapshots with all of these tags are selected.
+   *
+   * @generated from field: repeated string tags = 3;
+   */
+  tags: string[];
+
+  /**
+   * optional, only snapshots from one of these hosts are selected.
+   *
+   * @generated from field: repeated string hosts = 4;
+   */
+  hosts: string[];
+
+  /**
+   * only select snapshots that are not owned by a current plan or by another backrest instance, required if any plan backs up to the repo.
+   *
+   * @generated from field: bool only_unowned = 5;
+   */
+  onlyUnowned: boolean;
+};
+
+/**
+ * Describes the message v1.RepoRetentionPolicy.
+ * Use `create(RepoRetentionPolicySchema)` to create a new message.
+ */
+export const RepoRetentionPolicySchema: GenMessage<RepoRetentionPolicy> = /*@__PURE__*/
+  messageDesc(file_v1_config, 9);
 
 /**
  * @generated from message v1.PrunePolicy
@@ -595,7 +931,7 @@ export type PrunePolicy = Message<"v1.PrunePolicy"> & {
  * Use `create(PrunePolicySchema)` to create a new message.
  */
 export const PrunePolicySchema: GenMessage<PrunePolicy> = /*@__PURE__*/
-  messageDesc(file_v1_config, 6);
+  messageDesc(file_v1_config, 10);
 
 /**
  * @generated from message v1.CheckPolicy
@@ -633,7 +969,7 @@ export type CheckPolicy = Message<"v1.CheckPolicy"> & {
  * Use `create(CheckPolicySchema)` to create a new message.
  */
 export const CheckPolicySchema: GenMessage<CheckPolicy> = /*@__PURE__*/
-  messageDesc(file_v1_config, 7);
+  messageDesc(file_v1_config, 11);
 
 /**
  * @generated from message v1.Schedule
@@ -689,7 +1025,7 @@ export type Schedule = Message<"v1.Schedule"> & {
  * Use `create(ScheduleSchema)` to create a new message.
  */
 export const ScheduleSchema: GenMessage<Schedule> = /*@__PURE__*/
-  messageDesc(file_v1_config, 8);
+  messageDesc(file_v1_config, 12);
 
 /**
  * @generated from enum v1.Schedule.Clock
@@ -722,7 +1058,7 @@ export enum Schedule_Clock {
  * Describes the enum v1.Schedule.Clock.
  */
 export const Schedule_ClockSchema: GenEnum<Schedule_Clock> = /*@__PURE__*/
-  enumDesc(file_v1_config, 8, 0);
+  enumDesc(file_v1_config, 12, 0);
 
 /**
  * @generated from message v1.Hook
@@ -738,6 +1074,27 @@ export type Hook = Message<"v1.Hook"> & {
    */
   onError: Hook_OnError;
 
+  /**
+   * if set, events are buffered and sent as a single summary on the digest's schedule.
+   *
+   * @generated from field: v1.Hook.Digest digest = 3;
+   */
+  digest?: Hook_Digest;
+
+  /**
+   * if > 0, the hook is cancelled after this many seconds and the timeout is handled by on_error.
+   *
+   * @generated from field: int32 timeout_seconds = 4;
+   */
+  timeoutSeconds: number;
+
+  /**
+   * if set, the hook may run concurrently with adjacent parallel hooks triggered by the same event.
+   *
+   * @generated from field: bool parallel = 5;
+   */
+  parallel: boolean;
+
   /**
    * @generated from oneof v1.Hook.action
    */
@@ -789,6 +1146,30 @@ export type Hook = Message<"v1.Hook"> & {
      */
     value: Hook_Telegram;
     case: "actionTelegram";
+  } | {
+    /**
+     * @generated from field: v1.Hook.Email action_email = 108;
+     */
+    value: Hook_Email;
+    case: "actionEmail";
+  } | {
+    /**
+     * @generated from field: v1.Hook.Ntfy action_ntfy = 109;
+     */
+    value: Hook_Ntfy;
+    case: "actionNtfy";
+  } | {
+    /**
+     * @generated from field: v1.Hook.Pushover action_pushover = 110;
+     */
+    value: Hook_Pushover;
+    case: "actionPushover";
+  } | {
+    /**
+     * @generated from field: v1.Hook.Matrix action_matrix = 111;
+     */
+    value: Hook_Matrix;
+    case: "actionMatrix";
   } | { case: undefined; value?: undefined };
 };
 
@@ -797,39 +1178,147 @@ export type Hook = Message<"v1.Hook"> & {
  * Use `create(HookSchema)` to create a new message.
  */
 export const HookSchema: GenMessage<Hook> = /*@__PURE__*/
-  messageDesc(file_v1_config, 9);
+  messageDesc(file_v1_config, 13);
 
 /**
- * @generated from message v1.Hook.Command
+ * @generated from message v1.Hook.Digest
  */
-export type Hook_Command = Message<"v1.Hook.Command"> & {
+export type Hook_Digest = Message<"v1.Hook.Digest"> & {
   /**
-   * @generated from field: string command = 1;
+   * when buffered events are flushed, nothing is sent if no events occurred.
+   *
+   * @generated from field: v1.Schedule schedule = 1;
    */
-  command: string;
+  schedule?: Schedule;
 };
 
 /**
- * Describes the message v1.Hook.Command.
- * Use `create(Hook_CommandSchema)` to create a new message.
+ * Describes the message v1.Hook.Digest.
+ * Use `create(Hook_DigestSchema)` to create a new message.
  */
-export const Hook_CommandSchema: GenMessage<Hook_Command> = /*@__PURE__*/
-  messageDesc(file_v1_config, 9, 0);
+export const Hook_DigestSchema: GenMessage<Hook_Digest> = /*@__PURE__*/
+  messageDesc(file_v1_config, 13, 0);
 
 /**
- * @generated from message v1.Hook.Webhook
+ * @generated from message v1.Hook.Command
  */
-export type Hook_Webhook = Message<"v1.Hook.Webhook"> & {
+export type Hook_Command = Message<"v1.Hook.Command"> & {
   /**
-   * @generated from field: string webhook_url = 1;
+   * @generated from field: string command = 1;
    */
-  webhookUrl: string;
+  command: string;
 
   /**
-   * @generated from field: v1.Hook.Webhook.Method method = 2;
+   * directory the command runs in, defaults to backrest's working directory.
+   *
+   * @generated from field: string working_dir = 2;
+   */
+  workingDir: string;
+
+  /**
+   * additional environment variables in KEY=VALUE form.
+   *
+   * @generated from field: repeated string env = 3;
+   */
+  env: string[];
+
+  /**
+   * if set, the command runs as this user. Unix only and requires backrest to run as root.
+   *
+   * @generated from field: string user = 4;
+   */
+  user: string;
+
+  /**
+   * if > 0, output beyond this many bytes is dropped from the log.
+   *
+   * @generated from field: int64 max_output_bytes = 5;
+   */
+  maxOutputBytes: bigint;
+};
+
+/**
+ * Describes the message v1.Hook.Command.
+ * Use `create(Hook_CommandSchema)` to create a new message.
+ */
+export const Hook_CommandSchema: GenMessage<Hook_Command> = /*@__PURE__*/
+  messageDesc(file_v1_config, 13, 1);
+
+/**
+ * @generated from message v1.Hook.Webhook
+ */
+export type Hook_Webhook = Message<"v1.Hook.Webhook"> & {
+  /**
+   * @generated from field: string webhook_url = 1;
+   */
+  webhookUrl: string;
+
+  /**
+   * @generated from field: v1.Hook.Webhook.Method method = 2;
    */
   method: Hook_Webhook_Method;
 
+  /**
+   * extra headers in the format "Name: value".
+   *
+   * @generated from field: repeated string headers = 3;
+   */
+  headers: string[];
+
+  /**
+   * content type of the rendered template, defaults to text/plain.
+   *
+   * @generated from field: string content_type = 4;
+   */
+  contentType: string;
+
+  /**
+   * optional, sent as an "Authorization: Bearer" header.
+   *
+   * @generated from field: string bearer_token = 5;
+   */
+  bearerToken: string;
+
+  /**
+   * optional, basic auth credentials.
+   *
+   * @generated from field: string basic_auth_username = 6;
+   */
+  basicAuthUsername: string;
+
+  /**
+   * @generated from field: string basic_auth_password = 7;
+   */
+  basicAuthPassword: string;
+
+  /**
+   * optional, PEM encoded CA certificates used to verify the server.
+   *
+   * @generated from field: string tls_ca_cert = 8;
+   */
+  tlsCaCert: string;
+
+  /**
+   * skip verification of the server's certificate.
+   *
+   * @generated from field: bool tls_skip_verify = 9;
+   */
+  tlsSkipVerify: boolean;
+
+  /**
+   * request timeout, defaults to 30 seconds.
+   *
+   * @generated from field: int32 timeout_seconds = 10;
+   */
+  timeoutSeconds: number;
+
+  /**
+   * retries with exponential backoff on 5xx responses or connection errors.
+   *
+   * @generated from field: int32 max_retries = 11;
+   */
+  maxRetries: number;
+
   /**
    * @generated from field: string template = 100;
    */
@@ -841,7 +1330,7 @@ export type Hook_Webhook = Message<"v1.Hook.Webhook"> & {
  * Use `create(Hook_WebhookSchema)` to create a new message.
  */
 export const Hook_WebhookSchema: GenMessage<Hook_Webhook> = /*@__PURE__*/
-  messageDesc(file_v1_config, 9, 1);
+  messageDesc(file_v1_config, 13, 2);
 
 /**
  * @generated from enum v1.Hook.Webhook.Method
@@ -861,13 +1350,23 @@ export enum Hook_Webhook_Method {
    * @generated from enum value: POST = 2;
    */
   POST = 2,
+
+  /**
+   * @generated from enum value: PUT = 3;
+   */
+  PUT = 3,
+
+  /**
+   * @generated from enum value: PATCH = 4;
+   */
+  PATCH = 4,
 }
 
 /**
  * Describes the enum v1.Hook.Webhook.Method.
  */
 export const Hook_Webhook_MethodSchema: GenEnum<Hook_Webhook_Method> = /*@__PURE__*/
-  enumDesc(file_v1_config, 9, 1, 0);
+  enumDesc(file_v1_config, 13, 2, 0);
 
 /**
  * @generated from message v1.Hook.Discord
@@ -891,7 +1390,7 @@ export type Hook_Discord = Message<"v1.Hook.Discord"> & {
  * Use `create(Hook_DiscordSchema)` to create a new message.
  */
 export const Hook_DiscordSchema: GenMessage<Hook_Discord> = /*@__PURE__*/
-  messageDesc(file_v1_config, 9, 2);
+  messageDesc(file_v1_config, 13, 3);
 
 /**
  * @generated from message v1.Hook.Gotify
@@ -934,7 +1433,7 @@ export type Hook_Gotify = Message<"v1.Hook.Gotify"> & {
  * Use `create(Hook_GotifySchema)` to create a new message.
  */
 export const Hook_GotifySchema: GenMessage<Hook_Gotify> = /*@__PURE__*/
-  messageDesc(file_v1_config, 9, 3);
+  messageDesc(file_v1_config, 13, 4);
 
 /**
  * @generated from message v1.Hook.Slack
@@ -958,7 +1457,7 @@ export type Hook_Slack = Message<"v1.Hook.Slack"> & {
  * Use `create(Hook_SlackSchema)` to create a new message.
  */
 export const Hook_SlackSchema: GenMessage<Hook_Slack> = /*@__PURE__*/
-  messageDesc(file_v1_config, 9, 4);
+  messageDesc(file_v1_config, 13, 5);
 
 /**
  * @generated from message v1.Hook.Shoutrrr
@@ -980,7 +1479,7 @@ export type Hook_Shoutrrr = Message<"v1.Hook.Shoutrrr"> & {
  * Use `create(Hook_ShoutrrrSchema)` to create a new message.
  */
 export const Hook_ShoutrrrSchema: GenMessage<Hook_Shoutrrr> = /*@__PURE__*/
-  messageDesc(file_v1_config, 9, 5);
+  messageDesc(file_v1_config, 13, 6);
 
 /**
  * @generated from message v1.Hook.Healthchecks
@@ -1002,7 +1501,7 @@ export type Hook_Healthchecks = Message<"v1.Hook.Healthchecks"> & {
  * Use `create(Hook_HealthchecksSchema)` to create a new message.
  */
 export const Hook_HealthchecksSchema: GenMessage<Hook_Healthchecks> = /*@__PURE__*/
-  messageDesc(file_v1_config, 9, 6);
+  messageDesc(file_v1_config, 13, 7);
 
 /**
  * @generated from message v1.Hook.Telegram
@@ -1031,7 +1530,303 @@ export type Hook_Telegram = Message<"v1.Hook.Telegram"> & {
  * Use `create(Hook_TelegramSchema)` to create a new message.
  */
 export const Hook_TelegramSchema: GenMessage<Hook_Telegram> = /*@__PURE__*/
-  messageDesc(file_v1_config, 9, 7);
+  messageDesc(file_v1_config, 13, 8);
+
+/**
+ * @generated from message v1.Hook.Email
+ */
+export type Hook_Email = Message<"v1.Hook.Email"> & {
+  /**
+   * SMTP server host.
+   *
+   * @generated from field: string host = 1;
+   */
+  host: string;
+
+  /**
+   * SMTP server port, defaults to 465 for implicit TLS and 587 otherwise.
+   *
+   * @generated from field: int32 port = 2;
+   */
+  port: number;
+
+  /**
+   * @generated from field: v1.Hook.Email.Security security = 3;
+   */
+  security: Hook_Email_Security;
+
+  /**
+   * skip verification of the server's certificate.
+   *
+   * @generated from field: bool tls_skip_verify = 4;
+   */
+  tlsSkipVerify: boolean;
+
+  /**
+   * optional, PLAIN auth credentials.
+   *
+   * @generated from field: string username = 5;
+   */
+  username: string;
+
+  /**
+   * @generated from field: string password = 6;
+   */
+  password: string;
+
+  /**
+   * sender address.
+   *
+   * @generated from field: string from = 7;
+   */
+  from: string;
+
+  /**
+   * recipient addresses.
+   *
+   * @generated from field: repeated string to = 8;
+   */
+  to: string[];
+
+  /**
+   * send the rendered body as text/html instead of text/plain.
+   *
+   * @generated from field: bool html = 9;
+   */
+  html: boolean;
+
+  /**
+   * if set, attach up to this many bytes from the end of the triggering operation's log.
+   *
+   * @generated from field: int32 attach_log_bytes = 10;
+   */
+  attachLogBytes: number;
+
+  /**
+   * template for the message body.
+   *
+   * @generated from field: string template = 100;
+   */
+  template: string;
+
+  /**
+   * template for the message subject.
+   *
+   * @generated from field: string subject_template = 101;
+   */
+  subjectTemplate: string;
+};
+
+/**
+ * Describes the message v1.Hook.Email.
+ * Use `create(Hook_EmailSchema)` to create a new message.
+ */
+export const Hook_EmailSchema: GenMessage<Hook_Email> = /*@__PURE__*/
+  messageDesc(file_v1_config, 13, 9);
+
+/**
+ * @generated from enum v1.Hook.Email.Security
+ */
+export enum Hook_Email_Security {
+  /**
+   * upgrade the connection with STARTTLS, fails if the server does not support it.
+   *
+   * @generated from enum value: SECURITY_STARTTLS = 0;
+   */
+  STARTTLS = 0,
+
+  /**
+   * implicit TLS e.g. port 465.
+   *
+   * @generated from enum value: SECURITY_TLS = 1;
+   */
+  TLS = 1,
+
+  /**
+   * plaintext, only recommended for local relays.
+   *
+   * @generated from enum value: SECURITY_NONE = 2;
+   */
+  NONE = 2,
+}
+
+/**
+ * Describes the enum v1.Hook.Email.Security.
+ */
+export const Hook_Email_SecuritySchema: GenEnum<Hook_Email_Security> = /*@__PURE__*/
+  enumDesc(file_v1_config, 13, 9, 0);
+
+/**
+ * @generated from message v1.Hook.Ntfy
+ */
+export type Hook_Ntfy = Message<"v1.Hook.Ntfy"> & {
+  /**
+   * ntfy server, defaults to https://ntfy.sh.
+   *
+   * @generated from field: string base_url = 1;
+   */
+  baseUrl: string;
+
+  /**
+   * @generated from field: string topic = 2;
+   */
+  topic: string;
+
+  /**
+   * optional access token.
+   *
+   * @generated from field: string token = 3;
+   */
+  token: string;
+
+  /**
+   * 1 (min) to 5 (max), the server default is used if unset.
+   *
+   * @generated from field: int32 priority = 4;
+   */
+  priority: number;
+
+  /**
+   * tags or emoji shortcodes shown with the notification.
+   *
+   * @generated from field: repeated string tags = 5;
+   */
+  tags: string[];
+
+  /**
+   * optional URL opened when the notification is clicked.
+   *
+   * @generated from field: string click_url = 6;
+   */
+  clickUrl: string;
+
+  /**
+   * template for the message.
+   *
+   * @generated from field: string template = 100;
+   */
+  template: string;
+
+  /**
+   * template for the message title.
+   *
+   * @generated from field: string title_template = 101;
+   */
+  titleTemplate: string;
+};
+
+/**
+ * Describes the message v1.Hook.Ntfy.
+ * Use `create(Hook_NtfySchema)` to create a new message.
+ */
+export const Hook_NtfySchema: GenMessage<Hook_Ntfy> = /*@__PURE__*/
+  messageDesc(file_v1_config, 13, 10);
+
+/**
+ * @generated from message v1.Hook.Pushover
+ */
+export type Hook_Pushover = Message<"v1.Hook.Pushover"> & {
+  /**
+   * application API token.
+   *
+   * @generated from field: string token = 1;
+   */
+  token: string;
+
+  /**
+   * user or group key.
+   *
+   * @generated from field: string user_key = 2;
+   */
+  userKey: string;
+
+  /**
+   * optional, sends to a single device.
+   *
+   * @generated from field: string device = 3;
+   */
+  device: string;
+
+  /**
+   * -2 (lowest) to 2 (emergency).
+   *
+   * @generated from field: int32 priority = 4;
+   */
+  priority: number;
+
+  /**
+   * optional notification sound.
+   *
+   * @generated from field: string sound = 5;
+   */
+  sound: string;
+
+  /**
+   * optional supplementary URL.
+   *
+   * @generated from field: string url = 6;
+   */
+  url: string;
+
+  /**
+   * template for the message.
+   *
+   * @generated from field: string template = 100;
+   */
+  template: string;
+
+  /**
+   * template for the message title.
+   *
+   * @generated from field: string title_template = 101;
+   */
+  titleTemplate: string;
+};
+
+/**
+ * Describes the message v1.Hook.Pushover.
+ * Use `create(Hook_PushoverSchema)` to create a new message.
+ */
+export const Hook_PushoverSchema: GenMessage<Hook_Pushover> = /*@__PURE__*/
+  messageDesc(file_v1_config, 13, 11);
+
+/**
+ * @generated from message v1.Hook.Matrix
+ */
+export type Hook_Matrix = Message<"v1.Hook.Matrix"> & {
+  /**
+   * e.g. https://matrix.org
+   *
+   * @generated from field: string homeserver_url = 1;
+   */
+  homeserverUrl: string;
+
+  /**
+   * internal room ID e.g. !abc123:matrix.org, the access token's user must have joined the room.
+   *
+   * @generated from field: string room_id = 2;
+   */
+  roomId: string;
+
+  /**
+   * @generated from field: string access_token = 3;
+   */
+  accessToken: string;
+
+  /**
+   * template for the message.
+   *
+   * @generated from field: string template = 100;
+   */
+  template: string;
+};
+
+/**
+ * Describes the message v1.Hook.Matrix.
+ * Use `create(Hook_MatrixSchema)` to create a new message.
+ */
+export const Hook_MatrixSchema: GenMessage<Hook_Matrix> = /*@__PURE__*/
+  messageDesc(file_v1_config, 13, 12);
 
 /**
  * @generated from enum v1.Hook.Condition
@@ -1159,13 +1954,121 @@ export enum Hook_Condition {
    * @generated from enum value: CONDITION_FORGET_SUCCESS = 302;
    */
   FORGET_SUCCESS = 302,
+
+  /**
+   * staleness conditions
+   *
+   * plan's last successful backup is older than its stale_after_hours.
+   *
+   * @generated from enum value: CONDITION_PLAN_STALE = 400;
+   */
+  PLAN_STALE = 400,
+
+  /**
+   * a backup succeeded for a plan that was stale.
+   *
+   * @generated from enum value: CONDITION_PLAN_RECOVERED = 401;
+   */
+  PLAN_RECOVERED = 401,
+
+  /**
+   * restore conditions
+   *
+   * restore started.
+   *
+   * @generated from enum value: CONDITION_RESTORE_START = 500;
+   */
+  RESTORE_START = 500,
+
+  /**
+   * restore failed.
+   *
+   * @generated from enum value: CONDITION_RESTORE_ERROR = 501;
+   */
+  RESTORE_ERROR = 501,
+
+  /**
+   * restore succeeded.
+   *
+   * @generated from enum value: CONDITION_RESTORE_SUCCESS = 502;
+   */
+  RESTORE_SUCCESS = 502,
+
+  /**
+   * stats conditions
+   *
+   * stats started.
+   *
+   * @generated from enum value: CONDITION_STATS_START = 600;
+   */
+  STATS_START = 600,
+
+  /**
+   * stats failed.
+   *
+   * @generated from enum value: CONDITION_STATS_ERROR = 601;
+   */
+  STATS_ERROR = 601,
+
+  /**
+   * stats succeeded.
+   *
+   * @generated from enum value: CONDITION_STATS_SUCCESS = 602;
+   */
+  STATS_SUCCESS = 602,
+
+  /**
+   * index snapshots conditions
+   *
+   * snapshot indexing started.
+   *
+   * @generated from enum value: CONDITION_INDEX_SNAPSHOTS_START = 700;
+   */
+  INDEX_SNAPSHOTS_START = 700,
+
+  /**
+   * snapshot indexing failed.
+   *
+   * @generated from enum value: CONDITION_INDEX_SNAPSHOTS_ERROR = 701;
+   */
+  INDEX_SNAPSHOTS_ERROR = 701,
+
+  /**
+   * snapshot indexing succeeded.
+   *
+   * @generated from enum value: CONDITION_INDEX_SNAPSHOTS_SUCCESS = 702;
+   */
+  INDEX_SNAPSHOTS_SUCCESS = 702,
+
+  /**
+   * run command conditions
+   *
+   * run command started.
+   *
+   * @generated from enum value: CONDITION_RUN_COMMAND_START = 800;
+   */
+  RUN_COMMAND_START = 800,
+
+  /**
+   * run command failed.
+   *
+   * @generated from enum value: CONDITION_RUN_COMMAND_ERROR = 801;
+   */
+  RUN_COMMAND_ERROR = 801,
+
+  /**
+   * run command succeeded.
+   *
+   * @generated from enum value: CONDITION_RUN_COMMAND_SUCCESS = 802;
+   */
+  RUN_COMMAND_SUCCESS = 802,
 }
 
 /**
  * Describes the enum v1.Hook.Condition.
  */
 export const Hook_ConditionSchema: GenEnum<Hook_Condition> = /*@__PURE__*/
-  enumDesc(file_v1_config, 9, 0);
+  enumDesc(file_v1_config, 13, 0);
 
 /**
  * @generated from enum v1.Hook.OnError
@@ -1216,7 +2119,7 @@ export enum Hook_OnError {
  * Describes the enum v1.Hook.OnError.
  */
 export const Hook_OnErrorSchema: GenEnum<Hook_OnError> = /*@__PURE__*/
-  enumDesc(file_v1_config, 9, 1);
+  enumDesc(file_v1_config, 13, 1);
 
 /**
  * @generated from message v1.Auth
@@ -1242,7 +2145,7 @@ export type Auth = Message<"v1.Auth"> & {
  * Use `create(AuthSchema)` to create a new message.
  */
 export const AuthSchema: GenMessage<Auth> = /*@__PURE__*/
-  messageDesc(file_v1_config, 10);
+  messageDesc(file_v1_config, 14);
 
 /**
  * @generated from message v1.User
@@ -1270,5 +2173,5 @@ export type User = Message<"v1.User"> & {
  * Use `create(UserSchema)` to create a new message.
  */
 export const UserSchema: GenMessage<User> = /*@__PURE__*/
-  messageDesc(file_v1_config, 11);
+  messageDesc(file_v1_config, 15);
 
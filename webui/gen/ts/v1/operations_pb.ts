@@ -16,7 +16,7 @@ import type { Message } from "@bufbuild/protobuf";
  * Describes the file v1/operations.proto.
  */
 export const file_v1_operations: GenFile = /*@__PURE__*/
-  fileDesc("ChN2MS9vcGVyYXRpb25zLnByb3RvEgJ2MSIyCg1PcGVyYXRpb25MaXN0EiEKCm9wZXJhdGlvbnMYASADKAsyDS52MS5PcGVyYXRpb24iwAYKCU9wZXJhdGlvbhIKCgJpZBgBIAEoAxITCgtvcmlnaW5hbF9pZBgNIAEoAxINCgVtb2RubxgMIAEoAxIPCgdmbG93X2lkGAogASgDEhgKEG9yaWdpbmFsX2Zsb3dfaWQYDiABKAMSDwoHcmVwb19pZBgCIAEoCRIRCglyZXBvX2d1aWQYDyABKAkSDwoHcGxhbl9pZBgDIAEoCRITCgtpbnN0YW5jZV9pZBgLIAEoCRIfChdvcmlnaW5hbF9pbnN0YW5jZV9rZXlpZBgQIAEoCRITCgtzbmFwc2hvdF9pZBgIIAEoCRIjCgZzdGF0dXMYBCABKA4yEy52MS5PcGVyYXRpb25TdGF0dXMSGgoSdW5peF90aW1lX3N0YXJ0X21zGAUgASgDEhgKEHVuaXhfdGltZV9lbmRfbXMYBiABKAMSFwoPZGlzcGxheV9tZXNzYWdlGAcgASgJEg4KBmxvZ3JlZhgJIAEoCRIvChBvcGVyYXRpb25fYmFja3VwGGQgASgLMhMudjEuT3BlcmF0aW9uQmFja3VwSAASPgoYb3BlcmF0aW9uX2luZGV4X3NuYXBzaG90GGUgASgLMhoudjEuT3BlcmF0aW9uSW5kZXhTbmFwc2hvdEgAEi8KEG9wZXJhdGlvbl9mb3JnZXQYZiABKAsyEy52MS5PcGVyYXRpb25Gb3JnZXRIABItCg9vcGVyYXRpb25fcHJ1bmUYZyABKAsyEi52MS5PcGVyYXRpb25QcnVuZUgAEjEKEW9wZXJhdGlvbl9yZXN0b3JlGGggASgLMhQudjEuT3BlcmF0aW9uUmVzdG9yZUgAEi0KD29wZXJhdGlvbl9zdGF0cxhpIAEoCzISLnYxLk9wZXJhdGlvblN0YXRzSAASMgoSb3BlcmF0aW9uX3J1bl9ob29rGGogASgLMhQudjEuT3BlcmF0aW9uUnVuSG9va0gAEi0KD29wZXJhdGlvbl9jaGVjaxhrIAEoCzISLnYxLk9wZXJhdGlvbkNoZWNrSAASOAoVb3BlcmF0aW9uX3J1bl9jb21tYW5kGGwgASgLMhcudjEuT3BlcmF0aW9uUnVuQ29tbWFuZEgAQgQKAm9wIs8BCg5PcGVyYXRpb25FdmVudBIiCgprZWVwX2FsaXZlGAEgASgLMgwudHlwZXMuRW1wdHlIABIvChJjcmVhdGVkX29wZXJhdGlvbnMYAiABKAsyES52MS5PcGVyYXRpb25MaXN0SAASLwoSdXBkYXRlZF9vcGVyYXRpb25zGAMgASgLMhEudjEuT3BlcmF0aW9uTGlzdEgAEi4KEmRlbGV0ZWRfb3BlcmF0aW9ucxgEIAEoCzIQLnR5cGVzLkludDY0TGlzdEgAQgcKBWV2ZW50ImgKD09wZXJhdGlvbkJhY2t1cBIsCgtsYXN0X3N0YXR1cxgDIAEoCzIXLnYxLkJhY2t1cFByb2dyZXNzRW50cnkSJwoGZXJyb3JzGAQgAygLMhcudjEuQmFja3VwUHJvZ3Jlc3NFcnJvciJOChZPcGVyYXRpb25JbmRleFNuYXBzaG90EiQKCHNuYXBzaG90GAIgASgLMhIudjEuUmVzdGljU25hcHNob3QSDgoGZm9yZ290GAMgASgIIloKD09wZXJhdGlvbkZvcmdldBIiCgZmb3JnZXQYASADKAsyEi52MS5SZXN0aWNTbmFwc2hvdBIjCgZwb2xpY3kYAiABKAsyEy52MS5SZXRlbnRpb25Qb2xpY3kiOwoOT3BlcmF0aW9uUHJ1bmUSEgoGb3V0cHV0GAEgASgJQgIYARIVCg1vdXRwdXRfbG9ncmVmGAIgASgJIjsKDk9wZXJhdGlvbkNoZWNrEhIKBm91dHB1dBgBIAEoCUICGAESFQoNb3V0cHV0X2xvZ3JlZhgCIAEoCSJYChNPcGVyYXRpb25SdW5Db21tYW5kEg8KB2NvbW1hbmQYASABKAkSFQoNb3V0cHV0X2xvZ3JlZhgCIAEoCRIZChFvdXRwdXRfc2l6ZV9ieXRlcxgDIAEoAyJfChBPcGVyYXRpb25SZXN0b3JlEgwKBHBhdGgYASABKAkSDgoGdGFyZ2V0GAIgASgJEi0KC2xhc3Rfc3RhdHVzGAMgASgLMhgudjEuUmVzdG9yZVByb2dyZXNzRW50cnkiLgoOT3BlcmF0aW9uU3RhdHMSHAoFc3RhdHMYASABKAsyDS52MS5SZXBvU3RhdHMicQoQT3BlcmF0aW9uUnVuSG9vaxIRCglwYXJlbnRfb3AYBCABKAMSDAoEbmFtZRgBIAEoCRIVCg1vdXRwdXRfbG9ncmVmGAIgASgJEiUKCWNvbmRpdGlvbhgDIAEoDjISLnYxLkhvb2suQ29uZGl0aW9uKmAKEk9wZXJhdGlvbkV2ZW50VHlwZRIRCg1FVkVOVF9VTktOT1dOEAASEQoNRVZFTlRfQ1JFQVRFRBABEhEKDUVWRU5UX1VQREFURUQQAhIRCg1FVkVOVF9ERUxFVEVEEAMqwgEKD09wZXJhdGlvblN0YXR1cxISCg5TVEFUVVNfVU5LTk9XThAAEhIKDlNUQVRVU19QRU5ESU5HEAESFQoRU1RBVFVTX0lOUFJPR1JFU1MQAhISCg5TVEFUVVNfU1VDQ0VTUxADEhIKDlNUQVRVU19XQVJOSU5HEAcSEAoMU1RBVFVTX0VSUk9SEAQSGwoXU1RBVFVTX1NZU1RFTV9DQU5DRUxMRUQQBRIZChVTVEFUVVNfVVNFUl9DQU5DRUxMRUQQBkIsWipnaXRodWIuY29tL2dhcmV0aGdlb3JnZS9iYWNrcmVzdC9nZW4vZ28vdjFiBnByb3RvMw", [file_v1_restic, file_v1_config, file_types_value]);
+  fileDesc("ChN2MS9vcGVyYXRpb25zLnByb3RvEgJ2MSIyCg1PcGVyYXRpb25MaXN0EiEKCm9wZXJhdGlvbnMYASADKAsyDS52MS5PcGVyYXRpb24i5QYKCU9wZXJhdGlvbhIKCgJpZBgBIAEoAxITCgtvcmlnaW5hbF9pZBgNIAEoAxINCgVtb2RubxgMIAEoAxIPCgdmbG93X2lkGAogASgDEhgKEG9yaWdpbmFsX2Zsb3dfaWQYDiABKAMSDwoHcmVwb19pZBgCIAEoCRIRCglyZXBvX2d1aWQYDyABKAkSDwoHcGxhbl9pZBgDIAEoCRITCgtpbnN0YW5jZV9pZBgLIAEoCRIfChdvcmlnaW5hbF9pbnN0YW5jZV9rZXlpZBgQIAEoCRITCgtzbmFwc2hvdF9pZBgIIAEoCRIjCgZzdGF0dXMYBCABKA4yEy52MS5PcGVyYXRpb25TdGF0dXMSGgoSdW5peF90aW1lX3N0YXJ0X21zGAUgASgDEhgKEHVuaXhfdGltZV9lbmRfbXMYBiABKAMSFwoPZGlzcGxheV9tZXNzYWdlGAcgASgJEg4KBmxvZ3JlZhgJIAEoCRIjCgZyb2xsdXAYESABKAsyEy52MS5PcGVyYXRpb25Sb2xsdXASLwoQb3BlcmF0aW9uX2JhY2t1cBhkIAEoCzITLnYxLk9wZXJhdGlvbkJhY2t1cEgAEj4KGG9wZXJhdGlvbl9pbmRleF9zbmFwc2hvdBhlIAEoCzIaLnYxLk9wZXJhdGlvbkluZGV4U25hcHNob3RIABIvChBvcGVyYXRpb25fZm9yZ2V0GGYgASgLMhMudjEuT3BlcmF0aW9uRm9yZ2V0SAASLQoPb3BlcmF0aW9uX3BydW5lGGcgASgLMhIudjEuT3BlcmF0aW9uUHJ1bmVIABIxChFvcGVyYXRpb25fcmVzdG9yZRhoIAEoCzIULnYxLk9wZXJhdGlvblJlc3RvcmVIABItCg9vcGVyYXRpb25fc3RhdHMYaSABKAsyEi52MS5PcGVyYXRpb25TdGF0c0gAEjIKEm9wZXJhdGlvbl9ydW5faG9vaxhqIAEoCzIULnYxLk9wZXJhdGlvblJ1bkhvb2tIABItCg9vcGVyYXRpb25fY2hlY2sYayABKAsyEi52MS5PcGVyYXRpb25DaGVja0gAEjgKFW9wZXJhdGlvbl9ydW5fY29tbWFuZBhsIAEoCzIXLnYxLk9wZXJhdGlvblJ1bkNvbW1hbmRIAEIECgJvcCKxAQoPT3BlcmF0aW9uUm9sbHVwEg0KBWNvdW50GAEgASgDEhsKE2ZpcnN0X3N0YXJ0X3RpbWVfbXMYAiABKAMSGQoRdG90YWxfZHVyYXRpb25fbXMYAyABKAMSHQoVdG90YWxfZmlsZXNfcHJvY2Vzc2VkGAQgASgDEh0KFXRvdGFsX2J5dGVzX3Byb2Nlc3NlZBgFIAEoAxIZChF0b3RhbF9ieXRlc19hZGRlZBgGIAEoAyLPAQoOT3BlcmF0aW9uRXZlbnQSIgoKa2VlcF9hbGl2ZRgBIAEoCzIMLnR5cGVzLkVtcHR5SAASLwoSY3JlYXRlZF9vcGVyYXRpb25zGAIgASgLMhEudjEuT3BlcmF0aW9uTGlzdEgAEi8KEnVwZGF0ZWRfb3BlcmF0aW9ucxgDIAEoCzIRLnYxLk9wZXJhdGlvbkxpc3RIABIuChJkZWxldGVkX29wZXJhdGlvbnMYBCABKAsyEC50eXBlcy5JbnQ2NExpc3RIAEIHCgVldmVudCKBAQoPT3BlcmF0aW9uQmFja3VwEiwKC2xhc3Rfc3RhdHVzGAMgASgLMhcudjEuQmFja3VwUHJvZ3Jlc3NFbnRyeRInCgZlcnJvcnMYBCADKAsyFy52MS5CYWNrdXBQcm9ncmVzc0Vycm9yEhcKD3RpbWVsaW5lX2xvZ3JlZhgFIAEoCSLIAQoTQmFja3VwVGltZWxpbmVQb2ludBIUCgx1bml4X3RpbWVfbXMYASABKAMSEgoKZmlsZXNfZG9uZRgCIAEoAxISCgpieXRlc19kb25lGAMgASgDEhMKC3RvdGFsX2ZpbGVzGAQgASgDEhMKC3RvdGFsX2J5dGVzGAUgASgDEhUKDWN1cnJlbnRfZmlsZXMYBiADKAkSGAoQZmlsZXNfcGVyX3NlY29uZBgHIAEoARIYChBieXRlc19wZXJfc2Vjb25kGAggASgBIk4KFk9wZXJhdGlvbkluZGV4U25hcHNob3QSJAoIc25hcHNob3QYAiABKAsyEi52MS5SZXN0aWNTbmFwc2hvdBIOCgZmb3Jnb3QYAyABKAgiWgoPT3BlcmF0aW9uRm9yZ2V0EiIKBmZvcmdldBgBIAMoCzISLnYxLlJlc3RpY1NuYXBzaG90EiMKBnBvbGljeRgCIAEoCzITLnYxLlJldGVudGlvblBvbGljeSJlCg5PcGVyYXRpb25QcnVuZRISCgZvdXRwdXQYASABKAlCAhgBEhUKDW91dHB1dF9sb2dyZWYYAiABKAkSGAoLdW51c2VkX3NpemUYAyABKANIAIgBAUIOCgxfdW51c2VkX3NpemUiOwoOT3BlcmF0aW9uQ2hlY2sSEgoGb3V0cHV0GAEgASgJQgIYARIVCg1vdXRwdXRfbG9ncmVmGAIgASgJIlgKE09wZXJhdGlvblJ1bkNvbW1hbmQSDwoHY29tbWFuZBgBIAEoCRIVCg1vdXRwdXRfbG9ncmVmGAIgASgJEhkKEW91dHB1dF9zaXplX2J5dGVzGAMgASgDIl8KEE9wZXJhdGlvblJlc3RvcmUSDAoEcGF0aBgBIAEoCRIOCgZ0YXJnZXQYAiABKAkSLQoLbGFzdF9zdGF0dXMYAyABKAsyGC52MS5SZXN0b3JlUHJvZ3Jlc3NFbnRyeSIuCg5PcGVyYXRpb25TdGF0cxIcCgVzdGF0cxgBIAEoCzINLnYxLlJlcG9TdGF0cyJxChBPcGVyYXRpb25SdW5Ib29rEhEKCXBhcmVudF9vcBgEIAEoAxIMCgRuYW1lGAEgASgJEhUKDW91dHB1dF9sb2dyZWYYAiABKAkSJQoJY29uZGl0aW9uGAMgASgOMhIudjEuSG9vay5Db25kaXRpb24qYAoST3BlcmF0aW9uRXZlbnRUeXBlEhEKDUVWRU5UX1VOS05PV04QABIRCg1FVkVOVF9DUkVBVEVEEAESEQoNRVZFTlRfVVBEQVRFRBACEhEKDUVWRU5UX0RFTEVURUQQAyrCAQoPT3BlcmF0aW9uU3RhdHVzEhIKDlNUQVRVU19VTktOT1dOEAASEgoOU1RBVFVTX1BFTkRJTkcQARIVChFTVEFUVVNfSU5QUk9HUkVTUxACEhIKDlNUQVRVU19TVUNDRVNTEAMSEgoOU1RBVFVTX1dBUk5JTkcQBxIQCgxTVEFUVVNfRVJST1IQBBIbChdTVEFUVVNfU1lTVEVNX0NBTkNFTExFRBAFEhkKFVNUQVRVU19VU0VSX0NBTkNFTExFRBAGKqsCCg1PcGVyYXRpb25UeXBlEhoKFk9QRVJBVElPTl9UWVBFX1VOS05PV04QABIZChVPUEVSQVRJT05fVFlQRV9CQUNLVVAQZBIhCh1PUEVSQVRJT05fVFlQRV9JTkRFWF9TTkFQU0hPVBBlEhkKFU9QRVJBVElPTl9UWVBFX0ZPUkdFVBBmEhgKFE9QRVJBVElPTl9UWVBFX1BSVU5FEGcSGgoWT1BFUkFUSU9OX1RZUEVfUkVTVE9SRRBoEhgKFE9QRVJBVElPTl9UWVBFX1NUQVRTEGkSGwoXT1BFUkFUSU9OX1RZUEVfUlVOX0hPT0sQahIYChRPUEVSQVRJT05fVFlQRV9DSEVDSxBrEh4KGk9QRVJBVElPTl9UWVBFX1JVTl9DT01NQU5EEGxCLFoqZ2l0aHViLmNvbS9nYXJldGhnZW9yZ2UvYmFja3Jlc3QvZ2VuL2dvL3YxYgZwcm90bzM", [file_v1_restic, file_v1_config, file_types_value]);
 
 /**
  * @generated from message v1.OperationList
@@ -146,6 +146,13 @@ export type Operation = Message<"v1.Operation"> & {
    */
   logref: string;
 
+  /**
+   * optional, set if the operation is a rollup of operations compacted by garbage collection.
+   *
+   * @generated from field: v1.OperationRollup rollup = 17;
+   */
+  rollup?: OperationRollup;
+
   /**
    * @generated from oneof v1.Operation.op
    */
@@ -213,6 +220,63 @@ export type Operation = Message<"v1.Operation"> & {
 export const OperationSchema: GenMessage<Operation> = /*@__PURE__*/
   messageDesc(file_v1_operations, 1);
 
+/**
+ * OperationRollup summarizes the operations of a day that history compaction collapsed into a single operation.
+ * The rollup operation otherwise holds the details of the latest compacted operation.
+ *
+ * @generated from message v1.OperationRollup
+ */
+export type OperationRollup = Message<"v1.OperationRollup"> & {
+  /**
+   * number of operations compacted into the rollup.
+   *
+   * @generated from field: int64 count = 1;
+   */
+  count: bigint;
+
+  /**
+   * start time of the earliest compacted operation.
+   *
+   * @generated from field: int64 first_start_time_ms = 2;
+   */
+  firstStartTimeMs: bigint;
+
+  /**
+   * sum of the durations of the compacted operations.
+   *
+   * @generated from field: int64 total_duration_ms = 3;
+   */
+  totalDurationMs: bigint;
+
+  /**
+   * backup only, sum of the files processed by the compacted backups.
+   *
+   * @generated from field: int64 total_files_processed = 4;
+   */
+  totalFilesProcessed: bigint;
+
+  /**
+   * backup only, sum of the bytes processed by the compacted backups.
+   *
+   * @generated from field: int64 total_bytes_processed = 5;
+   */
+  totalBytesProcessed: bigint;
+
+  /**
+   * backup only, sum of the bytes added to the repo by the compacted backups.
+   *
+   * @generated from field: int64 total_bytes_added = 6;
+   */
+  totalBytesAdded: bigint;
+};
+
+/**
+ * Describes the message v1.OperationRollup.
+ * Use `create(OperationRollupSchema)` to create a new message.
+ */
+export const OperationRollupSchema: GenMessage<OperationRollup> = /*@__PURE__*/
+  messageDesc(file_v1_operations, 2);
+
 /**
  * OperationEvent is used in the wireformat to stream operation changes to clients
  *
@@ -254,7 +318,7 @@ export type OperationEvent = Message<"v1.OperationEvent"> & {
  * Use `create(OperationEventSchema)` to create a new message.
  */
 export const OperationEventSchema: GenMessage<OperationEvent> = /*@__PURE__*/
-  messageDesc(file_v1_operations, 2);
+  messageDesc(file_v1_operations, 3);
 
 /**
  * @generated from message v1.OperationBackup
@@ -269,6 +333,13 @@ export type OperationBackup = Message<"v1.OperationBackup"> & {
    * @generated from field: repeated v1.BackupProgressError errors = 4;
    */
   errors: BackupProgressError[];
+
+  /**
+   * logref of the backup's progress timeline, one JSON encoded BackupTimelinePoint per line.
+   *
+   * @generated from field: string timeline_logref = 5;
+   */
+  timelineLogref: string;
 };
 
 /**
@@ -276,7 +347,67 @@ export type OperationBackup = Message<"v1.OperationBackup"> & {
  * Use `create(OperationBackupSchema)` to create a new message.
  */
 export const OperationBackupSchema: GenMessage<OperationBackup> = /*@__PURE__*/
-  messageDesc(file_v1_operations, 3);
+  messageDesc(file_v1_operations, 4);
+
+/**
+ * BackupTimelinePoint is a sample of a backup's progress. Samples are recorded less often as a backup runs.
+ *
+ * @generated from message v1.BackupTimelinePoint
+ */
+export type BackupTimelinePoint = Message<"v1.BackupTimelinePoint"> & {
+  /**
+   * @generated from field: int64 unix_time_ms = 1;
+   */
+  unixTimeMs: bigint;
+
+  /**
+   * @generated from field: int64 files_done = 2;
+   */
+  filesDone: bigint;
+
+  /**
+   * @generated from field: int64 bytes_done = 3;
+   */
+  bytesDone: bigint;
+
+  /**
+   * total files found so far, restic scans the source while backing it up.
+   *
+   * @generated from field: int64 total_files = 4;
+   */
+  totalFiles: bigint;
+
+  /**
+   * @generated from field: int64 total_bytes = 5;
+   */
+  totalBytes: bigint;
+
+  /**
+   * files being read at the time of the sample.
+   *
+   * @generated from field: repeated string current_files = 6;
+   */
+  currentFiles: string[];
+
+  /**
+   * throughput since the previous point, computed when the timeline is served.
+   *
+   * @generated from field: double files_per_second = 7;
+   */
+  filesPerSecond: number;
+
+  /**
+   * @generated from field: double bytes_per_second = 8;
+   */
+  bytesPerSecond: number;
+};
+
+/**
+ * Describes the message v1.BackupTimelinePoint.
+ * Use `create(BackupTimelinePointSchema)` to create a new message.
+ */
+export const BackupTimelinePointSchema: GenMessage<BackupTimelinePoint> = /*@__PURE__*/
+  messageDesc(file_v1_operations, 5);
 
 /**
  * OperationIndexSnapshot tracks that a snapshot was detected by backrest. 
@@ -304,7 +435,7 @@ export type OperationIndexSnapshot = Message<"v1.OperationIndexSnapshot"> & {
  * Use `create(OperationIndexSnapshotSchema)` to create a new message.
  */
 export const OperationIndexSnapshotSchema: GenMessage<OperationIndexSnapshot> = /*@__PURE__*/
-  messageDesc(file_v1_operations, 4);
+  messageDesc(file_v1_operations, 6);
 
 /**
  * OperationForget tracks a forget operation.
@@ -328,7 +459,7 @@ export type OperationForget = Message<"v1.OperationForget"> & {
  * Use `create(OperationForgetSchema)` to create a new message.
  */
 export const OperationForgetSchema: GenMessage<OperationForget> = /*@__PURE__*/
-  messageDesc(file_v1_operations, 5);
+  messageDesc(file_v1_operations, 7);
 
 /**
  * OperationPrune tracks a prune operation.
@@ -350,6 +481,13 @@ export type OperationPrune = Message<"v1.OperationPrune"> & {
    * @generated from field: string output_logref = 2;
    */
   outputLogref: string;
+
+  /**
+   * bytes of unused data left in the repo after the prune, as reported by restic. Unset if unknown.
+   *
+   * @generated from field: optional int64 unused_size = 3;
+   */
+  unusedSize?: bigint;
 };
 
 /**
@@ -357,7 +495,7 @@ export type OperationPrune = Message<"v1.OperationPrune"> & {
  * Use `create(OperationPruneSchema)` to create a new message.
  */
 export const OperationPruneSchema: GenMessage<OperationPrune> = /*@__PURE__*/
-  messageDesc(file_v1_operations, 6);
+  messageDesc(file_v1_operations, 8);
 
 /**
  * OperationCheck tracks a check operation.
@@ -386,7 +524,7 @@ export type OperationCheck = Message<"v1.OperationCheck"> & {
  * Use `create(OperationCheckSchema)` to create a new message.
  */
 export const OperationCheckSchema: GenMessage<OperationCheck> = /*@__PURE__*/
-  messageDesc(file_v1_operations, 7);
+  messageDesc(file_v1_operations, 9);
 
 /**
  * OperationRunCommand tracks a long running command. Commands are grouped into a flow ID for each session.
@@ -417,7 +555,7 @@ export type OperationRunCommand = Message<"v1.OperationRunCommand"> & {
  * Use `create(OperationRunCommandSchema)` to create a new message.
  */
 export const OperationRunCommandSchema: GenMessage<OperationRunCommand> = /*@__PURE__*/
-  messageDesc(file_v1_operations, 8);
+  messageDesc(file_v1_operations, 10);
 
 /**
  * OperationRestore tracks a restore operation.
@@ -452,7 +590,7 @@ export type OperationRestore = Message<"v1.OperationRestore"> & {
  * Use `create(OperationRestoreSchema)` to create a new message.
  */
 export const OperationRestoreSchema: GenMessage<OperationRestore> = /*@__PURE__*/
-  messageDesc(file_v1_operations, 9);
+  messageDesc(file_v1_operations, 11);
 
 /**
  * OperationStats tracks a stats operation.
@@ -471,7 +609,7 @@ export type OperationStats = Message<"v1.OperationStats"> & {
  * Use `create(OperationStatsSchema)` to create a new message.
  */
 export const OperationStatsSchema: GenMessage<OperationStats> = /*@__PURE__*/
-  messageDesc(file_v1_operations, 10);
+  messageDesc(file_v1_operations, 12);
 
 /**
  * OperationRunHook tracks a hook that was run.
@@ -513,7 +651,7 @@ export type OperationRunHook = Message<"v1.OperationRunHook"> & {
  * Use `create(OperationRunHookSchema)` to create a new message.
  */
 export const OperationRunHookSchema: GenMessage<OperationRunHook> = /*@__PURE__*/
-  messageDesc(file_v1_operations, 11);
+  messageDesc(file_v1_operations, 13);
 
 /**
  * OperationEventType indicates whether the operation was created or updated
@@ -615,3 +753,66 @@ export enum OperationStatus {
 export const OperationStatusSchema: GenEnum<OperationStatus> = /*@__PURE__*/
   enumDesc(file_v1_operations, 1);
 
+/**
+ * OperationType identifies the kind of an operation, values match the field numbers of the Operation.op oneof.
+ *
+ * @generated from enum v1.OperationType
+ */
+export enum OperationType {
+  /**
+   * @generated from enum value: OPERATION_TYPE_UNKNOWN = 0;
+   */
+  UNKNOWN = 0,
+
+  /**
+   * @generated from enum value: OPERATION_TYPE_BACKUP = 100;
+   */
+  BACKUP = 100,
+
+  /**
+   * @generated from enum value: OPERATION_TYPE_INDEX_SNAPSHOT = 101;
+   */
+  INDEX_SNAPSHOT = 101,
+
+  /**
+   * @generated from enum value: OPERATION_TYPE_FORGET = 102;
+   */
+  FORGET = 102,
+
+  /**
+   * @generated from enum value: OPERATION_TYPE_PRUNE = 103;
+   */
+  PRUNE = 103,
+
+  /**
+   * @generated from enum value: OPERATION_TYPE_RESTORE = 104;
+   */
+  RESTORE = 104,
+
+  /**
+   * @generated from enum value: OPERATION_TYPE_STATS = 105;
+   */
+  STATS = 105,
+
+  /**
+   * @generated from enum value: OPERATION_TYPE_RUN_HOOK = 106;
+   */
+  RUN_HOOK = 106,
+
+  /**
+   * @generated from enum value: OPERATION_TYPE_CHECK = 107;
+   */
+  CHECK = 107,
+
+  /**
+   * @generated from enum value: OPERATION_TYPE_RUN_COMMAND = 108;
+   */
+  RUN_COMMAND = 108,
+}
+
+/**
+ * Describes the enum v1.OperationType.
+ */
+export const OperationTypeSchema: GenEnum<OperationType> = /*@__PURE__*/
+  enumDesc(file_v1_operations, 2);
+
@@ -4,11 +4,11 @@
 
 import type { GenEnum, GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegenv2";
 import { enumDesc, fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
-import type { ConfigSchema, RepoSchema } from "./config_pb";
+import type { Config, ConfigSchema, Hook, Hook_Condition, RepoSchema, RetentionPolicy } from "./config_pb";
 import { file_v1_config } from "./config_pb";
-import type { ResticSnapshotListSchema } from "./restic_pb";
+import type { ResticSnapshot, ResticSnapshotListSchema } from "./restic_pb";
 import { file_v1_restic } from "./restic_pb";
-import type { OperationEventSchema, OperationListSchema, OperationStatus } from "./operations_pb";
+import type { BackupTimelinePoint, Operation, OperationEventSchema, OperationListSchema, OperationStatus, OperationType } from "./operations_pb";
 import { file_v1_operations } from "./operations_pb";
 import type { BoolValueSchema, BytesValueSchema, Int64ValueSchema, StringListSchema, StringValueSchema } from "../types/value_pb";
 import { file_types_value } from "../types/value_pb";
@@ -21,7 +21,7 @@ import type { Message } from "@bufbuild/protobuf";
  * Describes the file v1/service.proto.
  */
 export const file_v1_service: GenFile = /*@__PURE__*/
-  fileDesc("ChB2MS9zZXJ2aWNlLnByb3RvEgJ2MSK/AgoKT3BTZWxlY3RvchILCgNpZHMYASADKAMSGAoLaW5zdGFuY2VfaWQYBiABKAlIAIgBARIkChdvcmlnaW5hbF9pbnN0YW5jZV9rZXlpZBgIIAEoCUgBiAEBEhYKCXJlcG9fZ3VpZBgHIAEoCUgCiAEBEhQKB3BsYW5faWQYAyABKAlIA4gBARIYCgtzbmFwc2hvdF9pZBgEIAEoCUgEiAEBEhQKB2Zsb3dfaWQYBSABKANIBYgBARIWCgltb2Rub19ndGUYCSABKANIBogBAUIOCgxfaW5zdGFuY2VfaWRCGgoYX29yaWdpbmFsX2luc3RhbmNlX2tleWlkQgwKCl9yZXBvX2d1aWRCCgoIX3BsYW5faWRCDgoMX3NuYXBzaG90X2lkQgoKCF9mbG93X2lkQgwKCl9tb2Rub19ndGUiwAEKEURvUmVwb1Rhc2tSZXF1ZXN0Eg8KB3JlcG9faWQYASABKAkSKAoEdGFzaxgCIAEoDjIaLnYxLkRvUmVwb1Rhc2tSZXF1ZXN0LlRhc2sicAoEVGFzaxINCglUQVNLX05PTkUQABIYChRUQVNLX0lOREVYX1NOQVBTSE9UUxABEg4KClRBU0tfUFJVTkUQAhIOCgpUQVNLX0NIRUNLEAMSDgoKVEFTS19TVEFUUxAEEg8KC1RBU0tfVU5MT0NLEAUiTAoTQ2xlYXJIaXN0b3J5UmVxdWVzdBIgCghzZWxlY3RvchgBIAEoCzIOLnYxLk9wU2VsZWN0b3ISEwoLb25seV9mYWlsZWQYAiABKAgiRgoNRm9yZ2V0UmVxdWVzdBIPCgdyZXBvX2lkGAEgASgJEg8KB3BsYW5faWQYAiABKAkSEwoLc25hcHNob3RfaWQYAyABKAkiOAoUTGlzdFNuYXBzaG90c1JlcXVlc3QSDwoHcmVwb19pZBgBIAEoCRIPCgdwbGFuX2lkGAIgASgJIkgKFEdldE9wZXJhdGlvbnNSZXF1ZXN0EiAKCHNlbGVjdG9yGAEgASgLMg4udjEuT3BTZWxlY3RvchIOCgZsYXN0X24YAiABKAMibQoWUmVzdG9yZVNuYXBzaG90UmVxdWVzdBIPCgdwbGFuX2lkGAEgASgJEg8KB3JlcG9faWQYBSABKAkSEwoLc25hcHNob3RfaWQYAiABKAkSDAoEcGF0aBgDIAEoCRIOCgZ0YXJnZXQYBCABKAkiUAoYTGlzdFNuYXBzaG90RmlsZXNSZXF1ZXN0EhEKCXJlcG9fZ3VpZBgBIAEoCRITCgtzbmFwc2hvdF9pZBgCIAEoCRIMCgRwYXRoGAMgASgJIkcKGUxpc3RTbmFwc2hvdEZpbGVzUmVzcG9uc2USDAoEcGF0aBgBIAEoCRIcCgdlbnRyaWVzGAIgAygLMgsudjEuTHNFbnRyeSIdCg5Mb2dEYXRhUmVxdWVzdBILCgNyZWYYASABKAkiOQoVR2V0RG93bmxvYWRVUkxSZXF1ZXN0Eg0KBW9wX2lkGAEgASgDEhEKCWZpbGVfcGF0aBgCIAEoCSKWAQoHTHNFbnRyeRIMCgRuYW1lGAEgASgJEgwKBHR5cGUYAiABKAkSDAoEcGF0aBgDIAEoCRILCgN1aWQYBCABKAMSCwoDZ2lkGAUgASgDEgwKBHNpemUYBiABKAMSDAoEbW9kZRgHIAEoAxINCgVtdGltZRgIIAEoCRINCgVhdGltZRgJIAEoCRINCgVjdGltZRgKIAEoCSI1ChFSdW5Db21tYW5kUmVxdWVzdBIPCgdyZXBvX2lkGAEgASgJEg8KB2NvbW1hbmQYAiABKAkitQUKGFN1bW1hcnlEYXNoYm9hcmRSZXNwb25zZRI8Cg5yZXBvX3N1bW1hcmllcxgBIAMoCzIkLnYxLlN1bW1hcnlEYXNoYm9hcmRSZXNwb25zZS5TdW1tYXJ5EjwKDnBsYW5fc3VtbWFyaWVzGAIgAygLMiQudjEuU3VtbWFyeURhc2hib2FyZFJlc3BvbnNlLlN1bW1hcnkSEwoLY29uZmlnX3BhdGgYCiABKAkSEQoJZGF0YV9wYXRoGAsgASgJGu4CCgdTdW1tYXJ5EgoKAmlkGAEgASgJEh0KFWJhY2t1cHNfZmFpbGVkXzMwZGF5cxgCIAEoAxIjChtiYWNrdXBzX3dhcm5pbmdfbGFzdF8zMGRheXMYAyABKAMSIwobYmFja3Vwc19zdWNjZXNzX2xhc3RfMzBkYXlzGAQgASgDEiEKGWJ5dGVzX3NjYW5uZWRfbGFzdF8zMGRheXMYBSABKAMSHwoXYnl0ZXNfYWRkZWRfbGFzdF8zMGRheXMYBiABKAMSFwoPdG90YWxfc25hcHNob3RzGAcgASgDEhkKEWJ5dGVzX3NjYW5uZWRfYXZnGAggASgDEhcKD2J5dGVzX2FkZGVkX2F2ZxgJIAEoAxIbChNuZXh0X2JhY2t1cF90aW1lX21zGAogASgDEkAKDnJlY2VudF9iYWNrdXBzGAsgASgLMigudjEuU3VtbWFyeURhc2hib2FyZFJlc3BvbnNlLkJhY2t1cENoYXJ0GoMBCgtCYWNrdXBDaGFydBIPCgdmbG93X2lkGAEgAygDEhQKDHRpbWVzdGFtcF9tcxgCIAMoAxITCgtkdXJhdGlvbl9tcxgDIAMoAxIjCgZzdGF0dXMYBCADKA4yEy52MS5PcGVyYXRpb25TdGF0dXMSEwoLYnl0ZXNfYWRkZWQYBSADKAMyrwkKCEJhY2tyZXN0EjEKCUdldENvbmZpZxIWLmdvb2dsZS5wcm90b2J1Zi5FbXB0eRoKLnYxLkNvbmZpZyIAEiUKCVNldENvbmZpZxIKLnYxLkNvbmZpZxoKLnYxLkNvbmZpZyIAEi8KD0NoZWNrUmVwb0V4aXN0cxIILnYxLlJlcG8aEC50eXBlcy5Cb29sVmFsdWUiABIhCgdBZGRSZXBvEggudjEuUmVwbxoKLnYxLkNvbmZpZyIAEi4KClJlbW92ZVJlcG8SEi50eXBlcy5TdHJpbmdWYWx1ZRoKLnYxLkNvbmZpZyIAEkQKEkdldE9wZXJhdGlvbkV2ZW50cxIWLmdvb2dsZS5wcm90b2J1Zi5FbXB0eRoSLnYxLk9wZXJhdGlvbkV2ZW50IgAwARI+Cg1HZXRPcGVyYXRpb25zEhgudjEuR2V0T3BlcmF0aW9uc1JlcXVlc3QaES52MS5PcGVyYXRpb25MaXN0IgASQwoNTGlzdFNuYXBzaG90cxIYLnYxLkxpc3RTbmFwc2hvdHNSZXF1ZXN0GhYudjEuUmVzdGljU25hcHNob3RMaXN0IgASUgoRTGlzdFNuYXBzaG90RmlsZXMSHC52MS5MaXN0U25hcHNob3RGaWxlc1JlcXVlc3QaHS52MS5MaXN0U25hcHNob3RGaWxlc1Jlc3BvbnNlIgASNgoGQmFja3VwEhIudHlwZXMuU3RyaW5nVmFsdWUaFi5nb29nbGUucHJvdG9idWYuRW1wdHkiABI9CgpEb1JlcG9UYXNrEhUudjEuRG9SZXBvVGFza1JlcXVlc3QaFi5nb29nbGUucHJvdG9idWYuRW1wdHkiABI1CgZGb3JnZXQSES52MS5Gb3JnZXRSZXF1ZXN0GhYuZ29vZ2xlLnByb3RvYnVmLkVtcHR5IgASPwoHUmVzdG9yZRIaLnYxLlJlc3RvcmVTbmFwc2hvdFJlcXVlc3QaFi5nb29nbGUucHJvdG9idWYuRW1wdHkiABI1CgZDYW5jZWwSES50eXBlcy5JbnQ2NFZhbHVlGhYuZ29vZ2xlLnByb3RvYnVmLkVtcHR5IgASNAoHR2V0TG9ncxISLnYxLkxvZ0RhdGFSZXF1ZXN0GhEudHlwZXMuQnl0ZXNWYWx1ZSIAMAESOAoKUnVuQ29tbWFuZBIVLnYxLlJ1bkNvbW1hbmRSZXF1ZXN0GhEudHlwZXMuSW50NjRWYWx1ZSIAEkEKDkdldERvd25sb2FkVVJMEhkudjEuR2V0RG93bmxvYWRVUkxSZXF1ZXN0GhIudHlwZXMuU3RyaW5nVmFsdWUiABJBCgxDbGVhckhpc3RvcnkSFy52MS5DbGVhckhpc3RvcnlSZXF1ZXN0GhYuZ29vZ2xlLnByb3RvYnVmLkVtcHR5IgASOwoQUGF0aEF1dG9jb21wbGV0ZRISLnR5cGVzLlN0cmluZ1ZhbHVlGhEudHlwZXMuU3RyaW5nTGlzdCIAEk0KE0dldFN1bW1hcnlEYXNoYm9hcmQSFi5nb29nbGUucHJvdG9idWYuRW1wdHkaHC52MS5TdW1tYXJ5RGFzaGJvYXJkUmVzcG9uc2UiAEIsWipnaXRodWIuY29tL2dhcmV0aGdlb3JnZS9iYWNrcmVzdC9nZW4vZ28vdjFiBnByb3RvMw", [file_v1_config, file_v1_restic, file_v1_operations, file_types_value, file_google_protobuf_empty, file_google_api_annotations]);
+  fileDesc("ChB2MS9zZXJ2aWNlLnByb3RvEgJ2MSL1AwoKT3BTZWxlY3RvchILCgNpZHMYASADKAMSGAoLaW5zdGFuY2VfaWQYBiABKAlIAIgBARIkChdvcmlnaW5hbF9pbnN0YW5jZV9rZXlpZBgIIAEoCUgBiAEBEhYKCXJlcG9fZ3VpZBgHIAEoCUgCiAEBEhQKB3BsYW5faWQYAyABKAlIA4gBARIYCgtzbmFwc2hvdF9pZBgEIAEoCUgEiAEBEhQKB2Zsb3dfaWQYBSABKANIBYgBARIWCgltb2Rub19ndGUYCSABKANIBogBARIeChFzdGFydF90aW1lX2d0ZV9tcxgKIAEoA0gHiAEBEh0KEHN0YXJ0X3RpbWVfbHRfbXMYCyABKANICIgBARIlCghzdGF0dXNlcxgMIAMoDjITLnYxLk9wZXJhdGlvblN0YXR1cxIjCghvcF90eXBlcxgNIAMoDjIRLnYxLk9wZXJhdGlvblR5cGVCDgoMX2luc3RhbmNlX2lkQhoKGF9vcmlnaW5hbF9pbnN0YW5jZV9rZXlpZEIMCgpfcmVwb19ndWlkQgoKCF9wbGFuX2lkQg4KDF9zbmFwc2hvdF9pZEIKCghfZmxvd19pZEIMCgpfbW9kbm9fZ3RlQhQKEl9zdGFydF90aW1lX2d0ZV9tc0ITChFfc3RhcnRfdGltZV9sdF9tcyJJChBTZXRDb25maWdSZXF1ZXN0EhoKBmNvbmZpZxgBIAEoCzIKLnYxLkNvbmZpZxIZChFjb25maXJtX3JldGVudGlvbhgCIAEoCCLSAQoRRG9SZXBvVGFza1JlcXVlc3QSDwoHcmVwb19pZBgBIAEoCRIoCgR0YXNrGAIgASgOMhoudjEuRG9SZXBvVGFza1JlcXVlc3QuVGFzayKBAQoEVGFzaxINCglUQVNLX05PTkUQABIYChRUQVNLX0lOREVYX1NOQVBTSE9UUxABEg4KClRBU0tfUFJVTkUQAhIOCgpUQVNLX0NIRUNLEAMSDgoKVEFTS19TVEFUUxAEEg8KC1RBU0tfVU5MT0NLEAUSDwoLVEFTS19GT1JHRVQQBiJMChNDbGVhckhpc3RvcnlSZXF1ZXN0EiAKCHNlbGVjdG9yGAEgASgLMg4udjEuT3BTZWxlY3RvchITCgtvbmx5X2ZhaWxlZBgCIAEoCCJGCg1Gb3JnZXRSZXF1ZXN0Eg8KB3JlcG9faWQYASABKAkSDwoHcGxhbl9pZBgCIAEoCRITCgtzbmFwc2hvdF9pZBgDIAEoCSJPChdQcmV2aWV3UmV0ZW50aW9uUmVxdWVzdBIPCgdwbGFuX2lkGAEgASgJEiMKBnBvbGljeRgCIAEoCzITLnYxLlJldGVudGlvblBvbGljeSJEChhQcmV2aWV3UmV0ZW50aW9uUmVzcG9uc2USKAoJZGVjaXNpb25zGAEgAygLMhUudjEuUmV0ZW50aW9uRGVjaXNpb24iWAoRUmV0ZW50aW9uRGVjaXNpb24SJAoIc25hcHNob3QYASABKAsyEi52MS5SZXN0aWNTbmFwc2hvdBIMCgRrZWVwGAIgASgIEg8KB3JlYXNvbnMYAyADKAkiZQoVQWRvcHRTbmFwc2hvdHNSZXF1ZXN0Eg8KB3BsYW5faWQYASABKAkSDQoFaG9zdHMYAiADKAkSDQoFcGF0aHMYAyADKAkSDAoEdGFncxgEIAMoCRIPCgdkcnlfcnVuGAUgASgIIj8KFkFkb3B0U25hcHNob3RzUmVzcG9uc2USJQoJc25hcHNob3RzGAEgAygLMhIudjEuUmVzdGljU25hcHNob3QiYwoTSG9sZFNuYXBzaG90UmVxdWVzdBIPCgdyZXBvX2lkGAEgASgJEhMKC3NuYXBzaG90X2lkGAIgASgJEg4KBnJlYXNvbhgDIAEoCRIWCg5leHBpcmVfdW5peF9tcxgEIAEoAyJCChpSZWxlYXNlU25hcHNob3RIb2xkUmVxdWVzdBIPCgdyZXBvX2lkGAEgASgJEhMKC3NuYXBzaG90X2lkGAIgASgJIjgKFExpc3RTbmFwc2hvdHNSZXF1ZXN0Eg8KB3JlcG9faWQYASABKAkSDwoHcGxhbl9pZBgCIAEoCSJIChRHZXRPcGVyYXRpb25zUmVxdWVzdBIgCghzZWxlY3RvchgBIAEoCzIOLnYxLk9wU2VsZWN0b3ISDgoGbGFzdF9uGAIgASgDIm0KFlJlc3RvcmVTbmFwc2hvdFJlcXVlc3QSDwoHcGxhbl9pZBgBIAEoCRIPCgdyZXBvX2lkGAUgASgJEhMKC3NuYXBzaG90X2lkGAIgASgJEgwKBHBhdGgYAyABKAkSDgoGdGFyZ2V0GAQgASgJIlAKGExpc3RTbmFwc2hvdEZpbGVzUmVxdWVzdBIRCglyZXBvX2d1aWQYASABKAkSEwoLc25hcHNob3RfaWQYAiABKAkSDAoEcGF0aBgDIAEoCSJHChlMaXN0U25hcHNob3RGaWxlc1Jlc3BvbnNlEgwKBHBhdGgYASABKAkSHAoHZW50cmllcxgCIAMoCzILLnYxLkxzRW50cnkiHQoOTG9nRGF0YVJlcXVlc3QSCwoDcmVmGAEgASgJIjkKFUdldERvd25sb2FkVVJMUmVxdWVzdBINCgVvcF9pZBgBIAEoAxIRCglmaWxlX3BhdGgYAiABKAkilgEKB0xzRW50cnkSDAoEbmFtZRgBIAEoCRIMCgR0eXBlGAIgASgJEgwKBHBhdGgYAyABKAkSCwoDdWlkGAQgASgDEgsKA2dpZBgFIAEoAxIMCgRzaXplGAYgASgDEgwKBG1vZGUYByABKAMSDQoFbXRpbWUYCCABKAkSDQoFYXRpbWUYCSABKAkSDQoFY3RpbWUYCiABKAkiNQoRUnVuQ29tbWFuZFJlcXVlc3QSDwoHcmVwb19pZBgBIAEoCRIPCgdjb21tYW5kGAIgASgJInIKD1Rlc3RIb29rUmVxdWVzdBIWCgRob29rGAEgASgLMggudjEuSG9vaxIlCgljb25kaXRpb24YAiABKA4yEi52MS5Ib29rLkNvbmRpdGlvbhIPCgdyZXBvX2lkGAMgASgJEg8KB3BsYW5faWQYBCABKAkiYAoQVGVzdEhvb2tSZXNwb25zZRIPCgdwYXlsb2FkGAEgASgJEg4KBm91dHB1dBgCIAEoCRINCgVlcnJvchgDIAEoCRIcChRleGFtcGxlX29wZXJhdGlvbl9pZBgEIAEoAyKCAQoXU2VhcmNoT3BlcmF0aW9uc1JlcXVlc3QSDQoFcXVlcnkYASABKAkSEQoJcmF3X3F1ZXJ5GAIgASgIEiAKCHNlbGVjdG9yGAMgASgLMg4udjEuT3BTZWxlY3RvchIUCgxpbmNsdWRlX2xvZ3MYBCABKAgSDQoFbGltaXQYBSABKAUiRwoYU2VhcmNoT3BlcmF0aW9uc1Jlc3BvbnNlEisKB3Jlc3VsdHMYASADKAsyGi52MS5TZWFyY2hPcGVyYXRpb25zUmVzdWx0InQKFlNlYXJjaE9wZXJhdGlvbnNSZXN1bHQSIAoJb3BlcmF0aW9uGAEgASgLMg0udjEuT3BlcmF0aW9uEg0KBWZpZWxkGAIgASgJEikKB3NuaXBwZXQYAyADKAsyGC52MS5TZWFyY2hTbmlwcGV0U2VnbWVudCI3ChRTZWFyY2hTbmlwcGV0U2VnbWVudBIMCgR0ZXh0GAEgASgJEhEKCWhpZ2hsaWdodBgCIAEoCCKbAQoXRXhwb3J0T3BlcmF0aW9uc1JlcXVlc3QSIAoIc2VsZWN0b3IYASABKAsyDi52MS5PcFNlbGVjdG9yEjIKBmZvcm1hdBgCIAEoDjIiLnYxLkV4cG9ydE9wZXJhdGlvbnNSZXF1ZXN0LkZvcm1hdCIqCgZGb3JtYXQSEAoMRk9STUFUX0pTT05MEAASDgoKRk9STUFUX0NTVhABIicKF0ltcG9ydE9wZXJhdGlvbnNSZXF1ZXN0EgwKBGRhdGEYASABKAwiPQoYSW1wb3J0T3BlcmF0aW9uc1Jlc3BvbnNlEhAKCGltcG9ydGVkGAEgASgDEg8KB3NraXBwZWQYAiABKAMiowIKDUxvZ1N0b3JlU3RhdHMSEQoJbG9nX2NvdW50GAEgASgDEhMKC3RvdGFsX2J5dGVzGAIgASgDEhgKEGlucHJvZ3Jlc3NfY291bnQYAyABKAMSGAoQaW5wcm9ncmVzc19ieXRlcxgEIAEoAxITCgtxdW90YV9ieXRlcxgFIAEoAxI/ChFieV9vcGVyYXRpb25fdHlwZRgGIAMoCzIkLnYxLkxvZ1N0b3JlU3RhdHMuT3BlcmF0aW9uVHlwZVVzYWdlGmAKEk9wZXJhdGlvblR5cGVVc2FnZRIiCgdvcF90eXBlGAEgASgOMhEudjEuT3BlcmF0aW9uVHlwZRIRCglsb2dfY291bnQYAiABKAMSEwoLdG90YWxfYnl0ZXMYAyABKAMiPQoYR2V0QmFja3VwVGltZWxpbmVSZXF1ZXN0Eg0KBW9wX2lkGAEgASgDEhIKCm1heF9wb2ludHMYAiABKAUixAEKGUdldEJhY2t1cFRpbWVsaW5lUmVzcG9uc2USJwoGcG9pbnRzGAEgAygLMhcudjEuQmFja3VwVGltZWxpbmVQb2ludBI9Cg1zbG93ZXN0X2ZpbGVzGAIgAygLMiYudjEuR2V0QmFja3VwVGltZWxpbmVSZXNwb25zZS5GaWxlVGltZRIQCghjb21wbGV0ZRgDIAEoCBotCghGaWxlVGltZRIMCgRwYXRoGAEgASgJEhMKC2R1cmF0aW9uX21zGAIgASgDIrUFChhTdW1tYXJ5RGFzaGJvYXJkUmVzcG9uc2USPAoOcmVwb19zdW1tYXJpZXMYASADKAsyJC52MS5TdW1tYXJ5RGFzaGJvYXJkUmVzcG9uc2UuU3VtbWFyeRI8Cg5wbGFuX3N1bW1hcmllcxgCIAMoCzIkLnYxLlN1bW1hcnlEYXNoYm9hcmRSZXNwb25zZS5TdW1tYXJ5EhMKC2NvbmZpZ19wYXRoGAogASgJEhEKCWRhdGFfcGF0aBgLIAEoCRruAgoHU3VtbWFyeRIKCgJpZBgBIAEoCRIdChViYWNrdXBzX2ZhaWxlZF8zMGRheXMYAiABKAMSIwobYmFja3Vwc193YXJuaW5nX2xhc3RfMzBkYXlzGAMgASgDEiMKG2JhY2t1cHNfc3VjY2Vzc19sYXN0XzMwZGF5cxgEIAEoAxIhChlieXRlc19zY2FubmVkX2xhc3RfMzBkYXlzGAUgASgDEh8KF2J5dGVzX2FkZGVkX2xhc3RfMzBkYXlzGAYgASgDEhcKD3RvdGFsX3NuYXBzaG90cxgHIAEoAxIZChFieXRlc19zY2FubmVkX2F2ZxgIIAEoAxIXCg9ieXRlc19hZGRlZF9hdmcYCSABKAMSGwoTbmV4dF9iYWNrdXBfdGltZV9tcxgKIAEoAxJACg5yZWNlbnRfYmFja3VwcxgLIAEoCzIoLnYxLlN1bW1hcnlEYXNoYm9hcmRSZXNwb25zZS5CYWNrdXBDaGFydBqDAQoLQmFja3VwQ2hhcnQSDwoHZmxvd19pZBgBIAMoAxIUCgx0aW1lc3RhbXBfbXMYAiADKAMSEwoLZHVyYXRpb25fbXMYAyADKAMSIwoGc3RhdHVzGAQgAygOMhMudjEuT3BlcmF0aW9uU3RhdHVzEhMKC2J5dGVzX2FkZGVkGAUgAygDMtMPCghCYWNrcmVzdBIxCglHZXRDb25maWcSFi5nb29nbGUucHJvdG9idWYuRW1wdHkaCi52MS5Db25maWciABIlCglTZXRDb25maWcSCi52MS5Db25maWcaCi52MS5Db25maWciABI6ChRTZXRDb25maWdXaXRoT3B0aW9ucxIULnYxLlNldENvbmZpZ1JlcXVlc3QaCi52MS5Db25maWciABIvCg9DaGVja1JlcG9FeGlzdHMSCC52MS5SZXBvGhAudHlwZXMuQm9vbFZhbHVlIgASIQoHQWRkUmVwbxIILnYxLlJlcG8aCi52MS5Db25maWciABIuCgpSZW1vdmVSZXBvEhIudHlwZXMuU3RyaW5nVmFsdWUaCi52MS5Db25maWciABJEChJHZXRPcGVyYXRpb25FdmVudHMSFi5nb29nbGUucHJvdG9idWYuRW1wdHkaEi52MS5PcGVyYXRpb25FdmVudCIAMAESPgoNR2V0T3BlcmF0aW9ucxIYLnYxLkdldE9wZXJhdGlvbnNSZXF1ZXN0GhEudjEuT3BlcmF0aW9uTGlzdCIAEkMKDUxpc3RTbmFwc2hvdHMSGC52MS5MaXN0U25hcHNob3RzUmVxdWVzdBoWLnYxLlJlc3RpY1NuYXBzaG90TGlzdCIAElIKEUxpc3RTbmFwc2hvdEZpbGVzEhwudjEuTGlzdFNuYXBzaG90RmlsZXNSZXF1ZXN0Gh0udjEuTGlzdFNuYXBzaG90RmlsZXNSZXNwb25zZSIAEjYKBkJhY2t1cBISLnR5cGVzLlN0cmluZ1ZhbHVlGhYuZ29vZ2xlLnByb3RvYnVmLkVtcHR5IgASPQoKRG9SZXBvVGFzaxIVLnYxLkRvUmVwb1Rhc2tSZXF1ZXN0GhYuZ29vZ2xlLnByb3RvYnVmLkVtcHR5IgASNQoGRm9yZ2V0EhEudjEuRm9yZ2V0UmVxdWVzdBoWLmdvb2dsZS5wcm90b2J1Zi5FbXB0eSIAEk8KEFByZXZpZXdSZXRlbnRpb24SGy52MS5QcmV2aWV3UmV0ZW50aW9uUmVxdWVzdBocLnYxLlByZXZpZXdSZXRlbnRpb25SZXNwb25zZSIAEkkKDkFkb3B0U25hcHNob3RzEhkudjEuQWRvcHRTbmFwc2hvdHNSZXF1ZXN0GhoudjEuQWRvcHRTbmFwc2hvdHNSZXNwb25zZSIAEkEKDEhvbGRTbmFwc2hvdBIXLnYxLkhvbGRTbmFwc2hvdFJlcXVlc3QaFi5nb29nbGUucHJvdG9idWYuRW1wdHkiABJPChNSZWxlYXNlU25hcHNob3RIb2xkEh4udjEuUmVsZWFzZVNuYXBzaG90SG9sZFJlcXVlc3QaFi5nb29nbGUucHJvdG9idWYuRW1wdHkiABI/CgdSZXN0b3JlEhoudjEuUmVzdG9yZVNuYXBzaG90UmVxdWVzdBoWLmdvb2dsZS5wcm90b2J1Zi5FbXB0eSIAEjUKBkNhbmNlbBIRLnR5cGVzLkludDY0VmFsdWUaFi5nb29nbGUucHJvdG9idWYuRW1wdHkiABI0CgdHZXRMb2dzEhIudjEuTG9nRGF0YVJlcXVlc3QaES50eXBlcy5CeXRlc1ZhbHVlIgAwARI4CgpSdW5Db21tYW5kEhUudjEuUnVuQ29tbWFuZFJlcXVlc3QaES50eXBlcy5JbnQ2NFZhbHVlIgASQQoOR2V0RG93bmxvYWRVUkwSGS52MS5HZXREb3dubG9hZFVSTFJlcXVlc3QaEi50eXBlcy5TdHJpbmdWYWx1ZSIAEkEKDENsZWFySGlzdG9yeRIXLnYxLkNsZWFySGlzdG9yeVJlcXVlc3QaFi5nb29nbGUucHJvdG9idWYuRW1wdHkiABI7ChBQYXRoQXV0b2NvbXBsZXRlEhIudHlwZXMuU3RyaW5nVmFsdWUaES50eXBlcy5TdHJpbmdMaXN0IgASTQoTR2V0U3VtbWFyeURhc2hib2FyZBIWLmdvb2dsZS5wcm90b2J1Zi5FbXB0eRocLnYxLlN1bW1hcnlEYXNoYm9hcmRSZXNwb25zZSIAEjcKCFRlc3RIb29rEhMudjEuVGVzdEhvb2tSZXF1ZXN0GhQudjEuVGVzdEhvb2tSZXNwb25zZSIAEk8KEFNlYXJjaE9wZXJhdGlvbnMSGy52MS5TZWFyY2hPcGVyYXRpb25zUmVxdWVzdBocLnYxLlNlYXJjaE9wZXJhdGlvbnNSZXNwb25zZSIAEkYKEEV4cG9ydE9wZXJhdGlvbnMSGy52MS5FeHBvcnRPcGVyYXRpb25zUmVxdWVzdBoRLnR5cGVzLkJ5dGVzVmFsdWUiADABEk8KEEltcG9ydE9wZXJhdGlvbnMSGy52MS5JbXBvcnRPcGVyYXRpb25zUmVxdWVzdBocLnYxLkltcG9ydE9wZXJhdGlvbnNSZXNwb25zZSIAEj8KEEdldExvZ1N0b3JlU3RhdHMSFi5nb29nbGUucHJvdG9idWYuRW1wdHkaES52MS5Mb2dTdG9yZVN0YXRzIgASUgoRR2V0QmFja3VwVGltZWxpbmUSHC52MS5HZXRCYWNrdXBUaW1lbGluZVJlcXVlc3QaHS52MS5HZXRCYWNrdXBUaW1lbGluZVJlc3BvbnNlIgBCLFoqZ2l0aHViLmNvbS9nYXJldGhnZW9yZ2UvYmFja3Jlc3QvZ2VuL2dvL3YxYgZwcm90bzM", [file_v1_config, file_v1_restic, file_v1_operations, file_types_value, file_google_protobuf_empty, file_google_api_annotations]);
 
 /**
  * OpSelector is a message that can be used to select operations e.g. by query.
@@ -68,6 +68,34 @@ export type OpSelector = Message<"v1.OpSelector"> & {
    * @generated from field: optional int64 modno_gte = 9;
    */
   modnoGte?: bigint;
+
+  /**
+   * selects operations that started at or after this time.
+   *
+   * @generated from field: optional int64 start_time_gte_ms = 10;
+   */
+  startTimeGteMs?: bigint;
+
+  /**
+   * selects operations that started before this time.
+   *
+   * @generated from field: optional int64 start_time_lt_ms = 11;
+   */
+  startTimeLtMs?: bigint;
+
+  /**
+   * selects operations with any of these statuses.
+   *
+   * @generated from field: repeated v1.OperationStatus statuses = 12;
+   */
+  statuses: OperationStatus[];
+
+  /**
+   * selects operations of any of these types.
+   *
+   * @generated from field: repeated v1.OperationType op_types = 13;
+   */
+  opTypes: OperationType[];
 };
 
 /**
@@ -77,6 +105,30 @@ export type OpSelector = Message<"v1.OpSelector"> & {
 export const OpSelectorSchema: GenMessage<OpSelector> = /*@__PURE__*/
   messageDesc(file_v1_service, 0);
 
+/**
+ * @generated from message v1.SetConfigRequest
+ */
+export type SetConfigRequest = Message<"v1.SetConfigRequest"> & {
+  /**
+   * @generated from field: v1.Config config = 1;
+   */
+  config?: Config;
+
+  /**
+   * applies retention policy changes that would remove more snapshots than the confirmation threshold.
+   *
+   * @generated from field: bool confirm_retention = 2;
+   */
+  confirmRetention: boolean;
+};
+
+/**
+ * Describes the message v1.SetConfigRequest.
+ * Use `create(SetConfigRequestSchema)` to create a new message.
+ */
+export const SetConfigRequestSchema: GenMessage<SetConfigRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service, 1);
+
 /**
  * @generated from message v1.DoRepoTaskRequest
  */
@@ -97,7 +149,7 @@ export type DoRepoTaskRequest = Message<"v1.DoRepoTaskRequest"> & {
  * Use `create(DoRepoTaskRequestSchema)` to create a new message.
  */
 export const DoRepoTaskRequestSchema: GenMessage<DoRepoTaskRequest> = /*@__PURE__*/
-  messageDesc(file_v1_service, 1);
+  messageDesc(file_v1_service, 2);
 
 /**
  * @generated from enum v1.DoRepoTaskRequest.Task
@@ -132,13 +184,20 @@ export enum DoRepoTaskRequest_Task {
    * @generated from enum value: TASK_UNLOCK = 5;
    */
   UNLOCK = 5,
+
+  /**
+   * applies the repo's retention policy.
+   *
+   * @generated from enum value: TASK_FORGET = 6;
+   */
+  FORGET = 6,
 }
 
 /**
  * Describes the enum v1.DoRepoTaskRequest.Task.
  */
 export const DoRepoTaskRequest_TaskSchema: GenEnum<DoRepoTaskRequest_Task> = /*@__PURE__*/
-  enumDesc(file_v1_service, 1, 0);
+  enumDesc(file_v1_service, 2, 0);
 
 /**
  * @generated from message v1.ClearHistoryRequest
@@ -160,7 +219,7 @@ export type ClearHistoryRequest = Message<"v1.ClearHistoryRequest"> & {
  * Use `create(ClearHistoryRequestSchema)` to create a new message.
  */
 export const ClearHistoryRequestSchema: GenMessage<ClearHistoryRequest> = /*@__PURE__*/
-  messageDesc(file_v1_service, 2);
+  messageDesc(file_v1_service, 3);
 
 /**
  * @generated from message v1.ForgetRequest
@@ -187,7 +246,203 @@ export type ForgetRequest = Message<"v1.ForgetRequest"> & {
  * Use `create(ForgetRequestSchema)` to create a new message.
  */
 export const ForgetRequestSchema: GenMessage<ForgetRequest> = /*@__PURE__*/
-  messageDesc(file_v1_service, 3);
+  messageDesc(file_v1_service, 4);
+
+/**
+ * @generated from message v1.PreviewRetentionRequest
+ */
+export type PreviewRetentionRequest = Message<"v1.PreviewRetentionRequest"> & {
+  /**
+   * @generated from field: string plan_id = 1;
+   */
+  planId: string;
+
+  /**
+   * optional, the candidate policy to evaluate. Defaults to the plan's current policy.
+   *
+   * @generated from field: v1.RetentionPolicy policy = 2;
+   */
+  policy?: RetentionPolicy;
+};
+
+/**
+ * Describes the message v1.PreviewRetentionRequest.
+ * Use `create(PreviewRetentionRequestSchema)` to create a new message.
+ */
+export const PreviewRetentionRequestSchema: GenMessage<PreviewRetentionRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service, 5);
+
+/**
+ * @generated from message v1.PreviewRetentionResponse
+ */
+export type PreviewRetentionResponse = Message<"v1.PreviewRetentionResponse"> & {
+  /**
+   * one decision per snapshot, newest first.
+   *
+   * @generated from field: repeated v1.RetentionDecision decisions = 1;
+   */
+  decisions: RetentionDecision[];
+};
+
+/**
+ * Describes the message v1.PreviewRetentionResponse.
+ * Use `create(PreviewRetentionResponseSchema)` to create a new message.
+ */
+export const PreviewRetentionResponseSchema: GenMessage<PreviewRetentionResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service, 6);
+
+/**
+ * @generated from message v1.RetentionDecision
+ */
+export type RetentionDecision = Message<"v1.RetentionDecision"> & {
+  /**
+   * @generated from field: v1.ResticSnapshot snapshot = 1;
+   */
+  snapshot?: ResticSnapshot;
+
+  /**
+   * @generated from field: bool keep = 2;
+   */
+  keep: boolean;
+
+  /**
+   * the rules that kept the snapshot e.g. "daily snapshot", empty if the snapshot would be removed.
+   *
+   * @generated from field: repeated string reasons = 3;
+   */
+  reasons: string[];
+};
+
+/**
+ * Describes the message v1.RetentionDecision.
+ * Use `create(RetentionDecisionSchema)` to create a new message.
+ */
+export const RetentionDecisionSchema: GenMessage<RetentionDecision> = /*@__PURE__*/
+  messageDesc(file_v1_service, 7);
+
+/**
+ * @generated from message v1.AdoptSnapshotsRequest
+ */
+export type AdoptSnapshotsRequest = Message<"v1.AdoptSnapshotsRequest"> & {
+  /**
+   * the plan to assign snapshots to, snapshots are selected from the plan's repo.
+   *
+   * @generated from field: string plan_id = 1;
+   */
+  planId: string;
+
+  /**
+   * optional, only select snapshots from one of these hosts.
+   *
+   * @generated from field: repeated string hosts = 2;
+   */
+  hosts: string[];
+
+  /**
+   * optional, only select snapshots that include all of these paths.
+   *
+   * @generated from field: repeated string paths = 3;
+   */
+  paths: string[];
+
+  /**
+   * optional, only select snapshots that have all of these tags.
+   *
+   * @generated from field: repeated string tags = 4;
+   */
+  tags: string[];
+
+  /**
+   * if set, the selected snapshots are returned but not adopted.
+   *
+   * @generated from field: bool dry_run = 5;
+   */
+  dryRun: boolean;
+};
+
+/**
+ * Describes the message v1.AdoptSnapshotsRequest.
+ * Use `create(AdoptSnapshotsRequestSchema)` to create a new message.
+ */
+export const AdoptSnapshotsRequestSchema: GenMessage<AdoptSnapshotsRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service, 8);
+
+/**
+ * @generated from message v1.AdoptSnapshotsResponse
+ */
+export type AdoptSnapshotsResponse = Message<"v1.AdoptSnapshotsResponse"> & {
+  /**
+   * the snapshots that were adopted (or would be adopted for a dry run).
+   *
+   * @generated from field: repeated v1.ResticSnapshot snapshots = 1;
+   */
+  snapshots: ResticSnapshot[];
+};
+
+/**
+ * Describes the message v1.AdoptSnapshotsResponse.
+ * Use `create(AdoptSnapshotsResponseSchema)` to create a new message.
+ */
+export const AdoptSnapshotsResponseSchema: GenMessage<AdoptSnapshotsResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service, 9);
+
+/**
+ * @generated from message v1.HoldSnapshotRequest
+ */
+export type HoldSnapshotRequest = Message<"v1.HoldSnapshotRequest"> & {
+  /**
+   * @generated from field: string repo_id = 1;
+   */
+  repoId: string;
+
+  /**
+   * @generated from field: string snapshot_id = 2;
+   */
+  snapshotId: string;
+
+  /**
+   * optional, a human readable reason for the hold.
+   *
+   * @generated from field: string reason = 3;
+   */
+  reason: string;
+
+  /**
+   * optional, the time at which the hold expires. If unset the snapshot is held indefinitely.
+   *
+   * @generated from field: int64 expire_unix_ms = 4;
+   */
+  expireUnixMs: bigint;
+};
+
+/**
+ * Describes the message v1.HoldSnapshotRequest.
+ * Use `create(HoldSnapshotRequestSchema)` to create a new message.
+ */
+export const HoldSnapshotRequestSchema: GenMessage<HoldSnapshotRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service, 10);
+
+/**
+ * @generated from message v1.ReleaseSnapshotHoldRequest
+ */
+export type ReleaseSnapshotHoldRequest = Message<"v1.ReleaseSnapshotHoldRequest"> & {
+  /**
+   * @generated from field: string repo_id = 1;
+   */
+  repoId: string;
+
+  /**
+   * @generated from field: string snapshot_id = 2;
+   */
+  snapshotId: string;
+};
+
+/**
+ * Describes the message v1.ReleaseSnapshotHoldRequest.
+ * Use `create(ReleaseSnapshotHoldRequestSchema)` to create a new message.
+ */
+export const ReleaseSnapshotHoldRequestSchema: GenMessage<ReleaseSnapshotHoldRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service, 11);
 
 /**
  * @generated from message v1.ListSnapshotsRequest
@@ -209,7 +464,7 @@ export type ListSnapshotsRequest = Message<"v1.ListSnapshotsRequest"> & {
  * Use `create(ListSnapshotsRequestSchema)` to create a new message.
  */
 export const ListSnapshotsRequestSchema: GenMessage<ListSnapshotsRequest> = /*@__PURE__*/
-  messageDesc(file_v1_service, 4);
+  messageDesc(file_v1_service, 12);
 
 /**
  * @generated from message v1.GetOperationsRequest
@@ -233,7 +488,7 @@ export type GetOperationsRequest = Message<"v1.GetOperationsRequest"> & {
  * Use `create(GetOperationsRequestSchema)` to create a new message.
  */
 export const GetOperationsRequestSchema: GenMessage<GetOperationsRequest> = /*@__PURE__*/
-  messageDesc(file_v1_service, 5);
+  messageDesc(file_v1_service, 13);
 
 /**
  * @generated from message v1.RestoreSnapshotRequest
@@ -270,7 +525,7 @@ export type RestoreSnapshotRequest = Message<"v1.RestoreSnapshotRequest"> & {
  * Use `create(RestoreSnapshotRequestSchema)` to create a new message.
  */
 export const RestoreSnapshotRequestSchema: GenMessage<RestoreSnapshotRequest> = /*@__PURE__*/
-  messageDesc(file_v1_service, 6);
+  messageDesc(file_v1_service, 14);
 
 /**
  * @generated from message v1.ListSnapshotFilesRequest
@@ -297,7 +552,7 @@ export type ListSnapshotFilesRequest = Message<"v1.ListSnapshotFilesRequest"> &
  * Use `create(ListSnapshotFilesRequestSchema)` to create a new message.
  */
 export const ListSnapshotFilesRequestSchema: GenMessage<ListSnapshotFilesRequest> = /*@__PURE__*/
-  messageDesc(file_v1_service, 7);
+  messageDesc(file_v1_service, 15);
 
 /**
  * @generated from message v1.ListSnapshotFilesResponse
@@ -319,7 +574,7 @@ export type ListSnapshotFilesResponse = Message<"v1.ListSnapshotFilesResponse">
  * Use `create(ListSnapshotFilesResponseSchema)` to create a new message.
  */
 export const ListSnapshotFilesResponseSchema: GenMessage<ListSnapshotFilesResponse> = /*@__PURE__*/
-  messageDesc(file_v1_service, 8);
+  messageDesc(file_v1_service, 16);
 
 /**
  * @generated from message v1.LogDataRequest
@@ -336,7 +591,7 @@ export type LogDataRequest = Message<"v1.LogDataRequest"> & {
  * Use `create(LogDataRequestSchema)` to create a new message.
  */
 export const LogDataRequestSchema: GenMessage<LogDataRequest> = /*@__PURE__*/
-  messageDesc(file_v1_service, 9);
+  messageDesc(file_v1_service, 17);
 
 /**
  * @generated from message v1.GetDownloadURLRequest
@@ -358,7 +613,7 @@ export type GetDownloadURLRequest = Message<"v1.GetDownloadURLRequest"> & {
  * Use `create(GetDownloadURLRequestSchema)` to create a new message.
  */
 export const GetDownloadURLRequestSchema: GenMessage<GetDownloadURLRequest> = /*@__PURE__*/
-  messageDesc(file_v1_service, 10);
+  messageDesc(file_v1_service, 18);
 
 /**
  * @generated from message v1.LsEntry
@@ -420,7 +675,7 @@ export type LsEntry = Message<"v1.LsEntry"> & {
  * Use `create(LsEntrySchema)` to create a new message.
  */
 export const LsEntrySchema: GenMessage<LsEntry> = /*@__PURE__*/
-  messageDesc(file_v1_service, 11);
+  messageDesc(file_v1_service, 19);
 
 /**
  * @generated from message v1.RunCommandRequest
@@ -442,7 +697,462 @@ export type RunCommandRequest = Message<"v1.RunCommandRequest"> & {
  * Use `create(RunCommandRequestSchema)` to create a new message.
  */
 export const RunCommandRequestSchema: GenMessage<RunCommandRequest> = /*@__PURE__*/
-  messageDesc(file_v1_service, 12);
+  messageDesc(file_v1_service, 20);
+
+/**
+ * @generated from message v1.TestHookRequest
+ */
+export type TestHookRequest = Message<"v1.TestHookRequest"> & {
+  /**
+   * @generated from field: v1.Hook hook = 1;
+   */
+  hook?: Hook;
+
+  /**
+   * the event the hook is run for.
+   *
+   * @generated from field: v1.Hook.Condition condition = 2;
+   */
+  condition: Hook_Condition;
+
+  /**
+   * the repo the hook runs for, its operations provide the example variables.
+   *
+   * @generated from field: string repo_id = 3;
+   */
+  repoId: string;
+
+  /**
+   * optional, the plan the hook runs for, its operations are preferred for the example variables.
+   *
+   * @generated from field: string plan_id = 4;
+   */
+  planId: string;
+};
+
+/**
+ * Describes the message v1.TestHookRequest.
+ * Use `create(TestHookRequestSchema)` to create a new message.
+ */
+export const TestHookRequestSchema: GenMessage<TestHookRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service, 21);
+
+/**
+ * @generated from message v1.TestHookResponse
+ */
+export type TestHookResponse = Message<"v1.TestHookResponse"> & {
+  /**
+   * the hook's rendered template, or the rendered script for command hooks.
+   *
+   * @generated from field: string payload = 1;
+   */
+  payload: string;
+
+  /**
+   * the log written by the hook, includes responses from notification services.
+   *
+   * @generated from field: string output = 2;
+   */
+  output: string;
+
+  /**
+   * the error returned by the hook e.g. a template or delivery error, empty on success.
+   *
+   * @generated from field: string error = 3;
+   */
+  error: string;
+
+  /**
+   * the operation the example variables were built from, 0 if none was found.
+   *
+   * @generated from field: int64 example_operation_id = 4;
+   */
+  exampleOperationId: bigint;
+};
+
+/**
+ * Describes the message v1.TestHookResponse.
+ * Use `create(TestHookResponseSchema)` to create a new message.
+ */
+export const TestHookResponseSchema: GenMessage<TestHookResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service, 22);
+
+/**
+ * @generated from message v1.SearchOperationsRequest
+ */
+export type SearchOperationsRequest = Message<"v1.SearchOperationsRequest"> & {
+  /**
+   * words that must all match, or an SQLite FTS5 query if raw_query is set.
+   *
+   * @generated from field: string query = 1;
+   */
+  query: string;
+
+  /**
+   * query uses the FTS5 query syntax e.g. "permission NEAR denied" or "lock* OR timeout".
+   *
+   * @generated from field: bool raw_query = 2;
+   */
+  rawQuery: boolean;
+
+  /**
+   * optional, restricts results to the selected operations.
+   *
+   * @generated from field: v1.OpSelector selector = 3;
+   */
+  selector?: OpSelector;
+
+  /**
+   * also match operation logs, only available if the server indexes logs.
+   *
+   * @generated from field: bool include_logs = 4;
+   */
+  includeLogs: boolean;
+
+  /**
+   * maximum number of results, defaults to 100.
+   *
+   * @generated from field: int32 limit = 5;
+   */
+  limit: number;
+};
+
+/**
+ * Describes the message v1.SearchOperationsRequest.
+ * Use `create(SearchOperationsRequestSchema)` to create a new message.
+ */
+export const SearchOperationsRequestSchema: GenMessage<SearchOperationsRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service, 23);
+
+/**
+ * @generated from message v1.SearchOperationsResponse
+ */
+export type SearchOperationsResponse = Message<"v1.SearchOperationsResponse"> & {
+  /**
+   * @generated from field: repeated v1.SearchOperationsResult results = 1;
+   */
+  results: SearchOperationsResult[];
+};
+
+/**
+ * Describes the message v1.SearchOperationsResponse.
+ * Use `create(SearchOperationsResponseSchema)` to create a new message.
+ */
+export const SearchOperationsResponseSchema: GenMessage<SearchOperationsResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service, 24);
+
+/**
+ * @generated from message v1.SearchOperationsResult
+ */
+export type SearchOperationsResult = Message<"v1.SearchOperationsResult"> & {
+  /**
+   * @generated from field: v1.Operation operation = 1;
+   */
+  operation?: Operation;
+
+  /**
+   * the field the snippet is taken from, one of "display_message", "errors" or "logs".
+   *
+   * @generated from field: string field = 2;
+   */
+  field: string;
+
+  /**
+   * excerpt of the field around the match.
+   *
+   * @generated from field: repeated v1.SearchSnippetSegment snippet = 3;
+   */
+  snippet: SearchSnippetSegment[];
+};
+
+/**
+ * Describes the message v1.SearchOperationsResult.
+ * Use `create(SearchOperationsResultSchema)` to create a new message.
+ */
+export const SearchOperationsResultSchema: GenMessage<SearchOperationsResult> = /*@__PURE__*/
+  messageDesc(file_v1_service, 25);
+
+/**
+ * @generated from message v1.SearchSnippetSegment
+ */
+export type SearchSnippetSegment = Message<"v1.SearchSnippetSegment"> & {
+  /**
+   * @generated from field: string text = 1;
+   */
+  text: string;
+
+  /**
+   * the text matches the query.
+   *
+   * @generated from field: bool highlight = 2;
+   */
+  highlight: boolean;
+};
+
+/**
+ * Describes the message v1.SearchSnippetSegment.
+ * Use `create(SearchSnippetSegmentSchema)` to create a new message.
+ */
+export const SearchSnippetSegmentSchema: GenMessage<SearchSnippetSegment> = /*@__PURE__*/
+  messageDesc(file_v1_service, 26);
+
+/**
+ * @generated from message v1.ExportOperationsRequest
+ */
+export type ExportOperationsRequest = Message<"v1.ExportOperationsRequest"> & {
+  /**
+   * the operations to export, all operations if unset.
+   *
+   * @generated from field: v1.OpSelector selector = 1;
+   */
+  selector?: OpSelector;
+
+  /**
+   * @generated from field: v1.ExportOperationsRequest.Format format = 2;
+   */
+  format: ExportOperationsRequest_Format;
+};
+
+/**
+ * Describes the message v1.ExportOperationsRequest.
+ * Use `create(ExportOperationsRequestSchema)` to create a new message.
+ */
+export const ExportOperationsRequestSchema: GenMessage<ExportOperationsRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service, 27);
+
+/**
+ * @generated from enum v1.ExportOperationsRequest.Format
+ */
+export enum ExportOperationsRequest_Format {
+  /**
+   * one JSON encoded Operation per line, can be imported with ImportOperations.
+   *
+   * @generated from enum value: FORMAT_JSONL = 0;
+   */
+  JSONL = 0,
+
+  /**
+   * a summary of each operation for use in spreadsheets.
+   *
+   * @generated from enum value: FORMAT_CSV = 1;
+   */
+  CSV = 1,
+}
+
+/**
+ * Describes the enum v1.ExportOperationsRequest.Format.
+ */
+export const ExportOperationsRequest_FormatSchema: GenEnum<ExportOperationsRequest_Format> = /*@__PURE__*/
+  enumDesc(file_v1_service, 27, 0);
+
+/**
+ * @generated from message v1.ImportOperationsRequest
+ */
+export type ImportOperationsRequest = Message<"v1.ImportOperationsRequest"> & {
+  /**
+   * operations in the JSONL format written by ExportOperations.
+   *
+   * @generated from field: bytes data = 1;
+   */
+  data: Uint8Array;
+};
+
+/**
+ * Describes the message v1.ImportOperationsRequest.
+ * Use `create(ImportOperationsRequestSchema)` to create a new message.
+ */
+export const ImportOperationsRequestSchema: GenMessage<ImportOperationsRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service, 28);
+
+/**
+ * @generated from message v1.ImportOperationsResponse
+ */
+export type ImportOperationsResponse = Message<"v1.ImportOperationsResponse"> & {
+  /**
+   * number of operations added to the log.
+   *
+   * @generated from field: int64 imported = 1;
+   */
+  imported: bigint;
+
+  /**
+   * number of operations already in the log or incomplete when exported.
+   *
+   * @generated from field: int64 skipped = 2;
+   */
+  skipped: bigint;
+};
+
+/**
+ * Describes the message v1.ImportOperationsResponse.
+ * Use `create(ImportOperationsResponseSchema)` to create a new message.
+ */
+export const ImportOperationsResponseSchema: GenMessage<ImportOperationsResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service, 29);
+
+/**
+ * @generated from message v1.LogStoreStats
+ */
+export type LogStoreStats = Message<"v1.LogStoreStats"> & {
+  /**
+   * number of stored logs.
+   *
+   * @generated from field: int64 log_count = 1;
+   */
+  logCount: bigint;
+
+  /**
+   * compressed size of the stored logs, the size the quota applies to.
+   *
+   * @generated from field: int64 total_bytes = 2;
+   */
+  totalBytes: bigint;
+
+  /**
+   * number of logs still being written.
+   *
+   * @generated from field: int64 inprogress_count = 3;
+   */
+  inprogressCount: bigint;
+
+  /**
+   * uncompressed size of the logs still being written.
+   *
+   * @generated from field: int64 inprogress_bytes = 4;
+   */
+  inprogressBytes: bigint;
+
+  /**
+   * limit on total_bytes, 0 if unlimited.
+   *
+   * @generated from field: int64 quota_bytes = 5;
+   */
+  quotaBytes: bigint;
+
+  /**
+   * usage of the stored logs, largest first.
+   *
+   * @generated from field: repeated v1.LogStoreStats.OperationTypeUsage by_operation_type = 6;
+   */
+  byOperationType: LogStoreStats_OperationTypeUsage[];
+};
+
+/**
+ * Describes the message v1.LogStoreStats.
+ * Use `create(LogStoreStatsSchema)` to create a new message.
+ */
+export const LogStoreStatsSchema: GenMessage<LogStoreStats> = /*@__PURE__*/
+  messageDesc(file_v1_service, 30);
+
+/**
+ * @generated from message v1.LogStoreStats.OperationTypeUsage
+ */
+export type LogStoreStats_OperationTypeUsage = Message<"v1.LogStoreStats.OperationTypeUsage"> & {
+  /**
+   * OPERATION_TYPE_UNKNOWN for logs whose operation is no longer in the log.
+   *
+   * @generated from field: v1.OperationType op_type = 1;
+   */
+  opType: OperationType;
+
+  /**
+   * @generated from field: int64 log_count = 2;
+   */
+  logCount: bigint;
+
+  /**
+   * @generated from field: int64 total_bytes = 3;
+   */
+  totalBytes: bigint;
+};
+
+/**
+ * Describes the message v1.LogStoreStats.OperationTypeUsage.
+ * Use `create(LogStoreStats_OperationTypeUsageSchema)` to create a new message.
+ */
+export const LogStoreStats_OperationTypeUsageSchema: GenMessage<LogStoreStats_OperationTypeUsage> = /*@__PURE__*/
+  messageDesc(file_v1_service, 30, 0);
+
+/**
+ * @generated from message v1.GetBackupTimelineRequest
+ */
+export type GetBackupTimelineRequest = Message<"v1.GetBackupTimelineRequest"> & {
+  /**
+   * ID of the backup operation.
+   *
+   * @generated from field: int64 op_id = 1;
+   */
+  opId: bigint;
+
+  /**
+   * downsample the timeline to at most this many points, all recorded points are returned if 0.
+   *
+   * @generated from field: int32 max_points = 2;
+   */
+  maxPoints: number;
+};
+
+/**
+ * Describes the message v1.GetBackupTimelineRequest.
+ * Use `create(GetBackupTimelineRequestSchema)` to create a new message.
+ */
+export const GetBackupTimelineRequestSchema: GenMessage<GetBackupTimelineRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service, 31);
+
+/**
+ * @generated from message v1.GetBackupTimelineResponse
+ */
+export type GetBackupTimelineResponse = Message<"v1.GetBackupTimelineResponse"> & {
+  /**
+   * @generated from field: repeated v1.BackupTimelinePoint points = 1;
+   */
+  points: BackupTimelinePoint[];
+
+  /**
+   * files the backup spent the most time reading, longest first.
+   *
+   * @generated from field: repeated v1.GetBackupTimelineResponse.FileTime slowest_files = 2;
+   */
+  slowestFiles: GetBackupTimelineResponse_FileTime[];
+
+  /**
+   * false while the backup is running, later requests return more points.
+   *
+   * @generated from field: bool complete = 3;
+   */
+  complete: boolean;
+};
+
+/**
+ * Describes the message v1.GetBackupTimelineResponse.
+ * Use `create(GetBackupTimelineResponseSchema)` to create a new message.
+ */
+export const GetBackupTimelineResponseSchema: GenMessage<GetBackupTimelineResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service, 32);
+
+/**
+ * @generated from message v1.GetBackupTimelineResponse.FileTime
+ */
+export type GetBackupTimelineResponse_FileTime = Message<"v1.GetBackupTimelineResponse.FileTime"> & {
+  /**
+   * @generated from field: string path = 1;
+   */
+  path: string;
+
+  /**
+   * approximate time the file was being read, at the resolution of the timeline.
+   *
+   * @generated from field: int64 duration_ms = 2;
+   */
+  durationMs: bigint;
+};
+
+/**
+ * Describes the message v1.GetBackupTimelineResponse.FileTime.
+ * Use `create(GetBackupTimelineResponse_FileTimeSchema)` to create a new message.
+ */
+export const GetBackupTimelineResponse_FileTimeSchema: GenMessage<GetBackupTimelineResponse_FileTime> = /*@__PURE__*/
+  messageDesc(file_v1_service, 32, 0);
 
 /**
  * @generated from message v1.SummaryDashboardResponse
@@ -474,7 +1184,7 @@ export type SummaryDashboardResponse = Message<"v1.SummaryDashboardResponse"> &
  * Use `create(SummaryDashboardResponseSchema)` to create a new message.
  */
 export const SummaryDashboardResponseSchema: GenMessage<SummaryDashboardResponse> = /*@__PURE__*/
-  messageDesc(file_v1_service, 13);
+  messageDesc(file_v1_service, 33);
 
 /**
  * @generated from message v1.SummaryDashboardResponse.Summary
@@ -545,7 +1255,7 @@ export type SummaryDashboardResponse_Summary = Message<"v1.SummaryDashboardRespo
  * Use `create(SummaryDashboardResponse_SummarySchema)` to create a new message.
  */
 export const SummaryDashboardResponse_SummarySchema: GenMessage<SummaryDashboardResponse_Summary> = /*@__PURE__*/
-  messageDesc(file_v1_service, 13, 0);
+  messageDesc(file_v1_service, 33, 0);
 
 /**
  * @generated from message v1.SummaryDashboardResponse.BackupChart
@@ -582,7 +1292,7 @@ export type SummaryDashboardResponse_BackupChart = Message<"v1.SummaryDashboardR
  * Use `create(SummaryDashboardResponse_BackupChartSchema)` to create a new message.
  */
 export const SummaryDashboardResponse_BackupChartSchema: GenMessage<SummaryDashboardResponse_BackupChart> = /*@__PURE__*/
-  messageDesc(file_v1_service, 13, 1);
+  messageDesc(file_v1_service, 33, 1);
 
 /**
  * @generated from service v1.Backrest
@@ -604,6 +1314,16 @@ export const Backrest: GenService<{
     input: typeof ConfigSchema;
     output: typeof ConfigSchema;
   },
+  /**
+   * SetConfigWithOptions sets the config like SetConfig, the options allow changes that SetConfig rejects e.g. retention policies removing many snapshots.
+   *
+   * @generated from rpc v1.Backrest.SetConfigWithOptions
+   */
+  setConfigWithOptions: {
+    methodKind: "unary";
+    input: typeof SetConfigRequestSchema;
+    output: typeof ConfigSchema;
+  },
   /**
    * @generated from rpc v1.Backrest.CheckRepoExists
    */
@@ -690,6 +1410,46 @@ export const Backrest: GenService<{
     input: typeof ForgetRequestSchema;
     output: typeof EmptySchema;
   },
+  /**
+   * PreviewRetention evaluates a candidate retention policy against a plan's snapshots without forgetting anything.
+   *
+   * @generated from rpc v1.Backrest.PreviewRetention
+   */
+  previewRetention: {
+    methodKind: "unary";
+    input: typeof PreviewRetentionRequestSchema;
+    output: typeof PreviewRetentionResponseSchema;
+  },
+  /**
+   * AdoptSnapshots assigns snapshots created outside of backrest to a plan by tagging them and reindexing the repo.
+   *
+   * @generated from rpc v1.Backrest.AdoptSnapshots
+   */
+  adoptSnapshots: {
+    methodKind: "unary";
+    input: typeof AdoptSnapshotsRequestSchema;
+    output: typeof AdoptSnapshotsResponseSchema;
+  },
+  /**
+   * HoldSnapshot places a hold on a snapshot, exempting it from forget operations until the hold is released or expires.
+   *
+   * @generated from rpc v1.Backrest.HoldSnapshot
+   */
+  holdSnapshot: {
+    methodKind: "unary";
+    input: typeof HoldSnapshotRequestSchema;
+    output: typeof EmptySchema;
+  },
+  /**
+   * ReleaseSnapshotHold removes the hold on a snapshot, making it eligible for forget operations again.
+   *
+   * @generated from rpc v1.Backrest.ReleaseSnapshotHold
+   */
+  releaseSnapshotHold: {
+    methodKind: "unary";
+    input: typeof ReleaseSnapshotHoldRequestSchema;
+    output: typeof EmptySchema;
+  },
   /**
    * Restore schedules a restore operation.
    *
@@ -770,6 +1530,66 @@ export const Backrest: GenService<{
     input: typeof EmptySchema;
     output: typeof SummaryDashboardResponseSchema;
   },
+  /**
+   * TestHook runs a hook immediately with example variables built from the latest relevant operation and returns the rendered payload and outcome.
+   *
+   * @generated from rpc v1.Backrest.TestHook
+   */
+  testHook: {
+    methodKind: "unary";
+    input: typeof TestHookRequestSchema;
+    output: typeof TestHookResponseSchema;
+  },
+  /**
+   * SearchOperations returns operations whose display message, backup errors or logs match a full-text query, best matches first.
+   *
+   * @generated from rpc v1.Backrest.SearchOperations
+   */
+  searchOperations: {
+    methodKind: "unary";
+    input: typeof SearchOperationsRequestSchema;
+    output: typeof SearchOperationsResponseSchema;
+  },
+  /**
+   * ExportOperations streams the selected operations as JSONL, or as a CSV summary with one row per operation.
+   *
+   * @generated from rpc v1.Backrest.ExportOperations
+   */
+  exportOperations: {
+    methodKind: "server_streaming";
+    input: typeof ExportOperationsRequestSchema;
+    output: typeof BytesValueSchema;
+  },
+  /**
+   * ImportOperations merges operations exported as JSONL into the log, assigning them new operation and flow IDs.
+   *
+   * @generated from rpc v1.Backrest.ImportOperations
+   */
+  importOperations: {
+    methodKind: "unary";
+    input: typeof ImportOperationsRequestSchema;
+    output: typeof ImportOperationsResponseSchema;
+  },
+  /**
+   * GetLogStoreStats reports the disk space used by operation logs, grouped by the type of the operation owning them.
+   *
+   * @generated from rpc v1.Backrest.GetLogStoreStats
+   */
+  getLogStoreStats: {
+    methodKind: "unary";
+    input: typeof EmptySchema;
+    output: typeof LogStoreStatsSchema;
+  },
+  /**
+   * GetBackupTimeline returns the progress timeline of a running or completed backup operation.
+   *
+   * @generated from rpc v1.Backrest.GetBackupTimeline
+   */
+  getBackupTimeline: {
+    methodKind: "unary";
+    input: typeof GetBackupTimelineRequestSchema;
+    output: typeof GetBackupTimelineResponseSchema;
+  },
 }> = /*@__PURE__*/
   serviceDesc(file_v1_service, 0);
 
@@ -3,6 +3,7 @@ import {
   Operation,
   OperationForget,
   OperationRestore,
+  OperationRollup,
   OperationStatus,
 } from "../../gen/ts/v1/operations_pb";
 import {
@@ -189,6 +190,20 @@ export const OperationRow = ({
   const bodyItems: ItemType[] = [];
   const expandedBodyItems: string[] = [];
 
+  if (operation.rollup) {
+    expandedBodyItems.push("rollup");
+    bodyItems.push({
+      key: "rollup",
+      label: m.op_row_rollup_details(),
+      children: (
+        <RollupDetails
+          rollup={operation.rollup}
+          lastEndTimeMs={Number(operation.unixTimeEndMs)}
+        />
+      ),
+    });
+  }
+
   if (operation.op.case === "operationBackup") {
     if (operation.status === OperationStatus.STATUS_INPROGRESS) {
       expandedBodyItems.push("details");
@@ -629,6 +644,72 @@ const BackupOperationStatus = ({
   }
 };
 
+const RollupDetails = ({
+  rollup,
+  lastEndTimeMs,
+}: {
+  rollup: OperationRollup;
+  lastEndTimeMs: number;
+}) => {
+  return (
+    <>
+      <Row gutter={[16, 8]}>
+        <Col span={8}>
+          <Typography.Text strong>{m.op_row_rollup_count()}</Typography.Text>
+          <br />
+          <Typography.Text type="secondary">
+            {rollup.count.toString()}
+          </Typography.Text>
+        </Col>
+        <Col span={8}>
+          <Typography.Text strong>{m.op_row_rollup_period()}</Typography.Text>
+          <br />
+          <Typography.Text type="secondary">
+            {formatTime(Number(rollup.firstStartTimeMs))} -{" "}
+            {formatTime(lastEndTimeMs)}
+          </Typography.Text>
+        </Col>
+        <Col span={8}>
+          <Typography.Text strong>
+            {m.op_row_rollup_total_duration()}
+          </Typography.Text>
+          <br />
+          <Typography.Text type="secondary">
+            {formatDuration(Number(rollup.totalDurationMs))}
+          </Typography.Text>
+        </Col>
+      </Row>
+      {rollup.totalBytesProcessed > 0 ? (
+        <Row gutter={[16, 8]}>
+          <Col span={8}>
+            <Typography.Text strong>
+              {m.op_row_rollup_files_processed()}
+            </Typography.Text>
+            <br />
+            <Typography.Text type="secondary">
+              {rollup.totalFilesProcessed.toString()}
+            </Typography.Text>
+          </Col>
+          <Col span={8}>
+            <Typography.Text strong>{m.op_row_total_bytes()}</Typography.Text>
+            <br />
+            <Typography.Text type="secondary">
+              {formatBytes(Number(rollup.totalBytesProcessed))}
+            </Typography.Text>
+          </Col>
+          <Col span={8}>
+            <Typography.Text strong>{m.op_row_bytes_added()}</Typography.Text>
+            <br />
+            <Typography.Text type="secondary">
+              {formatBytes(Number(rollup.totalBytesAdded))}
+            </Typography.Text>
+          </Col>
+        </Row>
+      ) : null}
+    </>
+  );
+};
+
 const ForgetOperationDetails = ({
   forgetOp,
 }: {
@@ -1,4 +1,4 @@
-import { Operation, OperationStatus } from "../../gen/ts/v1/operations_pb";
+import { Operation, OperationRollup, OperationStatus } from "../../gen/ts/v1/operations_pb";
 import { formatBytes, formatDuration, normalizeSnapshotId } from "../lib/formatting";
 import * as m from "../paraglide/messages";
 
@@ -117,6 +117,11 @@ export const displayInfoForFlow = (ops: Operation[]): FlowDisplayInfo => {
       }
   }
 
+  // a rollup holds the details of the latest compacted operation, its subtitle summarizes all of them instead.
+  if (firstOp.rollup) {
+    info.subtitleComponents = subtitleComponentsForRollup(firstOp.rollup);
+  }
+
   for (let op of ops) {
     if (op.op.case === "operationIndexSnapshot") {
       if (op.op.value.forgot) {
@@ -135,6 +140,17 @@ export const displayInfoForFlow = (ops: Operation[]): FlowDisplayInfo => {
   return info;
 }
 
+const subtitleComponentsForRollup = (rollup: OperationRollup): string[] => {
+  const components = [m.op_subtitle_rollup({ count: rollup.count.toString() })];
+  const duration = formatDuration(Number(rollup.totalDurationMs));
+  if (rollup.totalBytesProcessed > 0) {
+    components.push(m.op_subtitle_summary({ bytes: formatBytes(Number(rollup.totalBytesProcessed)), duration }));
+  } else if (rollup.totalDurationMs > 100) {
+    components.push(m.op_subtitle_took({ duration }));
+  }
+  return components;
+};
+
 export const shouldHideOperation = (operation: Operation) => {
   return (
     operation.op.case === "operationStats" ||
@@ -60,7 +60,7 @@ export const unsubscribeFromOperations = (
 export const shouldHideOperation = (operation: Operation) => {
   return (
     operation.op.case === "operationStats" ||
-    (operation.status === OperationStatus.STATUS_SUCCESS && operation.op.case === "operationBackup" && !operation.snapshotId) ||
+    (operation.status === OperationStatus.STATUS_SUCCESS && operation.op.case === "operationBackup" && !operation.snapshotId && !operation.rollup) ||
     shouldHideStatus(operation.status)
   );
 };
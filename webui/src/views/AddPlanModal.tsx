@@ -50,6 +50,7 @@ import { debounce } from "../lib/util";
 import { StringList } from "../../gen/ts/types/value_pb";
 import { isWindows } from "../state/buildcfg";
 import * as m from "../paraglide/messages";
+import { Code } from "@connectrpc/connect";
 
 const { TextArea } = Input;
 const sep = isWindows ? "\\" : "/";
@@ -111,8 +112,23 @@ export const AddPlanModal = ({ template }: { template: Plan | null }) => {
       }
       configCopy.plans.splice(idx, 1);
 
-      // Update config and notify success.
-      setConfig(await backrestService.setConfig(configCopy));
+      // Update config and notify success, retention changes removing many snapshots must be confirmed.
+      try {
+        setConfig(await backrestService.setConfig(configCopy));
+      } catch (e: any) {
+        if (e.code !== Code.FailedPrecondition) {
+          throw e;
+        }
+        if (!(await confirmRetentionChange(e.rawMessage))) {
+          return;
+        }
+        setConfig(
+          await backrestService.setConfigWithOptions({
+            config: configCopy,
+            confirmRetention: true,
+          })
+        );
+      }
       showModal(null);
 
       alertsApi.success(
@@ -160,8 +176,23 @@ export const AddPlanModal = ({ template }: { template: Plan | null }) => {
         configCopy.plans.push(plan);
       }
 
-      // Update config and notify success.
-      setConfig(await backrestService.setConfig(configCopy));
+      // Update config and notify success, retention changes removing many snapshots must be confirmed.
+      try {
+        setConfig(await backrestService.setConfig(configCopy));
+      } catch (e: any) {
+        if (e.code !== Code.FailedPrecondition) {
+          throw e;
+        }
+        if (!(await confirmRetentionChange(e.rawMessage))) {
+          return;
+        }
+        setConfig(
+          await backrestService.setConfigWithOptions({
+            config: configCopy,
+            confirmRetention: true,
+          })
+        );
+      }
       showModal(null);
     } catch (e: any) {
       alertsApi.error(formatErrorAlert(e, m.add_plan_modal_error_operation_prefix()), 15);
@@ -838,3 +869,15 @@ const RetentionPolicyView = () => {
     </>
   );
 };
+
+const confirmRetentionChange = (message: string) =>
+  new Promise<boolean>((resolve) => {
+    Modal.confirm({
+      title: m.add_plan_modal_confirm_retention_title(),
+      content: message,
+      okText: m.add_plan_modal_confirm_retention_ok(),
+      okButtonProps: { danger: true },
+      onOk: () => resolve(true),
+      onCancel: () => resolve(false),
+    });
+  });